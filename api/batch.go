@@ -0,0 +1,205 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/metrics"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxBatchSize     = 10000
+	batchConcurrency = 100
+)
+
+// BatchRequest is a representation of batch request struct to api
+type BatchRequest struct {
+	// Seed is used for calls without own seed, so one seed may be requested for the whole batch. Seeds are
+	// single-use, so batch seed is checked once for all calls that use it. Batch with repeated calls is rejected,
+	// so signed call can't be executed twice with the same seed.
+	Seed  []byte    `json:"seed"`
+	Calls []Request `json:"calls"`
+}
+
+type batchAnswer struct {
+	Error   string   `json:"error,omitempty"`
+	Results []answer `json:"results"`
+	TraceID string   `json:"traceID,omitempty"`
+}
+
+// processCall verifies signature and makes single call. Seed of call must be checked already.
+func (ar *Runner) processCall(ctx context.Context, params Request) (interface{}, error) {
+	err := ar.verifySignature(ctx, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "Can't verify signature")
+	}
+
+	var result interface{}
+	ch := make(chan interface{}, 1)
+	go func() {
+		result, err = ar.makeCall(ctx, params)
+		ch <- nil
+	}()
+	select {
+	case <-ch:
+		if err != nil {
+			return nil, errors.Wrap(err, "Can't makeCall")
+		}
+		return result, nil
+	case <-time.After(time.Duration(ar.cfg.Timeout) * time.Second):
+		return nil, errors.New("Messagebus timeout exceeded")
+	}
+}
+
+// checkDuplicateCalls returns error if batch has calls with equal signed data: reference, method, params and seed.
+// Signature is not compared, because another valid signature of the same data may be derived from a known one.
+func checkDuplicateCalls(batch BatchRequest) error {
+	type signedData struct {
+		reference, method, params, seed string
+	}
+	calls := make(map[signedData]int, len(batch.Calls))
+	for i, call := range batch.Calls {
+		seed := call.Seed
+		if len(seed) == 0 {
+			seed = batch.Seed
+		}
+		key := signedData{
+			reference: call.Reference,
+			method:    call.Method,
+			params:    string(call.Params),
+			seed:      string(seed),
+		}
+		if first, ok := calls[key]; ok {
+			return errors.Errorf("[ checkDuplicateCalls ] call %d repeats call %d", i, first)
+		}
+		calls[key] = i
+	}
+	return nil
+}
+
+// processBatch makes calls of batch concurrently, results are in the same order as calls
+func (ar *Runner) processBatch(ctx context.Context, batch BatchRequest) []answer {
+	results := make([]answer, len(batch.Calls))
+
+	var batchSeedErr error
+	if len(batch.Seed) != 0 {
+		batchSeedErr = ar.checkSeed(batch.Seed)
+	} else {
+		batchSeedErr = errors.New("[ processBatch ] No seed in call and batch")
+	}
+
+	calls := make(chan int)
+	wg := sync.WaitGroup{}
+	workers := batchConcurrency
+	if workers > len(batch.Calls) {
+		workers = len(batch.Calls)
+	}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range calls {
+				params := batch.Calls[i]
+				var err error
+				if len(params.Seed) == 0 {
+					params.Seed = batch.Seed
+					err = batchSeedErr
+				} else {
+					err = ar.checkSeed(params.Seed)
+				}
+
+				startTime := time.Now()
+				var result interface{}
+				if err != nil {
+					err = errors.Wrap(err, "Can't checkSeed")
+				} else {
+					result, err = ar.processCall(ctx, params)
+				}
+				success := "success"
+				if err != nil {
+					success = "fail"
+					results[i].Error = err.Error()
+					inslogger.FromContext(ctx).Error(errors.Wrapf(err, "[ BatchHandler ] call %d failed", i))
+				} else {
+					results[i].Result = result
+				}
+				metrics.APIContractExecutionTime.WithLabelValues(params.Method, success).Observe(time.Since(startTime).Seconds())
+			}
+		}()
+	}
+	for i := range batch.Calls {
+		calls <- i
+	}
+	close(calls)
+	wg.Wait()
+
+	return results
+}
+
+func (ar *Runner) batchHandler() func(http.ResponseWriter, *http.Request) {
+	return func(response http.ResponseWriter, req *http.Request) {
+		traceID := utils.RandTraceID()
+		ctx, insLog := inslogger.WithTraceField(context.Background(), traceID)
+
+		ctx, span := instracer.StartSpan(ctx, "batchHandler")
+		defer span.End()
+
+		batch := BatchRequest{}
+		resp := batchAnswer{TraceID: traceID}
+
+		insLog.Infof("[ batchHandler ] Incoming request: %s", req.RequestURI)
+
+		defer func() {
+			res, err := json.MarshalIndent(resp, "", "    ")
+			if err != nil {
+				res = []byte(`{"error": "can't marshal answer to json'"}`)
+			}
+			response.Header().Add("Content-Type", "application/json")
+			_, err = response.Write(res)
+			if err != nil {
+				insLog.Errorf("Can't write response\n")
+			}
+		}()
+
+		_, err := UnmarshalRequest(req, &batch)
+		if err != nil {
+			resp.Error = err.Error()
+			insLog.Error(errors.Wrap(err, "[ BatchHandler ] Can't unmarshal request"))
+			return
+		}
+		if len(batch.Calls) > maxBatchSize {
+			resp.Error = errors.Errorf("[ BatchHandler ] Batch is too large: %d calls, at most %d allowed", len(batch.Calls), maxBatchSize).Error()
+			return
+		}
+		if err := checkDuplicateCalls(batch); err != nil {
+			resp.Error = err.Error()
+			insLog.Error(errors.Wrap(err, "[ BatchHandler ] Batch is rejected"))
+			return
+		}
+
+		resp.Results = ar.processBatch(ctx, batch)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDuplicateCalls(t *testing.T) {
+	call := Request{Reference: "ref", Method: "Transfer", Params: []byte("params"), Signature: []byte("sign")}
+	withSeed := call
+	withSeed.Seed = []byte("own seed")
+
+	assert.NoError(t, checkDuplicateCalls(BatchRequest{Seed: []byte("seed"), Calls: []Request{call, withSeed}}))
+
+	err := checkDuplicateCalls(BatchRequest{Seed: []byte("seed"), Calls: []Request{call, withSeed, call}})
+	assert.EqualError(t, err, "[ checkDuplicateCalls ] call 2 repeats call 0")
+
+	resigned := call
+	resigned.Signature = []byte("another sign")
+	assert.Error(t, checkDuplicateCalls(BatchRequest{Seed: []byte("seed"), Calls: []Request{call, resigned}}),
+		"signature doesn't make call distinct")
+
+	batchSeed := call
+	batchSeed.Seed = []byte("seed")
+	assert.Error(t, checkDuplicateCalls(BatchRequest{Seed: []byte("seed"), Calls: []Request{call, batchSeed}}),
+		"call with batch seed repeats call without seed")
+}
@@ -18,6 +18,8 @@ package api
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -26,6 +28,7 @@ import (
 	"github.com/insolar/insolar/api/seedmanager"
 	"github.com/insolar/insolar/application/extractor"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/core/utils"
 	"github.com/insolar/insolar/instrumentation/inslogger"
@@ -45,6 +48,8 @@ type Request struct {
 	Params    []byte `json:"params"`
 	Seed      []byte `json:"seed"`
 	Signature []byte `json:"signature"`
+	// Async makes api return registered request reference without waiting for result. Use call.Status to get it.
+	Async bool `json:"async,omitempty"`
 }
 
 type answer struct {
@@ -121,6 +126,11 @@ func (ar *Runner) makeCall(ctx context.Context, params Request) (interface{}, er
 		return nil, errors.Wrap(err, "[ makeCall ] failed to parse params.Reference")
 	}
 
+	err = validateCallParams(params.Method, params.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ makeCall ] Invalid params")
+	}
+
 	res, err := ar.ContractRequester.SendRequest(
 		ctx,
 		reference,
@@ -145,6 +155,66 @@ func (ar *Runner) makeCall(ctx context.Context, params Request) (interface{}, er
 	return result, nil
 }
 
+func randomNonce() (uint64, error) {
+	buf := make([]byte, 8)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+// submitCall registers call request and returns its reference without waiting for result
+func (ar *Runner) submitCall(ctx context.Context, params Request) (string, error) {
+	ctx, span := instracer.StartSpan(ctx, "SubmitRequest "+params.Method)
+	defer span.End()
+
+	reference, err := core.NewRefFromBase58(params.Reference)
+	if err != nil {
+		return "", errors.Wrap(err, "[ submitCall ] failed to parse params.Reference")
+	}
+
+	err = validateCallParams(params.Method, params.Params)
+	if err != nil {
+		return "", errors.Wrap(err, "[ submitCall ] Invalid params")
+	}
+
+	args, err := core.MarshalArgs(
+		*ar.CertificateManager.GetCertificate().GetRootDomainReference(),
+		params.Method,
+		params.Params,
+		params.Seed,
+		params.Signature,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "[ submitCall ] Can't marshal arguments")
+	}
+
+	nonce, err := randomNonce()
+	if err != nil {
+		return "", errors.Wrap(err, "[ submitCall ] Can't generate nonce")
+	}
+
+	res, err := ar.ContractRequester.CallMethod(
+		ctx,
+		&message.BaseLogicMessage{Nonce: nonce},
+		true,
+		reference,
+		"Call",
+		args,
+		nil,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "[ submitCall ] Can't send request")
+	}
+
+	registered, ok := res.(*reply.RegisterRequest)
+	if !ok {
+		return "", errors.Errorf("[ submitCall ] unexpected reply: %#v", res)
+	}
+	return registered.Request.String(), nil
+}
+
 func processError(err error, extraMsg string, resp *answer, insLog core.Logger) {
 	resp.Error = err.Error()
 	insLog.Error(errors.Wrapf(err, "[ CallHandler ] %s", extraMsg))
@@ -207,7 +277,11 @@ func (ar *Runner) callHandler() func(http.ResponseWriter, *http.Request) {
 		var result interface{}
 		ch := make(chan interface{}, 1)
 		go func() {
-			result, err = ar.makeCall(ctx, params)
+			if params.Async {
+				result, err = ar.submitCall(ctx, params)
+			} else {
+				result, err = ar.makeCall(ctx, params)
+			}
 			ch <- nil
 		}()
 		select {
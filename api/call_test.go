@@ -36,6 +36,7 @@ import (
 )
 
 const CallUrl = "http://localhost:19192/api/call"
+const BatchUrl = "http://localhost:19192/api/batch"
 
 type TimeoutSuite struct {
 	suite.Suite
@@ -59,7 +60,7 @@ func (suite *TimeoutSuite) TestRunner_callHandler() {
 		suite.ctx,
 		CallUrl,
 		suite.user,
-		&requester.RequestConfigJSON{},
+		&requester.RequestConfigJSON{Method: "GetMyBalance"},
 		seed[:],
 	)
 	suite.NoError(err)
@@ -81,7 +82,7 @@ func (suite *TimeoutSuite) TestRunner_callHandlerTimeout() {
 		suite.ctx,
 		CallUrl,
 		suite.user,
-		&requester.RequestConfigJSON{},
+		&requester.RequestConfigJSON{Method: "GetMyBalance"},
 		seed[:],
 	)
 	suite.NoError(err)
@@ -93,6 +94,104 @@ func (suite *TimeoutSuite) TestRunner_callHandlerTimeout() {
 	suite.Equal("", result.Result)
 }
 
+func (suite *TimeoutSuite) TestRunner_callHandlerInvalidParams() {
+	seed, err := suite.api.SeedGenerator.Next()
+	suite.NoError(err)
+	suite.api.SeedManager.Add(*seed)
+
+	resp, err := requester.SendWithSeed(
+		suite.ctx,
+		CallUrl,
+		suite.user,
+		&requester.RequestConfigJSON{Method: "Transfer", Params: []interface{}{100, "not a reference"}},
+		seed[:],
+	)
+	suite.NoError(err)
+
+	var result APIresp
+	err = json.Unmarshal(resp, &result)
+	suite.NoError(err)
+	suite.Equal("[ makeCall ] Invalid params: Transfer: invalid param 1 (to): invalid reference", result.Error)
+}
+
+type batchResp struct {
+	Results []APIresp
+	Error   string
+}
+
+func (suite *TimeoutSuite) TestRunner_batchHandler() {
+	seed, err := suite.api.SeedGenerator.Next()
+	suite.NoError(err)
+	suite.api.SeedManager.Add(*seed)
+
+	resp, err := requester.SendBatchWithSeed(
+		suite.ctx,
+		BatchUrl,
+		suite.user,
+		[]*requester.RequestConfigJSON{
+			{Method: "GetMyBalance"},
+			{Method: "DumpAllUsers"},
+			{Method: "GetBalance", Params: []interface{}{suite.user.Caller}},
+		},
+		seed[:],
+	)
+	suite.NoError(err)
+
+	var result batchResp
+	err = json.Unmarshal(resp, &result)
+	suite.NoError(err)
+	suite.Equal("", result.Error)
+	suite.Len(result.Results, 3)
+	for _, r := range result.Results {
+		suite.Equal("", r.Error)
+		suite.Equal("OK", r.Result)
+	}
+}
+
+func (suite *TimeoutSuite) TestRunner_batchHandlerDuplicateCalls() {
+	seed, err := suite.api.SeedGenerator.Next()
+	suite.NoError(err)
+	suite.api.SeedManager.Add(*seed)
+
+	resp, err := requester.SendBatchWithSeed(
+		suite.ctx,
+		BatchUrl,
+		suite.user,
+		[]*requester.RequestConfigJSON{{Method: "GetMyBalance"}, {Method: "DumpAllUsers"}, {Method: "GetMyBalance"}},
+		seed[:],
+	)
+	suite.NoError(err)
+
+	var result batchResp
+	err = json.Unmarshal(resp, &result)
+	suite.NoError(err)
+	suite.Contains(result.Error, "call 2 repeats call 0")
+	suite.Empty(result.Results)
+}
+
+func (suite *TimeoutSuite) TestRunner_batchHandlerItemErrors() {
+	seed, err := suite.api.SeedGenerator.Next()
+	suite.NoError(err)
+	suite.api.SeedManager.Add(*seed)
+
+	resp, err := requester.GetResponseBody(BatchUrl, requester.PostParams{
+		"seed": seed[:],
+		"calls": []requester.PostParams{
+			{"reference": suite.user.Caller, "method": "first", "signature": []byte("bad")},
+			{"reference": suite.user.Caller, "method": "second", "seed": []byte("bad")},
+		},
+	})
+	suite.NoError(err)
+
+	var result batchResp
+	err = json.Unmarshal(resp, &result)
+	suite.NoError(err)
+	suite.Equal("", result.Error)
+	suite.Len(result.Results, 2)
+	suite.Contains(result.Results[0].Error, "Can't verify signature")
+	suite.Contains(result.Results[1].Error, "Can't checkSeed")
+}
+
 func TestTimeoutSuite(t *testing.T) {
 	timeoutSuite := new(TimeoutSuite)
 	timeoutSuite.ctx, _ = inslogger.WithTraceField(context.Background(), "APItests")
@@ -0,0 +1,136 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// Call request statuses.
+const (
+	// CallPending is a status of request that waits for execution since previous pulses.
+	CallPending = "pending"
+	// CallExecuting is a status of request registered in current pulse and not executed yet.
+	CallExecuting = "executing"
+	// CallDone is a status of executed request.
+	CallDone = "done"
+)
+
+// CallStatusArgs is arguments that Call.Status accepts.
+type CallStatusArgs struct {
+	// Reference is a reference of called object (member reference for /api/call requests).
+	Reference string
+	// Request is a request reference returned by asynchronous call.
+	Request string
+}
+
+// CallStatusReply is reply for Call.Status requests.
+type CallStatusReply struct {
+	Status string      `json:"status"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+	// ResultRecord is an ID of registered result record.
+	ResultRecord string `json:"resultRecord,omitempty"`
+}
+
+// CallService is a service that provides API for tracking asynchronous calls.
+type CallService struct {
+	runner *Runner
+}
+
+// NewCallService creates new Call service instance.
+func NewCallService(runner *Runner) *CallService {
+	return &CallService{runner: runner}
+}
+
+// Status returns execution status of request submitted with "async" flag.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "call.Status",
+//     "params": {
+//       // Reference of called object. It is the member reference for /api/call requests.
+//       "Reference": str,
+//       // Request reference returned by asynchronous call.
+//       "Request": str
+//     },
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {
+//     "status": "pending"|"executing"|"done",
+//     "result": any, // Result of the call. Only for "done" status.
+//     "error": str, // Error returned by contract. Only for "done" status.
+//     "resultRecord": str // ID of registered result record. Only for "done" status.
+//   }
+//
+func (s *CallService) Status(r *http.Request, args *CallStatusArgs, reply *CallStatusReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ CallService.Status ] Incoming request: %s", r.RequestURI)
+
+	object, err := core.NewRefFromBase58(args.Reference)
+	if err != nil {
+		return errors.Wrap(err, "[ CallService.Status ] failed to parse args.Reference")
+	}
+	request, err := core.NewRefFromBase58(args.Request)
+	if err != nil {
+		return errors.Wrap(err, "[ CallService.Status ] failed to parse args.Request")
+	}
+
+	result, err := s.runner.ArtifactManager.GetRequestResult(ctx, *object, *request)
+	if err != nil {
+		return errors.Wrap(err, "[ CallService.Status ]")
+	}
+
+	if result.Result == nil {
+		reply.Status = CallExecuting
+		if result.Pending {
+			reply.Status = CallPending
+		}
+		return nil
+	}
+
+	reply.Status = CallDone
+	reply.ResultRecord = result.Result.String()
+	reply.Result, reply.Error, err = callResult(result.Payload)
+	if err != nil {
+		return errors.Wrap(err, "[ CallService.Status ]")
+	}
+	return nil
+}
+
+// callResult extracts result of member call from result record payload.
+func callResult(payload []byte) (interface{}, string, error) {
+	res, contractErr, err := extractor.CallResponse(payload)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "can't extract response")
+	}
+	if contractErr != nil {
+		return nil, contractErr.S, nil
+	}
+	return res, "", nil
+}
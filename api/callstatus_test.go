@@ -0,0 +1,97 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallService_Status(t *testing.T) {
+	object := testutils.RandomRef()
+	request := testutils.RandomRef()
+	args := &CallStatusArgs{Reference: object.String(), Request: request.String()}
+
+	newService := func(t *testing.T) (*CallService, *testutils.ArtifactManagerMock) {
+		am := testutils.NewArtifactManagerMock(t)
+		return NewCallService(&Runner{ArtifactManager: am}), am
+	}
+
+	t.Run("pending", func(t *testing.T) {
+		service, am := newService(t)
+		am.GetRequestResultMock.Return(&core.RequestResult{Pending: true}, nil)
+		reply := &CallStatusReply{}
+		err := service.Status(&http.Request{}, args, reply)
+		require.NoError(t, err)
+		assert.Equal(t, CallPending, reply.Status)
+	})
+
+	t.Run("executing", func(t *testing.T) {
+		service, am := newService(t)
+		am.GetRequestResultMock.Return(&core.RequestResult{}, nil)
+		reply := &CallStatusReply{}
+		err := service.Status(&http.Request{}, args, reply)
+		require.NoError(t, err)
+		assert.Equal(t, CallExecuting, reply.Status)
+	})
+
+	t.Run("done", func(t *testing.T) {
+		service, am := newService(t)
+		resultID := testutils.RandomID()
+		payload, err := core.MarshalArgs("ok", (*foundation.Error)(nil))
+		require.NoError(t, err)
+		am.GetRequestResultFunc = func(ctx context.Context, o, r core.RecordRef) (*core.RequestResult, error) {
+			require.Equal(t, object, o)
+			require.Equal(t, request, r)
+			return &core.RequestResult{Result: &resultID, Payload: payload}, nil
+		}
+		reply := &CallStatusReply{}
+		err = service.Status(&http.Request{}, args, reply)
+		require.NoError(t, err)
+		assert.Equal(t, CallDone, reply.Status)
+		assert.Equal(t, "ok", reply.Result)
+		assert.Equal(t, resultID.String(), reply.ResultRecord)
+	})
+
+	t.Run("done with contract error", func(t *testing.T) {
+		service, am := newService(t)
+		payload, err := core.MarshalArgs(nil, &foundation.Error{S: "not enough balance"})
+		require.NoError(t, err)
+		am.GetRequestResultMock.Return(&core.RequestResult{Result: &core.RecordID{}, Payload: payload}, nil)
+		reply := &CallStatusReply{}
+		err = service.Status(&http.Request{}, args, reply)
+		require.NoError(t, err)
+		assert.Equal(t, CallDone, reply.Status)
+		assert.Equal(t, "not enough balance", reply.Error)
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		service, am := newService(t)
+		am.GetRequestResultMock.Return(nil, core.ErrRequestNotFound)
+		err := service.Status(&http.Request{}, args, &CallStatusReply{})
+		require.Error(t, err)
+		assert.Equal(t, core.ErrRequestNotFound, errors.Cause(err))
+	})
+}
@@ -18,16 +18,22 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 
 	jsonrpc "github.com/gorilla/rpc/v2/json2"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/pkg/errors"
 )
 
 const (
 	errCodePulseNotFound = 10404
+
+	exportStreamDefaultSize = 10
 )
 
 // StorageExporterArgs is arguments that StorageExporter service accepts.
@@ -106,3 +112,67 @@ func (s *StorageExporterService) Export(r *http.Request, args *StorageExporterAr
 
 	return nil
 }
+
+// ExportStreamLine is a line of streaming export response.
+type ExportStreamLine struct {
+	// Cursor is a position right after this line. Pass it as "cursor" param to continue export.
+	Cursor string
+	// Data is exported data of one jet on one pulse (see Export for structure).
+	Data interface{} `json:",omitempty"`
+	// Error is set on the last line if export was interrupted.
+	Error string `json:",omitempty"`
+}
+
+// exportStreamHandler streams storage data as newline-delimited JSON.
+//
+//   Request:
+//   GET /api/export?cursor=<cursor>&size=<pulses>
+//     cursor - position to continue from, "Cursor" of the last received line (empty to start from the beginning).
+//     size - maximum number of pulses to export (default 10).
+//
+//   Response (Content-Type: application/x-ndjson), one line per jet on pulse:
+//   {"Cursor": str, "Data": { "Records": { ... }, "Pulse": { ... }, "JetID": str }}
+//
+//   Stream ends when there is no more persisted pulses or size is reached. Client should reconnect with the cursor
+//   of the last line to tail the ledger.
+func (ar *Runner) exportStreamHandler() func(http.ResponseWriter, *http.Request) {
+	return func(response http.ResponseWriter, req *http.Request) {
+		ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+		inslog.Infof("[ exportStreamHandler ] Incoming request: %s", req.RequestURI)
+
+		size := exportStreamDefaultSize
+		if s := req.URL.Query().Get("size"); s != "" {
+			var err error
+			size, err = strconv.Atoi(s)
+			if err != nil || size <= 0 {
+				http.Error(response, "[ exportStreamHandler ] size should be a positive number", http.StatusBadRequest)
+				return
+			}
+		}
+
+		response.Header().Set("Content-Type", "application/x-ndjson")
+		flusher, _ := response.(http.Flusher)
+		enc := json.NewEncoder(response)
+
+		_, err := ar.StorageExporter.ExportStream(ctx, req.URL.Query().Get("cursor"), size,
+			func(chunk *core.StorageExportChunk) error {
+				err := enc.Encode(&ExportStreamLine{Cursor: chunk.Cursor, Data: chunk.Data})
+				if err != nil {
+					return err
+				}
+				if flusher != nil {
+					flusher.Flush()
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			inslog.Error(errors.Wrap(err, "[ exportStreamHandler ] export failed"))
+			err = enc.Encode(&ExportStreamLine{Error: err.Error()})
+			if err != nil {
+				inslog.Error(errors.Wrap(err, "[ exportStreamHandler ] can't write error"))
+			}
+		}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// JetLoadArgs is arguments that Jet.Load accepts.
+type JetLoadArgs struct{}
+
+// JetLoad is a load of a single jet.
+type JetLoad struct {
+	// JetID is a base58 encoded jet ID.
+	JetID string `json:"jetId"`
+	// Jet is a human readable jet depth and prefix.
+	Jet             string `json:"jet"`
+	Pulse           uint32 `json:"pulse"`
+	Requests        int    `json:"requests"`
+	LatencyMs       int64  `json:"latencyMs"`
+	PendingRequests int    `json:"pendingRequests"`
+	DropSize        uint64 `json:"dropSize"`
+	ForceSplit      bool   `json:"forceSplit"`
+}
+
+// JetLoadReply is reply for Jet.Load requests.
+type JetLoadReply struct {
+	Jets []JetLoad `json:"jets"`
+}
+
+// JetSplitArgs is arguments that Jet.Split accepts.
+type JetSplitArgs struct {
+	// JetID is a base58 encoded jet ID.
+	JetID string `json:"jetId"`
+}
+
+// JetSplitReply is reply for Jet.Split requests.
+type JetSplitReply struct{}
+
+// JetService is a service that provides admin API for jets of light material node. It is served on admin address only.
+type JetService struct {
+	runner *Runner
+}
+
+// NewJetService creates new Jet service instance.
+func NewJetService(runner *Runner) *JetService {
+	return &JetService{runner: runner}
+}
+
+// Load returns load of jets executed by the node in current pulse.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "jet.Load",
+//     "params": {},
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {
+//     "jets": [
+//       {
+//         "jetId": str, // Base58 encoded jet ID.
+//         "jet": str, // Jet depth and prefix.
+//         "pulse": int,
+//         "requests": int, // Number of requests handled in current pulse.
+//         "latencyMs": int, // Average request handling time.
+//         "pendingRequests": int,
+//         "dropSize": int, // Size of the last drop in bytes.
+//         "forceSplit": bool // Jet will be split on the next pulse.
+//       }
+//     ]
+//   }
+//
+func (s *JetService) Load(r *http.Request, args *JetLoadArgs, reply *JetLoadReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ JetService.Load ] Incoming request: %s", r.RequestURI)
+
+	err := s.checkRole()
+	if err != nil {
+		return errors.Wrap(err, "[ JetService.Load ]")
+	}
+
+	loads, err := s.runner.JetLoadMonitor.JetLoads(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ JetService.Load ]")
+	}
+
+	reply.Jets = make([]JetLoad, 0, len(loads))
+	for _, load := range loads {
+		reply.Jets = append(reply.Jets, JetLoad{
+			JetID:           load.JetID.String(),
+			Jet:             load.JetID.DebugString(),
+			Pulse:           uint32(load.Pulse),
+			Requests:        load.Requests,
+			LatencyMs:       int64(load.Latency / time.Millisecond),
+			PendingRequests: load.PendingRequests,
+			DropSize:        load.DropSize,
+			ForceSplit:      load.ForceSplit,
+		})
+	}
+	return nil
+}
+
+// Split requests split of the jet on the next pulse. Jet should be executed by the node in current pulse.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "jet.Split",
+//     "params": {
+//       "jetId": str // Base58 encoded jet ID from jet.Load response.
+//     },
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {}
+//
+func (s *JetService) Split(r *http.Request, args *JetSplitArgs, reply *JetSplitReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ JetService.Split ] Incoming request: %s", r.RequestURI)
+
+	err := s.checkRole()
+	if err != nil {
+		return errors.Wrap(err, "[ JetService.Split ]")
+	}
+
+	jetID, err := core.NewIDFromBase58(args.JetID)
+	if err != nil {
+		return errors.Wrap(err, "[ JetService.Split ] failed to parse jet ID")
+	}
+	if jetID.Pulse() != core.PulseNumberJet {
+		return errors.Errorf("[ JetService.Split ] %s is not a jet ID", args.JetID)
+	}
+
+	err = s.runner.JetLoadMonitor.ForceSplit(ctx, *jetID)
+	if err != nil {
+		return errors.Wrap(err, "[ JetService.Split ]")
+	}
+	return nil
+}
+
+func (s *JetService) checkRole() error {
+	role := s.runner.CertificateManager.GetCertificate().GetRole()
+	if role != core.StaticRoleLightMaterial {
+		return errors.Errorf("jets are available on light material nodes only, node role is %s", role)
+	}
+	return nil
+}
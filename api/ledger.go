@@ -0,0 +1,84 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// LedgerBackupArgs is arguments that Ledger.Backup accepts.
+type LedgerBackupArgs struct{}
+
+// LedgerBackupReply is reply for Ledger.Backup requests.
+type LedgerBackupReply struct {
+	// Path is a snapshot file path on the node.
+	Path string `json:"path"`
+	// Pulse is a pulse the snapshot is consistent as of.
+	Pulse uint32 `json:"pulse"`
+}
+
+// LedgerService is a service that provides admin API for heavy node storage. It is served on admin address only.
+type LedgerService struct {
+	runner *Runner
+}
+
+// NewLedgerService creates new Ledger service instance.
+func NewLedgerService(runner *Runner) *LedgerService {
+	return &LedgerService{runner: runner}
+}
+
+// Backup makes a snapshot of heavy node storage in the node's backup directory.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "ledger.Backup",
+//     "params": {},
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {
+//     "path": str, // Snapshot file path on the node. Restore it with "insolar -c ledger_restore".
+//     "pulse": int // Pulse the snapshot is consistent as of.
+//   }
+//
+func (s *LedgerService) Backup(r *http.Request, args *LedgerBackupArgs, reply *LedgerBackupReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ LedgerService.Backup ] Incoming request: %s", r.RequestURI)
+
+	role := s.runner.CertificateManager.GetCertificate().GetRole()
+	if role != core.StaticRoleHeavyMaterial {
+		return errors.Errorf("[ LedgerService.Backup ] backup is available on heavy material nodes only, node role is %s", role)
+	}
+
+	path, pulse, err := s.runner.StorageBackuper.Backup(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ LedgerService.Backup ]")
+	}
+
+	reply.Path = path
+	reply.Pulse = uint32(pulse)
+	return nil
+}
@@ -48,8 +48,15 @@ type Runner struct {
 	NetworkSwitcher     core.NetworkSwitcher     `inject:""`
 	NodeNetwork         core.NodeNetwork         `inject:""`
 	PulseStorage        core.PulseStorage        `inject:""`
+	ArtifactManager     core.ArtifactManager     `inject:""`
+	StorageBackuper     core.StorageBackuper     `inject:""`
+	JetLoadMonitor      core.JetLoadMonitor      `inject:""`
+	NodeLeaver          core.NodeLeaver          `inject:""`
+	EventBus            core.EventBus            `inject:""`
 	server              *http.Server
 	rpcServer           *rpc.Server
+	adminServer         *http.Server
+	adminRPCServer      *rpc.Server
 	cfg                 *configuration.APIRunner
 	keyCache            map[string]crypto.PublicKey
 	cacheLock           *sync.RWMutex
@@ -77,32 +84,49 @@ func checkConfig(cfg *configuration.APIRunner) error {
 	return nil
 }
 
-func (ar *Runner) registerServices(rpcServer *rpc.Server) error {
-	err := rpcServer.RegisterService(NewStorageExporterService(ar), "exporter")
-	if err != nil {
-		return errors.New("[ registerServices ] Can't RegisterService: exporter")
-	}
-
-	err = rpcServer.RegisterService(NewSeedService(ar), "seed")
-	if err != nil {
-		return errors.New("[ registerServices ] Can't RegisterService: seed")
-	}
+type rpcService struct {
+	name    string
+	service interface{}
+	// descriptions of methods for API schema
+	descriptions map[string]string
+}
 
-	err = rpcServer.RegisterService(NewInfoService(ar), "info")
-	if err != nil {
-		return errors.New("[ registerServices ] Can't RegisterService: info")
+func (ar *Runner) services() []rpcService {
+	return []rpcService{
+		{"exporter", NewStorageExporterService(ar), map[string]string{"Export": "Returns storage data of pulses."}},
+		{"seed", NewSeedService(ar), map[string]string{"Get": "Returns new seed for signed requests."}},
+		{"info", NewInfoService(ar), map[string]string{"Get": "Returns references of root domain, root member and node domain."}},
+		{"status", NewStatusService(ar), map[string]string{"Get": "Returns network state and active nodes."}},
+		{"cert", NewNodeCertService(ar), map[string]string{"Get": "Returns certificate of node."}},
+		{"object", NewObjectService(ar), map[string]string{"History": "Returns states of object."}},
+		{"member", NewMemberService(ar), map[string]string{
+			"GetTransferHistory": "Returns page of transfers of member, newest first.",
+			"ListMembers":        "Returns page of members after cursor.",
+		}},
+		{"call", NewCallService(ar), map[string]string{"Status": "Returns status of asynchronous call."}},
+		{"schema", NewSchemaService(ar), map[string]string{"Get": "Returns OpenRPC document of API."}},
 	}
+}
 
-	err = rpcServer.RegisterService(NewStatusService(ar), "status")
-	if err != nil {
-		return errors.New("[ registerServices ] Can't RegisterService: status")
+// adminServices are served on AdminAddress only.
+func (ar *Runner) adminServices() []rpcService {
+	return []rpcService{
+		{"node", NewNodeService(ar), map[string]string{"Leave": "Starts graceful leave of the node from the network."}},
+		{"ledger", NewLedgerService(ar), map[string]string{"Backup": "Makes backup of heavy node storage."}},
+		{"jet", NewJetService(ar), map[string]string{
+			"Load":  "Returns load of jets executed by light material node.",
+			"Split": "Splits jet on the next pulse.",
+		}},
 	}
+}
 
-	err = rpcServer.RegisterService(NewNodeCertService(ar), "cert")
-	if err != nil {
-		return errors.New("[ registerServices ] Can't RegisterService: cert")
+func (ar *Runner) registerServices(rpcServer *rpc.Server, services []rpcService) error {
+	for _, s := range services {
+		err := rpcServer.RegisterService(s.service, s.name)
+		if err != nil {
+			return errors.New("[ registerServices ] Can't RegisterService: " + s.name)
+		}
 	}
-
 	return nil
 }
 
@@ -125,10 +149,19 @@ func NewRunner(cfg *configuration.APIRunner) (*Runner, error) {
 
 	rpcServer.RegisterCodec(jsonrpc.NewCodec(), "application/json")
 
-	if err := ar.registerServices(rpcServer); err != nil {
+	if err := ar.registerServices(rpcServer, ar.services()); err != nil {
 		return nil, errors.Wrap(err, "[ NewAPIRunner ] Can't register services:")
 	}
 
+	if cfg.AdminAddress != "" {
+		ar.adminServer = &http.Server{Addr: cfg.AdminAddress}
+		ar.adminRPCServer = rpc.NewServer()
+		ar.adminRPCServer.RegisterCodec(jsonrpc.NewCodec(), "application/json")
+		if err := ar.registerServices(ar.adminRPCServer, ar.adminServices()); err != nil {
+			return nil, errors.Wrap(err, "[ NewAPIRunner ] Can't register admin services:")
+		}
+	}
+
 	return &ar, nil
 }
 
@@ -142,6 +175,15 @@ func (ar *Runner) Start(ctx context.Context) error {
 	ar.SeedManager = seedmanager.New()
 	http.HandleFunc(ar.cfg.Call, ar.callHandler())
 	http.Handle(ar.cfg.RPC, ar.rpcServer)
+	if ar.cfg.Batch != "" {
+		http.HandleFunc(ar.cfg.Batch, ar.batchHandler())
+	}
+	if ar.cfg.Export != "" {
+		http.HandleFunc(ar.cfg.Export, ar.exportStreamHandler())
+	}
+	if ar.cfg.Subscribe != "" {
+		http.HandleFunc(ar.cfg.Subscribe, ar.subscribeHandler())
+	}
 	inslog := inslogger.FromContext(ctx)
 	inslog.Info("Starting ApiRunner ...")
 	inslog.Info("Config: ", ar.cfg)
@@ -154,6 +196,32 @@ func (ar *Runner) Start(ctx context.Context) error {
 			inslog.Error("Httpserver: ListenAndServe() error: ", err)
 		}
 	}()
+
+	if ar.adminServer != nil {
+		if err := ar.startAdmin(ctx); err != nil {
+			return errors.Wrap(err, "Can't start admin API")
+		}
+	}
+	return nil
+}
+
+// startAdmin runs admin api server. It uses its own mux, so admin services are not reachable on public Address.
+func (ar *Runner) startAdmin(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(ar.cfg.RPC, ar.adminRPCServer)
+	ar.adminServer.Handler = mux
+
+	inslog := inslogger.FromContext(ctx)
+	inslog.Info("Starting admin ApiRunner on ", ar.adminServer.Addr)
+	listener, err := net.Listen("tcp", ar.adminServer.Addr)
+	if err != nil {
+		return errors.Wrap(err, "Can't start listening")
+	}
+	go func() {
+		if err := ar.adminServer.Serve(listener); err != nil {
+			inslog.Error("Admin httpserver: ListenAndServe() error: ", err)
+		}
+	}()
 	return nil
 }
 
@@ -168,6 +236,12 @@ func (ar *Runner) Stop(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "Can't gracefully stop API server")
 	}
+	if ar.adminServer != nil {
+		err = ar.adminServer.Shutdown(ctxWithTimeout)
+		if err != nil {
+			return errors.Wrap(err, "Can't gracefully stop admin API server")
+		}
+	}
 
 	return nil
 }
@@ -96,6 +96,21 @@ func (suite *MainAPISuite) TestNewApiRunnerNoRequiredParams() {
 	suite.NoError(err)
 }
 
+func (suite *MainAPISuite) TestAdminServicesAreNotPublic() {
+	cfg := configuration.NewAPIRunner()
+	api, err := NewRunner(&cfg)
+	suite.NoError(err)
+
+	public := map[string]bool{}
+	for _, s := range api.services() {
+		public[s.name] = true
+	}
+	for _, s := range api.adminServices() {
+		suite.False(public[s.name], "%s must be served on admin address only", s.name)
+	}
+	suite.True(public["status"])
+}
+
 func TestMainTestSuite(t *testing.T) {
 	ctx, _ := inslogger.WithTraceField(context.Background(), "APItests")
 	http.DefaultServeMux = new(http.ServeMux)
@@ -0,0 +1,194 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// MemberCallArgs is signed member call arguments. Method of the call is defined by JSON-RPC method.
+type MemberCallArgs struct {
+	// Reference is a reference of calling member.
+	Reference string
+	// Params is signed call params in the same encoding as for /api/call.
+	Params []byte
+	// Seed is a seed received from seed.Get.
+	Seed []byte
+	// Signature is a member signature of reference, method, params and seed.
+	Signature []byte
+}
+
+// Transfer is a single wallet transfer in TransferHistoryReply.
+type Transfer struct {
+	Direction    string `json:"direction"`
+	Counterparty string `json:"counterparty,omitempty"`
+	Allowance    string `json:"allowance"`
+	Amount       uint   `json:"amount"`
+	Pulse        uint32 `json:"pulse"`
+	Request      string `json:"request"`
+}
+
+// TransferHistoryReply is reply for Member.GetTransferHistory requests.
+type TransferHistoryReply struct {
+	Transfers  []Transfer `json:"transfers"`
+	NextCursor int        `json:"nextCursor,omitempty"`
+}
+
+// MemberInfo is a single member in ListMembersReply.
+type MemberInfo struct {
+	Reference       string `json:"reference"`
+	Name            string `json:"member"`
+	Balance         uint   `json:"wallet"`
+	WalletReference string `json:"walletReference"`
+}
+
+// ListMembersReply is reply for Member.ListMembers requests.
+type ListMembersReply struct {
+	Members    []MemberInfo `json:"members"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
+// MemberService is a service that provides JSON-RPC wrappers for member calls.
+type MemberService struct {
+	runner *Runner
+}
+
+// NewMemberService creates new Member service instance.
+func NewMemberService(runner *Runner) *MemberService {
+	return &MemberService{runner: runner}
+}
+
+// GetTransferHistory returns wallet transfers of a member from the newest to the oldest.
+// Members can read only their own history, root member can read history of any member.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "member.GetTransferHistory",
+//     "params": {
+//       // Calling member reference.
+//       "Reference": str,
+//       // Base64 encoded signed params: [member reference, cursor, limit]. Use "nextCursor" of previous reply as
+//       // cursor to fetch next page, zero for the newest transfers. Limit is 20 by default and at most 100.
+//       "Params": str,
+//       "Seed": str,
+//       "Signature": str
+//     },
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {
+//     "transfers": [{
+//       "direction": "out"|"in"|"refund",
+//       "counterparty": str, // Counterparty wallet reference. Omitted for refunds.
+//       "allowance": str, // Reference of allowance the amount was transferred with.
+//       "amount": int,
+//       "pulse": int, // Pulse number the transfer was logged in.
+//       "request": str // Reference of the request that logged the transfer.
+//     }],
+//     "nextCursor": int // Cursor of the next page. Omitted on the last page.
+//   }
+//
+func (s *MemberService) GetTransferHistory(r *http.Request, args *MemberCallArgs, reply *TransferHistoryReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ MemberService.GetTransferHistory ] Incoming request: %s", r.RequestURI)
+
+	err := s.call(ctx, "GetTransferHistory", args, reply)
+	if err != nil {
+		return errors.Wrap(err, "[ MemberService.GetTransferHistory ]")
+	}
+	return nil
+}
+
+// ListMembers returns a page of members. Only root member can list members.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "member.ListMembers",
+//     "params": {
+//       // Root member reference.
+//       "Reference": str,
+//       // Base64 encoded signed params: [cursor, limit]. Use "nextCursor" of previous reply as cursor to fetch next
+//       // page, empty for the first page. Limit is 20 by default and at most 100.
+//       "Params": str,
+//       "Seed": str,
+//       "Signature": str
+//     },
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {
+//     "members": [{
+//       "reference": str, // Member reference.
+//       "member": str, // Member name.
+//       "wallet": int, // Wallet balance.
+//       "walletReference": str
+//     }],
+//     "nextCursor": str // Cursor of the next page. Omitted on the last page.
+//   }
+//
+func (s *MemberService) ListMembers(r *http.Request, args *MemberCallArgs, reply *ListMembersReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ MemberService.ListMembers ] Incoming request: %s", r.RequestURI)
+
+	err := s.call(ctx, "ListMembers", args, reply)
+	if err != nil {
+		return errors.Wrap(err, "[ MemberService.ListMembers ]")
+	}
+	return nil
+}
+
+// call makes signed member call and unmarshals JSON returned by contract to reply.
+func (s *MemberService) call(ctx context.Context, method string, args *MemberCallArgs, reply interface{}) error {
+	params := Request{
+		Reference: args.Reference,
+		Method:    method,
+		Params:    args.Params,
+		Seed:      args.Seed,
+		Signature: args.Signature,
+	}
+
+	err := s.runner.checkSeed(params.Seed)
+	if err != nil {
+		return err
+	}
+	err = s.runner.verifySignature(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.runner.makeCall(ctx, params)
+	if err != nil {
+		return err
+	}
+	data, ok := result.([]byte)
+	if !ok {
+		return errors.Errorf("unexpected result type %T", result)
+	}
+	return errors.Wrap(json.Unmarshal(data, reply), "can't unmarshal result")
+}
@@ -0,0 +1,81 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// NodeLeaveArgs is arguments that Node.Leave accepts.
+type NodeLeaveArgs struct{}
+
+// NodeLeaveReply is reply for Node.Leave requests.
+type NodeLeaveReply struct {
+	// WaitsConfirmation is false if the node hands off its work without confirmation of leave by network.
+	WaitsConfirmation bool `json:"waitsConfirmation"`
+}
+
+// NodeService is a service that provides admin API for the node. It is served on APIRunner.AdminAddress only.
+type NodeService struct {
+	runner *Runner
+}
+
+// NewNodeService creates new Node service instance.
+func NewNodeService(runner *Runner) *NodeService {
+	return &NodeService{runner: runner}
+}
+
+// Leave starts graceful leave of the node from the network. Node is stopped when its work is handed off.
+// Network doesn't confirm node leave until consensus is run on pulse, so by default the work is handed off
+// without confirmation. Response reports whether the node waits for confirmation.
+// It is available on admin address only (e.g. http://localhost:19001/api/rpc).
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "node.Leave",
+//     "params": {},
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {
+//     "waitsConfirmation": bool // Node waits for the network to confirm its leave.
+//   }
+//
+func (s *NodeService) Leave(r *http.Request, args *NodeLeaveArgs, reply *NodeLeaveReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodeService.Leave ] Incoming request: %s", r.RequestURI)
+
+	reply.WaitsConfirmation = s.runner.NodeLeaver.WaitsConfirmation()
+	if !reply.WaitsConfirmation {
+		inslog.Warn("[ NodeService.Leave ] node leave is not confirmed by network, work is handed off without confirmation")
+	}
+
+	go func() {
+		err := s.runner.NodeLeaver.Leave(ctx)
+		if err != nil {
+			inslog.Error("[ NodeService.Leave ] ", err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNodeLeaver struct {
+	left              chan struct{}
+	waitsConfirmation bool
+}
+
+func (l *testNodeLeaver) Leave(ctx context.Context) error {
+	close(l.left)
+	return nil
+}
+
+func (l *testNodeLeaver) Left() <-chan struct{} {
+	return l.left
+}
+
+func (l *testNodeLeaver) WaitsConfirmation() bool {
+	return l.waitsConfirmation
+}
+
+func postNodeLeave(t *testing.T, url string) string {
+	body := []byte(`{"jsonrpc": "2.0", "method": "node.Leave", "params": {}, "id": 1}`)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(respBody)
+}
+
+func TestNodeService_Leave_AdminOnly(t *testing.T) {
+	ctx := context.Background()
+	http.DefaultServeMux = new(http.ServeMux)
+	cfg := configuration.NewAPIRunner()
+	cfg.Address = "localhost:19111"
+	cfg.AdminAddress = "localhost:19011"
+	api, err := NewRunner(&cfg)
+	require.NoError(t, err)
+	leaver := &testNodeLeaver{left: make(chan struct{})}
+	api.NodeLeaver = leaver
+
+	require.NoError(t, api.Start(ctx))
+	defer api.Stop(ctx)
+
+	resp := postNodeLeave(t, "http://"+cfg.Address+cfg.RPC)
+	assert.Contains(t, resp, "can't find service")
+	select {
+	case <-leaver.Left():
+		t.Fatal("node.Leave must not be served on public address")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	resp = postNodeLeave(t, "http://"+cfg.AdminAddress+cfg.RPC)
+	assert.NotContains(t, resp, "error")
+	assert.Contains(t, resp, `"waitsConfirmation":false`)
+	select {
+	case <-leaver.Left():
+	case <-time.After(time.Second):
+		t.Fatal("node.Leave is not served on admin address")
+	}
+}
+
+func TestNewRunner_AdminDisabled(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	cfg.AdminAddress = ""
+	api, err := NewRunner(&cfg)
+	require.NoError(t, err)
+	assert.Nil(t, api.adminServer)
+}
@@ -0,0 +1,145 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	objectHistoryDefaultLimit = 100
+	objectHistoryMaxLimit     = 1000
+)
+
+// ObjectHistoryArgs is arguments that Object.History accepts.
+type ObjectHistoryArgs struct {
+	// Reference is an object reference.
+	Reference string
+	// From is a state to start from. Latest state is used if empty.
+	From string
+	// Limit is a maximum number of states to return.
+	Limit int
+}
+
+// ObjectState is a single object state in ObjectHistoryReply.
+type ObjectState struct {
+	State      string `json:"state"`
+	Pulse      uint32 `json:"pulse"`
+	Request    string `json:"request"`
+	Kind       string `json:"kind"`
+	MemoryHash string `json:"memory_hash"`
+}
+
+// ObjectHistoryReply is reply for Object.History requests.
+type ObjectHistoryReply struct {
+	States   []ObjectState `json:"states"`
+	NextFrom string        `json:"next_from,omitempty"`
+}
+
+// ObjectService is a service that provides API for reading object lifelines.
+type ObjectService struct {
+	runner *Runner
+}
+
+// NewObjectService creates new Object service instance.
+func NewObjectService(runner *Runner) *ObjectService {
+	return &ObjectService{runner: runner}
+}
+
+// History returns object states from the newest to the oldest.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "object.History",
+//     "params": {
+//       // Object reference.
+//       "Reference": str,
+//       // State to start from. Use "NextFrom" of previous reply to fetch next chunk, empty for the latest state.
+//       "From": str,
+//       // Maximum number of states to return (default 100, at most 1000).
+//       "Limit": int
+//     },
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {
+//     "states": [{
+//       "state": str, // State record ID.
+//       "pulse": int, // Pulse number the state was registered in.
+//       "request": str, // Reference of the request that produced the state.
+//       "kind": "activation"|"amend"|"deactivation",
+//       "memory_hash": str // Hex encoded hash of object memory. Empty for deactivation.
+//     }],
+//     "next_from": str // State to continue from. Omitted when the activation state is reached.
+//   }
+//
+func (s *ObjectService) History(r *http.Request, args *ObjectHistoryArgs, reply *ObjectHistoryReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ObjectService.History ] Incoming request: %s", r.RequestURI)
+
+	head, err := core.NewRefFromBase58(args.Reference)
+	if err != nil {
+		return errors.Wrap(err, "[ ObjectService.History ] failed to parse args.Reference")
+	}
+
+	var from *core.RecordID
+	if args.From != "" {
+		from, err = core.NewIDFromBase58(args.From)
+		if err != nil {
+			return errors.Wrap(err, "[ ObjectService.History ] failed to parse args.From")
+		}
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = objectHistoryDefaultLimit
+	}
+	if limit > objectHistoryMaxLimit {
+		limit = objectHistoryMaxLimit
+	}
+
+	history, err := s.runner.ArtifactManager.GetObjectHistory(ctx, *head, from, limit)
+	if err != nil {
+		return errors.Wrap(err, "[ ObjectService.History ]")
+	}
+
+	reply.States = make([]ObjectState, 0, len(history.States))
+	for _, state := range history.States {
+		reply.States = append(reply.States, ObjectState{
+			State:      state.State.String(),
+			Pulse:      uint32(state.Pulse),
+			Request:    state.Request.String(),
+			Kind:       string(state.Kind),
+			MemoryHash: hex.EncodeToString(state.MemoryHash),
+		})
+	}
+	if history.NextFrom != nil {
+		reply.NextFrom = history.NextFrom.String()
+	}
+
+	return nil
+}
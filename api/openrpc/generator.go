@@ -0,0 +1,213 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package openrpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	typeOfError         = reflect.TypeOf((*error)(nil)).Elem()
+	typeOfRequest       = reflect.TypeOf((*http.Request)(nil))
+	typeOfTime          = reflect.TypeOf(time.Time{})
+	typeOfJSONMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	typeOfTextMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Generator builds OpenRPC document from Go types of services.
+type Generator struct {
+	methods []*Method
+	schemas map[string]*Schema
+}
+
+// NewGenerator creates new Generator instance.
+func NewGenerator() *Generator {
+	return &Generator{schemas: map[string]*Schema{}}
+}
+
+// AddService adds methods of JSON-RPC service registered in gorilla rpc server with provided name. Methods that don't
+// look like `func (*T) Method(*http.Request, *Args, *Reply) error` are skipped like gorilla rpc does.
+// Descriptions are optional, they are keyed by method name without service name.
+func (g *Generator) AddService(name string, endpoint string, service interface{}, descriptions map[string]string) {
+	rcvr := reflect.TypeOf(service)
+	for i := 0; i < rcvr.NumMethod(); i++ {
+		m := rcvr.Method(i)
+		mtype := m.Type
+		if m.PkgPath != "" || mtype.NumIn() != 4 || mtype.NumOut() != 1 {
+			continue
+		}
+		if mtype.In(1) != typeOfRequest || mtype.Out(0) != typeOfError {
+			continue
+		}
+		args, reply := mtype.In(2), mtype.In(3)
+		if args.Kind() != reflect.Ptr || reply.Kind() != reflect.Ptr {
+			continue
+		}
+
+		g.methods = append(g.methods, &Method{
+			Name:           name + "." + m.Name,
+			Description:    descriptions[m.Name],
+			ParamStructure: ByName,
+			Params: []*ContentDescriptor{
+				{Name: "params", Required: true, Schema: g.SchemaOf(args.Elem())},
+			},
+			Result:   &ContentDescriptor{Name: "result", Schema: g.SchemaOf(reply.Elem())},
+			Endpoint: endpoint,
+		})
+	}
+}
+
+// AddMethod adds method described by hand.
+func (g *Generator) AddMethod(m *Method) {
+	g.methods = append(g.methods, m)
+}
+
+// Document returns OpenRPC document with all added methods sorted by name.
+func (g *Generator) Document(info Info) *Document {
+	methods := make([]*Method, len(g.methods))
+	copy(methods, g.methods)
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+
+	doc := &Document{
+		OpenRPC: Version,
+		Info:    info,
+		Methods: methods,
+	}
+	if len(g.schemas) > 0 {
+		doc.Components = &Components{Schemas: g.schemas}
+	}
+	return doc
+}
+
+// SchemaOf returns JSON schema of values of type t as encoding/json marshals them. Named structs are added to
+// components as "<package>.<Type>" and referenced.
+func (g *Generator) SchemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == typeOfTime:
+		return &Schema{Type: TypeString, Format: "date-time"}
+	case t.Implements(typeOfJSONMarshaler) || reflect.PtrTo(t).Implements(typeOfJSONMarshaler):
+		return &Schema{}
+	case t.Implements(typeOfTextMarshaler) || reflect.PtrTo(t).Implements(typeOfTextMarshaler):
+		return String()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Integer()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Unsigned()
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeNumber}
+	case reflect.String:
+		return String()
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: TypeString, ContentEncoding: "base64"}
+		}
+		return ArrayOf(g.SchemaOf(t.Elem()))
+	case reflect.Map:
+		return &Schema{Type: TypeObject, AdditionalProperties: g.SchemaOf(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	}
+	// Interfaces and other kinds may hold any value.
+	return &Schema{}
+}
+
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	var name string
+	if t.Name() != "" {
+		name = path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := g.schemas[name]; ok {
+			return &Schema{Ref: "#/components/schemas/" + name}
+		}
+		// Placeholder stops recursion on self-referencing types.
+		g.schemas[name] = &Schema{}
+	}
+
+	schema := &Schema{Type: TypeObject, Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		fieldName, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			fieldName, opts = tag[:idx], tag[idx+1:]
+		}
+
+		if field.Anonymous && fieldName == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := g.inlineStruct(embedded)
+				for k, v := range inner.Properties {
+					schema.Properties[k] = v
+				}
+				schema.Required = append(schema.Required, inner.Required...)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if fieldName == "" {
+			fieldName = field.Name
+		}
+		schema.Properties[fieldName] = g.SchemaOf(field.Type)
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, fieldName)
+		}
+	}
+
+	if name == "" {
+		return schema
+	}
+	g.schemas[name] = schema
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// inlineStruct returns schema of embedded struct with properties instead of reference.
+func (g *Generator) inlineStruct(t reflect.Type) *Schema {
+	schema := g.SchemaOf(t)
+	if schema.Ref == "" {
+		return schema
+	}
+	return g.schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+}
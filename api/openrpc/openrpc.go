@@ -0,0 +1,118 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package openrpc describes API methods with OpenRPC document and validates params with JSON schemas.
+package openrpc
+
+// Version is a version of OpenRPC specification of generated documents.
+const Version = "1.0.0"
+
+// Param structures of method.
+const (
+	ByName     = "by-name"
+	ByPosition = "by-position"
+)
+
+// Document is an OpenRPC document.
+type Document struct {
+	OpenRPC    string      `json:"openrpc"`
+	Info       Info        `json:"info"`
+	Methods    []*Method   `json:"methods"`
+	Components *Components `json:"components,omitempty"`
+}
+
+// Info is a metadata of API.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Method describes API method.
+type Method struct {
+	Name           string               `json:"name"`
+	Description    string               `json:"description,omitempty"`
+	ParamStructure string               `json:"paramStructure,omitempty"`
+	Params         []*ContentDescriptor `json:"params"`
+	Result         *ContentDescriptor   `json:"result"`
+	// Endpoint is a path of endpoint that serves the method.
+	Endpoint string `json:"x-endpoint,omitempty"`
+}
+
+// ContentDescriptor describes method param or result.
+type ContentDescriptor struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Components holds schemas referenced from methods.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// Schema is a JSON schema of value.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// JSON schema types.
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeArray   = "array"
+	TypeObject  = "object"
+)
+
+// FormatReference is a format of string with base58 reference.
+const FormatReference = "reference"
+
+// String returns schema of string.
+func String() *Schema {
+	return &Schema{Type: TypeString}
+}
+
+// Reference returns schema of string with base58 reference.
+func Reference() *Schema {
+	return &Schema{Type: TypeString, Format: FormatReference}
+}
+
+// Integer returns schema of integer.
+func Integer() *Schema {
+	return &Schema{Type: TypeInteger}
+}
+
+// Unsigned returns schema of non-negative integer.
+func Unsigned() *Schema {
+	zero := float64(0)
+	return &Schema{Type: TypeInteger, Minimum: &zero}
+}
+
+// ArrayOf returns schema of array with items of provided schema.
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: TypeArray, Items: items}
+}
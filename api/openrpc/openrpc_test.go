@@ -0,0 +1,149 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package openrpc
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testArgs struct {
+	Reference string
+	Limit     int    `json:"limit,omitempty"`
+	Skipped   string `json:"-"`
+}
+
+type testItem struct {
+	Amount uint   `json:"amount"`
+	Data   []byte `json:"data"`
+	Next   *testItem
+}
+
+type testReply struct {
+	Items []testItem       `json:"items"`
+	Meta  map[string]int64 `json:"meta"`
+	Any   interface{}      `json:"any"`
+}
+
+type testService struct{}
+
+func (s *testService) Get(r *http.Request, args *testArgs, reply *testReply) error {
+	return nil
+}
+
+func (s *testService) NotRPC(args *testArgs) error {
+	return nil
+}
+
+func TestGenerator_AddService(t *testing.T) {
+	g := NewGenerator()
+	g.AddService("test", "/api/rpc", &testService{}, map[string]string{"Get": "Returns items."})
+	g.AddMethod(&Method{Name: "Call", ParamStructure: ByPosition})
+	doc := g.Document(Info{Title: "test", Version: "1"})
+
+	require.Len(t, doc.Methods, 2)
+	assert.Equal(t, "Call", doc.Methods[0].Name)
+	get := doc.Methods[1]
+	assert.Equal(t, "test.Get", get.Name)
+	assert.Equal(t, "Returns items.", get.Description)
+	assert.Equal(t, "/api/rpc", get.Endpoint)
+	assert.Equal(t, "#/components/schemas/openrpc.testArgs", get.Params[0].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/openrpc.testReply", get.Result.Schema.Ref)
+
+	schemas := doc.Components.Schemas
+	args := schemas["openrpc.testArgs"]
+	assert.Equal(t, []string{"Reference"}, args.Required)
+	assert.Equal(t, TypeString, args.Properties["Reference"].Type)
+	assert.Equal(t, TypeInteger, args.Properties["limit"].Type)
+	assert.NotContains(t, args.Properties, "Skipped")
+
+	reply := schemas["openrpc.testReply"]
+	assert.Equal(t, TypeArray, reply.Properties["items"].Type)
+	assert.Equal(t, "#/components/schemas/openrpc.testItem", reply.Properties["items"].Items.Ref)
+	assert.Equal(t, TypeInteger, reply.Properties["meta"].AdditionalProperties.Type)
+	assert.Equal(t, "", reply.Properties["any"].Type)
+
+	item := schemas["openrpc.testItem"]
+	assert.Equal(t, float64(0), *item.Properties["amount"].Minimum)
+	assert.Equal(t, "base64", item.Properties["data"].ContentEncoding)
+	assert.Equal(t, "#/components/schemas/openrpc.testItem", item.Properties["Next"].Ref)
+}
+
+func TestGenerator_SchemaOf(t *testing.T) {
+	g := NewGenerator()
+	assert.Equal(t, TypeBoolean, g.SchemaOf(reflect.TypeOf(true)).Type)
+	assert.Equal(t, TypeNumber, g.SchemaOf(reflect.TypeOf(1.5)).Type)
+	assert.Equal(t, TypeArray, g.SchemaOf(reflect.TypeOf([]string{})).Type)
+	assert.Equal(t, TypeString, g.SchemaOf(reflect.TypeOf(new(*string))).Type)
+}
+
+func TestValidateParams(t *testing.T) {
+	method := &Method{
+		Name: "Transfer",
+		Params: []*ContentDescriptor{
+			{Name: "amount", Required: true, Schema: Unsigned()},
+			{Name: "to", Required: true, Schema: Reference()},
+			{Name: "approvers", Schema: ArrayOf(Reference())},
+		},
+	}
+	ref := testutils.RandomRef().String()
+
+	tests := []struct {
+		name   string
+		params []interface{}
+		err    string
+	}{
+		{"valid", []interface{}{uint64(10), ref}, ""},
+		{"float amount from JSON", []interface{}{float64(10), ref, []interface{}{ref}}, ""},
+		{"missing param", []interface{}{uint64(10)}, "Transfer: missing param 1 (to)"},
+		{"too many params", []interface{}{1, ref, nil, nil}, "Transfer: expected at most 3 params, got 4"},
+		{"wrong type", []interface{}{"10", ref}, "Transfer: invalid param 0 (amount): expected integer, got string"},
+		{"fraction", []interface{}{1.5, ref}, "Transfer: invalid param 0 (amount): expected integer, got number"},
+		{"negative", []interface{}{int64(-1), ref}, "Transfer: invalid param 0 (amount): must be greater than or equal to 0"},
+		{"invalid reference", []interface{}{1, "abc"}, "Transfer: invalid param 1 (to): invalid reference"},
+		{"invalid item", []interface{}{1, ref, []interface{}{ref, 2}}, "Transfer: invalid param 2 (approvers): [1]: expected string, got integer"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateParams(method, test.params)
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestValidate_Object(t *testing.T) {
+	schema := &Schema{
+		Type:       TypeObject,
+		Required:   []string{"name"},
+		Properties: map[string]*Schema{"name": String(), "enabled": {Type: TypeBoolean}},
+	}
+
+	assert.NoError(t, Validate(schema, map[string]interface{}{"name": "a", "enabled": true}))
+	assert.NoError(t, Validate(schema, map[interface{}]interface{}{"name": "a"}))
+	assert.EqualError(t, Validate(schema, map[string]interface{}{}), "missing property name")
+	assert.EqualError(t, Validate(schema, map[string]interface{}{"name": "a", "enabled": "yes"}), "enabled: expected boolean, got string")
+	assert.EqualError(t, Validate(schema, []interface{}{}), "expected object, got array")
+}
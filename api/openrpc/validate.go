@@ -0,0 +1,203 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package openrpc
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// Validate checks that value decoded from JSON or CBOR matches schema. Referenced schemas are not supported.
+func Validate(schema *Schema, value interface{}) error {
+	return validate(schema, value, "")
+}
+
+// ValidateParams checks positional params of method.
+func ValidateParams(method *Method, params []interface{}) error {
+	if len(params) > len(method.Params) {
+		return errors.Errorf("%s: expected at most %d params, got %d", method.Name, len(method.Params), len(params))
+	}
+	for i, param := range method.Params {
+		if i >= len(params) {
+			if param.Required {
+				return errors.Errorf("%s: missing param %d (%s)", method.Name, i, param.Name)
+			}
+			continue
+		}
+		err := validate(param.Schema, params[i], "")
+		if err != nil {
+			return errors.Wrapf(err, "%s: invalid param %d (%s)", method.Name, i, param.Name)
+		}
+	}
+	return nil
+}
+
+func validate(schema *Schema, value interface{}, path string) error {
+	if schema.Ref != "" {
+		return pathError(path, "unresolved schema reference %s", schema.Ref)
+	}
+
+	switch schema.Type {
+	case "":
+		return nil
+	case TypeString:
+		s, ok := value.(string)
+		if !ok {
+			return typeError(path, schema.Type, value)
+		}
+		if schema.Format == FormatReference {
+			if _, err := core.NewRefFromBase58(s); err != nil {
+				return pathError(path, "invalid reference")
+			}
+		}
+	case TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return typeError(path, schema.Type, value)
+		}
+	case TypeInteger, TypeNumber:
+		n, ok := toFloat(value)
+		if !ok || (schema.Type == TypeInteger && n != math.Trunc(n)) {
+			return typeError(path, schema.Type, value)
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			return pathError(path, "must be greater than or equal to %v", *schema.Minimum)
+		}
+	case TypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			return typeError(path, schema.Type, value)
+		}
+		if schema.Items == nil {
+			return nil
+		}
+		for i, item := range items {
+			if err := validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case TypeObject:
+		return validateObject(schema, value, path)
+	default:
+		return pathError(path, "unknown schema type %s", schema.Type)
+	}
+	return nil
+}
+
+func validateObject(schema *Schema, value interface{}, path string) error {
+	fields := map[string]interface{}{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		fields = v
+	case map[interface{}]interface{}:
+		// CBOR decoder makes maps with interface keys.
+		for key, field := range v {
+			name, ok := key.(string)
+			if !ok {
+				return pathError(path, "object keys must be strings")
+			}
+			fields[name] = field
+		}
+	default:
+		return typeError(path, schema.Type, value)
+	}
+
+	for _, name := range schema.Required {
+		if _, ok := fields[name]; !ok {
+			return pathError(path, "missing property %s", name)
+		}
+	}
+	for name, field := range fields {
+		fieldSchema, ok := schema.Properties[name]
+		if !ok {
+			fieldSchema = schema.AdditionalProperties
+		}
+		if fieldSchema == nil {
+			continue
+		}
+		if err := validate(fieldSchema, field, strings.TrimPrefix(path+"."+name, ".")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func typeError(path string, expected string, value interface{}) error {
+	return pathError(path, "expected %s, got %s", expected, typeName(value))
+}
+
+func pathError(path string, format string, args ...interface{}) error {
+	if path == "" {
+		return errors.Errorf(format, args...)
+	}
+	return errors.Errorf(path+": "+format, args...)
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return TypeString
+	case bool:
+		return TypeBoolean
+	case []interface{}:
+		return TypeArray
+	case map[string]interface{}, map[interface{}]interface{}:
+		return TypeObject
+	}
+	if n, ok := toFloat(value); ok {
+		if n == math.Trunc(n) {
+			return TypeInteger
+		}
+		return TypeNumber
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -149,14 +149,30 @@ func SendWithSeed(ctx context.Context, url string, userCfg *UserConfigJSON, reqC
 		return nil, errors.New("[ Send ] Configs must be initialized")
 	}
 
+	request, err := signRequest(ctx, userCfg, reqCfg, seed)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Send ]")
+	}
+	request["seed"] = seed
+
+	body, err := GetResponseBody(url, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Send ] Problem with sending target request")
+	}
+
+	return body, nil
+}
+
+// signRequest returns request params signed with seed. Seed itself is not included.
+func signRequest(ctx context.Context, userCfg *UserConfigJSON, reqCfg *RequestConfigJSON, seed []byte) (PostParams, error) {
 	params, err := constructParams(reqCfg.Params)
 	if err != nil {
-		return nil, errors.Wrap(err, "[ Send ] Problem with serializing params")
+		return nil, errors.Wrap(err, "[ signRequest ] Problem with serializing params")
 	}
 
 	callerRef, err := core.NewRefFromBase58(userCfg.Caller)
 	if err != nil {
-		return nil, errors.Wrap(err, "[ Send ] Failed to parse userCfg.Caller")
+		return nil, errors.Wrap(err, "[ signRequest ] Failed to parse userCfg.Caller")
 	}
 
 	serRequest, err := core.MarshalArgs(
@@ -165,32 +181,69 @@ func SendWithSeed(ctx context.Context, url string, userCfg *UserConfigJSON, reqC
 		params,
 		seed)
 	if err != nil {
-		return nil, errors.Wrap(err, "[ Send ] Problem with serializing request")
+		return nil, errors.Wrap(err, "[ signRequest ] Problem with serializing request")
 	}
 
 	verboseInfo(ctx, "Signing request ...")
 	cs := scheme.Signer(userCfg.privateKeyObject)
 	signature, err := cs.Sign(serRequest)
 	if err != nil {
-		return nil, errors.Wrap(err, "[ Send ] Problem with signing request")
+		return nil, errors.Wrap(err, "[ signRequest ] Problem with signing request")
 	}
 	verboseInfo(ctx, "Signing request completed")
 
-	body, err := GetResponseBody(url, PostParams{
+	return PostParams{
 		"params":    params,
 		"method":    reqCfg.Method,
 		"reference": userCfg.Caller,
-		"seed":      seed,
 		"signature": signature.Bytes(),
-	})
+	}, nil
+}
+
+// SendBatchWithSeed sends batch of requests signed with one known seed
+func SendBatchWithSeed(ctx context.Context, url string, userCfg *UserConfigJSON, reqCfgs []*RequestConfigJSON, seed []byte) ([]byte, error) {
+	if userCfg == nil {
+		return nil, errors.New("[ SendBatch ] Configs must be initialized")
+	}
+
+	calls := make([]PostParams, 0, len(reqCfgs))
+	for _, reqCfg := range reqCfgs {
+		if reqCfg == nil {
+			return nil, errors.New("[ SendBatch ] Configs must be initialized")
+		}
+		request, err := signRequest(ctx, userCfg, reqCfg, seed)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ SendBatch ]")
+		}
+		calls = append(calls, request)
+	}
 
+	body, err := GetResponseBody(url, PostParams{
+		"seed":  seed,
+		"calls": calls,
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "[ Send ] Problem with sending target request")
+		return nil, errors.Wrap(err, "[ SendBatch ] Problem with sending target request")
 	}
 
 	return body, nil
 }
 
+// SendBatch first gets seed and after that makes batch request
+func SendBatch(ctx context.Context, url string, userCfg *UserConfigJSON, reqCfgs []*RequestConfigJSON) ([]byte, error) {
+	seed, err := GetSeed(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SendBatch ] Problem with getting seed")
+	}
+
+	response, err := SendBatchWithSeed(ctx, url+"/batch", userCfg, reqCfgs, seed)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ SendBatch ]")
+	}
+
+	return response, nil
+}
+
 // Send first gets seed and after that makes target request
 func Send(ctx context.Context, url string, userCfg *UserConfigJSON, reqCfg *RequestConfigJSON) ([]byte, error) {
 	verboseInfo(ctx, "Sending GETSEED request ...")
@@ -0,0 +1,197 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/openrpc"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/version"
+)
+
+// memberMethods describes methods of member contract that are called with /api/call. Params are positional.
+// The list must follow switch of member.Call, TestMemberMethods_MatchContract checks method names and param counts.
+var memberMethods = []*openrpc.Method{
+	memberMethod("CreateMember", "Creates new member. Only root member may call it.",
+		param("name", openrpc.String()),
+		param("publicKey", openrpc.String()),
+	),
+	memberMethod("GetMyBalance", "Returns balance of caller."),
+	memberMethod("GetBalance", "Returns balance of member.",
+		param("member", openrpc.Reference()),
+	),
+	memberMethod("Transfer", "Transfers amount from caller to member.",
+		param("amount", openrpc.Unsigned()),
+		param("to", openrpc.Reference()),
+	),
+	memberMethod("DumpUserInfo", "Returns info of member. Only root member may dump other members.",
+		param("member", openrpc.Reference()),
+	),
+	memberMethod("DumpAllUsers", "Returns info of all members. Only root member may call it."),
+	memberMethod("ListMembers", "Returns page of members after cursor. Only root member may call it.",
+		param("cursor", openrpc.String()),
+		param("limit", openrpc.Integer()),
+	),
+	memberMethod("GetTransferHistory", "Returns page of transfers of member, newest first.",
+		param("member", openrpc.Reference()),
+		param("cursor", openrpc.Integer()),
+		param("limit", openrpc.Integer()),
+	),
+	memberMethod("RegisterNode", "Registers node. Only root member may call it.",
+		param("publicKey", openrpc.String()),
+		param("role", openrpc.String()),
+	),
+	memberMethod("GetNodeRef", "Returns reference of node with public key.",
+		param("publicKey", openrpc.String()),
+	),
+	memberMethod("EscrowTransfer", "Transfers amount to member in escrow with optional time lock and approvers.",
+		param("amount", openrpc.Unsigned()),
+		param("to", openrpc.Reference()),
+		param("release", openrpc.Integer()),
+		param("expire", openrpc.Integer()),
+		param("approvers", openrpc.ArrayOf(openrpc.Reference())),
+		param("required", openrpc.Integer()),
+	),
+	memberMethod("ApproveTransfer", "Approves escrow transfer.",
+		param("allowance", openrpc.Reference()),
+	),
+	memberMethod("AcceptTransfer", "Accepts escrow transfer to caller.",
+		param("allowance", openrpc.Reference()),
+	),
+	memberMethod("CancelTransfer", "Cancels escrow transfer of caller.",
+		param("allowance", openrpc.Reference()),
+	),
+	memberMethod("GetTransferStatus", "Returns status of escrow transfer.",
+		param("allowance", openrpc.Reference()),
+	),
+	memberMethod("CreateToken", "Creates token with initial supply on caller token wallet.",
+		param("name", openrpc.String()),
+		param("symbol", openrpc.String()),
+		param("amount", openrpc.Unsigned()),
+	),
+	memberMethod("MintToken", "Mints amount of token to member. Only token issuer may call it.",
+		param("token", openrpc.Reference()),
+		param("amount", openrpc.Unsigned()),
+		param("to", openrpc.Reference()),
+	),
+	memberMethod("BurnToken", "Burns amount of token of caller.",
+		param("token", openrpc.Reference()),
+		param("amount", openrpc.Unsigned()),
+	),
+	memberMethod("TransferToken", "Transfers amount of token from caller to member.",
+		param("token", openrpc.Reference()),
+		param("amount", openrpc.Unsigned()),
+		param("to", openrpc.Reference()),
+	),
+	memberMethod("GetTokenBalance", "Returns token balance of member.",
+		param("token", openrpc.Reference()),
+		param("member", openrpc.Reference()),
+	),
+}
+
+var memberMethodsByName = func() map[string]*openrpc.Method {
+	methods := map[string]*openrpc.Method{}
+	for _, m := range memberMethods {
+		methods[m.Name] = m
+	}
+	return methods
+}()
+
+func memberMethod(name string, description string, params ...*openrpc.ContentDescriptor) *openrpc.Method {
+	if params == nil {
+		params = []*openrpc.ContentDescriptor{}
+	}
+	return &openrpc.Method{
+		Name:           name,
+		Description:    description,
+		ParamStructure: openrpc.ByPosition,
+		Params:         params,
+		Result:         &openrpc.ContentDescriptor{Name: "result", Schema: &openrpc.Schema{}},
+	}
+}
+
+func param(name string, schema *openrpc.Schema) *openrpc.ContentDescriptor {
+	return &openrpc.ContentDescriptor{Name: name, Required: true, Schema: schema}
+}
+
+// validateCallParams checks params of member contract call before sending it to contract.
+func validateCallParams(method string, params []byte) error {
+	m, ok := memberMethodsByName[method]
+	if !ok {
+		return errors.New("unknown method " + method)
+	}
+
+	var args []interface{}
+	if len(params) > 0 {
+		err := core.Deserialize(params, &args)
+		if err != nil {
+			return errors.Wrap(err, "can't deserialize params")
+		}
+	}
+	return openrpc.ValidateParams(m, args)
+}
+
+// SchemaReply is an OpenRPC document of API.
+type SchemaReply = openrpc.Document
+
+// SchemaService is a service that provides API schema.
+type SchemaService struct {
+	runner *Runner
+}
+
+// NewSchemaService creates new Schema service instance.
+func NewSchemaService(runner *Runner) *SchemaService {
+	return &SchemaService{runner: runner}
+}
+
+// Get returns OpenRPC document that describes JSON-RPC methods and methods of member contract called with call
+// endpoint. Methods have "x-endpoint" field with path of endpoint that serves them.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "schema.Get",
+//     "id": str|int|null
+//   }
+//
+func (s *SchemaService) Get(r *http.Request, args *interface{}, reply *SchemaReply) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ SchemaService.Get ] Incoming request: %s", r.RequestURI)
+
+	*reply = *s.runner.schema()
+	return nil
+}
+
+func (ar *Runner) schema() *openrpc.Document {
+	g := openrpc.NewGenerator()
+	for _, s := range ar.services() {
+		g.AddService(s.name, ar.cfg.RPC, s.service, s.descriptions)
+	}
+	for _, m := range memberMethods {
+		method := *m
+		method.Endpoint = ar.cfg.Call
+		g.AddMethod(&method)
+	}
+	return g.Document(openrpc.Info{Title: "Insolar API", Version: version.Version})
+}
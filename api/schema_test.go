@@ -0,0 +1,147 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_schema(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	runner, err := NewRunner(&cfg)
+	require.NoError(t, err)
+
+	doc := runner.schema()
+	endpoints := map[string]string{}
+	for _, m := range doc.Methods {
+		endpoints[m.Name] = m.Endpoint
+	}
+	for _, name := range []string{"info.Get", "status.Get", "seed.Get", "cert.Get", "exporter.Export", "schema.Get"} {
+		assert.Equal(t, cfg.RPC, endpoints[name], name)
+	}
+	for _, m := range memberMethods {
+		assert.Equal(t, cfg.Call, endpoints[m.Name], m.Name)
+	}
+
+	_, err = json.Marshal(doc)
+	require.NoError(t, err)
+}
+
+func TestValidateCallParams(t *testing.T) {
+	params, err := core.MarshalArgs(float64(100), testutils.RandomRef().String())
+	require.NoError(t, err)
+	assert.NoError(t, validateCallParams("Transfer", params))
+
+	params, err = core.MarshalArgs()
+	require.NoError(t, err)
+	assert.NoError(t, validateCallParams("GetMyBalance", params))
+	assert.NoError(t, validateCallParams("GetMyBalance", nil))
+
+	assert.EqualError(t, validateCallParams("Unknown", params), "unknown method Unknown")
+
+	params, err = core.MarshalArgs(111, "000")
+	require.NoError(t, err)
+	assert.EqualError(
+		t, validateCallParams("CreateMember", params),
+		"CreateMember: invalid param 0 (name): expected string, got integer",
+	)
+}
+
+// memberCallParams parses member contract and returns count of positional params of methods handled by Member.Call.
+func memberCallParams(t *testing.T) map[string]int {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "../application/contract/member/member.go", nil, 0)
+	require.NoError(t, err)
+
+	funcs := map[string]*ast.FuncDecl{}
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+			funcs[fn.Name.Name] = fn
+		}
+	}
+
+	// Count of params is a count of targets of signer.UnmarshalParams(params, ...) in method implementation or in
+	// helper methods it calls.
+	var countParams func(fn *ast.FuncDecl) int
+	countParams = func(fn *ast.FuncDecl) int {
+		count := 0
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if sel.Sel.Name == "UnmarshalParams" {
+				count = len(call.Args) - 1
+			} else if helper, ok := funcs[sel.Sel.Name]; ok && helper != fn && count == 0 {
+				count = countParams(helper)
+			}
+			return true
+		})
+		return count
+	}
+
+	call, ok := funcs["Call"]
+	require.True(t, ok, "Member.Call not found")
+	methods := map[string]int{}
+	ast.Inspect(call, func(n ast.Node) bool {
+		clause, ok := n.(*ast.CaseClause)
+		if !ok {
+			return true
+		}
+		require.Len(t, clause.List, 1)
+		name, err := strconv.Unquote(clause.List[0].(*ast.BasicLit).Value)
+		require.NoError(t, err)
+
+		impl := ""
+		ast.Inspect(clause, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok && impl == "" {
+				impl = sel.Sel.Name
+			}
+			return true
+		})
+		fn, ok := funcs[impl]
+		require.True(t, ok, "implementation of %s not found", name)
+		methods[name] = countParams(fn)
+		return false
+	})
+	return methods
+}
+
+func TestMemberMethods_MatchContract(t *testing.T) {
+	contract := memberCallParams(t)
+
+	schema := map[string]int{}
+	for _, m := range memberMethods {
+		schema[m.Name] = len(m.Params)
+	}
+	assert.Equal(t, contract, schema, "memberMethods must describe every method of member.Call with its params")
+}
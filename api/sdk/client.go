@@ -0,0 +1,440 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/utils/backoff"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRetries             = 3
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultSeedTTL             = 500 * time.Millisecond
+	defaultSeedPrefetch        = 2
+	defaultRequestTimeout      = 30 * time.Second
+)
+
+// ErrNoHealthyNodes is returned when none of client nodes is ready to serve calls.
+var ErrNoHealthyNodes = errors.New("no healthy nodes")
+
+// CallError is an error returned by node for member call.
+type CallError struct {
+	Method  string
+	Message string
+	TraceID string
+}
+
+func (e *CallError) Error() string {
+	return fmt.Sprintf("%s failed: %s (trace id %s)", e.Method, e.Message, e.TraceID)
+}
+
+type cachedSeed struct {
+	value   []byte
+	expires time.Time
+}
+
+type node struct {
+	url string
+
+	lock     sync.Mutex
+	healthy  bool
+	checked  time.Time
+	seeds    []cachedSeed
+	fetching bool
+}
+
+// popSeed returns the oldest seed that is not expired or nil. Must be called under lock.
+func (n *node) popSeed(now time.Time) []byte {
+	for len(n.seeds) > 0 {
+		seed := n.seeds[0]
+		n.seeds = n.seeds[1:]
+		if now.Before(seed.expires) {
+			return seed.value
+		}
+	}
+	return nil
+}
+
+// Client sends signed member calls to API of several nodes.
+//
+// Calls are balanced between nodes with complete network state. Node that fails to respond is not used until the
+// next health check. Seeds are requested in advance and each call is sent to the node that issued its seed.
+//
+// Tunable fields must be set before the first call.
+type Client struct {
+	nodes  []*node
+	cursor uint32
+	client *http.Client
+
+	// Retries is a number of retries of a call that didn't reach contract, e.g. node is down or seed is expired.
+	// Calls that may have been executed are never retried.
+	Retries int
+	// Backoff defines pauses between retries.
+	Backoff backoff.Backoff
+	// HealthCheckInterval is a period node status is trusted for.
+	HealthCheckInterval time.Duration
+	// SeedTTL is a period a seed is used for after it was requested. It must be less than seed TTL of nodes.
+	SeedTTL time.Duration
+	// SeedPrefetch is a number of seeds requested from each node in advance.
+	SeedPrefetch int
+}
+
+// NewClient creates Client for API urls of nodes (e.g. http://localhost:19101/api).
+func NewClient(urls []string) (*Client, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("[ NewClient ] no API urls")
+	}
+	nodes := make([]*node, 0, len(urls))
+	for _, u := range urls {
+		nodes = append(nodes, &node{url: strings.TrimSuffix(u, "/")})
+	}
+	return &Client{
+		nodes:               nodes,
+		client:              &http.Client{Timeout: defaultRequestTimeout},
+		Retries:             defaultRetries,
+		Backoff:             backoff.Backoff{Min: 100 * time.Millisecond, Max: 2 * time.Second, Factor: 2, Jitter: true},
+		HealthCheckInterval: defaultHealthCheckInterval,
+		SeedTTL:             defaultSeedTTL,
+		SeedPrefetch:        defaultSeedPrefetch,
+	}, nil
+}
+
+// Member returns client of member calls signed by m.
+func (c *Client) Member(m *Member) *MemberClient {
+	return &MemberClient{client: c, member: m}
+}
+
+// Info returns references of root objects.
+func (c *Client) Info(ctx context.Context) (*requester.InfoResponse, error) {
+	info := &requester.InfoResponse{}
+	err := c.retry(ctx, func() (bool, error) {
+		n, err := c.pickNode(ctx)
+		if err != nil {
+			return true, err
+		}
+		err = c.rpc(ctx, n, "info.Get", info)
+		if err != nil {
+			c.fail(ctx, n)
+			return true, err
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Info ]")
+	}
+	return info, nil
+}
+
+// Call signs member call with params, sends it to one of healthy nodes and unmarshals its result into result.
+// Result may be nil if it is not needed.
+func (c *Client) Call(ctx context.Context, m *Member, method string, params []interface{}, result interface{}) error {
+	_, err := c.invoke(ctx, m, method, params, result)
+	return err
+}
+
+// invoke is Call that also returns trace id of the last attempt.
+func (c *Client) invoke(ctx context.Context, m *Member, method string, params []interface{}, result interface{}) (string, error) {
+	serialized, err := core.MarshalArgs(params...)
+	if err != nil {
+		return "", errors.Wrap(err, "[ Call ] can't marshal params")
+	}
+	reference, err := core.NewRefFromBase58(m.Reference)
+	if err != nil {
+		return "", errors.Wrap(err, "[ Call ] can't parse member reference")
+	}
+	var traceID string
+	err = c.retry(ctx, func() (bool, error) {
+		n, err := c.pickNode(ctx)
+		if err != nil {
+			return true, err
+		}
+		seed, err := c.seed(ctx, n)
+		if err != nil {
+			c.fail(ctx, n)
+			return true, err
+		}
+
+		request, err := core.MarshalArgs(*reference, method, serialized, seed)
+		if err != nil {
+			return false, errors.Wrap(err, "can't marshal request")
+		}
+		signature, err := m.signer.Sign(request)
+		if err != nil {
+			return false, errors.Wrap(err, "can't sign request")
+		}
+
+		body, err := c.post(ctx, n.url+"/call", map[string]interface{}{
+			"reference": m.Reference,
+			"method":    method,
+			"params":    serialized,
+			"seed":      seed,
+			"signature": signature.Bytes(),
+		})
+		if err != nil {
+			// Request could reach the node unless it wasn't even connected.
+			if isDialError(err) {
+				c.fail(ctx, n)
+				return true, err
+			}
+			return false, err
+		}
+
+		resp := struct {
+			Error   string
+			Result  json.RawMessage
+			TraceID string
+		}{}
+		err = json.Unmarshal(body, &resp)
+		if err != nil {
+			return false, errors.Wrap(err, "can't unmarshal response")
+		}
+		traceID = resp.TraceID
+		if resp.Error != "" {
+			// Seed is checked before the call is registered.
+			return strings.Contains(resp.Error, "Incorrect seed"), &CallError{
+				Method:  method,
+				Message: resp.Error,
+				TraceID: resp.TraceID,
+			}
+		}
+		if result != nil && len(resp.Result) > 0 {
+			err = json.Unmarshal(resp.Result, result)
+			if err != nil {
+				return false, errors.Wrap(err, "can't unmarshal result")
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return traceID, errors.Wrap(err, "[ Call ] "+method)
+	}
+	return traceID, nil
+}
+
+// retry calls attempt until it succeeds, returns not retriable error or retries are exhausted.
+func (c *Client) retry(ctx context.Context, attempt func() (bool, error)) error {
+	for i := 0; ; i++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		retriable, err := attempt()
+		if err == nil || !retriable || i >= c.Retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), err.Error())
+		case <-time.After(c.Backoff.ForAttempt(i)):
+		}
+	}
+}
+
+// pickNode returns the next healthy node.
+func (c *Client) pickNode(ctx context.Context) (*node, error) {
+	start := int(atomic.AddUint32(&c.cursor, 1))
+	for i := 0; i < len(c.nodes); i++ {
+		n := c.nodes[(start+i)%len(c.nodes)]
+		if c.isHealthy(ctx, n) {
+			return n, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, ErrNoHealthyNodes
+}
+
+// isHealthy checks node status unless the last check is recent enough.
+func (c *Client) isHealthy(ctx context.Context, n *node) bool {
+	n.lock.Lock()
+	healthy, checked := n.healthy, n.checked
+	n.lock.Unlock()
+	if time.Since(checked) < c.HealthCheckInterval {
+		return healthy
+	}
+
+	status := &requester.StatusResponse{}
+	err := c.rpc(ctx, n, "status.Get", status)
+	if ctx.Err() != nil {
+		return false
+	}
+	healthy = err == nil && status.NetworkState == core.CompleteNetworkState.String()
+
+	n.lock.Lock()
+	n.healthy = healthy
+	n.checked = time.Now()
+	n.lock.Unlock()
+	return healthy
+}
+
+// fail excludes node until the next health check. Failures caused by cancelled context are ignored.
+func (c *Client) fail(ctx context.Context, n *node) {
+	if ctx.Err() != nil {
+		return
+	}
+	n.lock.Lock()
+	n.healthy = false
+	n.checked = time.Now()
+	n.seeds = nil
+	n.lock.Unlock()
+}
+
+// seed returns cached seed of node or requests a new one. Cache is refilled in background.
+func (c *Client) seed(ctx context.Context, n *node) ([]byte, error) {
+	n.lock.Lock()
+	seed := n.popSeed(time.Now())
+	n.lock.Unlock()
+
+	c.prefetchSeeds(n)
+	if seed != nil {
+		return seed, nil
+	}
+	return c.fetchSeed(ctx, n)
+}
+
+func (c *Client) prefetchSeeds(n *node) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.fetching || len(n.seeds) >= c.SeedPrefetch {
+		return
+	}
+	n.fetching = true
+
+	go func() {
+		defer func() {
+			n.lock.Lock()
+			n.fetching = false
+			n.lock.Unlock()
+		}()
+		for {
+			n.lock.Lock()
+			full := len(n.seeds) >= c.SeedPrefetch
+			n.lock.Unlock()
+			if full {
+				return
+			}
+
+			expires := time.Now().Add(c.SeedTTL)
+			ctx, cancel := context.WithTimeout(context.Background(), c.SeedTTL)
+			seed, err := c.fetchSeed(ctx, n)
+			cancel()
+			if err != nil {
+				return
+			}
+
+			n.lock.Lock()
+			n.seeds = append(n.seeds, cachedSeed{value: seed, expires: expires})
+			n.lock.Unlock()
+		}
+	}()
+}
+
+func (c *Client) fetchSeed(ctx context.Context, n *node) ([]byte, error) {
+	reply := struct {
+		Seed []byte
+	}{}
+	err := c.rpc(ctx, n, "seed.Get", &reply)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ fetchSeed ]")
+	}
+	return reply.Seed, nil
+}
+
+// rpc calls JSON-RPC method without params on node.
+func (c *Client) rpc(ctx context.Context, n *node, method string, result interface{}) error {
+	body, err := c.post(ctx, n.url+"/rpc", map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp := struct {
+		Result json.RawMessage
+		Error  *struct {
+			Code    int
+			Message string
+		}
+	}{}
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return errors.Wrapf(err, "[ rpc ] can't unmarshal %s response", method)
+	}
+	if resp.Error != nil {
+		return errors.Errorf("[ rpc ] %s failed: %s", method, resp.Error.Message)
+	}
+	err = json.Unmarshal(resp.Result, result)
+	if err != nil {
+		return errors.Wrapf(err, "[ rpc ] can't unmarshal %s result", method)
+	}
+	return nil
+}
+
+func (c *Client) post(ctx context.Context, endpoint string, request interface{}) ([]byte, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ post ] can't marshal request")
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "[ post ] can't create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "[ post ] can't send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("[ post ] unexpected response status: %s", resp.Status)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ post ] can't read response")
+	}
+	return body, nil
+}
+
+func isDialError(err error) bool {
+	urlErr, ok := errors.Cause(err).(*url.Error)
+	if !ok {
+		return false
+	}
+	opErr, ok := urlErr.Err.(*net.OpError)
+	return ok && opErr.Op == "dial"
+}
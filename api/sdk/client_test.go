@@ -0,0 +1,337 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package sdk
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCall struct {
+	Reference string
+	Method    string
+	Params    []byte
+	Seed      []byte
+	Signature []byte
+}
+
+// testNode is a fake node API that checks seeds and signatures of calls.
+type testNode struct {
+	*httptest.Server
+	t         *testing.T
+	state     string
+	publicKey interface{}
+
+	lock   sync.Mutex
+	seeds  map[string]bool
+	calls  []testCall
+	handle func(call testCall, params []interface{}) (interface{}, string)
+}
+
+func newTestNode(t *testing.T, publicKey interface{}) *testNode {
+	n := &testNode{
+		t:         t,
+		state:     core.CompleteNetworkState.String(),
+		publicKey: publicKey,
+		seeds:     map[string]bool{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/rpc", n.rpc)
+	mux.HandleFunc("/api/call", n.call)
+	n.Server = httptest.NewServer(mux)
+	return n
+}
+
+func (n *testNode) apiURL() string {
+	return n.URL + "/api"
+}
+
+func (n *testNode) callCount() int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return len(n.calls)
+}
+
+func (n *testNode) rpc(w http.ResponseWriter, r *http.Request) {
+	req := struct{ Method string }{}
+	require.NoError(n.t, json.NewDecoder(r.Body).Decode(&req))
+
+	var result interface{}
+	switch req.Method {
+	case "status.Get":
+		result = map[string]interface{}{"NetworkState": n.state}
+	case "info.Get":
+		result = map[string]interface{}{"RootMember": testutils.RandomRef().String()}
+	case "seed.Get":
+		seed := []byte(testutils.RandomString())
+		n.lock.Lock()
+		n.seeds[string(seed)] = true
+		n.lock.Unlock()
+		result = map[string]interface{}{"Seed": seed}
+	}
+	require.NoError(n.t, json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "result": result}))
+}
+
+func (n *testNode) call(w http.ResponseWriter, r *http.Request) {
+	call := testCall{}
+	require.NoError(n.t, json.NewDecoder(r.Body).Decode(&call))
+
+	n.lock.Lock()
+	n.calls = append(n.calls, call)
+	knownSeed := n.seeds[string(call.Seed)]
+	delete(n.seeds, string(call.Seed))
+	handle := n.handle
+	n.lock.Unlock()
+
+	var result interface{}
+	var callErr string
+	if !knownSeed {
+		callErr = "[ checkSeed ] Incorrect seed"
+	} else {
+		ref, err := core.NewRefFromBase58(call.Reference)
+		require.NoError(n.t, err)
+		signed, err := core.MarshalArgs(*ref, call.Method, call.Params, call.Seed)
+		require.NoError(n.t, err)
+		verifier := platformpolicy.NewPlatformCryptographyScheme().Verifier(n.publicKey)
+		require.True(n.t, verifier.Verify(core.SignatureFromBytes(call.Signature), signed))
+
+		var params []interface{}
+		require.NoError(n.t, core.Deserialize(call.Params, &params))
+		result, callErr = handle(call, params)
+	}
+	require.NoError(n.t, json.NewEncoder(w).Encode(map[string]interface{}{
+		"result":  result,
+		"error":   callErr,
+		"traceID": "trace",
+	}))
+}
+
+func newTestMember(t *testing.T) (*Member, interface{}) {
+	return newTestMemberWithScheme(t, platformpolicy.DefaultSignatureScheme)
+}
+
+func newTestMemberWithScheme(t *testing.T, signatureScheme string) (*Member, interface{}) {
+	ks, err := platformpolicy.NewKeyProcessorByName(signatureScheme)
+	require.NoError(t, err)
+	privateKey, err := ks.GeneratePrivateKey()
+	require.NoError(t, err)
+	privateKeyStr, err := ks.ExportPrivateKeyPEM(privateKey)
+	require.NoError(t, err)
+	member, err := NewMember(testutils.RandomRef().String(), string(privateKeyStr))
+	require.NoError(t, err)
+	return member, ks.ExtractPublicKey(privateKey)
+}
+
+func assertEqualMembers(t *testing.T, expected *Member, actual *Member) {
+	assert.Equal(t, expected.Reference, actual.Reference)
+	assert.Equal(t, expected.SignatureScheme(), actual.SignatureScheme())
+	key, ok := expected.PrivateKey.(interface{ Equal(crypto.PrivateKey) bool })
+	require.True(t, ok)
+	assert.True(t, key.Equal(actual.PrivateKey))
+}
+
+func newTestClient(t *testing.T, urls ...string) *Client {
+	c, err := NewClient(urls)
+	require.NoError(t, err)
+	c.Backoff.Min = time.Millisecond
+	c.Backoff.Max = 10 * time.Millisecond
+	return c
+}
+
+func TestMemberClient(t *testing.T) {
+	ctx := context.Background()
+	member, publicKey := newTestMember(t)
+	n := newTestNode(t, publicKey)
+	defer n.Close()
+
+	to := testutils.RandomRef().String()
+	page, err := json.Marshal(MembersPage{Members: []UserInfo{{Reference: to, Name: "to", Balance: 10}}, NextCursor: to})
+	require.NoError(t, err)
+	n.handle = func(call testCall, params []interface{}) (interface{}, string) {
+		switch call.Method {
+		case "GetBalance":
+			assert.Equal(t, []interface{}{to}, params)
+			return 100, ""
+		case "Transfer":
+			assert.Equal(t, []interface{}{uint64(5), to}, params)
+			return nil, ""
+		case "ListMembers":
+			assert.Equal(t, []interface{}{"", uint64(10)}, params)
+			return page, ""
+		}
+		return nil, "unexpected method " + call.Method
+	}
+
+	mc := newTestClient(t, n.apiURL()).Member(member)
+
+	balance, err := mc.GetBalance(ctx, to)
+	require.NoError(t, err)
+	assert.Equal(t, uint(100), balance)
+
+	require.NoError(t, mc.Transfer(ctx, 5, to))
+
+	members, err := mc.ListMembers(ctx, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, to, members.NextCursor)
+	assert.Equal(t, []UserInfo{{Reference: to, Name: "to", Balance: 10}}, members.Members)
+
+	err = mc.BurnToken(ctx, to, 1)
+	require.Error(t, err)
+	callErr, ok := errors.Cause(err).(*CallError)
+	require.True(t, ok)
+	assert.Equal(t, "BurnToken", callErr.Method)
+	assert.Equal(t, "trace", callErr.TraceID)
+}
+
+func TestMemberClient_Ed25519(t *testing.T) {
+	member, publicKey := newTestMemberWithScheme(t, platformpolicy.Ed25519)
+	assert.Equal(t, platformpolicy.Ed25519, member.SignatureScheme())
+	n := newTestNode(t, publicKey)
+	defer n.Close()
+	n.handle = func(call testCall, params []interface{}) (interface{}, string) {
+		return nil, ""
+	}
+
+	err := newTestClient(t, n.apiURL()).Member(member).Transfer(context.Background(), 5, testutils.RandomRef().String())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n.callCount())
+}
+
+func TestNewMember_InvalidKey(t *testing.T) {
+	_, err := NewMember(testutils.RandomRef().String(), "not a key")
+	require.Error(t, err)
+}
+
+func TestClient_Failover(t *testing.T) {
+	ctx := context.Background()
+	member, publicKey := newTestMember(t)
+	handle := func(call testCall, params []interface{}) (interface{}, string) {
+		return nil, ""
+	}
+
+	down := newTestNode(t, publicKey)
+	down.Close()
+	syncing := newTestNode(t, publicKey)
+	defer syncing.Close()
+	syncing.state = core.VoidNetworkState.String()
+	syncing.handle = handle
+	up := newTestNode(t, publicKey)
+	defer up.Close()
+	up.handle = handle
+
+	c := newTestClient(t, down.apiURL(), syncing.apiURL(), up.apiURL())
+	for i := 0; i < 5; i++ {
+		require.NoError(t, c.Member(member).Transfer(ctx, 1, testutils.RandomRef().String()))
+	}
+	assert.Equal(t, 0, syncing.callCount())
+	assert.Equal(t, 5, up.callCount())
+}
+
+func TestClient_Retries(t *testing.T) {
+	ctx := context.Background()
+	member, publicKey := newTestMember(t)
+	n := newTestNode(t, publicKey)
+	defer n.Close()
+	n.handle = func(call testCall, params []interface{}) (interface{}, string) {
+		return nil, "insufficient funds"
+	}
+	c := newTestClient(t, n.apiURL())
+
+	t.Run("expired seed is retried", func(t *testing.T) {
+		n.lock.Lock()
+		n.seeds = map[string]bool{}
+		n.lock.Unlock()
+		c.nodes[0].seeds = []cachedSeed{{value: []byte("expired"), expires: time.Now().Add(time.Hour)}}
+
+		err := c.Member(member).Transfer(ctx, 1, testutils.RandomRef().String())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient funds")
+		assert.Equal(t, 2, n.callCount())
+	})
+
+	t.Run("contract error is not retried", func(t *testing.T) {
+		before := n.callCount()
+		err := c.Member(member).Transfer(ctx, 1, testutils.RandomRef().String())
+		require.Error(t, err)
+		assert.Equal(t, before+1, n.callCount())
+	})
+
+	t.Run("no healthy nodes", func(t *testing.T) {
+		n.state = core.VoidNetworkState.String()
+		c := newTestClient(t, n.apiURL())
+		err := c.Member(member).Transfer(ctx, 1, testutils.RandomRef().String())
+		require.Error(t, err)
+		assert.Equal(t, ErrNoHealthyNodes, errors.Cause(err))
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		err := c.Member(member).Transfer(ctx, 1, testutils.RandomRef().String())
+		require.Error(t, err)
+		assert.Equal(t, context.Canceled, errors.Cause(err))
+	})
+}
+
+func TestLoadMember(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sdk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	member, _ := newTestMember(t)
+	privateKey, err := platformpolicy.NewKeyProcessor().ExportPrivateKeyPEM(member.PrivateKey)
+	require.NoError(t, err)
+	keys, err := json.Marshal(map[string]string{"private_key": string(privateKey)})
+	require.NoError(t, err)
+	path := filepath.Join(dir, "keys.json")
+	require.NoError(t, ioutil.WriteFile(path, keys, 0600))
+
+	loaded, err := LoadMember(member.Reference, path)
+	require.NoError(t, err)
+	assertEqualMembers(t, member, loaded)
+}
+
+func TestMember_JSON(t *testing.T) {
+	for _, signatureScheme := range platformpolicy.SignatureSchemes() {
+		t.Run(signatureScheme, func(t *testing.T) {
+			member, _ := newTestMemberWithScheme(t, signatureScheme)
+			data, err := json.Marshal([]*Member{member})
+			require.NoError(t, err)
+
+			var members []*Member
+			require.NoError(t, json.Unmarshal(data, &members))
+			require.Len(t, members, 1)
+			assertEqualMembers(t, member, members[0])
+		})
+	}
+}
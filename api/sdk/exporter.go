@@ -0,0 +1,161 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultExportSize         = 10
+	defaultExportPollInterval = 10 * time.Second
+	maxExportLineSize         = 64 << 20
+)
+
+// ExportChunk is storage data of one jet on one pulse.
+type ExportChunk struct {
+	// Cursor is a position right after this chunk.
+	Cursor string
+	Data   struct {
+		Records map[string]json.RawMessage
+		Pulse   struct {
+			PulseNumber     uint32
+			PrevPulseNumber uint32
+			NextPulseNumber uint32
+			PulseTimestamp  int64
+		}
+		JetID string
+	}
+	Error string
+}
+
+// Exporter reads storage data from streaming export endpoint of a node.
+type Exporter struct {
+	url    string
+	client *http.Client
+
+	// Size is a number of pulses fetched by one request.
+	Size int
+	// PollInterval is a pause between requests when there is no new data.
+	PollInterval time.Duration
+}
+
+// NewExporter creates Exporter for export endpoint url (e.g. http://localhost:19101/api/export).
+func NewExporter(exportURL string) *Exporter {
+	return &Exporter{
+		url:          exportURL,
+		client:       &http.Client{},
+		Size:         defaultExportSize,
+		PollInterval: defaultExportPollInterval,
+	}
+}
+
+// Export fetches chunks after cursor and calls handler for each of them. Returns cursor to continue from.
+//
+// Returned cursor points to the last chunk passed to handler, so export may be resumed after any error.
+func (e *Exporter) Export(ctx context.Context, cursor string, handler func(*ExportChunk) error) (string, error) {
+	query := url.Values{}
+	query.Set("size", strconv.Itoa(e.Size))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, e.url+"?"+query.Encode(), nil)
+	if err != nil {
+		return cursor, errors.Wrap(err, "[ Export ] can't create request")
+	}
+	resp, err := e.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return cursor, errors.Wrap(err, "[ Export ] can't send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return cursor, fmt.Errorf("[ Export ] unexpected response status: %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(nil, maxExportLineSize)
+	for scanner.Scan() {
+		chunk := &ExportChunk{}
+		err = json.Unmarshal(scanner.Bytes(), chunk)
+		if err != nil {
+			return cursor, errors.Wrap(err, "[ Export ] can't unmarshal chunk")
+		}
+		if chunk.Error != "" {
+			return cursor, errors.New("[ Export ] export failed on node: " + chunk.Error)
+		}
+
+		err = handler(chunk)
+		if err != nil {
+			return cursor, errors.Wrap(err, "[ Export ] handler failed")
+		}
+		cursor = chunk.Cursor
+	}
+	if err := scanner.Err(); err != nil {
+		return cursor, errors.Wrap(err, "[ Export ] can't read response")
+	}
+
+	return cursor, nil
+}
+
+// Tail continuously exports chunks starting after cursor until context is done or handler fails.
+//
+// Network errors are retried after PollInterval.
+func (e *Exporter) Tail(ctx context.Context, cursor string, handler func(*ExportChunk) error) error {
+	for {
+		next, err := e.Export(ctx, cursor, func(chunk *ExportChunk) error {
+			if err := handler(chunk); err != nil {
+				return &handlerError{err: err}
+			}
+			return nil
+		})
+		if herr, ok := errors.Cause(err).(*handlerError); ok {
+			return herr.err
+		}
+
+		// Continue without pause while there is new data.
+		if err == nil && next != cursor {
+			cursor = next
+			continue
+		}
+		cursor = next
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(e.PollInterval):
+		}
+	}
+}
+
+type handlerError struct {
+	err error
+}
+
+func (e *handlerError) Error() string {
+	return e.err.Error()
+}
@@ -0,0 +1,205 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// MemberClient sends calls of member contract signed by the member.
+type MemberClient struct {
+	client *Client
+	member *Member
+}
+
+// Reference returns reference of the member.
+func (mc *MemberClient) Reference() string {
+	return mc.member.Reference
+}
+
+func (mc *MemberClient) call(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	return mc.client.Call(ctx, mc.member, method, params, result)
+}
+
+// callJSON calls method that returns JSON encoded bytes and unmarshals them into result.
+func (mc *MemberClient) callJSON(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+	var data []byte
+	err := mc.call(ctx, method, &data, params...)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, result)
+	if err != nil {
+		return errors.Wrapf(err, "[ %s ] can't unmarshal result", method)
+	}
+	return nil
+}
+
+// CreateMember creates new member with public key in PEM format and returns its reference.
+// Only root member may call it.
+func (mc *MemberClient) CreateMember(ctx context.Context, name string, publicKey string) (string, error) {
+	var ref string
+	err := mc.call(ctx, "CreateMember", &ref, name, publicKey)
+	return ref, err
+}
+
+// GetMyBalance returns balance of the member.
+func (mc *MemberClient) GetMyBalance(ctx context.Context) (uint, error) {
+	var balance uint
+	err := mc.call(ctx, "GetMyBalance", &balance)
+	return balance, err
+}
+
+// GetBalance returns balance of member.
+func (mc *MemberClient) GetBalance(ctx context.Context, member string) (uint, error) {
+	var balance uint
+	err := mc.call(ctx, "GetBalance", &balance, member)
+	return balance, err
+}
+
+// Transfer transfers amount from the member to member to.
+func (mc *MemberClient) Transfer(ctx context.Context, amount uint, to string) error {
+	return mc.call(ctx, "Transfer", nil, amount, to)
+}
+
+// DumpUserInfo returns info of member. Only root member may dump other members.
+func (mc *MemberClient) DumpUserInfo(ctx context.Context, member string) (*UserInfo, error) {
+	info := &UserInfo{}
+	err := mc.callJSON(ctx, "DumpUserInfo", info, member)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// DumpAllUsers returns info of all members. Only root member may call it.
+func (mc *MemberClient) DumpAllUsers(ctx context.Context) ([]UserInfo, error) {
+	var users []UserInfo
+	err := mc.callJSON(ctx, "DumpAllUsers", &users)
+	return users, err
+}
+
+// ListMembers returns page of at most limit members after cursor. Empty cursor starts from the first member.
+// Only root member may call it.
+func (mc *MemberClient) ListMembers(ctx context.Context, cursor string, limit int) (*MembersPage, error) {
+	page := &MembersPage{}
+	err := mc.callJSON(ctx, "ListMembers", page, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetTransferHistory returns page of at most limit transfers of member before cursor, newest first. Zero cursor starts
+// from the newest transfer. Members can read only their own history, root member can read history of any member.
+func (mc *MemberClient) GetTransferHistory(ctx context.Context, member string, cursor int, limit int) (*TransferHistory, error) {
+	history := &TransferHistory{}
+	err := mc.callJSON(ctx, "GetTransferHistory", history, member, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// RegisterNode registers node with public key and role and returns its certificate. Only root member may call it.
+func (mc *MemberClient) RegisterNode(ctx context.Context, publicKey string, role string) (string, error) {
+	var cert string
+	err := mc.call(ctx, "RegisterNode", &cert, publicKey, role)
+	return cert, err
+}
+
+// GetNodeRef returns reference of node with public key.
+func (mc *MemberClient) GetNodeRef(ctx context.Context, publicKey string) (string, error) {
+	var ref string
+	err := mc.call(ctx, "GetNodeRef", &ref, publicKey)
+	return ref, err
+}
+
+// EscrowTransfer transfers amount to member to in escrow and returns allowance reference.
+//
+// Transfer can be accepted after release time (unix seconds, zero for no lock) and when required number of approvers
+// approved it. It can be cancelled by the member after expire time.
+func (mc *MemberClient) EscrowTransfer(
+	ctx context.Context, amount uint, to string, release int64, expire int64, approvers []string, required int,
+) (string, error) {
+	if approvers == nil {
+		approvers = []string{}
+	}
+	var allowance string
+	err := mc.call(ctx, "EscrowTransfer", &allowance, amount, to, release, expire, approvers, required)
+	return allowance, err
+}
+
+// ApproveTransfer approves escrow transfer.
+func (mc *MemberClient) ApproveTransfer(ctx context.Context, allowance string) error {
+	return mc.call(ctx, "ApproveTransfer", nil, allowance)
+}
+
+// AcceptTransfer accepts escrow transfer to the member.
+func (mc *MemberClient) AcceptTransfer(ctx context.Context, allowance string) error {
+	return mc.call(ctx, "AcceptTransfer", nil, allowance)
+}
+
+// CancelTransfer cancels escrow transfer of the member.
+func (mc *MemberClient) CancelTransfer(ctx context.Context, allowance string) error {
+	return mc.call(ctx, "CancelTransfer", nil, allowance)
+}
+
+// GetTransferStatus returns status of escrow transfer.
+func (mc *MemberClient) GetTransferStatus(ctx context.Context, allowance string) (*TransferStatus, error) {
+	status := &TransferStatus{}
+	err := mc.callJSON(ctx, "GetTransferStatus", status, allowance)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// CreateToken creates token with initial supply on the member token wallet and returns token reference.
+func (mc *MemberClient) CreateToken(ctx context.Context, name string, symbol string, amount uint) (string, error) {
+	var token string
+	err := mc.call(ctx, "CreateToken", &token, name, symbol, amount)
+	return token, err
+}
+
+// MintToken mints amount of token to member to. Only token issuer may call it.
+func (mc *MemberClient) MintToken(ctx context.Context, token string, amount uint, to string) error {
+	return mc.call(ctx, "MintToken", nil, token, amount, to)
+}
+
+// BurnToken burns amount of token of the member.
+func (mc *MemberClient) BurnToken(ctx context.Context, token string, amount uint) error {
+	return mc.call(ctx, "BurnToken", nil, token, amount)
+}
+
+// TransferToken transfers amount of token from the member to member to.
+func (mc *MemberClient) TransferToken(ctx context.Context, token string, amount uint, to string) error {
+	return mc.call(ctx, "TransferToken", nil, token, amount, to)
+}
+
+// GetTokenBalance returns token balance of member.
+func (mc *MemberClient) GetTokenBalance(ctx context.Context, token string, member string) (uint, error) {
+	var balance uint
+	err := mc.call(ctx, "GetTokenBalance", &balance, token, member)
+	return balance, err
+}
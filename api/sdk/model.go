@@ -16,16 +16,146 @@
 
 package sdk
 
+import (
+	"crypto"
+	"encoding/json"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/keystore"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
 // Member model object
 type Member struct {
+	Reference string
+	// PrivateKey is a parsed private key of the member.
+	PrivateKey crypto.PrivateKey
+
+	signatureScheme string
+	signer          core.Signer
+}
+
+// NewMember creates Member with private key in PEM format.
+func NewMember(ref string, key string) (*Member, error) {
+	privateKey, err := platformpolicy.NewKeyProcessor().ImportPrivateKeyPEM([]byte(key))
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewMember ] can't import private key")
+	}
+	return NewMemberWithKey(ref, privateKey)
+}
+
+// NewMemberWithKey creates Member with parsed private key. Calls are signed by signature scheme of the key.
+func NewMemberWithKey(ref string, privateKey crypto.PrivateKey) (*Member, error) {
+	signatureScheme, err := platformpolicy.SignatureSchemeOfPrivateKey(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewMemberWithKey ]")
+	}
+	scheme, err := platformpolicy.NewPlatformCryptographySchemeByName(signatureScheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewMemberWithKey ]")
+	}
+	return &Member{
+		Reference:       ref,
+		PrivateKey:      privateKey,
+		signatureScheme: signatureScheme,
+		signer:          scheme.Signer(privateKey),
+	}, nil
+}
+
+// SignatureScheme returns name of signature scheme of the member key.
+func (m *Member) SignatureScheme() string {
+	return m.signatureScheme
+}
+
+// memberJSON is a serialized Member with private key in PEM format.
+type memberJSON struct {
 	Reference  string
 	PrivateKey string
 }
 
-// NewMember creates new Member
-func NewMember(ref string, key string) *Member {
-	return &Member{
-		Reference:  ref,
-		PrivateKey: key,
+// MarshalJSON implements json.Marshaler.
+func (m *Member) MarshalJSON() ([]byte, error) {
+	privateKey, err := platformpolicy.NewKeyProcessor().ExportPrivateKeyPEM(m.PrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ MarshalJSON ] can't export private key")
 	}
+	return json.Marshal(memberJSON{Reference: m.Reference, PrivateKey: string(privateKey)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Member) UnmarshalJSON(data []byte) error {
+	raw := memberJSON{}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return errors.Wrap(err, "[ UnmarshalJSON ]")
+	}
+	member, err := NewMember(raw.Reference, raw.PrivateKey)
+	if err != nil {
+		return errors.Wrap(err, "[ UnmarshalJSON ]")
+	}
+	*m = *member
+	return nil
+}
+
+// LoadMember creates Member with private key loaded by keystore from keysPath.
+// Encrypted key files are decrypted with passphrase from INSOLAR_KEYS_PASSPHRASE(_FILE) environment.
+func LoadMember(ref string, keysPath string) (*Member, error) {
+	ks, err := keystore.NewKeyStore(keysPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ LoadMember ] can't load keys")
+	}
+	privateKey, err := ks.GetPrivateKey("")
+	if err != nil {
+		return nil, errors.Wrap(err, "[ LoadMember ] can't get private key")
+	}
+	member, err := NewMemberWithKey(ref, privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ LoadMember ]")
+	}
+	return member, nil
+}
+
+// UserInfo is a member info returned by DumpUserInfo and ListMembers.
+type UserInfo struct {
+	Reference       string `json:"reference"`
+	Name            string `json:"member"`
+	Balance         uint   `json:"wallet"`
+	WalletReference string `json:"walletReference"`
+}
+
+// MembersPage is a page of members returned by ListMembers.
+type MembersPage struct {
+	Members []UserInfo `json:"members"`
+	// NextCursor is a cursor of the next page. It is empty on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// TransferRecord is a single wallet transfer in TransferHistory.
+type TransferRecord struct {
+	Direction    string `json:"direction"`
+	Counterparty string `json:"counterparty,omitempty"`
+	Allowance    string `json:"allowance"`
+	Amount       uint   `json:"amount"`
+	Pulse        uint32 `json:"pulse"`
+	Request      string `json:"request"`
+}
+
+// TransferHistory is a page of transfers returned by GetTransferHistory.
+type TransferHistory struct {
+	Transfers []TransferRecord `json:"transfers"`
+	// NextCursor is a cursor of the next page. It is zero on the last page.
+	NextCursor int `json:"nextCursor,omitempty"`
+}
+
+// TransferStatus is a status of escrow transfer returned by GetTransferStatus.
+type TransferStatus struct {
+	From        string   `json:"from"`
+	To          string   `json:"to"`
+	Amount      uint     `json:"amount"`
+	ReleaseTime int64    `json:"releaseTime"`
+	ExpireTime  int64    `json:"expireTime"`
+	Required    int      `json:"required"`
+	Approvals   []string `json:"approvals"`
+	Expired     bool     `json:"expired"`
 }
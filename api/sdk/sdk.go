@@ -18,120 +18,63 @@ package sdk
 
 import (
 	"context"
-	"encoding/json"
-	"io/ioutil"
-	"sync"
 
-	"github.com/insolar/insolar/api/requester"
-	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/testutils"
 	"github.com/pkg/errors"
 )
 
-type response struct {
-	Error   string
-	Result  interface{}
-	TraceID string
-}
-
-type ringBuffer struct {
-	sync.Mutex
-	urls   []string
-	cursor int
-}
-
-func (rb *ringBuffer) next() string {
-	rb.Lock()
-	defer rb.Unlock()
-	rb.cursor++
-	if rb.cursor >= len(rb.urls) {
-		rb.cursor = 0
-	}
-	return rb.urls[rb.cursor]
-}
-
-type memberKeys struct {
-	Private string `json:"private_key"`
-	Public  string `json:"public_key"`
-}
-
 // SDK is used to send messages to API
 type SDK struct {
-	apiURLs    *ringBuffer
-	rootMember *requester.UserConfigJSON
+	client     *Client
+	rootMember *Member
 }
 
 // NewSDK creates insSDK object
 func NewSDK(urls []string, rootMemberKeysPath string) (*SDK, error) {
-	buffer := &ringBuffer{urls: urls}
-
-	rawConf, err := ioutil.ReadFile(rootMemberKeysPath)
+	client, err := NewClient(urls)
 	if err != nil {
-		return nil, errors.Wrap(err, "[ NewSDK ] can't read keys from file")
+		return nil, errors.Wrap(err, "[ NewSDK ] can't create client")
 	}
 
-	keys := memberKeys{}
-	err = json.Unmarshal(rawConf, &keys)
-	if err != nil {
-		return nil, errors.Wrap(err, "[ NewSDK ] can't unmarshal keys")
-	}
-
-	response, err := requester.Info(buffer.next())
+	response, err := client.Info(context.Background())
 	if err != nil {
 		return nil, errors.Wrap(err, "[ NewSDK ] can't get info")
 	}
 
-	rootMember, err := requester.CreateUserConfig(response.RootMember, keys.Private)
+	rootMember, err := LoadMember(response.RootMember, rootMemberKeysPath)
 	if err != nil {
-		return nil, errors.Wrap(err, "[ NewSDK ] can't create user config")
+		return nil, errors.Wrap(err, "[ NewSDK ] can't load root member")
 	}
 
 	return &SDK{
-		apiURLs:    buffer,
+		client:     client,
 		rootMember: rootMember,
 	}, nil
 
 }
 
-func (sdk *SDK) sendRequest(ctx context.Context, method string, params []interface{}, userCfg *requester.UserConfigJSON) ([]byte, error) {
-	reqCfg := &requester.RequestConfigJSON{
-		Params: params,
-		Method: method,
-	}
-
-	body, err := requester.Send(ctx, sdk.apiURLs.next(), userCfg, reqCfg)
-	if err != nil {
-		return nil, errors.Wrap(err, "[ sendRequest ] can not send request")
-	}
-
-	return body, nil
+// Client returns client used by SDK.
+func (sdk *SDK) Client() *Client {
+	return sdk.client
 }
 
-func (sdk *SDK) getResponse(body []byte) (*response, error) {
-	res := &response{}
-	err := json.Unmarshal(body, &res)
-	if err != nil {
-		return nil, errors.Wrap(err, "[ getResponse ] problems with unmarshal response")
-	}
-
-	return res, nil
+// RootMember returns root member used to create members.
+func (sdk *SDK) RootMember() *Member {
+	return sdk.rootMember
 }
 
 // CreateMember api request creates member with new random keys
 func (sdk *SDK) CreateMember() (*Member, string, error) {
-	ctx := inslogger.ContextWithTrace(context.Background(), "CreateMember")
 	memberName := testutils.RandomString()
-	ks := platformpolicy.NewKeyProcessor()
-
-	privateKey, err := ks.GeneratePrivateKey()
+	ks, err := platformpolicy.NewKeyProcessorByName(sdk.rootMember.SignatureScheme())
 	if err != nil {
-		return nil, "", errors.Wrap(err, "[ CreateMember ] can't generate private key")
+		return nil, "", errors.Wrap(err, "[ CreateMember ] can't create key processor")
 	}
 
-	privateKeyStr, err := ks.ExportPrivateKeyPEM(privateKey)
+	privateKey, err := ks.GeneratePrivateKey()
 	if err != nil {
-		return nil, "", errors.Wrap(err, "[ CreateMember ] can't export private key")
+		return nil, "", errors.Wrap(err, "[ CreateMember ] can't generate private key")
 	}
 
 	memberPubKeyStr, err := ks.ExportPublicKeyPEM(ks.ExtractPublicKey(privateKey))
@@ -139,73 +82,37 @@ func (sdk *SDK) CreateMember() (*Member, string, error) {
 		return nil, "", errors.Wrap(err, "[ CreateMember ] can't extract public key")
 	}
 
+	var ref string
 	params := []interface{}{memberName, string(memberPubKeyStr)}
-	body, err := sdk.sendRequest(ctx, "CreateMember", params, sdk.rootMember)
+	traceID, err := sdk.client.invoke(context.Background(), sdk.rootMember, "CreateMember", params, &ref)
 	if err != nil {
-		return nil, "", errors.Wrap(err, "[ CreateMember ] can't send request")
+		return nil, traceID, errors.Wrap(err, "[ CreateMember ]")
 	}
 
-	response, err := sdk.getResponse(body)
+	member, err := NewMemberWithKey(ref, privateKey)
 	if err != nil {
-		return nil, "", errors.Wrap(err, "[ CreateMember ] can't get response")
+		return nil, traceID, errors.Wrap(err, "[ CreateMember ]")
 	}
-
-	if response.Error != "" {
-		return nil, response.TraceID, errors.New(response.Error)
-	}
-
-	return NewMember(response.Result.(string), string(privateKeyStr)), response.TraceID, nil
+	return member, traceID, nil
 }
 
 // Transfer method send money from one member to another
 func (sdk *SDK) Transfer(amount uint, from *Member, to *Member) (string, error) {
-	ctx := inslogger.ContextWithTrace(context.Background(), "Transfer")
 	params := []interface{}{amount, to.Reference}
-	config, err := requester.CreateUserConfig(from.Reference, from.PrivateKey)
-	if err != nil {
-		return "", errors.Wrap(err, "[ Transfer ] can't create user config")
-	}
-
-	body, err := sdk.sendRequest(ctx, "Transfer", params, config)
-	if err != nil {
-		return "", errors.Wrap(err, "[ Transfer ] can't send request")
-	}
-
-	response, err := sdk.getResponse(body)
+	traceID, err := sdk.client.invoke(context.Background(), from, "Transfer", params, nil)
 	if err != nil {
-		return "", errors.Wrap(err, "[ Transfer ] can't get response")
-	}
-
-	if response.Error != "" {
-		return response.TraceID, errors.New(response.Error)
+		return traceID, errors.Wrap(err, "[ Transfer ]")
 	}
 
-	return response.TraceID, nil
+	return traceID, nil
 }
 
 // GetBalance returns current balance of the given member.
 func (sdk *SDK) GetBalance(m *Member) (uint64, error) {
-	ctx := inslogger.ContextWithTrace(context.Background(), "GetBalance")
-	params := []interface{}{m.Reference}
-	config, err := requester.CreateUserConfig(m.Reference, m.PrivateKey)
-	if err != nil {
-		return 0, errors.Wrap(err, "[ GetBalance ] can't create user config")
-	}
-
-	body, err := sdk.sendRequest(ctx, "GetBalance", params, config)
+	balance, err := sdk.client.Member(m).GetBalance(context.Background(), m.Reference)
 	if err != nil {
-		return 0, errors.Wrap(err, "[ GetBalance ] can't send request")
-	}
-
-	response, err := sdk.getResponse(body)
-	if err != nil {
-		return 0, errors.Wrap(err, "[ GetBalance ] can't get response")
-	}
-
-	if response.Error != "" {
-		return 0, errors.New(response.Error)
+		return 0, errors.Wrap(err, "[ GetBalance ]")
 	}
 
-	// TODO FIXME don't transfer money in floats!
-	return uint64(response.Result.(float64)), nil
+	return uint64(balance), nil
 }
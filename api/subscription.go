@@ -0,0 +1,437 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+const (
+	subscriptionEventsBuffer = 1000
+	maxSubscriptions         = 1000
+	wsWriteTimeout           = 10 * time.Second
+)
+
+// Subscription topics.
+const (
+	// TopicPulse notifies about every new pulse.
+	TopicPulse = "pulse"
+	// TopicObject notifies about new states of object.
+	TopicObject = "object"
+	// TopicRequest notifies once about result of request.
+	TopicRequest = "request"
+)
+
+// SubscribeParams is params of "subscribe" method of subscription endpoint.
+type SubscribeParams struct {
+	Topic string `json:"topic"`
+	// Reference is object reference for "object" topic and request reference for "request" topic.
+	Reference string `json:"reference,omitempty"`
+	// Object is a reference of called object for "request" topic (member reference for /api/call requests).
+	Object string `json:"object,omitempty"`
+}
+
+// UnsubscribeParams is params of "unsubscribe" method of subscription endpoint.
+type UnsubscribeParams struct {
+	Subscription string `json:"subscription"`
+}
+
+// PulseNotification is a notification of "pulse" topic.
+type PulseNotification struct {
+	PulseNumber     core.PulseNumber `json:"pulseNumber"`
+	PrevPulseNumber core.PulseNumber `json:"prevPulseNumber"`
+	NextPulseNumber core.PulseNumber `json:"nextPulseNumber"`
+	Timestamp       int64            `json:"timestamp"`
+	Entropy         []byte           `json:"entropy"`
+}
+
+// ObjectNotification is a notification of "object" topic.
+type ObjectNotification struct {
+	Reference   string `json:"reference"`
+	State       string `json:"state"`
+	Deactivated bool   `json:"deactivated"`
+}
+
+// RequestNotification is a notification of "request" topic.
+type RequestNotification struct {
+	Request      string      `json:"request"`
+	ResultRecord string      `json:"resultRecord"`
+	Result       interface{} `json:"result,omitempty"`
+	Error        string      `json:"error,omitempty"`
+}
+
+type wsRequest struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type wsError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type wsNotification struct {
+	Subscription string      `json:"subscription"`
+	Topic        string      `json:"topic"`
+	Result       interface{} `json:"result"`
+}
+
+type wsMessage struct {
+	Version string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Result  interface{}      `json:"result,omitempty"`
+	Error   *wsError         `json:"error,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  *wsNotification  `json:"params,omitempty"`
+}
+
+// JSON-RPC 2.0 error codes.
+const (
+	wsParseError     = -32700
+	wsMethodNotFound = -32601
+	wsInvalidParams  = -32602
+	wsInternalError  = -32603
+)
+
+type subscription struct {
+	topic string
+	// reference is a subscribed object or request.
+	reference *core.RecordRef
+	// object is a called object of subscribed request.
+	object *core.RecordRef
+}
+
+func (s *subscription) match(event core.Event) bool {
+	switch s.topic {
+	case TopicPulse:
+		return event.Type == core.EventPulse
+	case TopicObject:
+		return event.Type == core.EventObjectState && event.Object.Equal(s.reference.Record())
+	case TopicRequest:
+		return event.Type == core.EventRequestResult && event.Request.Record().Equal(s.reference.Record())
+	}
+	return false
+}
+
+// wsSession serves subscriptions of one WebSocket connection.
+type wsSession struct {
+	runner *Runner
+	conn   *websocket.Conn
+
+	writeLock sync.Mutex
+
+	lock          sync.Mutex
+	subscriptions map[string]*subscription
+	lastID        uint64
+}
+
+// subscribeHandler serves WebSocket connections of clients that subscribe to node events.
+//
+// Requests and notifications are JSON-RPC 2.0 messages.
+//
+//   Subscribe request:
+//   {
+//     "jsonrpc": "2.0",
+//     "id": str|int,
+//     "method": "subscribe",
+//     "params": {
+//       "topic": "pulse"|"object"|"request",
+//       "reference": str, // Object reference for "object" topic and request reference for "request" topic.
+//       "object": str // Called object reference for "request" topic.
+//     }
+//   }
+//
+//   Subscribe response (result is subscription id):
+//   {"jsonrpc": "2.0", "id": str|int, "result": str}
+//
+//   Unsubscribe request (result is true):
+//   {"jsonrpc": "2.0", "id": str|int, "method": "unsubscribe", "params": {"subscription": str}}
+//
+//   Notification:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "subscription",
+//     "params": {
+//       "subscription": str,
+//       "topic": "pulse"|"object"|"request",
+//       "result": PulseNotification|ObjectNotification|RequestNotification
+//     }
+//   }
+//
+// Subscription to request is closed after the result is sent. If the request is already executed, the result is sent
+// right after subscription. Object and request notifications are published by virtual nodes that execute requests, so
+// clients should connect to the executor of the object to receive these notifications.
+//
+// Browsers may connect only from origins listed in configuration. Connections without Origin header are accepted.
+// checkOrigin allows requests from non-browser clients and from configured origins.
+func (ar *Runner) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range ar.cfg.SubscribeOrigins {
+		if origin == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (ar *Runner) subscribeHandler() func(http.ResponseWriter, *http.Request) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: ar.checkOrigin,
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			inslog.Error(errors.Wrap(err, "[ subscribeHandler ] can't upgrade connection"))
+			return
+		}
+		defer conn.Close()
+
+		session := &wsSession{
+			runner:        ar,
+			conn:          conn,
+			subscriptions: map[string]*subscription{},
+		}
+		session.serve(ctx)
+	}
+}
+
+func (s *wsSession) serve(ctx context.Context) {
+	events, cancel := s.runner.EventBus.Subscribe(ctx, subscriptionEventsBuffer)
+	defer cancel()
+
+	go s.notify(ctx, events)
+
+	for {
+		var req wsRequest
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		err = json.Unmarshal(data, &req)
+		if err != nil {
+			s.writeError(ctx, nil, wsParseError, "can't parse request")
+			continue
+		}
+		s.handle(ctx, &req)
+	}
+}
+
+func (s *wsSession) handle(ctx context.Context, req *wsRequest) {
+	switch req.Method {
+	case "subscribe":
+		var params SubscribeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			s.writeError(ctx, &req.ID, wsInvalidParams, "invalid params")
+			return
+		}
+		id, code, err := s.subscribe(params)
+		if err != nil {
+			s.writeError(ctx, &req.ID, code, err.Error())
+			return
+		}
+		s.write(ctx, &wsMessage{ID: &req.ID, Result: id})
+		if params.Topic == TopicRequest {
+			s.checkRequestResult(ctx, id)
+		}
+
+	case "unsubscribe":
+		var params UnsubscribeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			s.writeError(ctx, &req.ID, wsInvalidParams, "invalid params")
+			return
+		}
+		s.write(ctx, &wsMessage{ID: &req.ID, Result: s.remove(params.Subscription) != nil})
+
+	default:
+		s.writeError(ctx, &req.ID, wsMethodNotFound, "method not found: "+req.Method)
+	}
+}
+
+func (s *wsSession) subscribe(params SubscribeParams) (string, int, error) {
+	sub := &subscription{topic: params.Topic}
+	switch params.Topic {
+	case TopicPulse:
+	case TopicObject, TopicRequest:
+		ref, err := core.NewRefFromBase58(params.Reference)
+		if err != nil {
+			return "", wsInvalidParams, errors.Wrap(err, "invalid reference")
+		}
+		sub.reference = ref
+		if params.Topic == TopicRequest {
+			sub.object, err = core.NewRefFromBase58(params.Object)
+			if err != nil {
+				return "", wsInvalidParams, errors.Wrap(err, "invalid object")
+			}
+		}
+	default:
+		return "", wsInvalidParams, errors.New("unknown topic: " + params.Topic)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.subscriptions) >= maxSubscriptions {
+		return "", wsInternalError, errors.New("too many subscriptions")
+	}
+	s.lastID++
+	id := strconv.FormatUint(s.lastID, 10)
+	s.subscriptions[id] = sub
+	return id, 0, nil
+}
+
+func (s *wsSession) remove(id string) *subscription {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sub, ok := s.subscriptions[id]
+	if !ok {
+		return nil
+	}
+	delete(s.subscriptions, id)
+	return sub
+}
+
+// checkRequestResult sends result of already executed request.
+func (s *wsSession) checkRequestResult(ctx context.Context, id string) {
+	s.lock.Lock()
+	sub, ok := s.subscriptions[id]
+	s.lock.Unlock()
+	if !ok {
+		return
+	}
+
+	res, err := s.runner.ArtifactManager.GetRequestResult(ctx, *sub.object, *sub.reference)
+	if err != nil || res.Result == nil {
+		return
+	}
+	if s.remove(id) == nil {
+		// Result was already sent from event.
+		return
+	}
+	s.write(ctx, requestNotification(id, sub.reference, res.Result, res.Payload))
+}
+
+// notify sends notifications of events to matching subscriptions until events channel is closed.
+func (s *wsSession) notify(ctx context.Context, events <-chan core.Event) {
+	for event := range events {
+		for _, msg := range s.match(event) {
+			s.write(ctx, msg)
+		}
+	}
+	// Events channel is closed when client does not keep up with events or connection is closed.
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	_ = s.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscription closed"),
+		time.Now().Add(wsWriteTimeout),
+	)
+	s.conn.Close()
+}
+
+func (s *wsSession) match(event core.Event) []*wsMessage {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var messages []*wsMessage
+	for id, sub := range s.subscriptions {
+		if !sub.match(event) {
+			continue
+		}
+
+		switch event.Type {
+		case core.EventPulse:
+			messages = append(messages, notification(id, TopicPulse, &PulseNotification{
+				PulseNumber:     event.Pulse.PulseNumber,
+				PrevPulseNumber: event.Pulse.PrevPulseNumber,
+				NextPulseNumber: event.Pulse.NextPulseNumber,
+				Timestamp:       event.Pulse.PulseTimestamp,
+				Entropy:         event.Pulse.Entropy[:],
+			}))
+		case core.EventObjectState:
+			messages = append(messages, notification(id, TopicObject, &ObjectNotification{
+				Reference:   sub.reference.String(),
+				State:       event.State.String(),
+				Deactivated: event.Deactivated,
+			}))
+		case core.EventRequestResult:
+			delete(s.subscriptions, id)
+			messages = append(messages, requestNotification(id, sub.reference, event.Result, event.Payload))
+		}
+	}
+	return messages
+}
+
+func notification(id string, topic string, result interface{}) *wsMessage {
+	return &wsMessage{
+		Method: "subscription",
+		Params: &wsNotification{Subscription: id, Topic: topic, Result: result},
+	}
+}
+
+func requestNotification(id string, request *core.RecordRef, result *core.RecordID, payload []byte) *wsMessage {
+	n := &RequestNotification{
+		Request:      request.String(),
+		ResultRecord: result.String(),
+	}
+	var err error
+	n.Result, n.Error, err = callResult(payload)
+	if err != nil {
+		// Request is not a member call, result payload is left for call.Status.
+		n.Result, n.Error = nil, ""
+	}
+	return notification(id, TopicRequest, n)
+}
+
+func (s *wsSession) writeError(ctx context.Context, id *json.RawMessage, code int, message string) {
+	s.write(ctx, &wsMessage{ID: id, Error: &wsError{Code: code, Message: message}})
+}
+
+func (s *wsSession) write(ctx context.Context, msg *wsMessage) {
+	msg.Version = "2.0"
+
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	err := s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if err == nil {
+		err = s.conn.WriteJSON(msg)
+	}
+	if err != nil {
+		inslogger.FromContext(ctx).Debug(errors.Wrap(err, "[ wsSession.write ] can't write message"))
+	}
+}
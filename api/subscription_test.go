@@ -0,0 +1,226 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/eventbus"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type wsTestMessage struct {
+	ID     *int            `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *wsError        `json:"error"`
+	Method string          `json:"method"`
+	Params struct {
+		Subscription string          `json:"subscription"`
+		Topic        string          `json:"topic"`
+		Result       json.RawMessage `json:"result"`
+	} `json:"params"`
+}
+
+type wsTestClient struct {
+	t      *testing.T
+	conn   *websocket.Conn
+	lastID int
+}
+
+func (c *wsTestClient) call(method string, params interface{}) *wsTestMessage {
+	c.lastID++
+	err := c.conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      c.lastID,
+		"method":  method,
+		"params":  params,
+	})
+	require.NoError(c.t, err)
+
+	msg := c.read()
+	require.NotNil(c.t, msg.ID)
+	require.Equal(c.t, c.lastID, *msg.ID)
+	return msg
+}
+
+func (c *wsTestClient) subscribe(params SubscribeParams) string {
+	msg := c.call("subscribe", params)
+	require.Nil(c.t, msg.Error)
+	var id string
+	require.NoError(c.t, json.Unmarshal(msg.Result, &id))
+	return id
+}
+
+func (c *wsTestClient) read() *wsTestMessage {
+	err := c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	require.NoError(c.t, err)
+	msg := &wsTestMessage{}
+	err = c.conn.ReadJSON(msg)
+	require.NoError(c.t, err)
+	return msg
+}
+
+func (c *wsTestClient) readNotification(subscription string, result interface{}) {
+	msg := c.read()
+	require.Equal(c.t, "subscription", msg.Method)
+	require.Equal(c.t, subscription, msg.Params.Subscription)
+	require.NoError(c.t, json.Unmarshal(msg.Params.Result, result))
+}
+
+func TestRunner_subscribeHandler(t *testing.T) {
+	ctx := context.Background()
+	bus := eventbus.NewEventBus()
+	am := testutils.NewArtifactManagerMock(t)
+	cfg := configuration.NewAPIRunner()
+	runner := &Runner{EventBus: bus, ArtifactManager: am, cfg: &cfg}
+
+	server := httptest.NewServer(http.HandlerFunc(runner.subscribeHandler()))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := &wsTestClient{t: t, conn: conn}
+
+	t.Run("pulse", func(t *testing.T) {
+		id := client.subscribe(SubscribeParams{Topic: TopicPulse})
+		bus.Publish(ctx, core.Event{Type: core.EventPulse, Pulse: &core.Pulse{PulseNumber: 65537}})
+
+		var pulse PulseNotification
+		client.readNotification(id, &pulse)
+		assert.Equal(t, core.PulseNumber(65537), pulse.PulseNumber)
+
+		msg := client.call("unsubscribe", UnsubscribeParams{Subscription: id})
+		assert.Equal(t, "true", string(msg.Result))
+	})
+
+	t.Run("object", func(t *testing.T) {
+		object := testutils.RandomRef()
+		state := testutils.RandomID()
+		id := client.subscribe(SubscribeParams{Topic: TopicObject, Reference: object.String()})
+
+		other := testutils.RandomID()
+		bus.Publish(ctx, core.Event{Type: core.EventObjectState, Object: &other, State: &other})
+		bus.Publish(ctx, core.Event{Type: core.EventObjectState, Object: object.Record(), State: &state})
+
+		var n ObjectNotification
+		client.readNotification(id, &n)
+		assert.Equal(t, object.String(), n.Reference)
+		assert.Equal(t, state.String(), n.State)
+		client.call("unsubscribe", UnsubscribeParams{Subscription: id})
+	})
+
+	t.Run("request", func(t *testing.T) {
+		am.GetRequestResultMock.Return(&core.RequestResult{}, nil)
+
+		request := testutils.RandomRef()
+		result := testutils.RandomID()
+		payload, err := core.MarshalArgs("ok", (*foundation.Error)(nil))
+		require.NoError(t, err)
+
+		id := client.subscribe(SubscribeParams{
+			Topic:     TopicRequest,
+			Reference: request.String(),
+			Object:    testutils.RandomRef().String(),
+		})
+		bus.Publish(ctx, core.Event{
+			Type:    core.EventRequestResult,
+			Object:  request.Record(),
+			Request: &request,
+			Result:  &result,
+			Payload: payload,
+		})
+
+		var n RequestNotification
+		client.readNotification(id, &n)
+		assert.Equal(t, request.String(), n.Request)
+		assert.Equal(t, result.String(), n.ResultRecord)
+		assert.Equal(t, "ok", n.Result)
+
+		// Subscription is closed after result.
+		msg := client.call("unsubscribe", UnsubscribeParams{Subscription: id})
+		assert.Equal(t, "false", string(msg.Result))
+	})
+
+	t.Run("executed request", func(t *testing.T) {
+		result := testutils.RandomID()
+		am.GetRequestResultMock.Return(&core.RequestResult{Result: &result}, nil)
+
+		request := testutils.RandomRef()
+		id := client.subscribe(SubscribeParams{
+			Topic:     TopicRequest,
+			Reference: request.String(),
+			Object:    testutils.RandomRef().String(),
+		})
+
+		var n RequestNotification
+		client.readNotification(id, &n)
+		assert.Equal(t, result.String(), n.ResultRecord)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		msg := client.call("subscribe", SubscribeParams{Topic: "unknown"})
+		require.NotNil(t, msg.Error)
+		assert.Equal(t, wsInvalidParams, msg.Error.Code)
+
+		msg = client.call("subscribe", SubscribeParams{Topic: TopicObject, Reference: "invalid"})
+		require.NotNil(t, msg.Error)
+		assert.Equal(t, wsInvalidParams, msg.Error.Code)
+
+		msg = client.call("publish", nil)
+		require.NotNil(t, msg.Error)
+		assert.Equal(t, wsMethodNotFound, msg.Error.Code)
+	})
+}
+
+func TestRunner_subscribeHandler_CheckOrigin(t *testing.T) {
+	cfg := configuration.NewAPIRunner()
+	cfg.SubscribeOrigins = []string{"https://allowed.example"}
+	runner := &Runner{EventBus: eventbus.NewEventBus(), cfg: &cfg}
+
+	server := httptest.NewServer(http.HandlerFunc(runner.subscribeHandler()))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	dial := func(origin string) error {
+		header := http.Header{}
+		if origin != "" {
+			header.Set("Origin", origin)
+		}
+		conn, _, err := websocket.DefaultDialer.Dial(url, header)
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+
+	assert.NoError(t, dial(""))
+	assert.NoError(t, dial("https://allowed.example"))
+	assert.Error(t, dial("https://evil.example"))
+}
@@ -17,9 +17,11 @@
 package allowance
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/insolar/insolar/application/proxy/tokenwallet"
 	"github.com/insolar/insolar/application/proxy/wallet"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
@@ -27,15 +29,33 @@ import (
 
 type Allowance struct {
 	foundation.BaseContract
-	To         core.RecordRef
-	Amount     uint
-	ExpireTime int64
+	From        core.RecordRef
+	To          core.RecordRef
+	Amount      uint
+	ExpireTime  int64
+	ReleaseTime int64
+	Approvers   []core.RecordRef
+	Required    int
+	Approvals   []core.RecordRef
 }
 
 func (a *Allowance) isExpired() bool {
 	return a.GetContext().Time.After(time.Unix(a.ExpireTime, 0))
 }
 
+func (a *Allowance) isReleased() bool {
+	return !a.GetContext().Time.Before(time.Unix(a.ReleaseTime, 0))
+}
+
+func contains(refs []core.RecordRef, ref core.RecordRef) bool {
+	for _, r := range refs {
+		if r == ref {
+			return true
+		}
+	}
+	return false
+}
+
 // TakeAmount allows take amount and delete allowance
 func (a *Allowance) TakeAmount() (uint, error) {
 	if *(a.GetContext().Caller) != a.To {
@@ -44,10 +64,67 @@ func (a *Allowance) TakeAmount() (uint, error) {
 	if a.isExpired() {
 		return 0, fmt.Errorf("[ TakeAmount ] Allowance expiried")
 	}
+	if !a.isReleased() {
+		return 0, fmt.Errorf("[ TakeAmount ] Allowance is locked until %s", time.Unix(a.ReleaseTime, 0).UTC())
+	}
+	if len(a.Approvals) < a.Required {
+		return 0, fmt.Errorf("[ TakeAmount ] Allowance is approved by %d of %d approvers", len(a.Approvals), a.Required)
+	}
 	a.SelfDestruct()
 	return a.Amount, nil
 }
 
+// Approve adds approval of caller member
+func (a *Allowance) Approve() error {
+	caller := *a.GetContext().Caller
+	if !contains(a.Approvers, caller) {
+		return fmt.Errorf("[ Approve ] Only approvers can approve allowance")
+	}
+	if contains(a.Approvals, caller) {
+		return fmt.Errorf("[ Approve ] Allowance is already approved by caller")
+	}
+	if a.isExpired() {
+		return fmt.Errorf("[ Approve ] Allowance expiried")
+	}
+	a.Approvals = append(a.Approvals, caller)
+	return nil
+}
+
+// Refund returns amount of expired allowance to owner and deletes allowance
+func (a *Allowance) Refund() (uint, error) {
+	if *(a.GetContext().Caller) != *(a.GetContext().Parent) {
+		return 0, fmt.Errorf("[ Refund ] Only owner can refund allowance")
+	}
+	if !a.isExpired() {
+		return 0, fmt.Errorf("[ Refund ] Allowance is not expired yet")
+	}
+	a.SelfDestruct()
+	return a.Amount, nil
+}
+
+// GetStatus returns allowance status
+func (a *Allowance) GetStatus() ([]byte, error) {
+	approvals := make([]string, 0, len(a.Approvals))
+	for _, ref := range a.Approvals {
+		approvals = append(approvals, ref.String())
+	}
+	return json.Marshal(map[string]interface{}{
+		"from":        a.From.String(),
+		"to":          a.To.String(),
+		"amount":      a.Amount,
+		"releaseTime": a.ReleaseTime,
+		"expireTime":  a.ExpireTime,
+		"required":    a.Required,
+		"approvals":   approvals,
+		"expired":     a.isExpired(),
+	})
+}
+
+// GetSender returns reference of wallet that made allowance
+func (a *Allowance) GetSender() (core.RecordRef, error) {
+	return a.From, nil
+}
+
 // GetBalanceForOwner returns balance
 func (a *Allowance) GetBalanceForOwner() (uint, error) {
 	return a.Amount, nil
@@ -67,8 +144,37 @@ func (a *Allowance) GetExpiredBalance() (uint, error) {
 
 // New check is caller wallet and makes new allowance
 func New(to *core.RecordRef, amount uint, expire int64) (*Allowance, error) {
-	if !wallet.PrototypeReference.Equal(*foundation.GetContext().CallerPrototype) {
+	callerPrototype := *foundation.GetContext().CallerPrototype
+	if !wallet.PrototypeReference.Equal(callerPrototype) && !tokenwallet.PrototypeReference.Equal(callerPrototype) {
 		return nil, fmt.Errorf("[ New Allowance ] : Can't create allowance from not wallet contract")
 	}
-	return &Allowance{To: *to, Amount: amount, ExpireTime: expire}, nil
+	return &Allowance{From: *foundation.GetContext().Caller, To: *to, Amount: amount, ExpireTime: expire}, nil
+}
+
+// NewEscrow check is caller wallet and makes new allowance that can be taken after release time
+// and approval of required number of approvers
+func NewEscrow(to *core.RecordRef, amount uint, release int64, expire int64, approvers []core.RecordRef, required int) (*Allowance, error) {
+	if !wallet.PrototypeReference.Equal(*foundation.GetContext().CallerPrototype) {
+		return nil, fmt.Errorf("[ NewEscrow ] : Can't create allowance from not wallet contract")
+	}
+	if release >= expire {
+		return nil, fmt.Errorf("[ NewEscrow ] : Release time must be before expire time")
+	}
+	if required < 0 || required > len(approvers) {
+		return nil, fmt.Errorf("[ NewEscrow ] : Required approvals must be between 0 and %d", len(approvers))
+	}
+	for i, ref := range approvers {
+		if contains(approvers[:i], ref) {
+			return nil, fmt.Errorf("[ NewEscrow ] : Duplicate approver %s", ref)
+		}
+	}
+	return &Allowance{
+		From:        *foundation.GetContext().Caller,
+		To:          *to,
+		Amount:      amount,
+		ExpireTime:  expire,
+		ReleaseTime: release,
+		Approvers:   approvers,
+		Required:    required,
+	}, nil
 }
@@ -20,8 +20,11 @@ import (
 	"fmt"
 
 	"github.com/insolar/insolar/application/contract/member/signer"
+	"github.com/insolar/insolar/application/proxy/allowance"
 	"github.com/insolar/insolar/application/proxy/nodedomain"
 	"github.com/insolar/insolar/application/proxy/rootdomain"
+	"github.com/insolar/insolar/application/proxy/token"
+	"github.com/insolar/insolar/application/proxy/tokenwallet"
 	"github.com/insolar/insolar/application/proxy/wallet"
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
@@ -94,10 +97,34 @@ func (m *Member) Call(rootDomain core.RecordRef, method string, params []byte, s
 		return m.dumpUserInfoCall(rootDomain, params)
 	case "DumpAllUsers":
 		return m.dumpAllUsersCall(rootDomain)
+	case "ListMembers":
+		return m.listMembersCall(rootDomain, params)
+	case "GetTransferHistory":
+		return m.getTransferHistoryCall(rootDomain, params)
 	case "RegisterNode":
 		return m.registerNodeCall(rootDomain, params)
 	case "GetNodeRef":
 		return m.getNodeRefCall(rootDomain, params)
+	case "EscrowTransfer":
+		return m.escrowTransferCall(params)
+	case "ApproveTransfer":
+		return m.approveTransferCall(params)
+	case "AcceptTransfer":
+		return m.acceptTransferCall(params)
+	case "CancelTransfer":
+		return m.cancelTransferCall(params)
+	case "GetTransferStatus":
+		return m.getTransferStatusCall(params)
+	case "CreateToken":
+		return m.createTokenCall(params)
+	case "MintToken":
+		return m.mintTokenCall(params)
+	case "BurnToken":
+		return m.burnTokenCall(params)
+	case "TransferToken":
+		return m.transferTokenCall(params)
+	case "GetTokenBalance":
+		return m.getTokenBalanceCall(params)
 	}
 	return nil, &foundation.Error{S: "Unknown method"}
 }
@@ -159,6 +186,95 @@ func (m *Member) transferCall(params []byte) (interface{}, error) {
 	return nil, w.Transfer(amount, to)
 }
 
+func (m *Member) escrowTransferCall(params []byte) (interface{}, error) {
+	var amount uint
+	var toStr string
+	var release int64
+	var expire int64
+	var approversStr []string
+	var required int
+	if err := signer.UnmarshalParams(params, &amount, &toStr, &release, &expire, &approversStr, &required); err != nil {
+		return nil, fmt.Errorf("[ escrowTransferCall ] Can't unmarshal params: %s", err.Error())
+	}
+	to, err := core.NewRefFromBase58(toStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ escrowTransferCall ] Failed to parse 'to' param: %s", err.Error())
+	}
+	if m.GetReference() == *to {
+		return nil, fmt.Errorf("[ escrowTransferCall ] Recipient must be different from the sender")
+	}
+	approvers := make([]core.RecordRef, 0, len(approversStr))
+	for _, approverStr := range approversStr {
+		approver, err := core.NewRefFromBase58(approverStr)
+		if err != nil {
+			return nil, fmt.Errorf("[ escrowTransferCall ] Failed to parse 'approvers' param: %s", err.Error())
+		}
+		approvers = append(approvers, *approver)
+	}
+	w, err := wallet.GetImplementationFrom(m.GetReference())
+	if err != nil {
+		return nil, fmt.Errorf("[ escrowTransferCall ] Can't get implementation: %s", err.Error())
+	}
+
+	ref, err := w.EscrowTransfer(amount, to, release, expire, approvers, required)
+	if err != nil {
+		return nil, err
+	}
+	return ref.String(), nil
+}
+
+func (m *Member) parseAllowanceParam(method string, params []byte) (*core.RecordRef, error) {
+	var allowanceStr string
+	if err := signer.UnmarshalParams(params, &allowanceStr); err != nil {
+		return nil, fmt.Errorf("[ %s ] Can't unmarshal params: %s", method, err.Error())
+	}
+	ref, err := core.NewRefFromBase58(allowanceStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ %s ] Failed to parse 'allowance' param: %s", method, err.Error())
+	}
+	return ref, nil
+}
+
+func (m *Member) approveTransferCall(params []byte) (interface{}, error) {
+	ref, err := m.parseAllowanceParam("approveTransferCall", params)
+	if err != nil {
+		return nil, err
+	}
+	return nil, allowance.GetObject(*ref).Approve()
+}
+
+func (m *Member) acceptTransferCall(params []byte) (interface{}, error) {
+	ref, err := m.parseAllowanceParam("acceptTransferCall", params)
+	if err != nil {
+		return nil, err
+	}
+	w, err := wallet.GetImplementationFrom(m.GetReference())
+	if err != nil {
+		return nil, fmt.Errorf("[ acceptTransferCall ] Can't get implementation: %s", err.Error())
+	}
+	return nil, w.Accept(ref)
+}
+
+func (m *Member) cancelTransferCall(params []byte) (interface{}, error) {
+	ref, err := m.parseAllowanceParam("cancelTransferCall", params)
+	if err != nil {
+		return nil, err
+	}
+	w, err := wallet.GetImplementationFrom(m.GetReference())
+	if err != nil {
+		return nil, fmt.Errorf("[ cancelTransferCall ] Can't get implementation: %s", err.Error())
+	}
+	return nil, w.Refund(ref)
+}
+
+func (m *Member) getTransferStatusCall(params []byte) (interface{}, error) {
+	ref, err := m.parseAllowanceParam("getTransferStatusCall", params)
+	if err != nil {
+		return nil, err
+	}
+	return allowance.GetObject(*ref).GetStatus()
+}
+
 func (m *Member) dumpUserInfoCall(ref core.RecordRef, params []byte) (interface{}, error) {
 	rootDomain := rootdomain.GetObject(ref)
 	var user string
@@ -173,6 +289,43 @@ func (m *Member) dumpAllUsersCall(ref core.RecordRef) (interface{}, error) {
 	return rootDomain.DumpAllUsers()
 }
 
+func (m *Member) listMembersCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	rootDomain := rootdomain.GetObject(ref)
+	var cursor string
+	var limit int
+	if err := signer.UnmarshalParams(params, &cursor, &limit); err != nil {
+		return nil, fmt.Errorf("[ listMembersCall ] Can't unmarshal params: %s", err.Error())
+	}
+	return rootDomain.ListMembers(cursor, limit)
+}
+
+func (m *Member) getTransferHistoryCall(ref core.RecordRef, params []byte) (interface{}, error) {
+	var member string
+	var cursor int
+	var limit int
+	if err := signer.UnmarshalParams(params, &member, &cursor, &limit); err != nil {
+		return nil, fmt.Errorf("[ getTransferHistoryCall ] Can't unmarshal params: %s", err.Error())
+	}
+	memberRef, err := core.NewRefFromBase58(member)
+	if err != nil {
+		return nil, fmt.Errorf("[ getTransferHistoryCall ] Failed to parse 'member' param: %s", err.Error())
+	}
+	if *memberRef != m.GetReference() {
+		rootMember, err := rootdomain.GetObject(ref).GetRootMemberRef()
+		if err != nil {
+			return nil, fmt.Errorf("[ getTransferHistoryCall ] Can't get root member: %s", err.Error())
+		}
+		if m.GetReference() != *rootMember {
+			return nil, fmt.Errorf("[ getTransferHistoryCall ] You can get only your own history")
+		}
+	}
+	w, err := wallet.GetImplementationFrom(*memberRef)
+	if err != nil {
+		return nil, fmt.Errorf("[ getTransferHistoryCall ] Can't get implementation: %s", err.Error())
+	}
+	return w.GetTransferHistory(cursor, limit)
+}
+
 func (m *Member) registerNodeCall(ref core.RecordRef, params []byte) (interface{}, error) {
 	var publicKey string
 	var role string
@@ -215,3 +368,104 @@ func (m *Member) getNodeRefCall(ref core.RecordRef, params []byte) (interface{},
 
 	return nodeRef, nil
 }
+
+func (m *Member) createTokenCall(params []byte) (interface{}, error) {
+	var name string
+	var symbol string
+	var amount uint
+	if err := signer.UnmarshalParams(params, &name, &symbol, &amount); err != nil {
+		return nil, fmt.Errorf("[ createTokenCall ] Can't unmarshal params: %s", err.Error())
+	}
+
+	issuer := m.GetReference()
+	t, err := token.New(name, symbol, &issuer).AsChild(issuer)
+	if err != nil {
+		return nil, fmt.Errorf("[ createTokenCall ] Can't create token: %s", err.Error())
+	}
+	if amount > 0 {
+		if err := t.Mint(&issuer, amount); err != nil {
+			return nil, fmt.Errorf("[ createTokenCall ] Can't mint initial supply: %s", err.Error())
+		}
+	}
+
+	return t.GetReference().String(), nil
+}
+
+func (m *Member) mintTokenCall(params []byte) (interface{}, error) {
+	var tokenStr string
+	var amount uint
+	var toStr string
+	if err := signer.UnmarshalParams(params, &tokenStr, &amount, &toStr); err != nil {
+		return nil, fmt.Errorf("[ mintTokenCall ] Can't unmarshal params: %s", err.Error())
+	}
+	tokenRef, err := core.NewRefFromBase58(tokenStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ mintTokenCall ] Failed to parse 'token' param: %s", err.Error())
+	}
+	to, err := core.NewRefFromBase58(toStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ mintTokenCall ] Failed to parse 'to' param: %s", err.Error())
+	}
+
+	return nil, token.GetObject(*tokenRef).Mint(to, amount)
+}
+
+func (m *Member) burnTokenCall(params []byte) (interface{}, error) {
+	var tokenStr string
+	var amount uint
+	if err := signer.UnmarshalParams(params, &tokenStr, &amount); err != nil {
+		return nil, fmt.Errorf("[ burnTokenCall ] Can't unmarshal params: %s", err.Error())
+	}
+	tokenRef, err := core.NewRefFromBase58(tokenStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ burnTokenCall ] Failed to parse 'token' param: %s", err.Error())
+	}
+
+	return nil, token.GetObject(*tokenRef).Burn(amount)
+}
+
+func (m *Member) transferTokenCall(params []byte) (interface{}, error) {
+	var tokenStr string
+	var amount uint
+	var toStr string
+	if err := signer.UnmarshalParams(params, &tokenStr, &amount, &toStr); err != nil {
+		return nil, fmt.Errorf("[ transferTokenCall ] Can't unmarshal params: %s", err.Error())
+	}
+	tokenRef, err := core.NewRefFromBase58(tokenStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ transferTokenCall ] Failed to parse 'token' param: %s", err.Error())
+	}
+	to, err := core.NewRefFromBase58(toStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ transferTokenCall ] Failed to parse 'to' param: %s", err.Error())
+	}
+	if m.GetReference() == *to {
+		return nil, fmt.Errorf("[ transferTokenCall ] Recipient must be different from the sender")
+	}
+
+	me := m.GetReference()
+	walletRef, err := token.GetObject(*tokenRef).GetWallet(&me)
+	if err != nil {
+		return nil, fmt.Errorf("[ transferTokenCall ] Can't get wallet: %s", err.Error())
+	}
+
+	return nil, tokenwallet.GetObject(walletRef).Transfer(amount, to)
+}
+
+func (m *Member) getTokenBalanceCall(params []byte) (interface{}, error) {
+	var tokenStr string
+	var member string
+	if err := signer.UnmarshalParams(params, &tokenStr, &member); err != nil {
+		return nil, fmt.Errorf("[ getTokenBalanceCall ] Can't unmarshal params: %s", err.Error())
+	}
+	tokenRef, err := core.NewRefFromBase58(tokenStr)
+	if err != nil {
+		return nil, fmt.Errorf("[ getTokenBalanceCall ] Failed to parse 'token' param: %s", err.Error())
+	}
+	memberRef, err := core.NewRefFromBase58(member)
+	if err != nil {
+		return nil, fmt.Errorf("[ getTokenBalanceCall ] Failed to parse 'member' param: %s", err.Error())
+	}
+
+	return token.GetObject(*tokenRef).GetBalance(memberRef)
+}
@@ -26,6 +26,11 @@ import (
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 )
 
+const (
+	listMembersDefaultLimit = 20
+	listMembersMaxLimit     = 100
+)
+
 // RootDomain is smart contract representing entrance point to system
 type RootDomain struct {
 	foundation.BaseContract
@@ -74,8 +79,10 @@ func (rd *RootDomain) getUserInfoMap(m *member.Member) (map[string]interface{},
 		return nil, fmt.Errorf("[ getUserInfoMap ] Can't get total balance: %s", err.Error())
 	}
 	return map[string]interface{}{
-		"member": name,
-		"wallet": balance,
+		"reference":       m.GetReference().String(),
+		"member":          name,
+		"wallet":          balance,
+		"walletReference": w.GetReference().String(),
 	}, nil
 }
 
@@ -130,6 +137,74 @@ func (rd *RootDomain) DumpAllUsers() ([]byte, error) {
 	return resJSON, nil
 }
 
+// ListMembers returns JSON with a page of members after cursor member reference.
+// Cursor is a value of "nextCursor" field of previous result, empty cursor starts from the first member.
+func (rd *RootDomain) ListMembers(cursor string, limit int) ([]byte, error) {
+	if *rd.GetContext().Caller != rd.RootMember {
+		return nil, fmt.Errorf("[ ListMembers ] Only root can call this method")
+	}
+	var cursorRef *core.RecordRef
+	if cursor != "" {
+		var err error
+		cursorRef, err = core.NewRefFromBase58(cursor)
+		if err != nil {
+			return nil, fmt.Errorf("[ ListMembers ] Failed to parse cursor: %s", err.Error())
+		}
+	}
+	if limit <= 0 {
+		limit = listMembersDefaultLimit
+	}
+	if limit > listMembersMaxLimit {
+		limit = listMembersMaxLimit
+	}
+
+	iterator, err := rd.NewChildrenTypedIterator(member.GetPrototype())
+	if err != nil {
+		return nil, fmt.Errorf("[ ListMembers ] Can't get children: %s", err.Error())
+	}
+
+	// Skip members up to cursor
+	for cursorRef != nil && iterator.HasNext() {
+		cref, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("[ ListMembers ] Can't get next child: %s", err.Error())
+		}
+		if cref == *cursorRef {
+			cursorRef = nil
+		}
+	}
+	if cursorRef != nil {
+		return nil, fmt.Errorf("[ ListMembers ] Cursor member is not found")
+	}
+
+	members := []map[string]interface{}{}
+	var last core.RecordRef
+	for len(members) < limit && iterator.HasNext() {
+		cref, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("[ ListMembers ] Can't get next child: %s", err.Error())
+		}
+		last = cref
+
+		if cref == rd.RootMember {
+			continue
+		}
+		userInfo, err := rd.getUserInfoMap(member.GetObject(cref))
+		if err != nil {
+			return nil, fmt.Errorf("[ ListMembers ] Problem with making request: %s", err.Error())
+		}
+		members = append(members, userInfo)
+	}
+
+	res := map[string]interface{}{
+		"members": members,
+	}
+	if iterator.HasNext() {
+		res["nextCursor"] = last.String()
+	}
+	return json.Marshal(res)
+}
+
 var INSATTR_Info_API = true
 
 // Info returns information about basic objects
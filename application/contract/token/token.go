@@ -0,0 +1,152 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/insolar/insolar/application/contract/wallet/safemath"
+	"github.com/insolar/insolar/application/proxy/tokenwallet"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+// Token - fungible token issued by member, wallets of token holders are children of the token
+type Token struct {
+	foundation.BaseContract
+	Name        string
+	Symbol      string
+	Issuer      core.RecordRef
+	TotalSupply uint
+	Wallets     map[string]core.RecordRef
+}
+
+// New creates new token
+func New(name string, symbol string, issuer *core.RecordRef) (*Token, error) {
+	if name == "" || symbol == "" {
+		return nil, fmt.Errorf("[ New Token ] Name and symbol must not be empty")
+	}
+	return &Token{
+		Name:    name,
+		Symbol:  symbol,
+		Issuer:  *issuer,
+		Wallets: map[string]core.RecordRef{},
+	}, nil
+}
+
+func (t *Token) checkIssuer() error {
+	if *t.GetContext().Caller != t.Issuer {
+		return fmt.Errorf("Only issuer can do this")
+	}
+	return nil
+}
+
+func (t *Token) getOrCreateWallet(member core.RecordRef) (core.RecordRef, error) {
+	if ref, ok := t.Wallets[member.String()]; ok {
+		return ref, nil
+	}
+
+	w, err := tokenwallet.New(&member).AsChild(t.GetReference())
+	if err != nil {
+		return core.RecordRef{}, fmt.Errorf("Can't save wallet as child: %s", err.Error())
+	}
+	ref := w.GetReference()
+	if t.Wallets == nil {
+		t.Wallets = map[string]core.RecordRef{}
+	}
+	t.Wallets[member.String()] = ref
+	return ref, nil
+}
+
+// Mint creates tokens on wallet of member, only issuer can mint tokens
+func (t *Token) Mint(member *core.RecordRef, amount uint) error {
+	if err := t.checkIssuer(); err != nil {
+		return fmt.Errorf("[ Mint ] %s", err.Error())
+	}
+	totalSupply, err := safemath.Add(t.TotalSupply, amount)
+	if err != nil {
+		return fmt.Errorf("[ Mint ] Total supply overflow: %s", err.Error())
+	}
+
+	ref, err := t.getOrCreateWallet(*member)
+	if err != nil {
+		return fmt.Errorf("[ Mint ] %s", err.Error())
+	}
+	err = tokenwallet.GetObject(ref).Mint(amount)
+	if err != nil {
+		return fmt.Errorf("[ Mint ] Can't mint: %s", err.Error())
+	}
+
+	t.TotalSupply = totalSupply
+	return nil
+}
+
+// Burn destroys tokens on wallet of issuer, only issuer can burn tokens
+func (t *Token) Burn(amount uint) error {
+	if err := t.checkIssuer(); err != nil {
+		return fmt.Errorf("[ Burn ] %s", err.Error())
+	}
+	totalSupply, err := safemath.Sub(t.TotalSupply, amount)
+	if err != nil {
+		return fmt.Errorf("[ Burn ] Not enough tokens: %s", err.Error())
+	}
+
+	ref, ok := t.Wallets[t.Issuer.String()]
+	if !ok {
+		return fmt.Errorf("[ Burn ] Issuer has no tokens")
+	}
+	err = tokenwallet.GetObject(ref).Burn(amount)
+	if err != nil {
+		return fmt.Errorf("[ Burn ] Can't burn: %s", err.Error())
+	}
+
+	t.TotalSupply = totalSupply
+	return nil
+}
+
+// GetWallet returns reference to token wallet of member, wallet is created if member has no one
+func (t *Token) GetWallet(member *core.RecordRef) (core.RecordRef, error) {
+	ref, err := t.getOrCreateWallet(*member)
+	if err != nil {
+		return core.RecordRef{}, fmt.Errorf("[ GetWallet ] %s", err.Error())
+	}
+	return ref, nil
+}
+
+// GetBalance returns token balance of member
+func (t *Token) GetBalance(member *core.RecordRef) (uint, error) {
+	ref, ok := t.Wallets[member.String()]
+	if !ok {
+		return 0, nil
+	}
+	return tokenwallet.GetObject(ref).GetBalance()
+}
+
+// GetTotalSupply returns amount of tokens in circulation
+func (t *Token) GetTotalSupply() (uint, error) {
+	return t.TotalSupply, nil
+}
+
+// GetInfo returns token info
+func (t *Token) GetInfo() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"name":        t.Name,
+		"symbol":      t.Symbol,
+		"issuer":      t.Issuer.String(),
+		"totalSupply": t.TotalSupply,
+	})
+}
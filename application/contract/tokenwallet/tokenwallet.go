@@ -0,0 +1,144 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package tokenwallet
+
+import (
+	"fmt"
+
+	"github.com/insolar/insolar/application/contract/wallet/safemath"
+	"github.com/insolar/insolar/application/proxy/allowance"
+	"github.com/insolar/insolar/application/proxy/token"
+	"github.com/insolar/insolar/application/proxy/tokenwallet"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+// TokenWallet - wallet of member for one token, it is a child of the token
+type TokenWallet struct {
+	foundation.BaseContract
+	Owner   core.RecordRef
+	Balance uint
+}
+
+// New creates new token wallet
+func New(owner *core.RecordRef) (*TokenWallet, error) {
+	return &TokenWallet{
+		Owner: *owner,
+	}, nil
+}
+
+func (w *TokenWallet) checkToken() error {
+	if *w.GetContext().Caller != *w.GetContext().Parent {
+		return fmt.Errorf("Only token can do this")
+	}
+	return nil
+}
+
+// Mint adds minted tokens to balance
+func (w *TokenWallet) Mint(amount uint) error {
+	if err := w.checkToken(); err != nil {
+		return fmt.Errorf("[ Mint ] %s", err.Error())
+	}
+	balance, err := safemath.Add(w.Balance, amount)
+	if err != nil {
+		return fmt.Errorf("[ Mint ] Couldn't add amount to balance: %s", err.Error())
+	}
+	w.Balance = balance
+	return nil
+}
+
+// Burn removes burnt tokens from balance
+func (w *TokenWallet) Burn(amount uint) error {
+	if err := w.checkToken(); err != nil {
+		return fmt.Errorf("[ Burn ] %s", err.Error())
+	}
+	balance, err := safemath.Sub(w.Balance, amount)
+	if err != nil {
+		return fmt.Errorf("[ Burn ] Not enough balance: %s", err.Error())
+	}
+	w.Balance = balance
+	return nil
+}
+
+// Transfer transfers tokens to wallet of given member
+func (w *TokenWallet) Transfer(amount uint, to *core.RecordRef) error {
+	if *w.GetContext().Caller != w.Owner {
+		return fmt.Errorf("[ Transfer ] Only owner can transfer tokens")
+	}
+
+	toWalletRef, err := token.GetObject(*w.GetContext().Parent).GetWallet(to)
+	if err != nil {
+		return fmt.Errorf("[ Transfer ] Can't get recipient wallet: %s", err.Error())
+	}
+
+	newBalance, err := safemath.Sub(w.Balance, amount)
+	if err != nil {
+		return fmt.Errorf("[ Transfer ] Not enough balance for transfer: %s", err.Error())
+	}
+
+	ah := allowance.New(&toWalletRef, amount, w.GetContext().Time.Unix()+10)
+	a, err := ah.AsChild(w.GetReference())
+	if err != nil {
+		return fmt.Errorf("[ Transfer ] Can't save as child: %s", err.Error())
+	}
+
+	// Changing balance only after allowance was successfully create
+	w.Balance = newBalance
+
+	r := a.GetReference()
+	return tokenwallet.GetObject(toWalletRef).AcceptNoWait(&r)
+}
+
+// Accept transforms allowance to balance
+func (w *TokenWallet) Accept(aRef *core.RecordRef) error {
+	b, err := allowance.GetObject(*aRef).TakeAmount()
+	if err != nil {
+		return fmt.Errorf("[ Accept ] Can't take amount: %s", err.Error())
+	}
+	w.Balance, err = safemath.Add(w.Balance, b)
+	if err != nil {
+		return fmt.Errorf("[ Accept ] Couldn't add amount to balance: %s", err.Error())
+	}
+	return nil
+}
+
+// GetBalance gets total balance
+func (w *TokenWallet) GetBalance() (uint, error) {
+	iterator, err := w.NewChildrenTypedIterator(allowance.GetPrototype())
+	if err != nil {
+		return 0, fmt.Errorf("[ GetBalance ] Can't get children: %s", err.Error())
+	}
+
+	for iterator.HasNext() {
+		cref, err := iterator.Next()
+		if err != nil {
+			return 0, fmt.Errorf("[ GetBalance ] Can't get next child: %s", err.Error())
+		}
+
+		if !cref.IsEmpty() {
+			balance, err := allowance.GetObject(cref).GetExpiredBalance()
+			if err != nil {
+				balance = 0
+			}
+
+			w.Balance, err = safemath.Add(w.Balance, balance)
+			if err != nil {
+				return 0, fmt.Errorf("[ GetBalance ] Couldn't add expired allowance to balance: %s", err.Error())
+			}
+		}
+	}
+	return w.Balance, nil
+}
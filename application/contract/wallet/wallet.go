@@ -17,6 +17,7 @@
 package wallet
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/insolar/insolar/application/contract/wallet/safemath"
@@ -26,10 +27,48 @@ import (
 	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
 )
 
+const (
+	transferHistoryDefaultLimit = 20
+	transferHistoryMaxLimit     = 100
+)
+
+// Directions of transfer log records
+const (
+	TransferOut    = "out"
+	TransferIn     = "in"
+	TransferRefund = "refund"
+)
+
+// TransferRecord is a record of wallet transfer log
+type TransferRecord struct {
+	Direction    string
+	Counterparty core.RecordRef
+	Allowance    core.RecordRef
+	Amount       uint
+	Pulse        core.PulseNumber
+	Request      core.RecordRef
+}
+
 // Wallet - basic wallet contract
 type Wallet struct {
 	foundation.BaseContract
-	Balance uint
+	Balance   uint
+	Transfers []TransferRecord
+}
+
+func (w *Wallet) logTransfer(direction string, counterparty core.RecordRef, allowance core.RecordRef, amount uint) {
+	ctx := w.GetContext()
+	record := TransferRecord{
+		Direction:    direction,
+		Counterparty: counterparty,
+		Allowance:    allowance,
+		Amount:       amount,
+		Pulse:        ctx.Pulse.PulseNumber,
+	}
+	if ctx.Request != nil {
+		record.Request = *ctx.Request
+	}
+	w.Transfers = append(w.Transfers, record)
 }
 
 // Transfer transfers money to given wallet
@@ -57,13 +96,61 @@ func (w *Wallet) Transfer(amount uint, to *core.RecordRef) error {
 	w.Balance = newBalance
 
 	r := a.GetReference()
+	w.logTransfer(TransferOut, toWalletRef, r, amount)
 	err = toWallet.AcceptNoWait(&r)
 	return err
 }
 
+// EscrowTransfer transfers money to given wallet through escrow allowance. Recipient can accept it after release time
+// when it is approved by required number of approvers, sender can refund it after expire time.
+func (w *Wallet) EscrowTransfer(amount uint, to *core.RecordRef, release int64, expire int64, approvers []core.RecordRef, required int) (core.RecordRef, error) {
+	toWallet, err := wallet.GetImplementationFrom(*to)
+	if err != nil {
+		return core.RecordRef{}, fmt.Errorf("[ EscrowTransfer ] Can't get implementation: %s", err.Error())
+	}
+
+	toWalletRef := toWallet.GetReference()
+
+	newBalance, err := safemath.Sub(w.Balance, amount)
+	if err != nil {
+		return core.RecordRef{}, fmt.Errorf("[ EscrowTransfer ] Not enough balance for transfer: %s", err.Error())
+	}
+
+	ah := allowance.NewEscrow(&toWalletRef, amount, release, expire, approvers, required)
+	a, err := ah.AsChild(w.GetReference())
+	if err != nil {
+		return core.RecordRef{}, fmt.Errorf("[ EscrowTransfer ] Can't save as child: %s", err.Error())
+	}
+
+	// Changing balance only after allowance was successfully create
+	w.Balance = newBalance
+
+	w.logTransfer(TransferOut, toWalletRef, a.GetReference(), amount)
+	return a.GetReference(), nil
+}
+
+// Refund returns amount of expired allowance to balance
+func (w *Wallet) Refund(aRef *core.RecordRef) error {
+	b, err := allowance.GetObject(*aRef).Refund()
+	if err != nil {
+		return fmt.Errorf("[ Refund ] Can't refund: %s", err.Error())
+	}
+	w.Balance, err = safemath.Add(w.Balance, b)
+	if err != nil {
+		return fmt.Errorf("[ Refund ] Couldn't add amount to balance: %s", err.Error())
+	}
+	w.logTransfer(TransferRefund, core.RecordRef{}, *aRef, b)
+	return nil
+}
+
 // Accept transforms allowance to balance
 func (w *Wallet) Accept(aRef *core.RecordRef) error {
-	b, err := allowance.GetObject(*aRef).TakeAmount()
+	a := allowance.GetObject(*aRef)
+	sender, err := a.GetSender()
+	if err != nil {
+		return fmt.Errorf("[ Accept ] Can't get sender: %s", err.Error())
+	}
+	b, err := a.TakeAmount()
 	if err != nil {
 		return fmt.Errorf("[ Accept ] Can't take amount: %s", err.Error())
 	}
@@ -71,6 +158,7 @@ func (w *Wallet) Accept(aRef *core.RecordRef) error {
 	if err != nil {
 		return fmt.Errorf("[ Accept ] Couldn't add amount to balance: %s", err.Error())
 	}
+	w.logTransfer(TransferIn, sender, *aRef, b)
 	return nil
 }
 
@@ -100,11 +188,61 @@ func (w *Wallet) GetBalance() (uint, error) {
 			if err != nil {
 				return 0, fmt.Errorf("[ GetBalance ] Couldn't add expired allowance to balance: %s", err.Error())
 			}
+			if balance > 0 {
+				w.logTransfer(TransferRefund, core.RecordRef{}, cref, balance)
+			}
 		}
 	}
 	return w.Balance, nil
 }
 
+// GetTransferHistory returns JSON with transfer log records from the newest to the oldest.
+// Cursor is a value of "nextCursor" field of previous result, zero cursor starts from the newest record.
+func (w *Wallet) GetTransferHistory(cursor int, limit int) ([]byte, error) {
+	if cursor < 0 || cursor > len(w.Transfers) {
+		return nil, fmt.Errorf("[ GetTransferHistory ] Invalid cursor %d", cursor)
+	}
+	if limit <= 0 {
+		limit = transferHistoryDefaultLimit
+	}
+	if limit > transferHistoryMaxLimit {
+		limit = transferHistoryMaxLimit
+	}
+
+	end := cursor
+	if end == 0 {
+		end = len(w.Transfers)
+	}
+	start := end - limit
+	if start < 0 {
+		start = 0
+	}
+
+	transfers := make([]map[string]interface{}, 0, end-start)
+	for i := end - 1; i >= start; i-- {
+		record := w.Transfers[i]
+		transfer := map[string]interface{}{
+			"direction": record.Direction,
+			"allowance": record.Allowance.String(),
+			"amount":    record.Amount,
+			"pulse":     record.Pulse,
+			"request":   record.Request.String(),
+		}
+		if !record.Counterparty.IsEmpty() {
+			transfer["counterparty"] = record.Counterparty.String()
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	res := map[string]interface{}{
+		"transfers": transfers,
+	}
+	if start > 0 {
+		res["nextCursor"] = start
+	}
+	return json.Marshal(res)
+}
+
 // New creates new allowance
 func New(balance uint) (*Wallet, error) {
 	return &Wallet{
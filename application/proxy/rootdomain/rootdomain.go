@@ -24,7 +24,7 @@ import (
 
 // PrototypeReference to prototype of this contract
 // error checking hides in generator
-var PrototypeReference, _ = core.NewRefFromBase58("1111C3jrgHyV9RYvRQjnZDcZb58a5ymPrgKt6o7N89.11111111111111111111111111111111")
+var PrototypeReference, _ = core.NewRefFromBase58("11112F9ecGQFjtjunH9YZHbou2fsWTU4xTjWJPjRehC.11111111111111111111111111111111")
 
 // RootDomain holds proxy type
 type RootDomain struct {
@@ -367,6 +367,62 @@ func (r *RootDomain) DumpAllUsersNoWait() error {
 	return nil
 }
 
+// ListMembers is proxy generated method
+func (r *RootDomain) ListMembers(cursor string, limit int) ([]byte, error) {
+	var args [2]interface{}
+	args[0] = cursor
+	args[1] = limit
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 []byte
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "ListMembers", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, err
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// ListMembersNoWait is proxy generated method
+func (r *RootDomain) ListMembersNoWait(cursor string, limit int) error {
+	var args [2]interface{}
+	args[0] = cursor
+	args[1] = limit
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "ListMembers", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Info is proxy generated method
 func (r *RootDomain) Info() (interface{}, error) {
 	var args [0]interface{}
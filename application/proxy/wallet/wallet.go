@@ -22,9 +22,18 @@ import (
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 )
 
+type TransferRecord struct {
+	Direction    string
+	Counterparty core.RecordRef
+	Allowance    core.RecordRef
+	Amount       uint
+	Pulse        core.PulseNumber
+	Request      core.RecordRef
+}
+
 // PrototypeReference to prototype of this contract
 // error checking hides in generator
-var PrototypeReference, _ = core.NewRefFromBase58("11112cpXtm7VKupDkbunmHxLBuKQ7t2oNHCD9LuDrEA.11111111111111111111111111111111")
+var PrototypeReference, _ = core.NewRefFromBase58("1111m2KnzW4N3eMK6YzWE7PBG2LcxNxLJuuKfuAQD3.11111111111111111111111111111111")
 
 // Wallet holds proxy type
 type Wallet struct {
@@ -208,6 +217,122 @@ func (r *Wallet) TransferNoWait(amount uint, to *core.RecordRef) error {
 	return nil
 }
 
+// EscrowTransfer is proxy generated method
+func (r *Wallet) EscrowTransfer(amount uint, to *core.RecordRef, release int64, expire int64, approvers []core.RecordRef, required int) (core.RecordRef, error) {
+	var args [6]interface{}
+	args[0] = amount
+	args[1] = to
+	args[2] = release
+	args[3] = expire
+	args[4] = approvers
+	args[5] = required
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 core.RecordRef
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "EscrowTransfer", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, err
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// EscrowTransferNoWait is proxy generated method
+func (r *Wallet) EscrowTransferNoWait(amount uint, to *core.RecordRef, release int64, expire int64, approvers []core.RecordRef, required int) error {
+	var args [6]interface{}
+	args[0] = amount
+	args[1] = to
+	args[2] = release
+	args[3] = expire
+	args[4] = approvers
+	args[5] = required
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "EscrowTransfer", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Refund is proxy generated method
+func (r *Wallet) Refund(aRef *core.RecordRef) error {
+	var args [1]interface{}
+	args[0] = aRef
+
+	var argsSerialized []byte
+
+	ret := [1]interface{}{}
+	var ret0 *foundation.Error
+	ret[0] = &ret0
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "Refund", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return err
+	}
+
+	if ret0 != nil {
+		return ret0
+	}
+	return nil
+}
+
+// RefundNoWait is proxy generated method
+func (r *Wallet) RefundNoWait(aRef *core.RecordRef) error {
+	var args [1]interface{}
+	args[0] = aRef
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "Refund", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Accept is proxy generated method
 func (r *Wallet) Accept(aRef *core.RecordRef) error {
 	var args [1]interface{}
@@ -311,3 +436,59 @@ func (r *Wallet) GetBalanceNoWait() error {
 
 	return nil
 }
+
+// GetTransferHistory is proxy generated method
+func (r *Wallet) GetTransferHistory(cursor int, limit int) ([]byte, error) {
+	var args [2]interface{}
+	args[0] = cursor
+	args[1] = limit
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 []byte
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "GetTransferHistory", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, err
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// GetTransferHistoryNoWait is proxy generated method
+func (r *Wallet) GetTransferHistoryNoWait(cursor int, limit int) error {
+	var args [2]interface{}
+	args[0] = cursor
+	args[1] = limit
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "GetTransferHistory", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
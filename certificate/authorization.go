@@ -26,10 +26,11 @@ import (
 
 // AuthorizationCertificate holds info about node from it certificate
 type AuthorizationCertificate struct {
-	PublicKey      string                     `json:"public_key"`
-	Reference      string                     `json:"reference"`
-	Role           string                     `json:"role"`
-	DiscoverySigns map[*core.RecordRef][]byte `json:"-"`
+	PublicKey       string                     `json:"public_key"`
+	SignatureScheme string                     `json:"signature_scheme,omitempty"`
+	Reference       string                     `json:"reference"`
+	Role            string                     `json:"role"`
+	DiscoverySigns  map[*core.RecordRef][]byte `json:"-"`
 
 	nodePublicKey crypto.PublicKey
 }
@@ -161,6 +161,16 @@ func (cert *Certificate) fillExtraFields(keyProcessor core.KeyProcessor) error {
 	}
 	cert.nodePublicKey = importedNodePubKey
 
+	keyScheme, err := platformpolicy.SignatureSchemeOfPublicKey(importedNodePubKey)
+	if err != nil {
+		return errors.Wrap(err, "[ fillExtraFields ] Bad PublicKey")
+	}
+	if cert.SignatureScheme == "" {
+		cert.SignatureScheme = keyScheme
+	} else if cert.SignatureScheme != keyScheme {
+		return errors.Errorf("[ fillExtraFields ] PublicKey scheme %s differs from certificate scheme %s", keyScheme, cert.SignatureScheme)
+	}
+
 	for _, pulsarKey := range cert.PulsarPublicKeys {
 		importedPulsarPubKey, err := keyProcessor.ImportPublicKeyPEM([]byte(pulsarKey))
 		if err != nil {
@@ -249,7 +259,13 @@ func NewCertificatesWithKeys(publicKey crypto.PublicKey, keyProcessor core.KeyPr
 		return nil, errors.Wrap(err, "[ ReadCertificate ] failed to retrieve public key from node private key")
 	}
 
+	keyScheme, err := platformpolicy.SignatureSchemeOfPublicKey(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadCertificate ] unsupported node public key")
+	}
+
 	cert.PublicKey = string(keyBytes)
+	cert.SignatureScheme = keyScheme
 	cert.nodePublicKey = publicKey
 	return &cert, nil
 }
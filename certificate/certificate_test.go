@@ -219,3 +219,36 @@ func TestSerializeDeserialize(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, cert, deserializedCert)
 }
+
+func TestReadCertificate_SignatureScheme(t *testing.T) {
+	cs, _ := cryptography.NewStorageBoundCryptographyService(TestKeys)
+	kp := platformpolicy.NewKeyProcessor()
+	pk, _ := cs.GetPublicKey()
+
+	cert, err := ReadCertificate(pk, kp, TestCert)
+	require.NoError(t, err)
+	require.Equal(t, platformpolicy.ECDSAP256, cert.SignatureScheme)
+
+	cert.SignatureScheme = platformpolicy.Ed25519
+	data, err := cert.Dump()
+	require.NoError(t, err)
+
+	_, err = ReadCertificateFromReader(pk, kp, bytes.NewReader([]byte(data)))
+	require.Contains(t, err.Error(), "differs from certificate scheme")
+}
+
+func TestNewCertificatesWithKeys_Ed25519(t *testing.T) {
+	kp, err := platformpolicy.NewKeyProcessorByName(platformpolicy.Ed25519)
+	require.NoError(t, err)
+	privateKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	cert, err := NewCertificatesWithKeys(kp.ExtractPublicKey(privateKey), kp)
+	require.NoError(t, err)
+	require.Equal(t, platformpolicy.Ed25519, cert.SignatureScheme)
+
+	sign, err := cert.SignNodePart(privateKey)
+	require.NoError(t, err)
+	verifier := scheme.Verifier(cert.GetPublicKey())
+	require.True(t, verifier.Verify(core.SignatureFromBytes(sign), cert.SerializeNodePart()))
+}
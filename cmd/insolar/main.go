@@ -17,6 +17,7 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -29,6 +30,8 @@ import (
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/cryptography"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/keystore"
+	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/testutils"
@@ -85,6 +88,7 @@ func check(msg string, err error) {
 
 var (
 	output             string
+	input              string
 	cmd                string
 	numberCertificates uint
 	configPath         string
@@ -92,19 +96,27 @@ var (
 	verbose            bool
 	sendUrls           string
 	rootAsCaller       bool
+	encryptKeys        bool
+	signatureScheme    string
 )
 
 func parseInputParams() {
 	var rootCmd = &cobra.Command{}
 	rootCmd.Flags().StringVarP(&cmd, "cmd", "c", "",
-		"available commands: default_config | random_ref | version | gen_keys | gen_certificate | send_request | gen_send_configs")
+		"available commands: default_config | random_ref | version | gen_keys | gen_certificate | send_request | gen_send_configs | "+
+			"ledger_backup | ledger_restore | tape_inspect")
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "be verbose (default false)")
 	rootCmd.Flags().StringVarP(&output, "output", "o", defaultStdoutPath, "output file (use - for STDOUT)")
+	rootCmd.Flags().StringVarP(&input, "input", "i", "", "input file (ledger backup for ledger_restore, tape for tape_inspect)")
 	rootCmd.Flags().StringVarP(&sendUrls, "url", "u", defaultURL, "api url")
 	rootCmd.Flags().UintVarP(&numberCertificates, "num_certs", "n", 3, "number of certificates")
 	rootCmd.Flags().StringVarP(&configPath, "config", "g", "config.json", "path to configuration file")
 	rootCmd.Flags().StringVarP(&paramsPath, "params", "p", "", "path to params file (default params.json)")
 	rootCmd.Flags().BoolVarP(&rootAsCaller, "root_as_caller", "r", false, "use root member as caller")
+	rootCmd.Flags().StringVar(&signatureScheme, "scheme", platformpolicy.DefaultSignatureScheme,
+		fmt.Sprintf("signature scheme of keys generated by gen_keys %v", platformpolicy.SignatureSchemes()))
+	rootCmd.Flags().BoolVar(&encryptKeys, "encrypt", false, "encrypt generated private key with passphrase from "+
+		"INSOLAR_KEYS_PASSPHRASE or INSOLAR_KEYS_PASSPHRASE_FILE")
 	err := rootCmd.Execute()
 	check("Wrong input params:", err)
 
@@ -139,7 +151,8 @@ func randomRef(out io.Writer) {
 }
 
 func generateKeysPair(out io.Writer) {
-	ks := platformpolicy.NewKeyProcessor()
+	ks, err := platformpolicy.NewKeyProcessorByName(signatureScheme)
+	check("Problems with signature scheme:", err)
 
 	privKey, err := ks.GeneratePrivateKey()
 	check("Problems with generating of private key:", err)
@@ -150,10 +163,21 @@ func generateKeysPair(out io.Writer) {
 	pubKeyStr, err := ks.ExportPublicKeyPEM(ks.ExtractPublicKey(privKey))
 	check("Problems with serialization of public key:", err)
 
-	result, err := json.MarshalIndent(map[string]interface{}{
-		"private_key": string(privKeyStr),
-		"public_key":  string(pubKeyStr),
-	}, "", "    ")
+	keys := map[string]interface{}{
+		"public_key": string(pubKeyStr),
+	}
+	if encryptKeys {
+		passphrase, err := keystore.ReadPassphrase()
+		check("Problems with reading passphrase:", err)
+
+		encrypted, err := keystore.EncryptPrivateKey(privKeyStr, passphrase)
+		check("Problems with encryption of private key:", err)
+		keys["encrypted_private_key"] = encrypted
+	} else {
+		keys["private_key"] = string(privKeyStr)
+	}
+
+	result, err := json.MarshalIndent(keys, "", "    ")
 	check("Problems with marshaling keys:", err)
 
 	writeToOutput(out, string(result))
@@ -218,6 +242,48 @@ func genSendConfigs(out io.Writer) {
 	writeToOutput(out, string(userConf)+"\n")
 }
 
+func openLedgerDB() storage.DBContext {
+	holder := configuration.NewHolder()
+	err := holder.LoadFromFile(configPath)
+	check("[ openLedgerDB ] failed to load configuration", err)
+
+	db, err := storage.NewDB(holder.Configuration.Ledger, nil)
+	check("[ openLedgerDB ] failed to open database", err)
+	return db
+}
+
+func ledgerBackup(out io.Writer) {
+	db := openLedgerDB()
+	defer db.Close()
+
+	ctx := inslogger.ContextWithTrace(context.Background(), "insolarUtility")
+	bw := bufio.NewWriter(out)
+	header, err := storage.Backup(ctx, db, bw)
+	check("[ ledgerBackup ]", err)
+	err = bw.Flush()
+	check("[ ledgerBackup ]", err)
+
+	verboseInfo(fmt.Sprintf("Backup is done. Pulse: %v, drops: %v", header.Pulse, len(header.Drops)))
+}
+
+func ledgerRestore(out io.Writer) {
+	if len(input) == 0 {
+		check("[ ledgerRestore ]", errors.New("input file is not set"))
+	}
+	in, err := os.Open(input)
+	check("[ ledgerRestore ] couldn't open file for reading", err)
+	defer in.Close()
+
+	db := openLedgerDB()
+	defer db.Close()
+
+	ctx := inslogger.ContextWithTrace(context.Background(), "insolarUtility")
+	header, err := storage.Restore(ctx, db, in)
+	check("[ ledgerRestore ]", err)
+
+	writeToOutput(out, fmt.Sprintf("Restored ledger as of pulse %v, drops verified: %v\n", header.Pulse, len(header.Drops)))
+}
+
 func main() {
 	parseInputParams()
 	out, err := chooseOutput(output)
@@ -238,5 +304,11 @@ func main() {
 		sendRequest(out)
 	case "gen_send_configs":
 		genSendConfigs(out)
+	case "ledger_backup":
+		ledgerBackup(out)
+	case "ledger_restore":
+		ledgerRestore(out)
+	case "tape_inspect":
+		tapeInspect(out)
 	}
 }
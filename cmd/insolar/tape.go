@@ -0,0 +1,143 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner"
+	"github.com/insolar/insolar/messagebus"
+)
+
+type tapeDump struct {
+	Version byte
+	Pulse   core.PulseNumber
+	Records []tapeRecordDump
+}
+
+type tapeRecordDump struct {
+	MsgHash   string
+	ReplyType string     `json:",omitempty"`
+	Reply     core.Reply `json:",omitempty"`
+	Error     string     `json:",omitempty"`
+}
+
+type caseDump struct {
+	Object   string
+	Pulse    core.PulseNumber
+	Requests []caseRequestDump
+}
+
+type caseRequestDump struct {
+	Request     string
+	MessageType string
+	Message     core.Message
+	ReplyType   string     `json:",omitempty"`
+	Reply       core.Reply `json:",omitempty"`
+	Error       string     `json:",omitempty"`
+	Tape        *tapeDump
+}
+
+func dumpTape(r io.Reader) (*tapeDump, error) {
+	file, err := messagebus.ReadTapeFile(r)
+	if err != nil {
+		return nil, err
+	}
+	dump := &tapeDump{
+		Version: file.Version,
+		Pulse:   file.Pulse,
+		Records: make([]tapeRecordDump, 0, len(file.Records)),
+	}
+	for _, record := range file.Records {
+		rd := tapeRecordDump{
+			MsgHash: hex.EncodeToString(record.MsgHash),
+			Reply:   record.Item.Reply,
+		}
+		if record.Item.Reply != nil {
+			rd.ReplyType = fmt.Sprintf("%T", record.Item.Reply)
+		}
+		if record.Item.Error != nil {
+			rd.Error = record.Item.Error.Error()
+		}
+		dump.Records = append(dump.Records, rd)
+	}
+	return dump, nil
+}
+
+func dumpCaseBind(r io.Reader) (*caseDump, error) {
+	cb, err := logicrunner.ReadCaseBind(r)
+	if err != nil {
+		return nil, err
+	}
+	dump := &caseDump{
+		Object: cb.RecordRef.String(),
+		Pulse:  cb.Pulse.PulseNumber,
+	}
+	for _, req := range cb.Requests {
+		tape, err := dumpTape(bytes.NewReader(req.MessageBusTape))
+		if err != nil {
+			return nil, errors.Wrapf(err, "broken tape of request %s", req.Request)
+		}
+		rd := caseRequestDump{
+			Request:     req.Request.String(),
+			MessageType: req.Parcel.Type().String(),
+			Message:     req.Parcel.Message(),
+			Reply:       req.Reply,
+			Error:       req.Error,
+			Tape:        tape,
+		}
+		if req.Reply != nil {
+			rd.ReplyType = fmt.Sprintf("%T", req.Reply)
+		}
+		dump.Requests = append(dump.Requests, rd)
+	}
+	return dump, nil
+}
+
+// tapeInspect prints content of message bus tape or execution tape saved by logic runner to tape directory.
+func tapeInspect(out io.Writer) {
+	if len(input) == 0 {
+		check("[ tapeInspect ]", errors.New("input file is not set"))
+	}
+	in, err := os.Open(input)
+	check("[ tapeInspect ] couldn't open file for reading", err)
+	defer in.Close()
+
+	data, err := ioutil.ReadAll(in)
+	check("[ tapeInspect ] couldn't read file", err)
+
+	var dump interface{}
+	if bytes.HasPrefix(data, []byte(logicrunner.CaseTapeMagic)) {
+		dump, err = dumpCaseBind(bytes.NewReader(data))
+	} else {
+		dump, err = dumpTape(bytes.NewReader(data))
+	}
+	check("[ tapeInspect ] couldn't read tape", err)
+
+	result, err := json.MarshalIndent(dump, "", "    ")
+	check("[ tapeInspect ]", err)
+	writeToOutput(out, string(result)+"\n")
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/delegationtoken"
 	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/eventbus"
 	"github.com/insolar/insolar/genesis"
 	"github.com/insolar/insolar/genesisdataprovider"
 	"github.com/insolar/insolar/keystore"
@@ -35,6 +36,7 @@ import (
 	"github.com/insolar/insolar/logicrunner"
 	"github.com/insolar/insolar/messagebus"
 	"github.com/insolar/insolar/metrics"
+	"github.com/insolar/insolar/network/leaver"
 	"github.com/insolar/insolar/network/nodenetwork"
 	"github.com/insolar/insolar/network/servicenetwork"
 	"github.com/insolar/insolar/network/state"
@@ -58,8 +60,10 @@ func initBootstrapComponents(ctx context.Context, cfg configuration.Configuratio
 	keyStore, err := keystore.NewKeyStore(cfg.KeysPath)
 	checkError(ctx, err, "failed to load KeyStore: ")
 
-	platformCryptographyScheme := platformpolicy.NewPlatformCryptographyScheme()
-	keyProcessor := platformpolicy.NewKeyProcessor()
+	platformCryptographyScheme, err := platformpolicy.NewPlatformCryptographySchemeByName(cfg.SignatureScheme)
+	checkError(ctx, err, "failed to create PlatformCryptographyScheme: ")
+	keyProcessor, err := platformpolicy.NewKeyProcessorByName(cfg.SignatureScheme)
+	checkError(ctx, err, "failed to create KeyProcessor: ")
 
 	cryptographyService := cryptography.NewCryptographyService()
 	earlyComponents.Register(platformCryptographyScheme, keyStore)
@@ -110,7 +114,7 @@ func initComponents(
 	genesisConfigPath string,
 	genesisKeyOut string,
 
-) (*component.Manager, error) {
+) (*component.Manager, core.NodeLeaver, *logicrunner.LogicRunner, error) {
 	cm := component.Manager{}
 
 	nodeNetwork, err := nodenetwork.NewNodeNetwork(cfg.Host, certManager.GetCertificate())
@@ -152,6 +156,8 @@ func initComponents(
 	networkCoordinator, err := networkcoordinator.New()
 	checkError(ctx, err, "failed to start NetworkCoordinator")
 
+	nodeLeaver := leaver.New(cfg.Service)
+
 	_, err = manager.NewVersionManager(cfg.VersionManager)
 	checkError(ctx, err, "failed to load VersionManager: ")
 
@@ -174,6 +180,7 @@ func initComponents(
 
 	components = append(components, []interface{}{
 		messageBus,
+		eventbus.NewEventBus(),
 		contractRequester,
 		&ld,
 		logicRunner,
@@ -189,11 +196,12 @@ func initComponents(
 		metricsHandler,
 		networkSwitcher,
 		networkCoordinator,
+		nodeLeaver,
 		phases.NewPhaseManager(),
 		cryptographyService,
 	}...)
 
 	cm.Inject(components...)
 
-	return &cm, nil
+	return &cm, nodeLeaver, logicRunner, nil
 }
@@ -18,15 +18,57 @@ package main
 
 import (
 	"context"
+	"io/ioutil"
+	"net"
+	"os"
 	"testing"
 
+	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/logicrunner"
+	"github.com/insolar/insolar/testutils"
 	"github.com/stretchr/testify/require"
 )
 
 func TestInitComponents(t *testing.T) {
 	ctx := context.Background()
 	cfg := configuration.NewConfiguration()
+	cm, _, _ := initTestComponents(ctx, t, cfg)
+
+	err := cm.Init(ctx)
+	require.NoError(t, err)
+
+	err = cm.Start(ctx)
+	require.NoError(t, err)
+
+	err = cm.Stop(ctx)
+	require.NoError(t, err)
+}
+
+func TestReplayCaseTape_NetworkIsNotStarted(t *testing.T) {
+	ctx := context.Background()
+	cfg := configuration.NewConfiguration()
+	cm, _, logicRunner := initTestComponents(ctx, t, cfg)
+	require.NoError(t, cm.Init(ctx))
+
+	tape, err := ioutil.TempFile("", "tape")
+	require.NoError(t, err)
+	defer os.Remove(tape.Name())
+	err = logicrunner.WriteCaseBind(ctx, tape, testutils.RandomRef(), *core.GenesisPulse, nil)
+	require.NoError(t, err)
+	require.NoError(t, tape.Close())
+
+	err = replayCaseTape(ctx, logicRunner, tape.Name())
+	require.NoError(t, err)
+
+	_, err = net.Dial("tcp", cfg.APIRunner.Address)
+	require.Error(t, err, "components are not started on replay")
+}
+
+func initTestComponents(
+	ctx context.Context, t *testing.T, cfg configuration.Configuration,
+) (*component.Manager, core.NodeLeaver, *logicrunner.LogicRunner) {
 	cfg.KeysPath = "testdata/bootstrap_keys.json"
 	cfg.CertificatePath = "testdata/certificate.json"
 
@@ -38,7 +80,7 @@ func TestInitComponents(t *testing.T) {
 		bootstrapComponents.CryptographyService,
 		bootstrapComponents.KeyProcessor,
 	)
-	cm, err := initComponents(
+	cm, nodeLeaver, logicRunner, err := initComponents(
 		ctx,
 		cfg,
 		bootstrapComponents.CryptographyService,
@@ -52,13 +94,7 @@ func TestInitComponents(t *testing.T) {
 	)
 	require.NoError(t, err)
 	require.NotNil(t, cm)
-
-	err = cm.Init(ctx)
-	require.NoError(t, err)
-
-	err = cm.Start(ctx)
-	require.NoError(t, err)
-
-	err = cm.Stop(ctx)
-	require.NoError(t, err)
+	require.NotNil(t, nodeLeaver)
+	require.NotNil(t, logicRunner)
+	return cm, nodeLeaver, logicRunner
 }
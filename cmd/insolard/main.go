@@ -42,6 +42,7 @@ type inputParams struct {
 	genesisConfigPath string
 	genesisKeyOut     string
 	traceEnabled      bool
+	replayPath        string
 }
 
 func parseInputParams() inputParams {
@@ -51,6 +52,7 @@ func parseInputParams() inputParams {
 	rootCmd.Flags().StringVarP(&result.genesisConfigPath, "genesis", "g", "", "path to genesis config file")
 	rootCmd.Flags().StringVarP(&result.genesisKeyOut, "keyout", "", ".", "genesis certificates path")
 	rootCmd.Flags().BoolVarP(&result.traceEnabled, "trace", "t", false, "enable tracing")
+	rootCmd.Flags().StringVarP(&result.replayPath, "replay", "", "", "replay execution tape saved by logic runner and exit")
 	err := rootCmd.Execute()
 	if err != nil {
 		log.Fatal("Wrong input params:", err)
@@ -129,7 +131,7 @@ func main() {
 	}
 	defer jaegerflush()
 
-	cm, err := initComponents(
+	cm, nodeLeaver, logicRunner, err := initComponents(
 		ctx,
 		*cfg,
 		bootstrapComponents.CryptographyService,
@@ -151,6 +153,12 @@ func main() {
 	err = cm.Init(ctx)
 	checkError(ctx, err, "failed to init components")
 
+	if params.replayPath != "" {
+		err = replayCaseTape(ctx, logicRunner, params.replayPath)
+		checkError(ctx, err, "failed to replay tape")
+		return
+	}
+
 	var gracefulStop = make(chan os.Signal, 1)
 	signal.Notify(gracefulStop, syscall.SIGTERM)
 	signal.Notify(gracefulStop, syscall.SIGINT)
@@ -158,8 +166,12 @@ func main() {
 	var waitChannel = make(chan bool)
 
 	go func() {
-		sig := <-gracefulStop
-		inslog.Debugln("caught sig: ", sig)
+		select {
+		case sig := <-gracefulStop:
+			inslog.Debugln("caught sig: ", sig)
+			leave(ctx, nodeLeaver, gracefulStop)
+		case <-nodeLeaver.Left():
+		}
 
 		inslog.Warn("GRACEFULL STOP APP")
 		err = cm.Stop(ctx)
@@ -171,9 +183,30 @@ func main() {
 	checkError(ctx, err, "failed to start components")
 	fmt.Println("Version: ", version.GetFullVersion())
 	fmt.Println("All components were started")
+
 	<-waitChannel
 }
 
+// leave hands off node's work to the network before stop. Second signal stops the node immediately.
+func leave(ctx context.Context, nodeLeaver core.NodeLeaver, signals <-chan os.Signal) {
+	inslog := inslogger.FromContext(ctx)
+	inslog.Warn("GRACEFULL LEAVE NETWORK")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- nodeLeaver.Leave(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			inslog.Error("failed to leave network gracefully: ", err)
+		}
+	case sig := <-signals:
+		inslog.Warnf("caught sig %v while leaving network, stopping immediately", sig)
+	}
+}
+
 func initLogger(ctx context.Context, cfg configuration.Log, traceid string) (context.Context, core.Logger) {
 	inslog, err := log.NewLog(cfg)
 	if err != nil {
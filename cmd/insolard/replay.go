@@ -0,0 +1,55 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/logicrunner"
+)
+
+// replayCaseTape validates execution saved to logic runner tape directory against local logic runner.
+// Only logic runner is started, network components stay stopped, so the node doesn't join the network.
+func replayCaseTape(ctx context.Context, lr *logicrunner.LogicRunner, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "[ replayCaseTape ] couldn't open tape")
+	}
+	defer f.Close()
+
+	err = lr.Start(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ replayCaseTape ] couldn't start logic runner")
+	}
+	defer func() {
+		if err := lr.Stop(ctx); err != nil {
+			inslogger.FromContext(ctx).Error("[ replayCaseTape ] couldn't stop logic runner: ", err)
+		}
+	}()
+
+	validated, err := lr.ReplayCaseBind(ctx, f)
+	if err != nil {
+		return errors.Wrapf(err, "[ replayCaseTape ] replay failed after %d validated requests", validated)
+	}
+	fmt.Printf("Replay of %s is done. Validated requests: %d\n", path, validated)
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -109,10 +110,21 @@ func main() {
 
 	go server.StartServer(ctx)
 	pulseTicker, refreshTicker := runPulsar(ctx, server, cfgHolder.Configuration.Pulsar)
+	retentionTicker := runRetention(ctx, storage, cfgHolder.Configuration.Pulsar.Retention)
+	historyServer := runHistoryServer(ctx, storage, cfgHolder.Configuration.Pulsar.HistoryListenAddress)
 
 	defer func() {
 		pulseTicker.Stop()
 		refreshTicker.Stop()
+		if retentionTicker != nil {
+			retentionTicker.Stop()
+		}
+		if historyServer != nil {
+			err = historyServer.Shutdown(ctx)
+			if err != nil {
+				inslog.Error(err)
+			}
+		}
 		err = storage.Close()
 		if err != nil {
 			inslog.Error(err)
@@ -139,9 +151,15 @@ func initPulsar(ctx context.Context, cfg configuration.Configuration) (*componen
 	if err != nil {
 		inslogger.FromContext(ctx).Fatal(err)
 	}
-	cryptographyScheme := platformpolicy.NewPlatformCryptographyScheme()
+	cryptographyScheme, err := platformpolicy.NewPlatformCryptographySchemeByName(cfg.SignatureScheme)
+	if err != nil {
+		inslogger.FromContext(ctx).Fatal(err)
+	}
 	cryptographyService := cryptography.NewCryptographyService()
-	keyProcessor := platformpolicy.NewKeyProcessor()
+	keyProcessor, err := platformpolicy.NewKeyProcessorByName(cfg.SignatureScheme)
+	if err != nil {
+		inslogger.FromContext(ctx).Fatal(err)
+	}
 
 	tp, err := transport.NewTransport(cfg.Pulsar.DistributionTransport, relay.NewProxy())
 	if err != nil {
@@ -224,6 +242,44 @@ func runPulsar(ctx context.Context, server *pulsar.Pulsar, cfg configuration.Pul
 	return
 }
 
+func runRetention(ctx context.Context, storage pulsarstorage.PulsarStorage, cfg configuration.PulsarRetention) *time.Ticker {
+	if cfg.MaxAge == 0 && cfg.MaxPulses == 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(time.Duration(cfg.Interval) * time.Millisecond)
+	go func() {
+		for range ticker.C {
+			var before time.Time
+			if cfg.MaxAge > 0 {
+				before = time.Now().Add(-time.Duration(cfg.MaxAge) * time.Second)
+			}
+			removed, err := storage.Prune(before, cfg.MaxPulses)
+			if err != nil {
+				inslogger.FromContext(ctx).Error(err)
+				continue
+			}
+			inslogger.FromContext(ctx).Debugf("Pruned %d pulses", removed)
+		}
+	}()
+	return ticker
+}
+
+func runHistoryServer(ctx context.Context, storage pulsarstorage.PulsarStorage, address string) *http.Server {
+	if address == "" {
+		return nil
+	}
+
+	server := &http.Server{Addr: address, Handler: pulsar.NewHistoryHandler(storage)}
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			inslogger.FromContext(ctx).Error(err)
+		}
+	}()
+	return server
+}
+
 func initLogger(ctx context.Context, cfg configuration.Log, traceid string) (context.Context, core.Logger) {
 	inslog, err := log.NewLog(cfg)
 	if err != nil {
@@ -26,7 +26,9 @@ import (
 )
 
 type Config struct {
-	Nodes    []string
+	Nodes []string
+	// Pulsars are addresses of pulsar history endpoints.
+	Pulsars  []string
 	Interval time.Duration
 	Timeout  time.Duration
 }
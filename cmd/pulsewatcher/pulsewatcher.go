@@ -43,7 +43,7 @@ func main() {
 	if err != nil {
 		log.Fatal(errors.Wrap(err, "couldn't load config file"))
 	}
-	if len(conf.Nodes) == 0 {
+	if len(conf.Nodes) == 0 && len(conf.Pulsars) == 0 {
 		log.Fatal("couldn't find any nodes in config file")
 	}
 	if conf.Interval == 0 {
@@ -96,6 +96,17 @@ func main() {
 				wg.Done()
 			}(url, i)
 		}
+		pulsarResults := make([]string, len(conf.Pulsars))
+		wg.Add(len(conf.Pulsars))
+		for i, url := range conf.Pulsars {
+			go func(url string, i int) {
+				defer wg.Done()
+				result := lastPulsarPulse(url)
+				lock.Lock()
+				pulsarResults[i] = url + " : " + result
+				lock.Unlock()
+			}(url, i)
+		}
 		wg.Wait()
 		fmt.Println("\033[2J")
 		fmt.Printf("%v\n\n", time.Now())
@@ -103,7 +114,39 @@ func main() {
 		for _, result := range results {
 			fmt.Println(result)
 		}
+		if len(pulsarResults) > 0 {
+			fmt.Println()
+		}
+		for _, result := range pulsarResults {
+			fmt.Println(result)
+		}
 		lock.Unlock()
 		time.Sleep(conf.Interval)
 	}
 }
+
+// lastPulsarPulse returns description of the last pulse saved by pulsar with history endpoint on url.
+func lastPulsarPulse(url string) string {
+	res, err := client.Get("http://" + url + "/pulses/last")
+	if err != nil {
+		return err.Error()
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return res.Status
+	}
+
+	var out struct {
+		PulseNumber    uint32
+		PulseTimestamp int64
+		Signs          []struct {
+			PublicKey string
+		}
+	}
+	err = json.NewDecoder(res.Body).Decode(&out)
+	if err != nil {
+		return err.Error()
+	}
+	return "pulsar : " + strconv.Itoa(int(out.PulseNumber)) + " : " + time.Unix(out.PulseTimestamp, 0).String() +
+		" : " + strconv.Itoa(len(out.Signs)) + " signs"
+}
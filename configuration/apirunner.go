@@ -23,22 +23,38 @@ import (
 // APIRunner holds configuration for api
 type APIRunner struct {
 	Address string
-	Call    string
-	RPC     string
-	Timeout uint32
+	// AdminAddress is an address of admin API (e.g. node.Leave) listener. It should be reachable by node operator only.
+	// Admin API is disabled if AdminAddress is empty.
+	AdminAddress string
+	Call         string
+	RPC          string
+	// Batch is a path of batch call endpoint.
+	Batch string
+	// Export is a path of streaming storage export endpoint.
+	Export string
+	// Subscribe is a path of WebSocket subscription endpoint.
+	Subscribe string
+	// SubscribeOrigins is a list of origins (e.g. "https://example.com") that browsers may open subscriptions from.
+	SubscribeOrigins []string
+	Timeout          uint32
 }
 
 // NewAPIRunner creates new api config
 func NewAPIRunner() APIRunner {
 	return APIRunner{
-		Address: "localhost:19101",
-		Call:    "/api/call",
-		RPC:     "/api/rpc",
-		Timeout: 15,
+		Address:          "localhost:19101",
+		AdminAddress:     "localhost:19001",
+		Call:             "/api/call",
+		RPC:              "/api/rpc",
+		Batch:            "/api/batch",
+		Export:           "/api/export",
+		Subscribe:        "/api/subscribe",
+		SubscribeOrigins: []string{},
+		Timeout:          15,
 	}
 }
 
 func (ar *APIRunner) String() string {
-	res := fmt.Sprintln("Addr ->", ar.Address, ", Call ->", ar.Call, ", RPC ->", ar.RPC)
+	res := fmt.Sprintln("Addr ->", ar.Address, ", AdminAddr ->", ar.AdminAddress, ", Call ->", ar.Call, ", RPC ->", ar.RPC)
 	return res
 }
@@ -40,6 +40,8 @@ type Configuration struct {
 	KeysPath        string
 	CertificatePath string
 	Tracer          Tracer
+	// SignatureScheme is a name of platformpolicy signature scheme used for node keys (ecdsa-p256, ed25519).
+	SignatureScheme string
 }
 
 // Holder provides methods to manage configuration
@@ -63,6 +65,7 @@ func NewConfiguration() Configuration {
 		KeysPath:        "./",
 		CertificatePath: "",
 		Tracer:          NewTracer(),
+		SignatureScheme: "ecdsa-p256",
 	}
 
 	return cfg
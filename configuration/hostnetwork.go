@@ -24,6 +24,8 @@ type Transport struct {
 	Address string
 	// if true transport will use network traversal technique(like STUN) to get PublicAddress
 	BehindNAT bool
+	// if true TCP transport will use mutual TLS with certificates derived from node keys
+	TLS bool
 }
 
 // HostNetwork holds configuration for HostNetwork
@@ -27,6 +27,8 @@ type Storage struct {
 	// TxRetriesOnConflict defines how many retries on transaction conflicts
 	// storage update methods should do.
 	TxRetriesOnConflict int
+	// BackupDirectory is a directory where database snapshots made by admin API are written.
+	BackupDirectory string
 }
 
 // PulseManager holds configuration for PulseManager.
@@ -39,6 +41,22 @@ type PulseManager struct {
 	HeavyBackoff Backoff
 	// SplitThreshold is a drop size threshold in bytes to perform split.
 	SplitThreshold uint64
+	// SplitRequestsThreshold is a number of requests to a jet per pulse to perform split. Zero value disables check.
+	SplitRequestsThreshold int
+	// SplitPendingThreshold is a number of pending requests in a jet to perform split. Zero value disables check.
+	SplitPendingThreshold int
+	// SplitLatencyThreshold is an average request handling time in a jet to perform split. Zero value disables check.
+	SplitLatencyThreshold time.Duration
+	// MergeThreshold is a drop size threshold in bytes to perform merge. Sibling jets are merged when drops of both
+	// are smaller than threshold for MergeAfterPulses pulses in a row. Zero value disables merge.
+	MergeThreshold uint64
+	// MergeAfterPulses is a number of pulses with small drops required to merge jets.
+	// Should not exceed JetSizesHistoryDepth.
+	MergeAfterPulses int
+	// SplitCooldownPulses is a number of pulses after split or merge during which jet is not split by load. It
+	// prevents merged jets from being split back right away. Applied only when merge is enabled, forced splits ignore
+	// it. Should not exceed JetSizesHistoryDepth.
+	SplitCooldownPulses int
 }
 
 // Backoff configures retry backoff algorithm
@@ -92,6 +110,7 @@ func NewLedger() Ledger {
 		Storage: Storage{
 			DataDirectory:       "./data",
 			TxRetriesOnConflict: 3,
+			BackupDirectory:     "./backup",
 		},
 
 		PulseManager: PulseManager{
@@ -103,7 +122,13 @@ func NewLedger() Ledger {
 				Max:    2 * time.Second,
 				Factor: 2,
 			},
-			SplitThreshold: 10 * 100, // 10 megabytes.
+			SplitThreshold:         10 * 1000 * 1000, // 10 megabytes.
+			SplitRequestsThreshold: 1000,
+			SplitPendingThreshold:  100,
+			SplitLatencyThreshold:  0,
+			MergeThreshold:         0,
+			MergeAfterPulses:       5,
+			SplitCooldownPulses:    5,
 		},
 
 		RecentStorage: RecentStorage{
@@ -27,6 +27,9 @@ type LogicRunner struct {
 	BuiltIn *BuiltIn
 	// GoPlugin - configuration of executor based on Go plugins
 	GoPlugin *GoPlugin
+	// TapeDirectory - directory to save executed requests with recorded message bus tapes to,
+	// recording is disabled if empty. Saved files are replayed by `insolard --replay <file>`
+	TapeDirectory string
 }
 
 // BuiltIn configuration, no options at the moment
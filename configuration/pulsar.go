@@ -44,6 +44,20 @@ type Pulsar struct {
 
 	DistributionTransport Transport
 	PulseDistributor      PulseDistributor
+
+	// HistoryListenAddress is an address of HTTP endpoint serving saved pulses. Empty address disables the endpoint.
+	HistoryListenAddress string
+	Retention            PulsarRetention
+}
+
+// PulsarRetention holds policy of pruning pulses saved by pulsar. Zero limits are disabled.
+type PulsarRetention struct {
+	// MaxAge is a period in seconds saved pulses are kept for.
+	MaxAge int64
+	// MaxPulses is a number of the latest saved pulses that are kept.
+	MaxPulses int
+	// Interval is a pause between prunes.
+	Interval int32 // ms
 }
 
 type PulseDistributor struct {
@@ -88,5 +102,8 @@ func NewPulsar() Pulsar {
 			PulseRequestTimeout:       1000,
 			RandomNodesCount:          5,
 		},
+		Retention: PulsarRetention{
+			Interval: 60000,
+		},
 	}
 }
@@ -18,12 +18,19 @@ package configuration
 
 // ServiceNetwork is configuration for ServiceNetwork.
 type ServiceNetwork struct {
-	Skip int // magic number that indicates what delta after last ignored pulse we should wait
+	Skip                int // magic number that indicates what delta after last ignored pulse we should wait
+	LeaveTimeout        int // seconds to wait until node leave is confirmed by network and executions are finished
+	LeaveConfirmTimeout int // seconds to wait for confirmation of node leave before handing off work without it
+	// LeaveConfirm enables waiting for the network to confirm node leave. The network doesn't confirm it
+	// until consensus is run on pulse, so it is disabled by default.
+	LeaveConfirm bool
 }
 
 // NewServiceNetwork creates a new ServiceNetwork configuration.
 func NewServiceNetwork() ServiceNetwork {
 	return ServiceNetwork{
-		Skip: 10,
+		Skip:                10,
+		LeaveTimeout:        60,
+		LeaveConfirmTimeout: 10,
 	}
 }
@@ -67,10 +67,27 @@ func (cpa *CapabilityPoolingAndActivation) Type() ClaimType {
 	return TypeCapabilityPollingAndActivation
 }
 
+// Violation types of NodeViolationBlame.
+const (
+	// ViolationInvalidPulseProof means that node state hash proof of the node doesn't match pulse.
+	ViolationInvalidPulseProof = uint8(iota + 1)
+	// ViolationMissingPulseProof means that node didn't send phase 1 packet in time.
+	ViolationMissingPulseProof
+	// ViolationInvalidGlobuleProof means that globule hash signature of the node doesn't match globule hash.
+	ViolationInvalidGlobuleProof
+	// ViolationMissingVote means that node didn't send phase 2 packet in time.
+	ViolationMissingVote
+)
+
 // NodeViolationBlame is a type 2.
 type NodeViolationBlame struct {
 	BlameNodeID   uint32
 	TypeViolation uint8
+	// PulseNumber is a pulse of consensus the violation was detected in.
+	PulseNumber core.PulseNumber
+	// Evidence of violation. Empty if node data is missing.
+	StateHash [HashLength]byte
+	Signature [SignatureLength]byte
 }
 
 func (nvb *NodeViolationBlame) Type() ClaimType {
@@ -100,12 +100,27 @@ func (nvb *NodeViolationBlame) Deserialize(data io.Reader) error {
 		return errors.Wrap(err, "[ NodeViolationBlame.Deserialize ] Can't read TypeViolation")
 	}
 
+	err = binary.Read(data, defaultByteOrder, &nvb.PulseNumber)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeViolationBlame.Deserialize ] Can't read PulseNumber")
+	}
+
+	err = binary.Read(data, defaultByteOrder, &nvb.StateHash)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeViolationBlame.Deserialize ] Can't read StateHash")
+	}
+
+	err = binary.Read(data, defaultByteOrder, &nvb.Signature)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeViolationBlame.Deserialize ] Can't read Signature")
+	}
+
 	return nil
 }
 
 // Serialize implements interface method
 func (nvb *NodeViolationBlame) Serialize() ([]byte, error) {
-	result := allocateBuffer(64)
+	result := allocateBuffer(256)
 	err := binary.Write(result, defaultByteOrder, nvb.BlameNodeID)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't write BlameNodeID")
@@ -116,6 +131,21 @@ func (nvb *NodeViolationBlame) Serialize() ([]byte, error) {
 		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't write TypeViolation")
 	}
 
+	err = binary.Write(result, defaultByteOrder, nvb.PulseNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't write PulseNumber")
+	}
+
+	err = binary.Write(result, defaultByteOrder, nvb.StateHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't write StateHash")
+	}
+
+	err = binary.Write(result, defaultByteOrder, nvb.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't write Signature")
+	}
+
 	return result.Bytes(), nil
 }
 
@@ -50,7 +50,11 @@ func TestCapabilityPoolingAndActivation(t *testing.T) {
 
 func makeNodeViolationBlame() *NodeViolationBlame {
 	nodeViolationBlame := &NodeViolationBlame{}
-	nodeViolationBlame.TypeViolation = uint8(4)
+	nodeViolationBlame.BlameNodeID = uint32(7)
+	nodeViolationBlame.TypeViolation = ViolationInvalidPulseProof
+	nodeViolationBlame.PulseNumber = core.PulseNumber(11)
+	nodeViolationBlame.StateHash = randomArray64()
+	nodeViolationBlame.Signature = randomArray71()
 
 	return nodeViolationBlame
 }
@@ -67,7 +71,7 @@ func makeNodeJoinClaim() *NodeJoinClaim {
 	nodeJoinClaim.JoinsAfter = uint32(67)
 	nodeJoinClaim.NodeRoleRecID = 32
 	nodeJoinClaim.NodeRef = testutils.RandomRef()
-	nodeJoinClaim.NodePK = randomArray64()
+	nodeJoinClaim.NodePK = randomArray71()
 	nodeJoinClaim.Signature = randomArray71()
 
 	return nodeJoinClaim
@@ -30,9 +30,12 @@ const (
 )
 
 const HashLength = 64
-const SignatureLength = 66
 const ReferenceLength = 64
-const PublicKeyLength = 64
+
+// SignatureLength and PublicKeyLength fit signatures and binary public keys of every platform signature scheme.
+// Shorter ones are padded with zeroes, see NewSignatureField and SignatureFromField.
+const SignatureLength = 66
+const PublicKeyLength = 66
 
 // ------------------------------PACKET HEADER------------------------------
 
@@ -273,7 +273,7 @@ func TestPhase1Packet_Deserialize(t *testing.T) {
 func makePhase2Packet() *Phase2Packet {
 	phase2Packet := &Phase2Packet{}
 	phase2Packet.packetHeader = *makeDefaultPacketHeader(Phase2)
-	phase2Packet.globuleHashSignature = randomArray64()
+	phase2Packet.globuleHashSignature = randomArray71()
 	phase2Packet.SignatureHeaderSection1 = randomArray71()
 	phase2Packet.SignatureHeaderSection2 = randomArray71()
 	phase2Packet.bitSet, _ = NewTriStateBitSet(134)
@@ -378,3 +378,35 @@ func getPhase3Packet(t *testing.T) *Phase3Packet {
 
 	return packet
 }
+
+func TestSignatureField(t *testing.T) {
+	signature := genRandomSlice(SignatureLength - 2)
+	field, err := NewSignatureField(signature)
+	assert.NoError(t, err)
+
+	restored, err := SignatureFromField(field[:], len(signature))
+	assert.NoError(t, err)
+	assert.Equal(t, signature, restored.Bytes())
+
+	_, err = SignatureFromField(field[:], len(signature)-1)
+	assert.Error(t, err, "signature is longer than scheme's one")
+
+	_, err = SignatureFromField(field[:], SignatureLength+1)
+	assert.Error(t, err, "scheme's signature doesn't fit the field")
+
+	_, err = NewSignatureField(genRandomSlice(SignatureLength + 1))
+	assert.Error(t, err)
+}
+
+func TestPublicKeyField(t *testing.T) {
+	key := genRandomSlice(32)
+	field, err := NewPublicKeyField(key)
+	assert.NoError(t, err)
+
+	restored, err := PublicKeyFromField(field[:], len(key))
+	assert.NoError(t, err)
+	assert.Equal(t, key, restored)
+
+	_, err = NewPublicKeyField(genRandomSlice(PublicKeyLength + 1))
+	assert.Error(t, err)
+}
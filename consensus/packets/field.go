@@ -0,0 +1,81 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package packets
+
+import (
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// NewSignatureField copies signature to fixed size packet field.
+func NewSignatureField(signature []byte) ([SignatureLength]byte, error) {
+	var field [SignatureLength]byte
+	err := fillField(field[:], signature)
+	if err != nil {
+		return field, errors.Wrap(err, "[ NewSignatureField ] invalid signature")
+	}
+	return field, nil
+}
+
+// SignatureFromField cuts signature of scheme's size from packet field.
+func SignatureFromField(field []byte, size int) (core.Signature, error) {
+	data, err := cutField(field, size)
+	if err != nil {
+		return core.Signature{}, errors.Wrap(err, "[ SignatureFromField ] invalid signature")
+	}
+	return core.SignatureFromBytes(data), nil
+}
+
+// NewPublicKeyField copies binary public key to fixed size packet field.
+func NewPublicKeyField(key []byte) ([PublicKeyLength]byte, error) {
+	var field [PublicKeyLength]byte
+	err := fillField(field[:], key)
+	if err != nil {
+		return field, errors.Wrap(err, "[ NewPublicKeyField ] invalid public key")
+	}
+	return field, nil
+}
+
+// PublicKeyFromField cuts binary public key of scheme's size from packet field.
+func PublicKeyFromField(field []byte, size int) ([]byte, error) {
+	data, err := cutField(field, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ PublicKeyFromField ] invalid public key")
+	}
+	return data, nil
+}
+
+func fillField(field []byte, data []byte) error {
+	if len(data) > len(field) {
+		return errors.Errorf("length %d exceeds field length %d", len(data), len(field))
+	}
+	copy(field, data)
+	return nil
+}
+
+func cutField(field []byte, size int) ([]byte, error) {
+	if size <= 0 || size > len(field) {
+		return nil, errors.Errorf("length %d doesn't fit field length %d", size, len(field))
+	}
+	for _, b := range field[size:] {
+		if b != 0 {
+			return nil, errors.Errorf("field is longer than %d", size)
+		}
+	}
+	return append([]byte(nil), field[:size]...), nil
+}
@@ -92,13 +92,16 @@ func (p1p *Phase1Packet) GetPacketHeader() (*RoutingHeader, error) {
 
 // SetPulseProof sets PulseProof and check struct fields len, returns error if invalid len
 func (p1p *Phase1Packet) SetPulseProof(proofStateHash, proofSignature []byte) error {
-	if len(proofStateHash) == HashLength && len(proofSignature) == SignatureLength {
-		copy(p1p.proofNodePulse.NodeStateHash[:], proofStateHash[:HashLength])
-		copy(p1p.proofNodePulse.NodeSignature[:], proofSignature[:SignatureLength])
-		return nil
+	if len(proofStateHash) != HashLength {
+		return errors.New("invalid proof fields len")
 	}
-
-	return errors.New("invalid proof fields len")
+	signature, err := NewSignatureField(proofSignature)
+	if err != nil {
+		return errors.Wrap(err, "invalid proof fields len")
+	}
+	copy(p1p.proofNodePulse.NodeStateHash[:], proofStateHash)
+	p1p.proofNodePulse.NodeSignature = signature
+	return nil
 }
 
 // AddClaim adds claim if phase1Packet has space for it and returns true, otherwise returns false
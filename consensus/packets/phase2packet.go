@@ -28,7 +28,7 @@ type Phase2Packet struct {
 	packetHeader PacketHeader
 
 	// -------------------- Section 1
-	globuleHashSignature    [HashLength]byte
+	globuleHashSignature    [SignatureLength]byte
 	bitSet                  BitSet
 	SignatureHeaderSection1 [SignatureLength]byte
 
@@ -78,12 +78,12 @@ func (p2p *Phase2Packet) GetGlobuleHashSignature() []byte {
 }
 
 func (p2p *Phase2Packet) SetGlobuleHashSignature(globuleHashSignature []byte) error {
-	if len(globuleHashSignature) == SignatureLength {
-		copy(p2p.globuleHashSignature[:], globuleHashSignature[:SignatureLength])
-		return nil
+	signature, err := NewSignatureField(globuleHashSignature)
+	if err != nil {
+		return errors.Wrap(err, "invalid proof fields len")
 	}
-
-	return errors.New("invalid proof fields len")
+	p2p.globuleHashSignature = signature
+	return nil
 }
 
 func (p2p *Phase2Packet) GetBitSet() BitSet {
@@ -0,0 +1,114 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package phases
+
+import (
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+// Blames are votes, not decisions. Node marks blamed nodes as Fraud or TimedOut in its bitsets and the node is
+// excluded from active list only if majority of phase 3 bitsets agrees. Blames of phase 1 violations are also sent to
+// other nodes in the next pulse, see isBroadcastBlame.
+
+// blameNode registers violation of the node with provided evidence. Only the first violation of the node is kept.
+func blameNode(
+	blames map[core.RecordRef]*packets.NodeViolationBlame,
+	node core.Node,
+	pulseNumber core.PulseNumber,
+	violation uint8,
+	stateHash []byte,
+	signature []byte,
+) error {
+	if _, ok := blames[node.ID()]; ok {
+		return nil
+	}
+
+	blame := &packets.NodeViolationBlame{
+		BlameNodeID:   uint32(node.ShortID()),
+		TypeViolation: violation,
+		PulseNumber:   pulseNumber,
+	}
+	if len(stateHash) > len(blame.StateHash) {
+		return errors.Errorf("[ blameNode ] state hash length %d exceeds field length %d", len(stateHash), len(blame.StateHash))
+	}
+	copy(blame.StateHash[:], stateHash)
+	var err error
+	blame.Signature, err = packets.NewSignatureField(signature)
+	if err != nil {
+		return errors.Wrap(err, "[ blameNode ] failed to set evidence signature")
+	}
+	blames[node.ID()] = blame
+	return nil
+}
+
+// blameMissing blames nodes which data was not received.
+func blameMissing(
+	blames map[core.RecordRef]*packets.NodeViolationBlame,
+	nodes []core.Node,
+	pulseNumber core.PulseNumber,
+	received func(ref core.RecordRef) bool,
+	violation uint8,
+) {
+	for _, node := range nodes {
+		if !received(node.ID()) {
+			// Blame without evidence can't fail.
+			_ = blameNode(blames, node, pulseNumber, violation, nil, nil)
+		}
+	}
+}
+
+// blameState returns state of the blamed node in phase 2 and phase 3 bitsets.
+func blameState(blame *packets.NodeViolationBlame) packets.TriState {
+	switch blame.TypeViolation {
+	case packets.ViolationMissingPulseProof, packets.ViolationMissingVote:
+		return packets.TimedOut
+	default:
+		return packets.Fraud
+	}
+}
+
+// isBroadcastBlame checks if blame is sent to other nodes. Phase 1 data is the same for all nodes, so receivers can
+// verify such blames by their own phase 1 results. Phase 2 violations are reflected only in phase 3 bitset.
+func isBroadcastBlame(blame *packets.NodeViolationBlame) bool {
+	switch blame.TypeViolation {
+	case packets.ViolationInvalidPulseProof, packets.ViolationMissingPulseProof:
+		return true
+	default:
+		return false
+	}
+}
+
+// verifyBlame checks blame received from other node against blames of the same pulse detected by current node. Blame
+// is confirmed only if current node observed the same violation with the same evidence, so a faulty node can't make
+// honest nodes blame others.
+func verifyBlame(
+	blame *packets.NodeViolationBlame,
+	pulseNumber core.PulseNumber,
+	own map[core.ShortNodeID]*packets.NodeViolationBlame,
+) bool {
+	if blame.PulseNumber != pulseNumber || !isBroadcastBlame(blame) {
+		return false
+	}
+	observed, ok := own[core.ShortNodeID(blame.BlameNodeID)]
+	if !ok {
+		return false
+	}
+	return *observed == *blame
+}
@@ -0,0 +1,93 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package phases
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlameNode_SignatureField(t *testing.T) {
+	node := makeSignedNode(t)
+	blames := make(map[core.RecordRef]*packets.NodeViolationBlame)
+
+	tooLong := bytes.Repeat([]byte{1}, packets.SignatureLength+1)
+	require.Error(t, blameNode(blames, node, 10, packets.ViolationInvalidPulseProof, nil, tooLong))
+	require.Empty(t, blames)
+
+	signature := bytes.Repeat([]byte{1}, 64)
+	require.NoError(t, blameNode(blames, node, 10, packets.ViolationInvalidPulseProof, []byte{2}, signature))
+	field, err := packets.NewSignatureField(signature)
+	require.NoError(t, err)
+	require.Equal(t, field, blames[node.ID()].Signature)
+}
+
+func TestVerifyBlame(t *testing.T) {
+	node := makeSignedNode(t)
+	other := makeSignedNode(t)
+	blames := make(map[core.RecordRef]*packets.NodeViolationBlame)
+	require.NoError(t, blameNode(blames, node, 10, packets.ViolationInvalidPulseProof, []byte{2}, []byte{3}))
+	blameMissing(blames, []core.Node{other}, 10, func(core.RecordRef) bool { return false }, packets.ViolationMissingPulseProof)
+	own := map[core.ShortNodeID]*packets.NodeViolationBlame{}
+	for _, blame := range blames {
+		own[core.ShortNodeID(blame.BlameNodeID)] = blame
+	}
+
+	confirmed := *blames[node.ID()]
+	require.True(t, verifyBlame(&confirmed, 10, own))
+	missing := *blames[other.ID()]
+	require.True(t, verifyBlame(&missing, 10, own))
+
+	require.False(t, verifyBlame(&confirmed, 20, own), "blame of other pulse")
+
+	forged := confirmed
+	forged.Signature[0] = 4
+	require.False(t, verifyBlame(&forged, 10, own), "evidence differs")
+
+	unknown := confirmed
+	unknown.BlameNodeID++
+	require.False(t, verifyBlame(&unknown, 10, own), "violation is not observed")
+
+	phase2 := confirmed
+	phase2.TypeViolation = packets.ViolationInvalidGlobuleProof
+	require.False(t, verifyBlame(&phase2, 10, map[core.ShortNodeID]*packets.NodeViolationBlame{
+		core.ShortNodeID(phase2.BlameNodeID): &phase2,
+	}), "phase 2 blames are not broadcast")
+}
+
+func TestFirstPhase_getSignedClaims_Blames(t *testing.T) {
+	node := makeSignedNode(t)
+	blames := make(map[core.RecordRef]*packets.NodeViolationBlame)
+	require.NoError(t, blameNode(blames, node, 10, packets.ViolationInvalidPulseProof, []byte{2}, []byte{3}))
+	blame := blames[node.ID()]
+
+	fp := &firstPhase{
+		blamedPulse: 10,
+		pulseBlames: map[core.ShortNodeID]*packets.NodeViolationBlame{node.ShortID(): blame},
+	}
+	confirmed := *blame
+	forged := *blame
+	forged.StateHash[0] = 5
+	claims, peerBlames := fp.getSignedClaims([]packets.ReferendumClaim{&confirmed, &forged, &packets.NodeLeaveClaim{}})
+	require.Equal(t, []packets.ReferendumClaim{&packets.NodeLeaveClaim{}}, claims, "blames don't change active list")
+	require.Equal(t, []*packets.NodeViolationBlame{&confirmed}, peerBlames)
+}
@@ -204,8 +204,6 @@ func (nc *NaiveCommunicator) ExchangePhase2(ctx context.Context, participants []
 			return result, nil
 		}
 	}
-
-	return result, nil
 }
 
 // ExchangePhase3 used in third consensus step to exchange data between participants
@@ -278,6 +276,12 @@ func (nc *NaiveCommunicator) phase2DataHandler(request network.Request) {
 		log.Warn("Wrong handler for request type: ", request.GetType().String())
 		return
 	}
+	packet, ok := request.GetData().(*packets.Phase2Packet)
+	if !ok {
+		log.Errorln("invalid Phase2Packet")
+		return
+	}
+	nc.phase2result <- phase2Result{request.GetSender(), packet}
 }
 
 func (nc *NaiveCommunicator) phase3DataHandler(request network.Request) {
@@ -47,12 +47,17 @@ func NewFirstPhase() FirstPhase {
 }
 
 type firstPhase struct {
-	Calculator   merkle.Calculator        `inject:""`
-	Communicator Communicator             `inject:""`
-	Cryptography core.CryptographyService `inject:""`
-	NodeKeeper   network.NodeKeeper       `inject:""`
+	Calculator   merkle.Calculator               `inject:""`
+	Communicator Communicator                    `inject:""`
+	Cryptography core.CryptographyService        `inject:""`
+	Scheme       core.PlatformCryptographyScheme `inject:""`
+	NodeKeeper   network.NodeKeeper              `inject:""`
 	State        *FirstPhaseState
 	UnsyncList   network.UnsyncList
+
+	// blamedPulse and pulseBlames keep blames of the previous consensus to verify blames of other nodes.
+	blamedPulse core.PulseNumber
+	pulseBlames map[core.ShortNodeID]*packets.NodeViolationBlame
 }
 
 // Execute do first phase
@@ -104,21 +109,31 @@ func (fp *firstPhase) Execute(ctx context.Context, pulse *core.Pulse) (*FirstPha
 
 	proofSet := make(map[core.RecordRef]*merkle.PulseProof)
 	claimMap := make(map[core.RecordRef][]packets.ReferendumClaim)
+	peerBlames := make(map[core.RecordRef][]*packets.NodeViolationBlame)
+	origin := fp.NodeKeeper.GetOrigin().ID()
 	for ref, packet := range resultPackets {
 		signIsCorrect, err := fp.isSignPhase1PacketRight(packet, ref)
 		if err != nil {
 			log.Warn("failed to check a sign: ", err.Error())
 		} else if !signIsCorrect {
-			log.Warn("recieved a bad sign packet: ", err.Error())
+			log.Warn("recieved a bad sign packet from ", ref)
 		}
 		rawProof := packet.GetPulseProof()
+		proofSignature, err := packets.SignatureFromField(rawProof.Signature(), fp.Scheme.SignatureSIze())
+		if err != nil {
+			log.Warn("recieved a bad proof signature from ", ref)
+		}
 		proofSet[ref] = &merkle.PulseProof{
 			BaseProof: merkle.BaseProof{
-				Signature: core.SignatureFromBytes(rawProof.Signature()),
+				Signature: proofSignature,
 			},
 			StateHash: rawProof.StateHash(),
 		}
-		claimMap[ref] = fp.getSignedClaims(packet.GetClaims())
+		claims, blames := fp.getSignedClaims(packet.GetClaims())
+		claimMap[ref] = claims
+		if ref != origin && len(blames) > 0 {
+			peerBlames[ref] = blames
+		}
 	}
 
 	if fp.NodeKeeper.GetState() == network.Waiting {
@@ -133,6 +148,28 @@ func (fp *firstPhase) Execute(ctx context.Context, pulse *core.Pulse) (*FirstPha
 
 	valid, fault := fp.validateProofs(pulseHash, proofSet)
 
+	blames := make(map[core.RecordRef]*packets.NodeViolationBlame)
+	for ref, proof := range fault {
+		node := fp.UnsyncList.GetActiveNode(ref)
+		if node == nil {
+			// Unknown nodes are not in active list, nothing to exclude.
+			continue
+		}
+		err = blameNode(blames, node, pulse.PulseNumber, packets.ViolationInvalidPulseProof, proof.StateHash, proof.Signature.Bytes())
+		if err != nil {
+			log.Warn("failed to blame node ", ref, ": ", err.Error())
+		}
+	}
+	// Joining node doesn't know active list before consensus, so it can't detect missing nodes.
+	if fp.NodeKeeper.GetState() == network.Ready {
+		received := func(ref core.RecordRef) bool {
+			_, ok := resultPackets[ref]
+			return ok
+		}
+		blameMissing(blames, activeNodes, pulse.PulseNumber, received, packets.ViolationMissingPulseProof)
+	}
+	fp.broadcastBlames(pulse.PulseNumber, blames)
+
 	return &FirstPhaseState{
 		PulseEntry:  entry,
 		PulseHash:   pulseHash,
@@ -140,9 +177,25 @@ func (fp *firstPhase) Execute(ctx context.Context, pulse *core.Pulse) (*FirstPha
 		ValidProofs: valid,
 		FaultProofs: fault,
 		UnsyncList:  fp.UnsyncList,
+		Blames:      blames,
+		PeerBlames:  peerBlames,
 	}, nil
 }
 
+// broadcastBlames remembers phase 1 blames to verify blames of other nodes and sends them to other nodes in the next
+// pulse.
+func (fp *firstPhase) broadcastBlames(pulseNumber core.PulseNumber, blames map[core.RecordRef]*packets.NodeViolationBlame) {
+	fp.blamedPulse = pulseNumber
+	fp.pulseBlames = make(map[core.ShortNodeID]*packets.NodeViolationBlame, len(blames))
+	for _, blame := range blames {
+		if !isBroadcastBlame(blame) {
+			continue
+		}
+		fp.pulseBlames[core.ShortNodeID(blame.BlameNodeID)] = blame
+		fp.NodeKeeper.AddPendingClaim(blame)
+	}
+}
+
 func (fp *firstPhase) signPhase1Packet(packet *packets.Phase1Packet) error {
 	data, err := packet.RawBytes()
 	if err != nil {
@@ -152,7 +205,10 @@ func (fp *firstPhase) signPhase1Packet(packet *packets.Phase1Packet) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to sign a phase 2 packet")
 	}
-	copy(packet.Signature[:], sign.Bytes())
+	packet.Signature, err = packets.NewSignatureField(sign.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "failed to set a phase 1 packet sign")
+	}
 	return nil
 }
 
@@ -163,7 +219,11 @@ func (fp *firstPhase) isSignPhase1PacketRight(packet *packets.Phase1Packet, reco
 	if err != nil {
 		return false, errors.Wrap(err, "failed to serialize packet")
 	}
-	return fp.Cryptography.Verify(key, core.SignatureFromBytes(raw), raw), nil
+	signature, err := packets.SignatureFromField(packet.Signature[:], fp.Scheme.SignatureSIze())
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get a sign")
+	}
+	return fp.Cryptography.Verify(key, signature, raw), nil
 }
 
 func (fp *firstPhase) validateProofs(
@@ -208,9 +268,20 @@ func detectSparseBitsetLength(claims map[core.RecordRef][]packets.ReferendumClai
 	return 0, errors.New("no announce claims were received")
 }
 
-func (fp *firstPhase) getSignedClaims(claims []packets.ReferendumClaim) []packets.ReferendumClaim {
+// getSignedClaims returns claims with correct signatures and confirmed blames. Blames are not returned as claims, they
+// don't change active list.
+func (fp *firstPhase) getSignedClaims(claims []packets.ReferendumClaim) ([]packets.ReferendumClaim, []*packets.NodeViolationBlame) {
 	result := make([]packets.ReferendumClaim, 0)
+	var blames []*packets.NodeViolationBlame
 	for _, claim := range claims {
+		if blame, ok := claim.(*packets.NodeViolationBlame); ok {
+			if !verifyBlame(blame, fp.blamedPulse, fp.pulseBlames) {
+				log.Warnf("[ getSignedClaims ] blame of node %d in pulse %d is not confirmed", blame.BlameNodeID, blame.PulseNumber)
+				continue
+			}
+			blames = append(blames, blame)
+			continue
+		}
 		joinClaim, ok := claim.(*packets.NodeJoinClaim)
 		if ok {
 			signConfirmed, err := fp.claimSignIsOk(joinClaim)
@@ -225,18 +296,25 @@ func (fp *firstPhase) getSignedClaims(claims []packets.ReferendumClaim) []packet
 		}
 		result = append(result, claim)
 	}
-	return result
+	return result, blames
 }
 
 func (fp *firstPhase) claimSignIsOk(claim *packets.NodeJoinClaim) (bool, error) {
-	keyProc := platformpolicy.NewKeyProcessor()
-	key, err := keyProc.ImportPublicKeyPEM(claim.NodePK[:])
+	keyData, err := packets.PublicKeyFromField(claim.NodePK[:], fp.Scheme.PublicKeySize())
+	if err != nil {
+		return false, errors.Wrap(err, "[ claimSignIsOk ] failed to get a key")
+	}
+	key, err := platformpolicy.NewKeyProcessor().ImportPublicKeyBinary(keyData)
 	if err != nil {
 		return false, errors.Wrap(err, "[ claimSignIsOk ] failed to import a key")
 	}
+	signature, err := packets.SignatureFromField(claim.Signature[:], fp.Scheme.SignatureSIze())
+	if err != nil {
+		return false, errors.Wrap(err, "[ claimSignIsOk ] failed to get a sign")
+	}
 	rawClaim, err := claim.SerializeWithoutSign()
 	if err != nil {
 		return false, errors.Wrap(err, "[ claimSignIsOk ] failed to serialize a claim")
 	}
-	return fp.Cryptography.Verify(key, core.SignatureFromBytes(claim.Signature[:]), rawClaim), nil
+	return fp.Cryptography.Verify(key, signature, rawClaim), nil
 }
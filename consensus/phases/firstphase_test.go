@@ -18,12 +18,17 @@
 package phases
 
 import (
+	"bytes"
 	"crypto"
 	"testing"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
 	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/testutils"
 	"github.com/insolar/insolar/testutils/merkle"
 	"github.com/insolar/insolar/testutils/network"
@@ -53,7 +58,7 @@ func TestFirstPhase_HandlePulse(t *testing.T) {
 	})
 
 	cm := component.Manager{}
-	cm.Inject(cryptoServ, nodeKeeperMock, firstPhase, pulseCalculatorMock, communicatorMock, consensusNetworkMock)
+	cm.Inject(cryptoServ, platformpolicy.NewPlatformCryptographyScheme(), nodeKeeperMock, firstPhase, pulseCalculatorMock, communicatorMock, consensusNetworkMock)
 
 	require.NotNil(t, firstPhase.Calculator)
 	require.NotNil(t, firstPhase.NodeKeeper)
@@ -68,3 +73,61 @@ func Test_consensusReached(t *testing.T) {
 	assert.True(t, consensusReached(201, 300))
 	assert.False(t, consensusReached(200, 300))
 }
+
+func TestFirstPhase_Ed25519(t *testing.T) {
+	scheme, err := platformpolicy.NewPlatformCryptographySchemeByName(platformpolicy.Ed25519)
+	require.NoError(t, err)
+	keyProcessor, err := platformpolicy.NewKeyProcessorByName(platformpolicy.Ed25519)
+	require.NoError(t, err)
+	privateKey, err := keyProcessor.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	origin := nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, keyProcessor.ExtractPublicKey(privateKey), "127.0.0.1:0", "")
+	nodeKeeper := nodenetwork.NewNodeKeeper(origin)
+	nodeKeeper.AddActiveNodes([]core.Node{origin})
+	cryptographyService := cryptography.NewKeyBoundCryptographyService(privateKey)
+	fp := &firstPhase{Cryptography: cryptographyService, Scheme: scheme, NodeKeeper: nodeKeeper}
+
+	cm := component.Manager{}
+	cm.Inject(cryptographyService, scheme, nodeKeeper)
+
+	claim, err := nodeKeeper.GetOriginClaim()
+	require.NoError(t, err)
+
+	proofSignature := bytes.Repeat([]byte{1}, scheme.SignatureSIze())
+	packet := packets.NewPhase1Packet()
+	require.NoError(t, packet.SetPacketHeader(&packets.RoutingHeader{PacketType: types.Phase1}))
+	require.NoError(t, packet.SetPulseProof(make([]byte, packets.HashLength), proofSignature))
+	require.True(t, packet.AddClaim(claim))
+	require.NoError(t, fp.signPhase1Packet(packet))
+
+	data, err := packet.Serialize()
+	require.NoError(t, err)
+	received := &packets.Phase1Packet{}
+	require.NoError(t, received.Deserialize(bytes.NewReader(data)))
+
+	ok, err := fp.isSignPhase1PacketRight(received, origin.ID())
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	signature, err := packets.SignatureFromField(received.GetPulseProof().Signature(), scheme.SignatureSIze())
+	require.NoError(t, err)
+	require.Equal(t, proofSignature, signature.Bytes())
+
+	claims := received.GetClaims()
+	require.Len(t, claims, 1)
+	joinClaim, ok := claims[0].(*packets.NodeJoinClaim)
+	require.True(t, ok)
+	ok, err = fp.claimSignIsOk(joinClaim)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	announce := &packets.NodeAnnounceClaim{NodeJoinClaim: *joinClaim, NodeCount: 1}
+	list := nodeKeeper.GetSparseUnsyncList(1)
+	list.AddClaims(
+		map[core.RecordRef][]packets.ReferendumClaim{origin.ID(): {announce}},
+		map[core.RecordRef]string{origin.ID(): origin.PhysicalAddress()},
+	)
+	require.Len(t, list.GetActiveNodes(), 1)
+	require.Equal(t, origin.PublicKey(), list.GetActiveNodes()[0].PublicKey())
+}
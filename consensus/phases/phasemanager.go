@@ -19,15 +19,18 @@ package phases
 
 import (
 	"context"
-	"fmt"
+	"sync"
 	"time"
 
 	"github.com/insolar/insolar/core"
-	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/network"
 	"github.com/pkg/errors"
 )
 
+// consensusResultsLimit is a number of the last pulses which consensus results are kept.
+const consensusResultsLimit = 100
+
 type PhaseManager interface {
 	OnPulse(ctx context.Context, pulse *core.Pulse) error
 }
@@ -39,6 +42,9 @@ type Phases struct {
 
 	PulseManager core.PulseManager  `inject:""`
 	NodeKeeper   network.NodeKeeper `inject:""`
+
+	resultsLock sync.RWMutex
+	results     []*ConsensusResult
 }
 
 // NewPhaseManager creates and returns a new phase manager.
@@ -46,10 +52,25 @@ func NewPhaseManager() PhaseManager {
 	return &Phases{}
 }
 
-// Start starts calculate args on phases.
+// OnPulse executes consensus phases for the pulse and records their outcome. Every phase is limited by a part of
+// the pulse duration. Note that ServiceNetwork doesn't call it on pulse yet.
 func (pm *Phases) OnPulse(ctx context.Context, pulse *core.Pulse) error {
-	var err error
+	result := &ConsensusResult{PulseNumber: pulse.PulseNumber}
+	result.Err = pm.execute(ctx, pulse, result)
+	pm.addResult(result)
+
+	logger := inslogger.FromContext(ctx)
+	for ref, blame := range result.Blames {
+		logger.Warnf("[ OnPulse ] node %s is blamed in pulse %d, violation: %d", ref, pulse.PulseNumber, blame.TypeViolation)
+	}
+	if result.Err != nil {
+		return result.Err
+	}
+	logger.Infof("[ OnPulse ] consensus for pulse %d is reached, blamed nodes: %d", pulse.PulseNumber, len(result.Blames))
+	return nil
+}
 
+func (pm *Phases) execute(ctx context.Context, pulse *core.Pulse, result *ConsensusResult) error {
 	pulseDuration, err := getPulseDuration(pulse)
 	if err != nil {
 		return errors.Wrap(err, "[ OnPulse ] Failed to get pulse duration")
@@ -62,18 +83,51 @@ func (pm *Phases) OnPulse(ctx context.Context, pulse *core.Pulse) error {
 	defer cancel()
 
 	firstPhaseState, err := pm.FirstPhase.Execute(tctx, pulse)
-	checkError(err)
+	if err != nil {
+		return errors.Wrap(err, "[ OnPulse ] Failed to execute first phase")
+	}
+	result.Blames = firstPhaseState.Blames
+	result.PeerBlames = firstPhaseState.PeerBlames
 
 	tctx, cancel = contextTimeout(ctx, *pulseDuration, 0.2)
 	defer cancel()
 
 	secondPhaseState, err := pm.SecondPhase.Execute(tctx, firstPhaseState)
-	checkError(err)
+	if err != nil {
+		return errors.Wrap(err, "[ OnPulse ] Failed to execute second phase")
+	}
 
-	fmt.Println(secondPhaseState) // TODO: remove after use
+	tctx, cancel = contextTimeout(ctx, *pulseDuration, 0.2)
+	defer cancel()
 
-	checkError(pm.ThirdPhase.Execute(ctx, secondPhaseState))
+	err = pm.ThirdPhase.Execute(tctx, secondPhaseState)
+	if err != nil {
+		return errors.Wrap(err, "[ OnPulse ] Failed to execute third phase")
+	}
+	return nil
+}
+
+func (pm *Phases) addResult(result *ConsensusResult) {
+	pm.resultsLock.Lock()
+	defer pm.resultsLock.Unlock()
+
+	pm.results = append(pm.results, result)
+	if len(pm.results) > consensusResultsLimit {
+		pm.results = pm.results[len(pm.results)-consensusResultsLimit:]
+	}
+}
 
+// Result returns outcome of consensus for provided pulse. Nil is returned if there was no consensus for the pulse or
+// the pulse is too old.
+func (pm *Phases) Result(pulseNumber core.PulseNumber) *ConsensusResult {
+	pm.resultsLock.RLock()
+	defer pm.resultsLock.RUnlock()
+
+	for _, result := range pm.results {
+		if result.PulseNumber == pulseNumber {
+			return result
+		}
+	}
 	return nil
 }
 
@@ -87,9 +141,3 @@ func contextTimeout(ctx context.Context, duration time.Duration, k float64) (con
 	timedCtx, cancelFund := context.WithTimeout(ctx, timeout)
 	return timedCtx, cancelFund
 }
-
-func checkError(err error) {
-	if err != nil {
-		log.Error(err)
-	}
-}
@@ -0,0 +1,93 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package phases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type firstPhaseStub struct {
+	blames map[core.RecordRef]*packets.NodeViolationBlame
+}
+
+func (p *firstPhaseStub) Execute(ctx context.Context, pulse *core.Pulse) (*FirstPhaseState, error) {
+	return &FirstPhaseState{Blames: p.blames}, nil
+}
+
+type secondPhaseStub struct {
+	err error
+}
+
+func (p *secondPhaseStub) Execute(ctx context.Context, state *FirstPhaseState) (*SecondPhaseState, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &SecondPhaseState{FirstPhaseState: state}, nil
+}
+
+type thirdPhaseStub struct {
+	hasDeadline bool
+}
+
+func (p *thirdPhaseStub) Execute(ctx context.Context, state *SecondPhaseState) error {
+	_, p.hasDeadline = ctx.Deadline()
+	return nil
+}
+
+func TestPhases_OnPulse_RecordsResult(t *testing.T) {
+	blamed := testutils.RandomRef()
+	blames := map[core.RecordRef]*packets.NodeViolationBlame{
+		blamed: {TypeViolation: packets.ViolationMissingPulseProof},
+	}
+	second := &secondPhaseStub{}
+	third := &thirdPhaseStub{}
+	pm := &Phases{
+		FirstPhase:  &firstPhaseStub{blames: blames},
+		SecondPhase: second,
+		ThirdPhase:  third,
+	}
+	ctx := context.Background()
+
+	err := pm.OnPulse(ctx, &core.Pulse{PulseNumber: 20, PrevPulseNumber: 10})
+	require.NoError(t, err)
+	require.True(t, third.hasDeadline, "third phase must be limited in time")
+	result := pm.Result(20)
+	require.NotNil(t, result)
+	require.NoError(t, result.Err)
+	require.Equal(t, blames, result.Blames)
+
+	second.err = errors.New("consensus not reached")
+	err = pm.OnPulse(ctx, &core.Pulse{PulseNumber: 30, PrevPulseNumber: 20})
+	require.Error(t, err)
+	require.Error(t, pm.Result(30).Err)
+	require.Nil(t, pm.Result(40))
+
+	second.err = nil
+	for i := 0; i < consensusResultsLimit; i++ {
+		pn := core.PulseNumber(40 + i*10)
+		require.NoError(t, pm.OnPulse(ctx, &core.Pulse{PulseNumber: pn, PrevPulseNumber: pn - 10}))
+	}
+	require.Nil(t, pm.Result(20), "old results are removed")
+}
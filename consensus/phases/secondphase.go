@@ -37,10 +37,11 @@ func NewSecondPhase() SecondPhase {
 }
 
 type secondPhase struct {
-	NodeKeeper   network.NodeKeeper       `inject:""`
-	Calculator   merkle.Calculator        `inject:""`
-	Communicator Communicator             `inject:""`
-	Cryptography core.CryptographyService `inject:""`
+	NodeKeeper   network.NodeKeeper              `inject:""`
+	Calculator   merkle.Calculator               `inject:""`
+	Communicator Communicator                    `inject:""`
+	Cryptography core.CryptographyService        `inject:""`
+	Scheme       core.PlatformCryptographyScheme `inject:""`
 }
 
 func (sp *secondPhase) Execute(ctx context.Context, state *FirstPhaseState) (*SecondPhaseState, error) {
@@ -64,7 +65,7 @@ func (sp *secondPhase) Execute(ctx context.Context, state *FirstPhaseState) (*Se
 	if err != nil {
 		return nil, errors.Wrap(err, "[ Execute ] Failed to set pulse proof in Phase2Packet.")
 	}
-	bitset, err := generatePhase2Bitset(state.UnsyncList, state.ValidProofs)
+	bitset, err := generatePhase2Bitset(state.UnsyncList, state.ValidProofs, state.Blames)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ Execute ] Failed to generate bitset for Phase2Packet")
 	}
@@ -74,24 +75,33 @@ func (sp *secondPhase) Execute(ctx context.Context, state *FirstPhaseState) (*Se
 		return nil, errors.Wrap(err, "failed to sign a packet")
 	}
 	activeNodes := state.UnsyncList.GetActiveNodes()
-	packets, err := sp.Communicator.ExchangePhase2(ctx, activeNodes, &packet)
+	answers, err := sp.Communicator.ExchangePhase2(ctx, activeNodes, &packet)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ Execute ] Failed to exchange results.")
 	}
 
 	nodeProofs := make(map[core.Node]*merkle.GlobuleProof)
+	pulseNumber := state.PulseEntry.Pulse.PulseNumber
 
-	for ref, packet := range packets {
+	for ref, packet := range answers {
 		signIsCorrect, err := sp.isSignPhase2PacketRight(packet, ref)
 		if err != nil {
 			log.Warn("failed to check a sign: ", err.Error())
 		} else if !signIsCorrect {
-			log.Warn("recieved a bad sign packet: ", err.Error())
+			log.Warn("recieved a bad sign packet from ", ref)
 		}
 		node := state.UnsyncList.GetActiveNode(ref)
+		if node == nil {
+			log.Warn("recieved a phase 2 packet from unknown node ", ref)
+			continue
+		}
+		proofSignature, err := packets.SignatureFromField(packet.GetGlobuleHashSignature(), sp.Scheme.SignatureSIze())
+		if err != nil {
+			log.Warn("recieved a bad globule proof signature from ", ref)
+		}
 		proof := &merkle.GlobuleProof{
 			BaseProof: merkle.BaseProof{
-				Signature: core.SignatureFromBytes(packet.GetGlobuleHashSignature()),
+				Signature: proofSignature,
 			},
 			PrevCloudHash: prevCloudHash,
 			GlobuleID:     globuleProof.GlobuleID,
@@ -99,18 +109,30 @@ func (sp *secondPhase) Execute(ctx context.Context, state *FirstPhaseState) (*Se
 			NodeRoot:      globuleProof.NodeRoot,
 		}
 
-		if !sp.Calculator.IsValid(proof, globuleHash, node.PublicKey()) {
+		if sp.Calculator.IsValid(proof, globuleHash, node.PublicKey()) {
 			nodeProofs[node] = proof
+		} else {
+			err = blameNode(state.Blames, node, pulseNumber, packets.ViolationInvalidGlobuleProof, globuleHash, proof.Signature.Bytes())
+			if err != nil {
+				log.Warn("failed to blame node ", ref, ": ", err.Error())
+			}
 		}
 	}
+	received := func(ref core.RecordRef) bool {
+		_, ok := answers[ref]
+		return ok
+	}
+	blameMissing(state.Blames, activeNodes, pulseNumber, received, packets.ViolationMissingVote)
 
-	// TODO: check
 	if !consensusReached(len(nodeProofs), len(activeNodes)) {
 		return nil, errors.New("[ Execute ] Consensus not reached")
 	}
 
-	// TODO: timeouts, deviants, etc.
-	sp.NodeKeeper.Sync(state.UnsyncList)
+	// Phase 3 bitset also marks nodes blamed in phase 2. Active list is changed only after phase 3.
+	deviantBitset, err := generatePhase2Bitset(state.UnsyncList, state.ValidProofs, state.Blames)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Execute ] Failed to generate bitset for Phase3Packet")
+	}
 	return &SecondPhaseState{
 		FirstPhaseState: state,
 
@@ -118,10 +140,16 @@ func (sp *secondPhase) Execute(ctx context.Context, state *FirstPhaseState) (*Se
 		GlobuleHash:     globuleHash,
 		GlobuleProof:    globuleProof,
 		GlobuleProofSet: nodeProofs,
+
+		DBitSet: deviantBitset,
 	}, nil
 }
 
-func generatePhase2Bitset(list network.UnsyncList, proofs map[core.Node]*merkle.PulseProof) (packets.BitSet, error) {
+func generatePhase2Bitset(
+	list network.UnsyncList,
+	proofs map[core.Node]*merkle.PulseProof,
+	blames map[core.RecordRef]*packets.NodeViolationBlame,
+) (packets.BitSet, error) {
 	bitset, err := packets.NewBitSet(list.Length())
 	if err != nil {
 		return nil, err
@@ -130,6 +158,9 @@ func generatePhase2Bitset(list network.UnsyncList, proofs map[core.Node]*merkle.
 	for node := range proofs {
 		cells = append(cells, packets.BitSetCell{NodeID: node.ID(), State: packets.Legit})
 	}
+	for ref, blame := range blames {
+		cells = append(cells, packets.BitSetCell{NodeID: ref, State: blameState(blame)})
+	}
 	err = bitset.ApplyChanges(cells, list)
 	if err != nil {
 		return nil, err
@@ -147,7 +178,10 @@ func (sp *secondPhase) signPhase2Packet(p *packets.Phase2Packet) error {
 		return errors.Wrap(err, "failed to sign a phase 2 packet")
 	}
 
-	copy(p.SignatureHeaderSection1[:], sign.Bytes())
+	p.SignatureHeaderSection1, err = packets.NewSignatureField(sign.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "failed to set a phase 2 packet sign")
+	}
 	// TODO: sign a second part after claim addition
 	return nil
 }
@@ -160,5 +194,9 @@ func (sp *secondPhase) isSignPhase2PacketRight(packet *packets.Phase2Packet, rec
 		return false, errors.Wrap(err, "failed to serialize")
 	}
 
-	return sp.Cryptography.Verify(key, core.SignatureFromBytes(raw), raw), nil
+	signature, err := packets.SignatureFromField(packet.SignatureHeaderSection1[:], sp.Scheme.SignatureSIze())
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get a sign")
+	}
+	return sp.Cryptography.Verify(key, signature, raw), nil
 }
@@ -0,0 +1,192 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package phases
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"testing"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/merkle"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	merkleMock "github.com/insolar/insolar/testutils/merkle"
+	"github.com/stretchr/testify/require"
+)
+
+func makeSignedNode(t *testing.T) core.Node {
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	privateKey, err := keyProcessor.GeneratePrivateKey()
+	require.NoError(t, err)
+	return nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, keyProcessor.ExtractPublicKey(privateKey), "127.0.0.1:0", "")
+}
+
+func makePhase2Packet(t *testing.T, signature []byte) *packets.Phase2Packet {
+	packet := &packets.Phase2Packet{}
+	require.NoError(t, packet.SetGlobuleHashSignature(signature))
+	bitset, err := packets.NewBitSet(1)
+	require.NoError(t, err)
+	packet.SetBitSet(bitset)
+	return packet
+}
+
+func TestSecondPhase_Execute_BlamesNodes(t *testing.T) {
+	nodes := make([]core.Node, 7)
+	for i := range nodes {
+		nodes[i] = makeSignedNode(t)
+	}
+	origin, badGlobule, badPulse, missing := nodes[0], nodes[4], nodes[5], nodes[6]
+
+	nodeKeeper := nodenetwork.NewNodeKeeper(origin)
+	nodeKeeper.AddActiveNodes(nodes)
+
+	goodSignature := bytes.Repeat([]byte{1}, packets.SignatureLength)
+	badSignature := bytes.Repeat([]byte{2}, packets.SignatureLength)
+
+	calculator := merkleMock.NewCalculatorMock(t)
+	calculator.GetGlobuleProofFunc = func(*merkle.GlobuleEntry) (merkle.OriginHash, *merkle.GlobuleProof, error) {
+		proof := &merkle.GlobuleProof{BaseProof: merkle.BaseProof{Signature: core.SignatureFromBytes(goodSignature)}}
+		return merkle.OriginHash("globule hash"), proof, nil
+	}
+	calculator.IsValidFunc = func(proof merkle.Proof, hash merkle.OriginHash, key crypto.PublicKey) bool {
+		// Phase 2 packet keeps only HashLength bytes of the signature.
+		return bytes.HasPrefix(goodSignature, proof.(*merkle.GlobuleProof).Signature.Bytes())
+	}
+
+	communicator := NewCommunicatorMock(t)
+	communicator.ExchangePhase2Func = func(
+		ctx context.Context, participants []core.Node, packet *packets.Phase2Packet,
+	) (map[core.RecordRef]*packets.Phase2Packet, error) {
+		result := make(map[core.RecordRef]*packets.Phase2Packet)
+		for _, node := range participants {
+			switch node {
+			case missing:
+			case badGlobule:
+				result[node.ID()] = makePhase2Packet(t, badSignature)
+			default:
+				result[node.ID()] = makePhase2Packet(t, goodSignature)
+			}
+		}
+		return result, nil
+	}
+
+	cryptography := testutils.NewCryptographyServiceMock(t)
+	cryptography.SignFunc = func([]byte) (*core.Signature, error) {
+		signature := core.SignatureFromBytes(nil)
+		return &signature, nil
+	}
+	cryptography.VerifyFunc = func(crypto.PublicKey, core.Signature, []byte) bool {
+		return true
+	}
+
+	validProofs := make(map[core.Node]*merkle.PulseProof)
+	for _, node := range nodes {
+		if node != badPulse {
+			validProofs[node] = &merkle.PulseProof{}
+		}
+	}
+	blames := make(map[core.RecordRef]*packets.NodeViolationBlame)
+	require.NoError(t, blameNode(blames, badPulse, 10, packets.ViolationInvalidPulseProof, []byte{3}, []byte{4}))
+
+	sp := &secondPhase{
+		NodeKeeper:   nodeKeeper,
+		Calculator:   calculator,
+		Communicator: communicator,
+		Cryptography: cryptography,
+		Scheme:       platformpolicy.NewPlatformCryptographyScheme(),
+	}
+	state, err := sp.Execute(context.Background(), &FirstPhaseState{
+		PulseEntry:  &merkle.PulseEntry{Pulse: &core.Pulse{PulseNumber: 10}},
+		ValidProofs: validProofs,
+		UnsyncList:  nodeKeeper.GetUnsyncList(),
+		Blames:      blames,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, state.DBitSet)
+	require.Len(t, state.GlobuleProofSet, 5)
+
+	require.Len(t, state.Blames, 3)
+	require.Equal(t, packets.ViolationInvalidGlobuleProof, state.Blames[badGlobule.ID()].TypeViolation)
+	require.Equal(t, uint32(badGlobule.ShortID()), state.Blames[badGlobule.ID()].BlameNodeID)
+	require.True(t, bytes.HasPrefix(state.Blames[badGlobule.ID()].Signature[:], badSignature[:packets.HashLength]))
+	require.Equal(t, packets.ViolationInvalidPulseProof, state.Blames[badPulse.ID()].TypeViolation)
+	require.Equal(t, packets.ViolationMissingVote, state.Blames[missing.ID()].TypeViolation)
+	require.Equal(t, core.PulseNumber(10), state.Blames[missing.ID()].PulseNumber)
+
+	// Blames are votes of phase 3 bitset, active list is not changed by the node itself.
+	cells, err := state.DBitSet.GetCells(state.UnsyncList)
+	require.NoError(t, err)
+	states := make(map[core.RecordRef]packets.TriState)
+	for _, cell := range cells {
+		states[cell.NodeID] = cell.State
+	}
+	require.Equal(t, packets.Fraud, states[badGlobule.ID()])
+	require.Equal(t, packets.Fraud, states[badPulse.ID()])
+	require.Equal(t, packets.TimedOut, states[missing.ID()])
+	require.Equal(t, packets.Legit, states[origin.ID()])
+	require.Len(t, nodeKeeper.GetActiveNodes(), 7)
+	require.Len(t, state.UnsyncList.GetActiveNodes(), 7)
+}
+
+func TestSecondPhase_Execute_ConsensusNotReached(t *testing.T) {
+	nodes := make([]core.Node, 3)
+	for i := range nodes {
+		nodes[i] = makeSignedNode(t)
+	}
+	nodeKeeper := nodenetwork.NewNodeKeeper(nodes[0])
+	nodeKeeper.AddActiveNodes(nodes)
+
+	calculator := merkleMock.NewCalculatorMock(t)
+	calculator.GetGlobuleProofFunc = func(*merkle.GlobuleEntry) (merkle.OriginHash, *merkle.GlobuleProof, error) {
+		signature := core.SignatureFromBytes(make([]byte, packets.SignatureLength))
+		return merkle.OriginHash("globule hash"), &merkle.GlobuleProof{BaseProof: merkle.BaseProof{Signature: signature}}, nil
+	}
+	calculator.IsValidMock.Return(true)
+
+	communicator := NewCommunicatorMock(t)
+	communicator.ExchangePhase2Func = func(
+		ctx context.Context, participants []core.Node, packet *packets.Phase2Packet,
+	) (map[core.RecordRef]*packets.Phase2Packet, error) {
+		return map[core.RecordRef]*packets.Phase2Packet{nodes[0].ID(): packet}, nil
+	}
+
+	cryptography := testutils.NewCryptographyServiceMock(t)
+	cryptography.SignFunc = func([]byte) (*core.Signature, error) {
+		signature := core.SignatureFromBytes(nil)
+		return &signature, nil
+	}
+	cryptography.VerifyMock.Return(true)
+
+	sp := &secondPhase{
+		NodeKeeper:   nodeKeeper,
+		Calculator:   calculator,
+		Communicator: communicator,
+		Cryptography: cryptography,
+		Scheme:       platformpolicy.NewPlatformCryptographyScheme(),
+	}
+	_, err := sp.Execute(context.Background(), &FirstPhaseState{
+		PulseEntry: &merkle.PulseEntry{Pulse: &core.Pulse{}},
+		UnsyncList: nodeKeeper.GetUnsyncList(),
+		Blames:     make(map[core.RecordRef]*packets.NodeViolationBlame),
+	})
+	require.Error(t, err)
+}
@@ -34,6 +34,11 @@ type FirstPhaseState struct {
 	FaultProofs map[core.RecordRef]*merkle.PulseProof
 
 	UnsyncList network.UnsyncList
+
+	// Blames holds violations detected during consensus by blamed node.
+	Blames map[core.RecordRef]*packets.NodeViolationBlame
+	// PeerBlames holds blames of previous pulse received from other nodes and confirmed by current node, by sender.
+	PeerBlames map[core.RecordRef][]*packets.NodeViolationBlame
 }
 
 type SecondPhaseState struct {
@@ -49,11 +54,24 @@ type SecondPhaseState struct {
 	NodeListCount uint16
 	NodeListHash  []byte
 
+	// DBitSet is a bitset for phase 3 with violations of both phase 1 and phase 2.
 	DBitSet packets.BitSet
 }
 
 type ThirdPhasePulseState struct {
 }
 
+// ConsensusResult is an outcome of consensus for a pulse.
+type ConsensusResult struct {
+	PulseNumber core.PulseNumber
+	// Blames holds violations detected during consensus by blamed node. Blamed node is excluded from active list only
+	// if majority of nodes marks it as Fraud or TimedOut in phase 3.
+	Blames map[core.RecordRef]*packets.NodeViolationBlame
+	// PeerBlames holds confirmed blames of previous pulse received from other nodes, by sender.
+	PeerBlames map[core.RecordRef][]*packets.NodeViolationBlame
+	// Err is set if consensus failed.
+	Err error
+}
+
 type ThirdPhaseReferendumState struct {
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/network"
 	"github.com/pkg/errors"
 )
@@ -35,21 +36,22 @@ func NewThirdPhase() ThirdPhase {
 }
 
 type thirdPhase struct {
-	Cryptography core.CryptographyService `inject:""`
-	Communicator Communicator             `inject:""`
-	NodeKeeper   network.NodeKeeper       `inject:""`
+	Cryptography core.CryptographyService        `inject:""`
+	Scheme       core.PlatformCryptographyScheme `inject:""`
+	Communicator Communicator                    `inject:""`
+	NodeKeeper   network.NodeKeeper              `inject:""`
 
 	newActiveNodeList []core.Node
-	// TODO: insert it from somewhere
-	mapper packets.BitSetMapper
 }
 
 func (tp *thirdPhase) Execute(ctx context.Context, state *SecondPhaseState) error {
-	var gSign [packets.SignatureLength]byte
-	copy(gSign[:], state.GlobuleProof.Signature.Bytes()[:packets.SignatureLength])
+	gSign, err := packets.NewSignatureField(state.GlobuleProof.Signature.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "[ Execute ] failed to set a globule proof sign")
+	}
 	packet := packets.NewPhase3Packet(gSign, state.DBitSet)
 
-	err := tp.signPhase3Packet(&packet)
+	err = tp.signPhase3Packet(&packet)
 
 	if err != nil {
 		return errors.Wrap(err, "[ Execute ] failed to sign a phase 3 packet")
@@ -61,6 +63,14 @@ func (tp *thirdPhase) Execute(ctx context.Context, state *SecondPhaseState) erro
 		return errors.Wrap(err, "[ Execute ] failed to get answers on phase 3")
 	}
 
+	if !consensusReached(len(answers), len(nodes)) {
+		return errors.New("[ Execute ] Consensus not reached on phase 3")
+	}
+
+	// Node is excluded only if majority of phase 3 answers marks it as Fraud or TimedOut.
+	origin := tp.NodeKeeper.GetOrigin().ID()
+	deviantVotes := make(map[core.RecordRef]int)
+	originVotes := make(map[core.RecordRef]struct{})
 	for ref, packet := range answers {
 		signed, err := tp.isSignPhase3PacketRight(packet, ref)
 		if err != nil {
@@ -68,31 +78,52 @@ func (tp *thirdPhase) Execute(ctx context.Context, state *SecondPhaseState) erro
 		} else if !signed {
 			return errors.New("recv not signed packet")
 		}
-		cells, err := packet.GetBitset().GetCells(tp.mapper)
+		cells, err := packet.GetBitset().GetCells(state.UnsyncList)
 		if err != nil {
 			return errors.Wrap(err, "[ Execute ] failed to get a cells")
 		}
 		for _, cell := range cells {
-			if cell.State == packets.Legit {
-				node, err := getNode(cell.NodeID, nodes)
-				if err != nil {
-					return errors.Wrap(err, "[ Execute ] failed to find a node on phase 3")
+			if cell.State == packets.Fraud || cell.State == packets.TimedOut {
+				deviantVotes[cell.NodeID]++
+				if ref == origin {
+					originVotes[cell.NodeID] = struct{}{}
 				}
-				tp.newActiveNodeList = append(tp.newActiveNodeList, node)
 			}
 		}
 	}
+	// Node that didn't answer on phase 3 in time is TimedOut for this node, unless it is already voted against.
+	for _, node := range nodes {
+		if _, ok := answers[node.ID()]; ok {
+			continue
+		}
+		if _, ok := originVotes[node.ID()]; !ok {
+			deviantVotes[node.ID()]++
+		}
+	}
 
-	return nil
-}
+	excludedSet := make(map[core.RecordRef]struct{})
+	for ref, votes := range deviantVotes {
+		if consensusReached(votes, len(answers)) {
+			excludedSet[ref] = struct{}{}
+		}
+	}
 
-func getNode(ref core.RecordRef, nodes []core.Node) (core.Node, error) {
+	tp.newActiveNodeList = make([]core.Node, 0, len(nodes))
+	excluded := make([]core.RecordRef, 0, len(excludedSet))
 	for _, node := range nodes {
-		if ref == node.ID() {
-			return node, nil
+		if _, ok := excludedSet[node.ID()]; ok {
+			excluded = append(excluded, node.ID())
+			continue
 		}
+		tp.newActiveNodeList = append(tp.newActiveNodeList, node)
+	}
+	if len(excluded) > 0 {
+		inslogger.FromContext(ctx).Warnf("[ Execute ] nodes %v are excluded from active list by consensus", excluded)
 	}
-	return nil, errors.New("[ getNode] failed to find a node on phase 3")
+
+	state.UnsyncList.ExcludeNodes(excluded)
+	tp.NodeKeeper.Sync(state.UnsyncList)
+	return nil
 }
 
 func (tp *thirdPhase) signPhase3Packet(p *packets.Phase3Packet) error {
@@ -105,7 +136,10 @@ func (tp *thirdPhase) signPhase3Packet(p *packets.Phase3Packet) error {
 		return errors.Wrap(err, "failed to sign a phase 2 packet")
 	}
 
-	copy(p.SignatureHeaderSection1[:], sign.Bytes())
+	p.SignatureHeaderSection1, err = packets.NewSignatureField(sign.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "failed to set a phase 3 packet sign")
+	}
 	// TODO: sign a second part after claim addition
 	return nil
 }
@@ -118,5 +152,9 @@ func (tp *thirdPhase) isSignPhase3PacketRight(packet *packets.Phase3Packet, reco
 		return false, errors.Wrap(err, "failed to serialize")
 	}
 
-	return tp.Cryptography.Verify(key, core.SignatureFromBytes(raw), raw), nil
+	signature, err := packets.SignatureFromField(packet.SignatureHeaderSection1[:], tp.Scheme.SignatureSIze())
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get a sign")
+	}
+	return tp.Cryptography.Verify(key, signature, raw), nil
 }
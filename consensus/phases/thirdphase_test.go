@@ -0,0 +1,218 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package phases
+
+import (
+	"context"
+	"crypto"
+	"testing"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/merkle"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThirdPhase_Execute_ExcludesByMajority(t *testing.T) {
+	nodes := make([]core.Node, 5)
+	for i := range nodes {
+		nodes[i] = makeSignedNode(t)
+	}
+	fraud, timedOut := nodes[3], nodes[4]
+
+	nodeKeeper := nodenetwork.NewNodeKeeper(nodes[0])
+	nodeKeeper.AddActiveNodes(nodes)
+	list := nodeKeeper.GetUnsyncList()
+
+	makeBitset := func(deviants map[core.Node]packets.TriState) packets.BitSet {
+		bitset, err := packets.NewBitSet(list.Length())
+		require.NoError(t, err)
+		cells := make([]packets.BitSetCell, 0, len(nodes))
+		for _, node := range nodes {
+			state, ok := deviants[node]
+			if !ok {
+				state = packets.Legit
+			}
+			cells = append(cells, packets.BitSetCell{NodeID: node.ID(), State: state})
+		}
+		require.NoError(t, bitset.ApplyChanges(cells, list))
+		return bitset
+	}
+
+	communicator := NewCommunicatorMock(t)
+	communicator.ExchangePhase3Func = func(
+		ctx context.Context, participants []core.Node, packet *packets.Phase3Packet,
+	) (map[core.RecordRef]*packets.Phase3Packet, error) {
+		result := make(map[core.RecordRef]*packets.Phase3Packet)
+		for i, node := range participants {
+			deviants := map[core.Node]packets.TriState{}
+			// Every node except the fraud one sees it, only one node sees timeout.
+			if node != fraud {
+				deviants[fraud] = packets.Fraud
+			}
+			if i == 0 {
+				deviants[timedOut] = packets.TimedOut
+			}
+			answer := packets.NewPhase3Packet([packets.SignatureLength]byte{}, makeBitset(deviants))
+			result[node.ID()] = &answer
+		}
+		return result, nil
+	}
+
+	cryptography := testutils.NewCryptographyServiceMock(t)
+	cryptography.SignFunc = func([]byte) (*core.Signature, error) {
+		signature := core.SignatureFromBytes(nil)
+		return &signature, nil
+	}
+	cryptography.VerifyFunc = func(crypto.PublicKey, core.Signature, []byte) bool {
+		return true
+	}
+
+	tp := &thirdPhase{
+		Cryptography: cryptography,
+		Scheme:       platformpolicy.NewPlatformCryptographyScheme(),
+		Communicator: communicator,
+		NodeKeeper:   nodeKeeper,
+	}
+	err := tp.Execute(context.Background(), &SecondPhaseState{
+		FirstPhaseState: &FirstPhaseState{UnsyncList: list},
+		GlobuleProof:    &merkle.GlobuleProof{},
+		DBitSet:         makeBitset(nil),
+	})
+	require.NoError(t, err)
+	require.Len(t, tp.newActiveNodeList, 4)
+	require.NotContains(t, tp.newActiveNodeList, fraud)
+	require.Contains(t, tp.newActiveNodeList, timedOut)
+
+	nodeKeeper.MoveSyncToActive()
+	require.Nil(t, nodeKeeper.GetActiveNode(fraud.ID()))
+	require.NotNil(t, nodeKeeper.GetActiveNode(timedOut.ID()))
+	require.Len(t, nodeKeeper.GetActiveNodes(), 4)
+}
+
+func TestThirdPhase_Execute_MissingAnswerIsTimedOut(t *testing.T) {
+	nodes := make([]core.Node, 5)
+	for i := range nodes {
+		nodes[i] = makeSignedNode(t)
+	}
+	silent := nodes[4]
+
+	nodeKeeper := nodenetwork.NewNodeKeeper(nodes[0])
+	nodeKeeper.AddActiveNodes(nodes)
+	list := nodeKeeper.GetUnsyncList()
+
+	makeBitset := func(deviant core.Node) packets.BitSet {
+		bitset, err := packets.NewBitSet(list.Length())
+		require.NoError(t, err)
+		cells := make([]packets.BitSetCell, 0, len(nodes))
+		for _, node := range nodes {
+			state := packets.Legit
+			if node == deviant {
+				state = packets.TimedOut
+			}
+			cells = append(cells, packets.BitSetCell{NodeID: node.ID(), State: state})
+		}
+		require.NoError(t, bitset.ApplyChanges(cells, list))
+		return bitset
+	}
+
+	communicator := NewCommunicatorMock(t)
+	communicator.ExchangePhase3Func = func(
+		ctx context.Context, participants []core.Node, packet *packets.Phase3Packet,
+	) (map[core.RecordRef]*packets.Phase3Packet, error) {
+		result := make(map[core.RecordRef]*packets.Phase3Packet)
+		for _, node := range participants {
+			if node == silent {
+				continue
+			}
+			// Only two nodes except origin have seen the silent node timed out on previous phases.
+			var deviant core.Node
+			if node == nodes[1] || node == nodes[2] {
+				deviant = silent
+			}
+			answer := packets.NewPhase3Packet([packets.SignatureLength]byte{}, makeBitset(deviant))
+			result[node.ID()] = &answer
+		}
+		return result, nil
+	}
+
+	cryptography := testutils.NewCryptographyServiceMock(t)
+	cryptography.SignFunc = func([]byte) (*core.Signature, error) {
+		signature := core.SignatureFromBytes(nil)
+		return &signature, nil
+	}
+	cryptography.VerifyFunc = func(crypto.PublicKey, core.Signature, []byte) bool {
+		return true
+	}
+
+	tp := &thirdPhase{
+		Cryptography: cryptography,
+		Scheme:       platformpolicy.NewPlatformCryptographyScheme(),
+		Communicator: communicator,
+		NodeKeeper:   nodeKeeper,
+	}
+	err := tp.Execute(context.Background(), &SecondPhaseState{
+		FirstPhaseState: &FirstPhaseState{UnsyncList: list},
+		GlobuleProof:    &merkle.GlobuleProof{},
+		DBitSet:         makeBitset(nil),
+	})
+	require.NoError(t, err)
+	require.Len(t, tp.newActiveNodeList, 4)
+	require.NotContains(t, tp.newActiveNodeList, silent)
+}
+
+func TestThirdPhase_Execute_ConsensusNotReached(t *testing.T) {
+	nodes := make([]core.Node, 3)
+	for i := range nodes {
+		nodes[i] = makeSignedNode(t)
+	}
+	nodeKeeper := nodenetwork.NewNodeKeeper(nodes[0])
+	nodeKeeper.AddActiveNodes(nodes)
+	list := nodeKeeper.GetUnsyncList()
+	bitset, err := packets.NewBitSet(list.Length())
+	require.NoError(t, err)
+
+	communicator := NewCommunicatorMock(t)
+	communicator.ExchangePhase3Func = func(
+		ctx context.Context, participants []core.Node, packet *packets.Phase3Packet,
+	) (map[core.RecordRef]*packets.Phase3Packet, error) {
+		return map[core.RecordRef]*packets.Phase3Packet{nodes[0].ID(): packet}, nil
+	}
+
+	cryptography := testutils.NewCryptographyServiceMock(t)
+	cryptography.SignFunc = func([]byte) (*core.Signature, error) {
+		signature := core.SignatureFromBytes(nil)
+		return &signature, nil
+	}
+
+	tp := &thirdPhase{
+		Cryptography: cryptography,
+		Scheme:       platformpolicy.NewPlatformCryptographyScheme(),
+		Communicator: communicator,
+		NodeKeeper:   nodeKeeper,
+	}
+	err = tp.Execute(context.Background(), &SecondPhaseState{
+		FirstPhaseState: &FirstPhaseState{UnsyncList: list},
+		GlobuleProof:    &merkle.GlobuleProof{},
+		DBitSet:         bitset,
+	})
+	require.Error(t, err)
+}
@@ -93,6 +93,19 @@ func (f *delegationTokenFactory) IssueGetCodeRedirect(
 	return &GetCodeRedirectToken{Signature: sign.Bytes()}, nil
 }
 
+// IssueGetObjectHistoryRedirect creates new token for provided message.
+func (f *delegationTokenFactory) IssueGetObjectHistoryRedirect(
+	sender *core.RecordRef, redirectedMessage core.Message,
+) (core.DelegationToken, error) {
+	parsedMessage := redirectedMessage.(*message.GetObjectHistory)
+	dataForSign := append(sender.Bytes(), message.ToBytes(parsedMessage)...)
+	sign, err := f.Cryptography.Sign(dataForSign)
+	if err != nil {
+		return nil, err
+	}
+	return &GetObjectHistoryRedirectToken{Signature: sign.Bytes()}, nil
+}
+
 // Verify performs token validation.
 func (f *delegationTokenFactory) Verify(parcel core.Parcel) (bool, error) {
 	if parcel.DelegationToken() == nil {
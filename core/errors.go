@@ -29,4 +29,6 @@ var (
 	ErrHotDataTimeout = errors.New("requests were abandoned due to hot-data timeout")
 	// ErrNoPendingRequest is returned when there are no pending requests on current LME
 	ErrNoPendingRequest = errors.New("no pending requests are available")
+	// ErrRequestNotFound is returned when ledger has neither request nor its result.
+	ErrRequestNotFound = errors.New("request is not found")
 )
@@ -0,0 +1,64 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package core
+
+import (
+	"context"
+)
+
+// EventType is a type of node event.
+type EventType uint8
+
+const (
+	// EventPulse is published when node switches to new pulse.
+	EventPulse EventType = iota + 1
+	// EventObjectState is published when new object state record is saved.
+	EventObjectState
+	// EventRequestResult is published when request result record is saved.
+	EventRequestResult
+)
+
+// Event is a node event delivered to EventBus subscribers. Set of filled fields depends on event type.
+type Event struct {
+	Type EventType
+
+	// Pulse is a new pulse (EventPulse).
+	Pulse *Pulse
+
+	// Object is a record ID of changed object (EventObjectState) or object that executed request (EventRequestResult).
+	Object *RecordID
+	// State is an ID of new object state record (EventObjectState).
+	State *RecordID
+	// Deactivated is true if object was deactivated (EventObjectState).
+	Deactivated bool
+
+	// Request is a reference of executed request (EventRequestResult).
+	Request *RecordRef
+	// Result is an ID of request result record (EventRequestResult).
+	Result *RecordID
+	// Payload is a result payload (EventRequestResult).
+	Payload []byte
+}
+
+// EventBus delivers node events to local subscribers.
+type EventBus interface {
+	// Publish sends event to all subscribers. It never blocks.
+	Publish(ctx context.Context, event Event)
+	// Subscribe returns channel of events and function that cancels subscription. Channel is closed when subscription
+	// is canceled or when subscriber does not read events and its buffer overflows.
+	Subscribe(ctx context.Context, buffer int) (<-chan Event, func())
+}
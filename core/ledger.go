@@ -18,6 +18,7 @@ package core
 
 import (
 	"context"
+	"time"
 )
 
 const (
@@ -148,6 +149,11 @@ type ArtifactManager interface {
 	// HasPendingRequests returns true if object has unclosed requests.
 	HasPendingRequests(ctx context.Context, object RecordRef) (bool, error)
 
+	// GetRequestResult returns execution state of request to provided object.
+	//
+	// ErrRequestNotFound is returned if ledger has neither request nor its result.
+	GetRequestResult(ctx context.Context, object, request RecordRef) (*RequestResult, error)
+
 	// GetDelegate returns provided object's delegate reference for provided type.
 	//
 	// Object delegate should be previously created for this object. If object delegate does not exist, an error will
@@ -159,6 +165,12 @@ type ArtifactManager interface {
 	// During iteration children refs will be fetched from remote source (parent object).
 	GetChildren(ctx context.Context, parent RecordRef, pulse *PulseNumber) (RefIterator, error)
 
+	// GetObjectHistory returns a chunk of object states from the newest to the oldest.
+	//
+	// If provided state is nil, the walk starts from the latest state. Returned history contains a state to continue
+	// from, it is nil when the activation record is reached.
+	GetObjectHistory(ctx context.Context, head RecordRef, from *RecordID, amount int) (*ObjectHistory, error)
+
 	// DeclareType creates new type record in storage.
 	//
 	// Type is a contract interface. It contains one method signature.
@@ -272,6 +284,42 @@ type RefIterator interface {
 	HasNext() bool
 }
 
+// ObjectStateInfo describes one record (activation, amend or deactivation) of object lifeline.
+type ObjectStateInfo struct {
+	State      RecordID
+	Pulse      PulseNumber
+	Request    RecordRef
+	Kind       ObjectStateKind
+	MemoryHash []byte
+}
+
+// ObjectStateKind is a kind of object state record.
+type ObjectStateKind string
+
+// Object state kinds.
+const (
+	ObjectStateActivation   = ObjectStateKind("activation")
+	ObjectStateAmend        = ObjectStateKind("amend")
+	ObjectStateDeactivation = ObjectStateKind("deactivation")
+)
+
+// ObjectHistory is a chunk of object states ordered from the newest to the oldest.
+type ObjectHistory struct {
+	States []ObjectStateInfo
+	// NextFrom is a state to continue from. It is nil when the activation record is reached.
+	NextFrom *RecordID
+}
+
+// RequestResult is an execution state of request.
+type RequestResult struct {
+	// Result is an ID of result record. It is nil while request is not executed.
+	Result *RecordID
+	// Payload is a method result saved with RegisterResult.
+	Payload []byte
+	// Pending is true if request is not executed since previous pulses.
+	Pending bool
+}
+
 // LocalStorage allows a node to save local data.
 //go:generate minimock -i github.com/insolar/insolar/core.LocalStorage -o ../testutils -s _mock.go
 type LocalStorage interface {
@@ -306,10 +354,51 @@ type StorageExportResult struct {
 	Size     int
 }
 
+// StorageExportChunk represents storage data view of one jet on one pulse.
+type StorageExportChunk struct {
+	Data interface{}
+	// Cursor is an opaque position right after this chunk. Pass it to ExportStream to resume export.
+	Cursor string
+}
+
 // StorageExporter provides methods for fetching data view from storage.
 type StorageExporter interface {
 	// Export returns data view from storage.
 	Export(ctx context.Context, fromPulse PulseNumber, size int) (*StorageExportResult, error)
+
+	// ExportStream calls handler for every jet of at most size pulses, starting right after cursor.
+	// Empty cursor means export from the beginning. Returns cursor to continue from.
+	ExportStream(ctx context.Context, cursor string, size int, handler func(*StorageExportChunk) error) (string, error)
+}
+
+// StorageBackuper makes snapshots of storage.
+type StorageBackuper interface {
+	// Backup writes consistent snapshot of storage and returns snapshot location and the pulse it is consistent as of.
+	Backup(ctx context.Context) (string, PulseNumber, error)
+}
+
+// JetLoad holds load metrics of a jet collected by light material node during a pulse.
+type JetLoad struct {
+	JetID RecordID
+	Pulse PulseNumber
+	// Requests is a number of requests handled for the jet.
+	Requests int
+	// Latency is an average time of request handling.
+	Latency time.Duration
+	// PendingRequests is a number of not finished requests registered in the jet.
+	PendingRequests int
+	// DropSize is a size of the last drop of the jet.
+	DropSize uint64
+	// ForceSplit is set when split was requested manually.
+	ForceSplit bool
+}
+
+// JetLoadMonitor provides load of jets executed by current node and allows to split them manually.
+type JetLoadMonitor interface {
+	// JetLoads returns load of jets executed by current node in current pulse.
+	JetLoads(ctx context.Context) ([]JetLoad, error)
+	// ForceSplit marks jet to be split on the next pulse.
+	ForceSplit(ctx context.Context, jetID RecordID) error
 }
 
 var (
@@ -226,6 +226,61 @@ func (*GetChildren) Type() core.MessageType {
 	return core.TypeGetChildren
 }
 
+// GetObjectHistory retrieves a chunk of object states.
+type GetObjectHistory struct {
+	ledgerMessage
+	Head      core.RecordRef
+	FromState *core.RecordID
+	Amount    int
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (m *GetObjectHistory) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*GetObjectHistory) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleLightExecutor
+}
+
+// DefaultTarget returns of target of this event.
+func (m *GetObjectHistory) DefaultTarget() *core.RecordRef {
+	return &m.Head
+}
+
+// Type implementation of Message interface.
+func (*GetObjectHistory) Type() core.MessageType {
+	return core.TypeGetObjectHistory
+}
+
+// GetRequestResult fetches execution state of request.
+type GetRequestResult struct {
+	ledgerMessage
+	Object  core.RecordRef
+	Request core.RecordRef
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (m *GetRequestResult) AllowedSenderObjectAndRole() (*core.RecordRef, core.DynamicRole) {
+	return nil, core.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*GetRequestResult) DefaultRole() core.DynamicRole {
+	return core.DynamicRoleLightExecutor
+}
+
+// DefaultTarget returns of target of this event.
+func (m *GetRequestResult) DefaultTarget() *core.RecordRef {
+	return &m.Object
+}
+
+// Type implementation of Message interface.
+func (*GetRequestResult) Type() core.MessageType {
+	return core.TypeGetRequestResult
+}
+
 // JetDrop spreads jet drop
 type JetDrop struct {
 	ledgerMessage
@@ -381,6 +436,7 @@ type HotData struct {
 	Drop               jet.JetDrop
 	RecentObjects      map[core.RecordID]*HotIndex
 	PendingRequests    map[core.RecordID]map[core.RecordID]struct{}
+	RequestResults     map[core.RecordID]map[core.RecordID][]byte // Encoded result indexes by object and request.
 	PulseNumber        core.PulseNumber
 	JetDropSizeHistory jet.DropSizeHistory
 }
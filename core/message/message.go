@@ -83,6 +83,10 @@ func getEmptyMessage(mt core.MessageType) (core.Message, error) {
 		return &GetPendingRequestID{}, nil
 	case core.TypeGetRequest:
 		return &GetRequest{}, nil
+	case core.TypeGetObjectHistory:
+		return &GetObjectHistory{}, nil
+	case core.TypeGetRequestResult:
+		return &GetRequestResult{}, nil
 
 	// heavy sync
 	case core.TypeHeavyStartStop:
@@ -233,6 +237,8 @@ func init() {
 	gob.Register(&Parcel{})
 	gob.Register(core.RecordRef{})
 	gob.Register(&GetChildren{})
+	gob.Register(&GetObjectHistory{})
+	gob.Register(&GetRequestResult{})
 
 	// NodeCert
 	gob.Register(&NodeSignPayload{})
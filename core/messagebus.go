@@ -170,6 +170,12 @@ type MessageBus interface {
 
 	// Called each new pulse, cleans next pulse messages buffer
 	OnPulse(context.Context, Pulse) error
+
+	// UseSendMiddleware appends middleware to the send path. Middlewares are called in order they were added.
+	UseSendMiddleware(m SendMiddleware)
+	// UseDeliverMiddleware appends middleware to the deliver path. Middlewares are called in order they were added,
+	// the last one calls registered message handler.
+	UseDeliverMiddleware(m DeliverMiddleware)
 }
 
 type TapeWriter interface {
@@ -201,6 +207,17 @@ func ContextWithMessageBus(ctx context.Context, bus MessageBus) context.Context
 // MessageHandler is a function for message handling. It should be registered via Register method.
 type MessageHandler func(context.Context, Parcel) (Reply, error)
 
+// ParcelSender is a function that sends parcel to the network and returns reply.
+type ParcelSender func(ctx context.Context, parcel Parcel, currentPulse Pulse, options *MessageSendOptions) (Reply, error)
+
+// SendMiddleware wraps sending of every parcel by MessageBus. It may modify parcel or options, return reply without
+// sending or fail the call.
+type SendMiddleware func(next ParcelSender) ParcelSender
+
+// DeliverMiddleware wraps handling of every parcel delivered by MessageBus, both received from the network and sent to
+// the node itself. It may return reply without calling message handler or fail the call.
+type DeliverMiddleware func(next MessageHandler) MessageHandler
+
 //go:generate stringer -type=MessageType
 const (
 	// Logicrunner
@@ -259,6 +276,10 @@ const (
 	TypeGetRequest
 	// TypeGetPendingRequestID fetches a pending request id from ledger
 	TypeGetPendingRequestID
+	// TypeGetObjectHistory fetches a chunk of object states.
+	TypeGetObjectHistory
+	// TypeGetRequestResult fetches execution state of request.
+	TypeGetRequestResult
 
 	// TypeValidationCheck checks if validation of a particular record can be performed.
 	TypeValidationCheck
@@ -293,4 +314,5 @@ const (
 	DTTypeGetObjectRedirect
 	DTTypeGetChildrenRedirect
 	DTTypeGetCodeRedirect
+	DTTypeGetObjectHistoryRedirect
 )
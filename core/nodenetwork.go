@@ -17,6 +17,7 @@
 package core
 
 import (
+	"context"
 	"crypto"
 )
 
@@ -67,3 +68,13 @@ type SwitcherWorkAround interface {
 	// SetIsBootstrapped method set is bootstrap completed
 	SetIsBootstrapped(isBootstrap bool)
 }
+
+// NodeLeaver performs planned leave of the current node from the network.
+type NodeLeaver interface {
+	// Leave announces leave of the node, hands off node's work and blocks until the node can be safely stopped.
+	Leave(ctx context.Context) error
+	// Left returns channel that is closed when leave is finished.
+	Left() <-chan struct{}
+	// WaitsConfirmation returns true if Leave waits for the network to confirm node leave.
+	WaitsConfirmation() bool
+}
@@ -74,6 +74,9 @@ type Pulse struct {
 
 	Entropy Entropy
 	Signs   map[string]PulseSenderConfirmation
+
+	// EntropyProof contains entropies of pulsars combined into Entropy. It is empty for pulses without proof.
+	EntropyProof []EntropyReveal
 }
 
 // PulseSenderConfirmation contains confirmations of the pulse from other pulsars
@@ -87,6 +90,30 @@ type PulseSenderConfirmation struct {
 	Signature       []byte
 }
 
+// EntropyReveal is an entropy contributed to a pulse by a pulsar. Signature is a commitment to the entropy that the
+// pulsar published before entropies were revealed, so no pulsar could choose its entropy after seeing the others.
+type EntropyReveal struct {
+	PublicKey string
+	Entropy   Entropy
+	Signature []byte
+}
+
+// EntropyCommitment returns data that pulsar signs to commit to its entropy for pulse.
+func EntropyCommitment(pulseNumber PulseNumber, entropy Entropy) []byte {
+	return append(pulseNumber.Bytes(), entropy[:]...)
+}
+
+// CombineEntropy combines revealed entropies into pulse entropy.
+func CombineEntropy(reveals []EntropyReveal) Entropy {
+	var result Entropy
+	for _, reveal := range reveals {
+		for i := range result {
+			result[i] ^= reveal.Entropy[i]
+		}
+	}
+	return result
+}
+
 // FirstPulseDate is the hardcoded date of the first pulse
 const firstPulseDate = 1535760000 //09/01/2018 @ 12:00am (UTC)
 
@@ -42,6 +42,8 @@ const (
 	TypeGetObjectRedirect
 	// TypeGetChildrenRedirect is a redirect reply for children-call
 	TypeGetChildrenRedirect
+	// TypeGetObjectHistoryRedirect is a redirect reply for object history call
+	TypeGetObjectHistoryRedirect
 
 	// Logicrunner
 
@@ -74,6 +76,10 @@ const (
 	TypeJet
 	// TypeRequest contains request.
 	TypeRequest
+	// TypeObjectHistory is a reply for fetching object states in chunks.
+	TypeObjectHistory
+	// TypeRequestResult is a reply for fetching execution state of request.
+	TypeRequestResult
 	// TypeHeavyError carries heavy record sync
 	TypeHeavyError
 
@@ -92,6 +98,8 @@ const (
 	ErrHotDataTimeout
 	// ErrNoPendingRequests is returned when there are no pending requests on current LME
 	ErrNoPendingRequests
+	// ErrRequestNotFound is returned when ledger has neither request nor its result.
+	ErrRequestNotFound
 )
 
 func getEmptyReply(t core.ReplyType) (core.Reply, error) {
@@ -126,6 +134,8 @@ func getEmptyReply(t core.ReplyType) (core.Reply, error) {
 		return &GetObjectRedirectReply{}, nil
 	case TypeGetChildrenRedirect:
 		return &GetChildrenRedirectReply{}, nil
+	case TypeGetObjectHistoryRedirect:
+		return &GetObjectHistoryRedirectReply{}, nil
 	case TypeJetMiss:
 		return &JetMiss{}, nil
 	case TypePendingRequests:
@@ -134,6 +144,10 @@ func getEmptyReply(t core.ReplyType) (core.Reply, error) {
 		return &Jet{}, nil
 	case TypeRequest:
 		return &Request{}, nil
+	case TypeObjectHistory:
+		return &ObjectHistory{}, nil
+	case TypeRequestResult:
+		return &RequestResult{}, nil
 
 	case TypeNodeSign:
 		return &NodeSign{}, nil
@@ -197,9 +211,12 @@ func init() {
 	gob.Register(&GetCodeRedirectReply{})
 	gob.Register(&GetObjectRedirectReply{})
 	gob.Register(&GetChildrenRedirectReply{})
+	gob.Register(&GetObjectHistoryRedirectReply{})
 	gob.Register(&HeavyError{})
 	gob.Register(&JetMiss{})
 	gob.Register(&NodeSign{})
 	gob.Register(&HasPendingRequests{})
 	gob.Register(&Request{})
+	gob.Register(&ObjectHistory{})
+	gob.Register(&RequestResult{})
 }
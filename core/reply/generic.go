@@ -57,6 +57,8 @@ func (e *Error) Error() error {
 		return core.ErrHotDataTimeout
 	case ErrNoPendingRequests:
 		return core.ErrNoPendingRequest
+	case ErrRequestNotFound:
+		return core.ErrRequestNotFound
 	}
 
 	return core.ErrUnknown
@@ -78,6 +78,30 @@ func (e *Children) Type() core.ReplyType {
 	return TypeChildren
 }
 
+// ObjectHistory is a chunk of object states.
+type ObjectHistory struct {
+	States   []core.ObjectStateInfo
+	NextFrom *core.RecordID
+}
+
+// Type implementation of Reply interface.
+func (e *ObjectHistory) Type() core.ReplyType {
+	return TypeObjectHistory
+}
+
+// RequestResult is an execution state of request.
+type RequestResult struct {
+	Result  *core.RecordID
+	Payload []byte
+	// Pending is true if request is not executed since previous pulses.
+	Pending bool
+}
+
+// Type implementation of Reply interface.
+func (e *RequestResult) Type() core.ReplyType {
+	return TypeRequestResult
+}
+
 // ObjectIndex contains serialized object index. It can be stored in DB without processing.
 type ObjectIndex struct {
 	Index []byte
@@ -60,6 +60,8 @@ type LogicRunner interface {
 	HandleValidationResultsMessage(context.Context, Parcel) (res Reply, err error)
 	HandleExecutorResultsMessage(context.Context, Parcel) (res Reply, err error)
 	OnPulse(context.Context, Pulse) error
+	// Drain waits until executions started by the node are finished and their results are handed off.
+	Drain(context.Context) error
 }
 
 // LogicCallContext is a context of contract execution
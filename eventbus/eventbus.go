@@ -0,0 +1,87 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package eventbus delivers node events (new pulses, saved object states and request results) to local subscribers.
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+type subscriber struct {
+	events chan core.Event
+}
+
+// EventBus is a component that implements core.EventBus.
+type EventBus struct {
+	lock        sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+// NewEventBus creates new EventBus instance.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+// Publish sends event to all subscribers. Subscribers that don't read events fast enough are unsubscribed.
+func (b *EventBus) Publish(ctx context.Context, event core.Event) {
+	var slow []*subscriber
+
+	b.lock.RLock()
+	for s := range b.subscribers {
+		select {
+		case s.events <- event:
+		default:
+			slow = append(slow, s)
+		}
+	}
+	b.lock.RUnlock()
+
+	for _, s := range slow {
+		inslogger.FromContext(ctx).Warn("[ EventBus.Publish ] subscriber buffer overflow, unsubscribing")
+		b.unsubscribe(s)
+	}
+}
+
+// Subscribe returns channel of events and function that cancels subscription.
+func (b *EventBus) Subscribe(ctx context.Context, buffer int) (<-chan core.Event, func()) {
+	s := &subscriber{events: make(chan core.Event, buffer)}
+
+	b.lock.Lock()
+	b.subscribers[s] = struct{}{}
+	b.lock.Unlock()
+
+	return s.events, func() {
+		b.unsubscribe(s)
+	}
+}
+
+func (b *EventBus) unsubscribe(s *subscriber) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.subscribers[s]; !ok {
+		return
+	}
+	delete(b.subscribers, s)
+	close(s.events)
+}
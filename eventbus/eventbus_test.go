@@ -0,0 +1,69 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBus_Publish(t *testing.T) {
+	ctx := context.Background()
+	bus := NewEventBus()
+
+	first, cancelFirst := bus.Subscribe(ctx, 1)
+	second, cancelSecond := bus.Subscribe(ctx, 1)
+	defer cancelSecond()
+
+	bus.Publish(ctx, core.Event{Type: core.EventPulse, Pulse: &core.Pulse{PulseNumber: 42}})
+
+	for _, events := range []<-chan core.Event{first, second} {
+		event := <-events
+		assert.Equal(t, core.EventPulse, event.Type)
+		assert.Equal(t, core.PulseNumber(42), event.Pulse.PulseNumber)
+	}
+
+	cancelFirst()
+	_, ok := <-first
+	assert.False(t, ok)
+	// Second cancel is noop.
+	cancelFirst()
+
+	bus.Publish(ctx, core.Event{Type: core.EventObjectState})
+	event := <-second
+	assert.Equal(t, core.EventObjectState, event.Type)
+}
+
+func TestEventBus_SlowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	bus := NewEventBus()
+
+	events, cancel := bus.Subscribe(ctx, 1)
+	defer cancel()
+
+	bus.Publish(ctx, core.Event{Type: core.EventPulse})
+	bus.Publish(ctx, core.Event{Type: core.EventPulse})
+
+	_, ok := <-events
+	require.True(t, ok)
+	_, ok = <-events
+	assert.False(t, ok, "slow subscriber must be unsubscribed")
+}
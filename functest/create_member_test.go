@@ -34,23 +34,22 @@ func TestCreateMember(t *testing.T) {
 
 func TestCreateMemberWrongNameType(t *testing.T) {
 	_, err := signedRequest(&root, "CreateMember", 111, "000")
-	require.EqualError(t, err, "[ makeCall ] Error in called method: [ createMemberCall ]: [ Deserialize ]: EOF")
+	require.EqualError(t, err, "[ makeCall ] Invalid params: CreateMember: invalid param 0 (name): expected string, got integer")
 }
 
 func TestCreateMemberWrongKeyType(t *testing.T) {
 	_, err := signedRequest(&root, "CreateMember", "Member", 111)
-	require.EqualError(t, err, "[ makeCall ] Error in called method: [ createMemberCall ]: [ Deserialize ]: EOF")
+	require.EqualError(t, err, "[ makeCall ] Invalid params: CreateMember: invalid param 1 (publicKey): expected string, got integer")
 }
 
-// no error
-func _TestCreateMemberOneParameter(t *testing.T) {
+func TestCreateMemberOneParameter(t *testing.T) {
 	_, err := signedRequest(&root, "CreateMember", "text")
-	require.Error(t, err)
+	require.EqualError(t, err, "[ makeCall ] Invalid params: CreateMember: missing param 1 (publicKey)")
 }
 
 func TestCreateMemberOneParameterOtherType(t *testing.T) {
 	_, err := signedRequest(&root, "CreateMember", 111)
-	require.EqualError(t, err, "[ makeCall ] Error in called method: [ createMemberCall ]: [ Deserialize ]: EOF")
+	require.EqualError(t, err, "[ makeCall ] Invalid params: CreateMember: invalid param 0 (name): expected string, got integer")
 }
 
 func TestCreateMembersWithSameName(t *testing.T) {
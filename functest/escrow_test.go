@@ -0,0 +1,104 @@
+// +build functest
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package functest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func escrowTransfer(t *testing.T, from *user, amount int, to *user, release time.Time, expire time.Time, required int, approvers ...*user) string {
+	approverRefs := make([]string, 0, len(approvers))
+	for _, approver := range approvers {
+		approverRefs = append(approverRefs, approver.ref)
+	}
+	result, err := signedRequest(from, "EscrowTransfer", amount, to.ref, release.Unix(), expire.Unix(), approverRefs, required)
+	require.NoError(t, err)
+	ref, ok := result.(string)
+	require.True(t, ok)
+	return ref
+}
+
+func TestEscrowTransferMultiSignature(t *testing.T) {
+	sender := createMember(t, "Sender")
+	recipient := createMember(t, "Recipient")
+	firstApprover := createMember(t, "FirstApprover")
+	secondApprover := createMember(t, "SecondApprover")
+	senderBalance := getBalanceNoErr(t, sender, sender.ref)
+	recipientBalance := getBalanceNoErr(t, recipient, recipient.ref)
+
+	now := time.Now()
+	allowance := escrowTransfer(t, sender, 100, recipient, now, now.Add(time.Hour), 2, firstApprover, secondApprover)
+	require.Equal(t, senderBalance-100, getBalanceNoErr(t, sender, sender.ref))
+
+	_, err := signedRequest(firstApprover, "ApproveTransfer", allowance)
+	require.NoError(t, err)
+
+	_, err = signedRequest(recipient, "AcceptTransfer", allowance)
+	require.Contains(t, err.Error(), "approvers")
+
+	_, err = signedRequest(secondApprover, "ApproveTransfer", allowance)
+	require.NoError(t, err)
+
+	_, err = signedRequest(recipient, "AcceptTransfer", allowance)
+	require.NoError(t, err)
+	require.Equal(t, recipientBalance+100, getBalanceNoErr(t, recipient, recipient.ref))
+}
+
+func TestEscrowTransferNotApprover(t *testing.T) {
+	sender := createMember(t, "Sender")
+	recipient := createMember(t, "Recipient")
+	approver := createMember(t, "Approver")
+
+	now := time.Now()
+	allowance := escrowTransfer(t, sender, 100, recipient, now, now.Add(time.Hour), 1, approver)
+
+	_, err := signedRequest(recipient, "ApproveTransfer", allowance)
+	require.Contains(t, err.Error(), "Only approvers can approve allowance")
+}
+
+func TestEscrowTransferTimeLock(t *testing.T) {
+	sender := createMember(t, "Sender")
+	recipient := createMember(t, "Recipient")
+
+	now := time.Now()
+	allowance := escrowTransfer(t, sender, 100, recipient, now.Add(time.Hour), now.Add(2*time.Hour), 0)
+
+	_, err := signedRequest(recipient, "AcceptTransfer", allowance)
+	require.Contains(t, err.Error(), "Allowance is locked until")
+}
+
+func TestEscrowTransferCancel(t *testing.T) {
+	sender := createMember(t, "Sender")
+	recipient := createMember(t, "Recipient")
+	senderBalance := getBalanceNoErr(t, sender, sender.ref)
+
+	now := time.Now()
+	allowance := escrowTransfer(t, sender, 100, recipient, now, now.Add(time.Hour), 0)
+
+	_, err := signedRequest(sender, "CancelTransfer", allowance)
+	require.Contains(t, err.Error(), "Allowance is not expired yet")
+
+	expired := escrowTransfer(t, sender, 100, recipient, now.Add(-2*time.Hour), now.Add(-time.Hour), 0)
+	_, err = signedRequest(sender, "CancelTransfer", expired)
+	require.NoError(t, err)
+	require.Equal(t, senderBalance-100, getBalanceNoErr(t, sender, sender.ref))
+}
@@ -64,5 +64,5 @@ func TestGetNodeRefInvalidParams(t *testing.T) {
 
 	nodeRef, err := getNodeRefSignedCall(123)
 	require.Equal(t, "", nodeRef)
-	require.Contains(t, err.Error(), "[ getNodeRefCall ] Can't unmarshal params: ")
+	require.EqualError(t, err, "[ makeCall ] Invalid params: GetNodeRef: invalid param 0 (publicKey): expected string, got integer")
 }
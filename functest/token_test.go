@@ -0,0 +1,105 @@
+// +build functest
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package functest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createToken(t *testing.T, issuer *user, amount int) string {
+	result, err := signedRequest(issuer, "CreateToken", "Loyalty points", "LP", amount)
+	require.NoError(t, err)
+	ref, ok := result.(string)
+	require.True(t, ok)
+	return ref
+}
+
+func getTokenBalance(t *testing.T, caller *user, token string, member string) int {
+	result, err := signedRequest(caller, "GetTokenBalance", token, member)
+	require.NoError(t, err)
+	amount, ok := result.(float64)
+	require.True(t, ok)
+	return int(amount)
+}
+
+func checkTokenBalanceFewTimes(t *testing.T, caller *user, token string, member string, expected int) {
+	for i := 0; i < times; i++ {
+		if getTokenBalance(t, caller, token, member) == expected {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+	t.Error("Received token balance is not equal expected")
+}
+
+func TestCreateToken(t *testing.T) {
+	issuer := createMember(t, "Issuer")
+	token := createToken(t, issuer, 1000)
+
+	require.Equal(t, 1000, getTokenBalance(t, issuer, token, issuer.ref))
+}
+
+func TestTransferToken(t *testing.T) {
+	issuer := createMember(t, "Issuer")
+	holder := createMember(t, "Holder")
+	token := createToken(t, issuer, 1000)
+
+	_, err := signedRequest(issuer, "TransferToken", token, 100, holder.ref)
+	require.NoError(t, err)
+
+	checkTokenBalanceFewTimes(t, holder, token, holder.ref, 100)
+	require.Equal(t, 900, getTokenBalance(t, issuer, token, issuer.ref))
+}
+
+func TestTransferTokenNotEnoughBalance(t *testing.T) {
+	issuer := createMember(t, "Issuer")
+	holder := createMember(t, "Holder")
+	token := createToken(t, issuer, 10)
+
+	_, err := signedRequest(issuer, "TransferToken", token, 100, holder.ref)
+	require.Contains(t, err.Error(), "Not enough balance for transfer")
+	require.Equal(t, 10, getTokenBalance(t, issuer, token, issuer.ref))
+}
+
+func TestMintAndBurnToken(t *testing.T) {
+	issuer := createMember(t, "Issuer")
+	holder := createMember(t, "Holder")
+	token := createToken(t, issuer, 10)
+
+	_, err := signedRequest(issuer, "MintToken", token, 50, holder.ref)
+	require.NoError(t, err)
+	require.Equal(t, 50, getTokenBalance(t, holder, token, holder.ref))
+
+	_, err = signedRequest(issuer, "BurnToken", token, 5)
+	require.NoError(t, err)
+	require.Equal(t, 5, getTokenBalance(t, issuer, token, issuer.ref))
+}
+
+func TestMintTokenNotIssuer(t *testing.T) {
+	issuer := createMember(t, "Issuer")
+	holder := createMember(t, "Holder")
+	token := createToken(t, issuer, 10)
+
+	_, err := signedRequest(holder, "MintToken", token, 50, holder.ref)
+	require.Contains(t, err.Error(), "Only issuer can do this")
+	require.Equal(t, 0, getTokenBalance(t, holder, token, holder.ref))
+}
@@ -0,0 +1,138 @@
+// +build functest
+
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package functest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type transferHistory struct {
+	Transfers []struct {
+		Direction    string
+		Counterparty string
+		Amount       int
+	}
+	NextCursor int
+}
+
+type membersPage struct {
+	Members []struct {
+		Reference string
+		Member    string
+	}
+	NextCursor string
+}
+
+func decodeJSONResult(t *testing.T, result interface{}, v interface{}) {
+	data, err := base64.StdEncoding.DecodeString(result.(string))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, v))
+}
+
+func getTransferHistory(t *testing.T, caller *user, member *user, cursor int, limit int) transferHistory {
+	result, err := signedRequest(caller, "GetTransferHistory", member.ref, cursor, limit)
+	require.NoError(t, err)
+	history := transferHistory{}
+	decodeJSONResult(t, result, &history)
+	return history
+}
+
+func TestGetTransferHistory(t *testing.T) {
+	sender := createMember(t, "Sender")
+	recipient := createMember(t, "Recipient")
+
+	for _, amount := range []int{10, 20, 30} {
+		_, err := signedRequest(sender, "Transfer", amount, recipient.ref)
+		require.NoError(t, err)
+	}
+
+	history := getTransferHistory(t, sender, sender, 0, 2)
+	require.Len(t, history.Transfers, 2)
+	require.Equal(t, "out", history.Transfers[0].Direction)
+	require.Equal(t, 30, history.Transfers[0].Amount)
+	require.Equal(t, 20, history.Transfers[1].Amount)
+	require.Equal(t, 1, history.NextCursor)
+
+	history = getTransferHistory(t, sender, sender, history.NextCursor, 2)
+	require.Len(t, history.Transfers, 1)
+	require.Equal(t, 10, history.Transfers[0].Amount)
+	require.Zero(t, history.NextCursor)
+
+	for i := 0; i < times; i++ {
+		history = getTransferHistory(t, recipient, recipient, 0, 0)
+		if len(history.Transfers) == 3 {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	require.Len(t, history.Transfers, 3)
+	require.Equal(t, "in", history.Transfers[0].Direction)
+}
+
+func TestGetTransferHistoryOfOther(t *testing.T) {
+	member1 := createMember(t, "Member1")
+	member2 := createMember(t, "Member2")
+
+	_, err := signedRequest(member1, "GetTransferHistory", member2.ref, 0, 0)
+	require.Contains(t, err.Error(), "You can get only your own history")
+
+	history := getTransferHistory(t, &root, member2, 0, 0)
+	require.Empty(t, history.Transfers)
+}
+
+func TestListMembers(t *testing.T) {
+	created := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		created[createMember(t, "Member").ref] = true
+	}
+
+	seen := map[string]bool{}
+	cursor := ""
+	for {
+		result, err := signedRequest(&root, "ListMembers", cursor, 2)
+		require.NoError(t, err)
+		page := membersPage{}
+		decodeJSONResult(t, result, &page)
+		require.True(t, len(page.Members) <= 2)
+		for _, m := range page.Members {
+			require.False(t, seen[m.Reference], "member is listed twice")
+			seen[m.Reference] = true
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	for ref := range created {
+		require.True(t, seen[ref])
+	}
+}
+
+func TestListMembersNoRoot(t *testing.T) {
+	member := createMember(t, "Member")
+
+	_, err := signedRequest(member, "ListMembers", "", 0)
+	require.Contains(t, err.Error(), "[ ListMembers ] Only root can call this method")
+}
@@ -39,15 +39,19 @@ import (
 )
 
 const (
-	nodeDomain        = "nodedomain"
-	nodeRecord        = "noderecord"
-	rootDomain        = "rootdomain"
-	walletContract    = "wallet"
-	memberContract    = "member"
-	allowanceContract = "allowance"
+	nodeDomain          = "nodedomain"
+	nodeRecord          = "noderecord"
+	rootDomain          = "rootdomain"
+	walletContract      = "wallet"
+	memberContract      = "member"
+	allowanceContract   = "allowance"
+	tokenContract       = "token"
+	tokenWalletContract = "tokenwallet"
 )
 
-var contractNames = []string{walletContract, memberContract, allowanceContract, rootDomain, nodeDomain, nodeRecord}
+var contractNames = []string{
+	walletContract, memberContract, allowanceContract, rootDomain, nodeDomain, nodeRecord, tokenContract, tokenWalletContract,
+}
 
 type messageBusLocker interface {
 	Lock(ctx context.Context)
@@ -0,0 +1,154 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package privatekey
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// PassphraseEnv is the environment variable holding the key file passphrase.
+	PassphraseEnv = "INSOLAR_KEYS_PASSPHRASE"
+	// PassphraseFileEnv is the environment variable holding a path to a file with the key file passphrase.
+	PassphraseFileEnv = "INSOLAR_KEYS_PASSPHRASE_FILE"
+
+	kdfScrypt     = "scrypt"
+	cipherAESGCM  = "aes-256-gcm"
+	scryptN       = 1 << 15
+	scryptR       = 8
+	scryptP       = 1
+	scryptKeyLen  = 32
+	scryptSaltLen = 32
+)
+
+// EncryptedKey is a passphrase protected private key as it is stored in a key file.
+type EncryptedKey struct {
+	KDF        string `json:"kdf"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Cipher     string `json:"cipher"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Encrypt seals PEM encoded private key with a key derived from passphrase.
+func Encrypt(key []byte, passphrase []byte) (*EncryptedKey, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("[ Encrypt ] passphrase is empty")
+	}
+
+	salt := make([]byte, scryptSaltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.Wrap(err, "[ Encrypt ] failed to generate salt")
+	}
+
+	encrypted := &EncryptedKey{
+		KDF:    kdfScrypt,
+		N:      scryptN,
+		R:      scryptR,
+		P:      scryptP,
+		Salt:   salt,
+		Cipher: cipherAESGCM,
+	}
+
+	aead, err := encrypted.aead(passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Encrypt ]")
+	}
+
+	encrypted.Nonce = make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, encrypted.Nonce); err != nil {
+		return nil, errors.Wrap(err, "[ Encrypt ] failed to generate nonce")
+	}
+
+	encrypted.Ciphertext = aead.Seal(nil, encrypted.Nonce, key, nil)
+	return encrypted, nil
+}
+
+// Decrypt opens encrypted private key with passphrase and returns PEM encoded key.
+func (k *EncryptedKey) Decrypt(passphrase []byte) ([]byte, error) {
+	aead, err := k.aead(passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Decrypt ]")
+	}
+
+	if len(k.Nonce) != aead.NonceSize() {
+		return nil, errors.New("[ Decrypt ] invalid nonce size")
+	}
+
+	key, err := aead.Open(nil, k.Nonce, k.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("[ Decrypt ] wrong passphrase or corrupted key")
+	}
+	return key, nil
+}
+
+func (k *EncryptedKey) aead(passphrase []byte) (cipher.AEAD, error) {
+	if k.KDF != kdfScrypt {
+		return nil, errors.Errorf("unsupported kdf %q", k.KDF)
+	}
+	if k.Cipher != cipherAESGCM {
+		return nil, errors.Errorf("unsupported cipher %q", k.Cipher)
+	}
+
+	derived, err := scrypt.Key(passphrase, k.Salt, k.N, k.R, k.P, scryptKeyLen)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive key")
+	}
+
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// ReadPassphrase returns key file passphrase from PassphraseEnv or from the file pointed by PassphraseFileEnv.
+func ReadPassphrase() ([]byte, error) {
+	if passphrase, ok := os.LookupEnv(PassphraseEnv); ok && len(passphrase) > 0 {
+		return []byte(passphrase), nil
+	}
+
+	path, ok := os.LookupEnv(PassphraseFileEnv)
+	if !ok || len(path) == 0 {
+		return nil, errors.Errorf("[ ReadPassphrase ] neither %s nor %s is set", PassphraseEnv, PassphraseFileEnv)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadPassphrase ] couldn't read passphrase from: "+path)
+	}
+
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if len(passphrase) == 0 {
+		return nil, errors.Errorf("[ ReadPassphrase ] passphrase file %s is empty", path)
+	}
+	return []byte(passphrase), nil
+}
@@ -50,24 +50,40 @@ func (p *keyLoader) Load(file string) (crypto.PrivateKey, error) {
 	return signer, nil
 }
 
+type keyFile struct {
+	PrivateKey          *string       `json:"private_key"`
+	EncryptedPrivateKey *EncryptedKey `json:"encrypted_private_key"`
+}
+
 // TODO: deprecated, use PEM format
 func readJSON(path string) ([]byte, error) {
 	data, err := ioutil.ReadFile(filepath.Clean(path))
 	if err != nil {
 		return nil, errors.Wrap(err, "[ read ] couldn't read keys from: "+path)
 	}
-	var keys map[string]string
+	var keys keyFile
 	err = json.Unmarshal(data, &keys)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ read ] failed to parse json.")
 	}
 
-	key, ok := keys["private_key"]
-	if !ok {
+	if keys.EncryptedPrivateKey != nil {
+		passphrase, err := ReadPassphrase()
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ read ] key file %s is encrypted", path)
+		}
+		key, err := keys.EncryptedPrivateKey.Decrypt(passphrase)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ read ] couldn't decrypt keys from: %s", path)
+		}
+		return key, nil
+	}
+
+	if keys.PrivateKey == nil {
 		return nil, errors.Errorf("[ read ] couldn't read keys from: %s", path)
 	}
 
-	return []byte(key), nil
+	return []byte(*keys.PrivateKey), nil
 }
 
 func pemParse(key []byte) (crypto.PrivateKey, error) {
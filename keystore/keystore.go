@@ -104,3 +104,18 @@ func NewKeyStore(path string) (core.KeyStore, error) {
 
 	return cachedKeyStore, nil
 }
+
+// EncryptedKey is a passphrase protected private key as it is stored in a key file
+// under the "encrypted_private_key" field.
+type EncryptedKey = privatekey.EncryptedKey
+
+// EncryptPrivateKey encrypts PEM encoded private key with passphrase.
+func EncryptPrivateKey(key []byte, passphrase []byte) (*EncryptedKey, error) {
+	return privatekey.Encrypt(key, passphrase)
+}
+
+// ReadPassphrase reads key file passphrase from INSOLAR_KEYS_PASSPHRASE
+// or from the file pointed by INSOLAR_KEYS_PASSPHRASE_FILE.
+func ReadPassphrase() ([]byte, error) {
+	return privatekey.ReadPassphrase()
+}
@@ -18,6 +18,10 @@ package keystore
 
 import (
 	"crypto/ecdsa"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -61,3 +65,68 @@ func TestKeyStore_GetPrivateKeyReturnsECDSA(t *testing.T) {
 	require.NotNil(t, ecdsaPK)
 	require.True(t, ok)
 }
+
+func writeEncryptedKeys(t *testing.T, passphrase string) string {
+	data, err := ioutil.ReadFile(testKeys)
+	require.NoError(t, err)
+
+	var keys map[string]string
+	require.NoError(t, json.Unmarshal(data, &keys))
+
+	encrypted, err := EncryptPrivateKey([]byte(keys["private_key"]), []byte(passphrase))
+	require.NoError(t, err)
+
+	data, err = json.Marshal(map[string]interface{}{
+		"public_key":            keys["public_key"],
+		"encrypted_private_key": encrypted,
+	})
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(t, err)
+	path := filepath.Join(dir, "keys.json")
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestKeyStore_EncryptedKeys(t *testing.T) {
+	path := writeEncryptedKeys(t, "secret")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	os.Setenv("INSOLAR_KEYS_PASSPHRASE", "secret")
+	defer os.Unsetenv("INSOLAR_KEYS_PASSPHRASE")
+
+	ks, err := NewKeyStore(path)
+	require.NoError(t, err)
+
+	pk, err := ks.GetPrivateKey("")
+	require.NoError(t, err)
+	_, ok := pk.(*ecdsa.PrivateKey)
+	require.True(t, ok)
+}
+
+func TestKeyStore_EncryptedKeysPassphraseFile(t *testing.T) {
+	path := writeEncryptedKeys(t, "secret")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	passFile := filepath.Join(filepath.Dir(path), "pass")
+	require.NoError(t, ioutil.WriteFile(passFile, []byte("secret\n"), 0600))
+	os.Setenv("INSOLAR_KEYS_PASSPHRASE_FILE", passFile)
+	defer os.Unsetenv("INSOLAR_KEYS_PASSPHRASE_FILE")
+
+	ks, err := NewKeyStore(path)
+	require.NoError(t, err)
+	require.NotNil(t, ks)
+}
+
+func TestKeyStore_EncryptedKeysWrongPassphrase(t *testing.T) {
+	path := writeEncryptedKeys(t, "secret")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	os.Setenv("INSOLAR_KEYS_PASSPHRASE", "wrong")
+	defer os.Unsetenv("INSOLAR_KEYS_PASSPHRASE")
+
+	ks, err := NewKeyStore(path)
+	require.Error(t, err)
+	require.Nil(t, ks)
+}
@@ -351,6 +351,50 @@ func (m *LedgerArtifactManager) HasPendingRequests(
 	}
 }
 
+// GetRequestResult returns execution state of request to provided object.
+//
+// ErrRequestNotFound is returned if ledger has neither request nor its result.
+func (m *LedgerArtifactManager) GetRequestResult(
+	ctx context.Context, object, request core.RecordRef,
+) (*core.RequestResult, error) {
+	var err error
+	ctx, span := instracer.StartSpan(ctx, "artifactmanager.GetRequestResult")
+	instrumenter := instrument(ctx, "GetRequestResult").err(&err)
+	defer func() {
+		if err != nil {
+			span.AddAttributes(trace.StringAttribute("error", err.Error()))
+		}
+		span.End()
+		instrumenter.end()
+	}()
+
+	currentPulse, err := m.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bus := core.MessageBusFromContext(ctx, m.DefaultBus)
+	sender := BuildSender(bus.Send, retryJetSender(currentPulse.PulseNumber, m.JetStorage))
+	genericReply, err := sender(ctx, &message.GetRequestResult{
+		Object:  object,
+		Request: request,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch rep := genericReply.(type) {
+	case *reply.RequestResult:
+		return &core.RequestResult{Result: rep.Result, Payload: rep.Payload, Pending: rep.Pending}, nil
+	case *reply.Error:
+		err = rep.Error()
+		return nil, err
+	default:
+		err = fmt.Errorf("GetRequestResult: unexpected reply: %#v", genericReply)
+		return nil, err
+	}
+}
+
 // GetDelegate returns provided object's delegate reference for provided prototype.
 //
 // Object delegate should be previously created for this object. If object delegate does not exist, an error will
@@ -424,6 +468,58 @@ func (m *LedgerArtifactManager) GetChildren(
 	return iter, err
 }
 
+// GetObjectHistory returns a chunk of object states from the newest to the oldest.
+//
+// If provided state is nil, the walk starts from the latest state. States will be fetched from remote source
+// (light or heavy node which holds the state).
+func (m *LedgerArtifactManager) GetObjectHistory(
+	ctx context.Context, head core.RecordRef, from *core.RecordID, amount int,
+) (*core.ObjectHistory, error) {
+	var err error
+
+	ctx, span := instracer.StartSpan(ctx, "artifactmanager.GetObjectHistory")
+	instrumenter := instrument(ctx, "GetObjectHistory").err(&err)
+	defer func() {
+		if err != nil {
+			span.AddAttributes(trace.StringAttribute("error", err.Error()))
+		}
+		span.End()
+		instrumenter.end()
+	}()
+
+	if amount <= 0 {
+		err = errors.New("amount should be positive")
+		return nil, err
+	}
+
+	currentPulse, err := m.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bus := core.MessageBusFromContext(ctx, m.DefaultBus)
+	sender := BuildSender(bus.Send, followRedirectSender(bus), retryJetSender(currentPulse.PulseNumber, m.JetStorage))
+	genericReply, err := sender(ctx, &message.GetObjectHistory{
+		Head:      head,
+		FromState: from,
+		Amount:    amount,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch rep := genericReply.(type) {
+	case *reply.ObjectHistory:
+		return &core.ObjectHistory{States: rep.States, NextFrom: rep.NextFrom}, nil
+	case *reply.Error:
+		err = rep.Error()
+		return nil, err
+	default:
+		err = fmt.Errorf("GetObjectHistory: unexpected reply: %#v", genericReply)
+		return nil, err
+	}
+}
+
 // DeclareType creates new type record in storage.
 //
 // Type is a contract interface. It contains one method signature.
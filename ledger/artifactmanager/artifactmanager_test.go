@@ -29,6 +29,7 @@ import (
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/jetload"
 	"github.com/insolar/insolar/ledger/recentstorage"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/index"
@@ -169,14 +170,29 @@ func getTestData(s *amSuite) (
 	handler.PulseTracker = s.pulseTracker
 	handler.DBContext = s.db
 	handler.JetStorage = s.jetStorage
+	handler.JetLoadMeter = jetload.NewMeter()
 
 	indexMock := recentstorage.NewRecentIndexStorageMock(s.T())
 	pendingMock := recentstorage.NewPendingStorageMock(s.T())
 
 	indexMock.AddObjectMock.Return()
-	pendingMock.GetRequestsForObjectMock.Return(nil)
-	pendingMock.AddPendingRequestMock.Return()
-	pendingMock.RemovePendingRequestMock.Return()
+	pending := map[core.RecordID]map[core.RecordID]struct{}{}
+	pendingMock.GetRequestsForObjectFunc = func(obj core.RecordID) []core.RecordID {
+		var requests []core.RecordID
+		for id := range pending[obj] {
+			requests = append(requests, id)
+		}
+		return requests
+	}
+	pendingMock.AddPendingRequestFunc = func(ctx context.Context, obj, req core.RecordID) {
+		if _, ok := pending[obj]; !ok {
+			pending[obj] = map[core.RecordID]struct{}{}
+		}
+		pending[obj][req] = struct{}{}
+	}
+	pendingMock.RemovePendingRequestFunc = func(ctx context.Context, obj, req core.RecordID) {
+		delete(pending[obj], req)
+	}
 
 	provideMock := recentstorage.NewProviderMock(s.T())
 	provideMock.GetIndexStorageMock.Return(indexMock)
@@ -740,6 +756,137 @@ func (s *amSuite) TestLedgerArtifactManager_GetChildren_FollowsRedirect() {
 	require.NoError(s.T(), err)
 }
 
+func (s *amSuite) TestLedgerArtifactManager_GetObjectHistory() {
+	ctx, os, am := getTestData(s)
+	jetID := *jet.NewID(0, nil)
+
+	memory := record.CalculateIDForBlob(am.PlatformCryptographyScheme, core.GenesisPulse.PulseNumber, []byte{1})
+	activateRequest := genRandomRef(0)
+	amendRequest := genRandomRef(0)
+	deactivateRequest := genRandomRef(0)
+
+	activateID, _ := os.SetRecord(
+		ctx,
+		jetID,
+		core.GenesisPulse.PulseNumber,
+		&record.ObjectActivateRecord{
+			SideEffectRecord: record.SideEffectRecord{
+				Domain:  domainRef,
+				Request: *activateRequest,
+			},
+			ObjectStateRecord: record.ObjectStateRecord{
+				Memory: memory,
+			},
+		})
+	amendID, _ := os.SetRecord(
+		ctx,
+		jetID,
+		core.GenesisPulse.PulseNumber,
+		&record.ObjectAmendRecord{
+			SideEffectRecord: record.SideEffectRecord{
+				Domain:  domainRef,
+				Request: *amendRequest,
+			},
+			ObjectStateRecord: record.ObjectStateRecord{
+				Memory: memory,
+			},
+			PrevState: *activateID,
+		})
+	deactivateID, _ := os.SetRecord(
+		ctx,
+		jetID,
+		core.GenesisPulse.PulseNumber,
+		&record.DeactivationRecord{
+			SideEffectRecord: record.SideEffectRecord{
+				Domain:  domainRef,
+				Request: *deactivateRequest,
+			},
+			PrevState: *amendID,
+		})
+	require.NoError(
+		s.T(),
+		os.SetObjectIndex(ctx, jetID, activateID, &index.ObjectLifeline{LatestState: deactivateID}),
+	)
+	objRef := *genRefWithID(activateID)
+
+	s.T().Run("returns all states from the latest", func(t *testing.T) {
+		history, err := am.GetObjectHistory(ctx, objRef, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, history.States, 3)
+		assert.Nil(t, history.NextFrom)
+
+		assert.Equal(t, *deactivateID, history.States[0].State)
+		assert.Equal(t, core.ObjectStateDeactivation, history.States[0].Kind)
+		assert.Equal(t, *deactivateRequest, history.States[0].Request)
+		assert.Nil(t, history.States[0].MemoryHash)
+
+		assert.Equal(t, *amendID, history.States[1].State)
+		assert.Equal(t, core.ObjectStateAmend, history.States[1].Kind)
+		assert.Equal(t, *amendRequest, history.States[1].Request)
+		assert.Equal(t, memory.Hash(), history.States[1].MemoryHash)
+
+		assert.Equal(t, *activateID, history.States[2].State)
+		assert.Equal(t, core.ObjectStateActivation, history.States[2].Kind)
+		assert.Equal(t, *activateRequest, history.States[2].Request)
+		assert.Equal(t, core.GenesisPulse.PulseNumber, history.States[2].Pulse)
+	})
+
+	s.T().Run("returns states in chunks", func(t *testing.T) {
+		history, err := am.GetObjectHistory(ctx, objRef, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, history.States, 2)
+		assert.Equal(t, *deactivateID, history.States[0].State)
+		assert.Equal(t, *amendID, history.States[1].State)
+		require.NotNil(t, history.NextFrom)
+		assert.Equal(t, *activateID, *history.NextFrom)
+
+		history, err = am.GetObjectHistory(ctx, objRef, history.NextFrom, 2)
+		require.NoError(t, err)
+		require.Len(t, history.States, 1)
+		assert.Equal(t, *activateID, history.States[0].State)
+		assert.Nil(t, history.NextFrom)
+	})
+
+	s.T().Run("fails on non-positive amount", func(t *testing.T) {
+		_, err := am.GetObjectHistory(ctx, objRef, nil, 0)
+		require.Error(t, err)
+	})
+}
+
+func (s *amSuite) TestLedgerArtifactManager_GetObjectHistory_FollowsRedirect() {
+	mc := minimock.NewController(s.T())
+	am := NewArtifactManger()
+	mb := testutils.NewMessageBusMock(mc)
+
+	am.DB = s.db
+	am.PulseStorage = makePulseStorage(s)
+
+	objRef := genRandomRef(0)
+	nodeRef := genRandomRef(0)
+	stateID := genRandomID(0)
+	mb.SendFunc = func(c context.Context, m core.Message, o *core.MessageSendOptions) (r core.Reply, r1 error) {
+		o = o.Safe()
+		if o.Receiver == nil {
+			return &reply.GetObjectHistoryRedirectReply{
+				Receiver:  nodeRef,
+				Token:     &delegationtoken.GetObjectHistoryRedirectToken{Signature: []byte{1, 2, 3}},
+				FromState: *stateID,
+			}, nil
+		}
+
+		token, ok := o.Token.(*delegationtoken.GetObjectHistoryRedirectToken)
+		assert.True(s.T(), ok)
+		assert.Equal(s.T(), []byte{1, 2, 3}, token.Signature)
+		assert.Equal(s.T(), nodeRef, o.Receiver)
+		assert.Equal(s.T(), stateID, m.(*message.GetObjectHistory).FromState)
+		return &reply.ObjectHistory{}, nil
+	}
+	am.DefaultBus = mb
+
+	_, err := am.GetObjectHistory(s.ctx, *objRef, nil, 10)
+	require.NoError(s.T(), err)
+}
+
 func (s *amSuite) TestLedgerArtifactManager_HandleJetDrop() {
 	s.T().Skip("jet drops are for validation and it doesn't work")
 
@@ -810,6 +957,7 @@ func (s *amSuite) TestLedgerArtifactManager_RegisterValidation() {
 	handler.PulseTracker = s.pulseTracker
 	handler.NodeStorage = s.nodeStorage
 	handler.JetStorage = s.jetStorage
+	handler.JetLoadMeter = jetload.NewMeter()
 
 	handler.RecentStorageProvider = provideMock
 
@@ -902,6 +1050,38 @@ func (s *amSuite) TestLedgerArtifactManager_RegisterResult() {
 	}, *rec.(*record.ResultRecord))
 }
 
+func (s *amSuite) TestLedgerArtifactManager_GetRequestResult() {
+	ctx, _, am := getTestData(s)
+
+	parcel := message.Parcel{Msg: &message.GenesisRequest{Name: "4K3NiGuqYGqKPnYp6XeGd2kdN4P9veL6rYcWkLKWXZCu.4FFB8zfQoGznSmzDxwv4njX1aR9ioL8GHSH17QXH2AFa"}}
+	requestID, err := am.RegisterRequest(ctx, *am.GenesisRef(), &parcel)
+	require.NoError(s.T(), err)
+	request := *core.NewRecordRef(core.RecordID{}, *requestID)
+
+	s.T().Run("returns empty result for not executed request", func(t *testing.T) {
+		res, err := am.GetRequestResult(ctx, *am.GenesisRef(), request)
+		require.NoError(t, err)
+		assert.Nil(t, res.Result)
+		assert.Nil(t, res.Payload)
+	})
+
+	s.T().Run("returns result of executed request", func(t *testing.T) {
+		resultID, err := am.RegisterResult(ctx, *am.GenesisRef(), request, []byte{1, 2, 3})
+		require.NoError(t, err)
+
+		res, err := am.GetRequestResult(ctx, *am.GenesisRef(), request)
+		require.NoError(t, err)
+		require.NotNil(t, res.Result)
+		assert.Equal(t, *resultID, *res.Result)
+		assert.Equal(t, []byte{1, 2, 3}, res.Payload)
+	})
+
+	s.T().Run("fails on unknown request", func(t *testing.T) {
+		_, err := am.GetRequestResult(ctx, *am.GenesisRef(), *genRandomRef(0))
+		assert.Equal(t, core.ErrRequestNotFound, err)
+	})
+}
+
 func (s *amSuite) TestLedgerArtifactManager_RegisterRequest_JetMiss() {
 	mc := minimock.NewController(s.T())
 	defer mc.Finish()
@@ -26,6 +26,7 @@ import (
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/jetload"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/jet"
 	"github.com/insolar/insolar/ledger/storage/storagetest"
@@ -147,6 +148,7 @@ func (s *componentSuite) TestLedgerArtifactManager_PendingRequest() {
 	handler.DBContext = s.db
 	handler.PulseTracker = s.pulseTracker
 	handler.ObjectStorage = s.objectStorage
+	handler.JetLoadMeter = jetload.NewMeter()
 
 	handler.PlatformCryptographyScheme = cryptoScheme
 	handler.Bus = mb
@@ -28,6 +28,7 @@ import (
 
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/insmetrics"
+	"github.com/insolar/insolar/ledger/jetload"
 	"github.com/insolar/insolar/ledger/recentstorage"
 	"github.com/insolar/insolar/ledger/storage/jet"
 
@@ -58,6 +59,7 @@ type MessageHandler struct {
 	PulseTracker               storage.PulseTracker            `inject:""`
 	DBContext                  storage.DBContext               `inject:""`
 	HotDataWaiter              HotDataWaiter                   `inject:""`
+	JetLoadMeter               jetload.Meter                   `inject:""`
 
 	certificate    core.Certificate
 	replayHandlers map[core.MessageType]core.MessageHandler
@@ -137,63 +139,88 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 			instrumentHandler("handleGetObject"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.measureJetLoad))
 
 	h.Bus.MustRegister(core.TypeGetDelegate,
 		BuildMiddleware(h.handleGetDelegate,
 			instrumentHandler("handleGetDelegate"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.measureJetLoad))
 
 	h.Bus.MustRegister(core.TypeGetChildren,
 		BuildMiddleware(h.handleGetChildren,
 			instrumentHandler("handleGetChildren"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.measureJetLoad))
+
+	h.Bus.MustRegister(core.TypeGetObjectHistory,
+		BuildMiddleware(h.handleGetObjectHistory,
+			instrumentHandler("handleGetObjectHistory"),
+			m.addFieldsToLogger,
+			m.checkJet,
+			m.waitForHotData,
+			m.measureJetLoad))
+
+	h.Bus.MustRegister(core.TypeGetRequestResult,
+		BuildMiddleware(h.handleGetRequestResult,
+			instrumentHandler("handleGetRequestResult"),
+			m.addFieldsToLogger,
+			m.checkJet,
+			m.waitForHotData,
+			m.measureJetLoad))
 
 	h.Bus.MustRegister(core.TypeSetRecord,
 		BuildMiddleware(h.handleSetRecord,
 			instrumentHandler("handleSetRecord"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.measureJetLoad))
 
 	h.Bus.MustRegister(core.TypeUpdateObject,
 		BuildMiddleware(h.handleUpdateObject,
 			instrumentHandler("handleUpdateObject"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.measureJetLoad))
 
 	h.Bus.MustRegister(core.TypeRegisterChild,
 		BuildMiddleware(h.handleRegisterChild,
 			instrumentHandler("handleRegisterChild"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.measureJetLoad))
 
 	h.Bus.MustRegister(core.TypeSetBlob,
 		BuildMiddleware(h.handleSetBlob,
 			instrumentHandler("handleSetBlob"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.measureJetLoad))
 
 	h.Bus.MustRegister(core.TypeGetObjectIndex,
 		BuildMiddleware(h.handleGetObjectIndex,
 			instrumentHandler("handleGetObjectIndex"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.measureJetLoad))
 
 	h.Bus.MustRegister(core.TypeGetPendingRequests,
 		BuildMiddleware(h.handleHasPendingRequests,
 			instrumentHandler("handleHasPendingRequests"),
 			m.addFieldsToLogger,
 			m.checkJet,
-			m.waitForHotData))
+			m.waitForHotData,
+			m.measureJetLoad))
 
 	h.Bus.MustRegister(core.TypeGetJet,
 		BuildMiddleware(h.handleGetJet,
@@ -210,6 +237,7 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 			h.handleGetRequest,
 			instrumentHandler("handleGetRequest"),
 			m.checkJet,
+			m.measureJetLoad,
 		),
 	)
 
@@ -219,6 +247,7 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 			h.handleGetPendingRequestID,
 			instrumentHandler("handleGetPendingRequestID"),
 			m.checkJet,
+			m.measureJetLoad,
 		),
 	)
 
@@ -244,6 +273,7 @@ func (h *MessageHandler) setReplayHandlers(m *middleware) {
 	h.replayHandlers[core.TypeGetObject] = BuildMiddleware(h.handleGetObject, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[core.TypeGetDelegate] = BuildMiddleware(h.handleGetDelegate, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[core.TypeGetChildren] = BuildMiddleware(h.handleGetChildren, m.addFieldsToLogger, m.checkJet)
+	h.replayHandlers[core.TypeGetObjectHistory] = BuildMiddleware(h.handleGetObjectHistory, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[core.TypeSetRecord] = BuildMiddleware(h.handleSetRecord, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[core.TypeUpdateObject] = BuildMiddleware(h.handleUpdateObject, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[core.TypeRegisterChild] = BuildMiddleware(h.handleRegisterChild, m.addFieldsToLogger, m.checkJet)
@@ -289,10 +319,20 @@ func (h *MessageHandler) setHandlersForHeavy(m *middleware) {
 			instrumentHandler("handleGetChildren"),
 			m.zeroJetForHeavy))
 
+	h.Bus.MustRegister(core.TypeGetObjectHistory,
+		BuildMiddleware(h.handleGetObjectHistory,
+			instrumentHandler("handleGetObjectHistory"),
+			m.zeroJetForHeavy))
+
 	h.Bus.MustRegister(core.TypeGetObjectIndex,
 		BuildMiddleware(h.handleGetObjectIndex,
 			instrumentHandler("handleGetObjectIndex"),
 			m.zeroJetForHeavy))
+
+	h.Bus.MustRegister(core.TypeGetRequestResult,
+		BuildMiddleware(h.handleGetRequestResult,
+			instrumentHandler("handleGetRequestResult"),
+			m.zeroJetForHeavy))
 }
 
 func (h *MessageHandler) handleSetRecord(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
@@ -303,12 +343,8 @@ func (h *MessageHandler) handleSetRecord(ctx context.Context, parcel core.Parcel
 	id := record.NewRecordIDFromRecord(h.PlatformCryptographyScheme, parcel.Pulse(), rec)
 
 	if !h.isHeavy {
-		recentStorage := h.RecentStorageProvider.GetPendingStorage(ctx, jetID)
 		if request, ok := rec.(record.Request); ok {
-			recentStorage.AddPendingRequest(ctx, request.GetObject(), *id)
-		}
-		if result, ok := rec.(*record.ResultRecord); ok {
-			recentStorage.RemovePendingRequest(ctx, result.Object, *result.Request.Record())
+			h.RecentStorageProvider.GetPendingStorage(ctx, jetID).AddPendingRequest(ctx, request.GetObject(), *id)
 		}
 	}
 
@@ -317,9 +353,69 @@ func (h *MessageHandler) handleSetRecord(ctx context.Context, parcel core.Parcel
 		return nil, err
 	}
 
+	if result, ok := rec.(*record.ResultRecord); ok {
+		err = h.ObjectStorage.SetRequestResult(
+			ctx, jetID, result.Object, *result.Request.Record(), &index.RequestResult{Result: *id, Payload: result.Payload},
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to save result index")
+		}
+		if !h.isHeavy {
+			// Request is removed from pending only after its result is indexed, so it can't be lost for
+			// GetRequestResult. Result indexes of recent objects are passed to the next executor with hot data.
+			h.RecentStorageProvider.GetPendingStorage(ctx, jetID).RemovePendingRequest(
+				ctx, result.Object, *result.Request.Record(),
+			)
+			h.RecentStorageProvider.GetIndexStorage(ctx, jetID).AddObject(ctx, result.Object)
+		}
+	}
+
 	return &reply.ID{ID: *id}, nil
 }
 
+func (h *MessageHandler) handleGetRequestResult(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+	msg := parcel.Message().(*message.GetRequestResult)
+	jetID := jetFromContext(ctx)
+	objectID := *msg.Object.Record()
+	requestID := *msg.Request.Record()
+
+	result, err := h.ObjectStorage.GetRequestResult(ctx, jetID, objectID, requestID)
+	if err == nil {
+		return &reply.RequestResult{Result: &result.Result, Payload: result.Payload}, nil
+	}
+	if err != storage.ErrNotFound {
+		return nil, errors.Wrap(err, "failed to fetch result index")
+	}
+	if h.isHeavy {
+		return &reply.Error{ErrType: reply.ErrRequestNotFound}, nil
+	}
+
+	for _, reqID := range h.RecentStorageProvider.GetPendingStorage(ctx, jetID).GetRequestsForObject(objectID) {
+		if reqID == requestID {
+			return &reply.RequestResult{Pending: reqID.Pulse() < parcel.Pulse()}, nil
+		}
+	}
+
+	// Neither result nor pending request is on the current node. Results that are not hot anymore are on heavy.
+	node, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
+	if err != nil {
+		return nil, err
+	}
+	if *node == h.JetCoordinator.Me() {
+		return &reply.Error{ErrType: reply.ErrRequestNotFound}, nil
+	}
+	genericReply, err := h.Bus.Send(ctx, &message.GetRequestResult{
+		Object:  msg.Object,
+		Request: msg.Request,
+	}, &core.MessageSendOptions{
+		Receiver: node,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch result from heavy")
+	}
+	return genericReply, nil
+}
+
 func (h *MessageHandler) handleSetBlob(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
 	msg := parcel.Message().(*message.SetBlob)
 	jetID := jetFromContext(ctx)
@@ -728,6 +824,152 @@ func (h *MessageHandler) handleGetChildren(
 	return &reply.Children{Refs: refs, NextFrom: nil}, nil
 }
 
+func (h *MessageHandler) handleGetObjectHistory(
+	ctx context.Context, parcel core.Parcel,
+) (core.Reply, error) {
+	logger := inslogger.FromContext(ctx)
+	logger.Debug("CALL handleGetObjectHistory")
+
+	msg := parcel.Message().(*message.GetObjectHistory)
+	jetID := jetFromContext(ctx)
+
+	if !h.isHeavy {
+		h.RecentStorageProvider.GetIndexStorage(ctx, jetID).AddObject(ctx, *msg.Head.Record())
+	}
+
+	// Counting from specified state or the latest.
+	currentState := msg.FromState
+	if currentState == nil {
+		idx, err := h.ObjectStorage.GetObjectIndex(ctx, jetID, msg.Head.Record(), false)
+		if err == storage.ErrNotFound {
+			if h.isHeavy {
+				return nil, fmt.Errorf("failed to fetch index for %v", msg.Head.Record())
+			}
+
+			heavy, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
+			if err != nil {
+				return nil, err
+			}
+			idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Head, heavy)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to fetch index from heavy")
+			}
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch object index")
+		}
+		currentState = idx.LatestState
+	}
+
+	// The object has no states.
+	if currentState == nil {
+		return &reply.ObjectHistory{States: nil, NextFrom: nil}, nil
+	}
+
+	var stateJet *core.RecordID
+	if h.isHeavy {
+		stateJet = &jetID
+	} else {
+		var actual bool
+		onHeavy, err := h.JetCoordinator.IsBeyondLimit(ctx, parcel.Pulse(), currentState.Pulse())
+		if err != nil {
+			return nil, err
+		}
+		if onHeavy {
+			node, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
+			if err != nil {
+				return nil, err
+			}
+			return reply.NewGetObjectHistoryRedirect(h.DelegationTokenFactory, parcel, node, *currentState)
+		}
+
+		stateTree, err := h.JetStorage.GetJetTree(ctx, currentState.Pulse())
+		if err != nil {
+			return nil, err
+		}
+		stateJet, actual = stateTree.Find(*msg.Head.Record())
+		if !actual {
+			actualJet, err := h.jetTreeUpdater.fetchJet(ctx, *msg.Head.Record(), currentState.Pulse())
+			if err != nil {
+				return nil, err
+			}
+			stateJet = actualJet
+		}
+	}
+
+	// Try to fetch the first state.
+	_, err := h.ObjectStorage.GetRecord(ctx, *stateJet, currentState)
+	if err == storage.ErrNotFound {
+		if h.isHeavy {
+			return nil, fmt.Errorf("failed to fetch state for %v. jet: %v, state: %v", msg.Head.Record(), stateJet.DebugString(), currentState.DebugString())
+		}
+		node, err := h.JetCoordinator.NodeForJet(ctx, *stateJet, parcel.Pulse(), currentState.Pulse())
+		if err != nil {
+			return nil, err
+		}
+		return reply.NewGetObjectHistoryRedirect(h.DelegationTokenFactory, parcel, node, *currentState)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch state")
+	}
+
+	var states []core.ObjectStateInfo
+	for currentState != nil {
+		// We have enough results.
+		if len(states) >= msg.Amount {
+			return &reply.ObjectHistory{States: states, NextFrom: currentState}, nil
+		}
+
+		rec, err := h.ObjectStorage.GetRecord(ctx, *stateJet, currentState)
+		// We don't have this state. Return what was collected.
+		if err == storage.ErrNotFound {
+			return &reply.ObjectHistory{States: states, NextFrom: currentState}, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch state")
+		}
+
+		state, ok := rec.(record.ObjectState)
+		if !ok {
+			return nil, errors.New("invalid object record")
+		}
+		states = append(states, objectStateInfo(*currentState, state))
+		currentState = state.PrevStateID()
+	}
+
+	return &reply.ObjectHistory{States: states, NextFrom: nil}, nil
+}
+
+func objectStateInfo(id core.RecordID, state record.ObjectState) core.ObjectStateInfo {
+	info := core.ObjectStateInfo{
+		State: id,
+		Pulse: id.Pulse(),
+	}
+
+	switch state.State() {
+	case record.StateActivation:
+		info.Kind = core.ObjectStateActivation
+	case record.StateAmend:
+		info.Kind = core.ObjectStateAmend
+	case record.StateDeactivation:
+		info.Kind = core.ObjectStateDeactivation
+	}
+
+	switch r := state.(type) {
+	case *record.ObjectActivateRecord:
+		info.Request = r.Request
+	case *record.ObjectAmendRecord:
+		info.Request = r.Request
+	case *record.DeactivationRecord:
+		info.Request = r.Request
+	}
+
+	if state.GetMemory() != nil {
+		info.MemoryHash = state.GetMemory().Hash()
+	}
+
+	return info
+}
+
 func (h *MessageHandler) handleGetRequest(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
 	jetID := jetFromContext(ctx)
 	msg := parcel.Message().(*message.GetRequest)
@@ -1157,6 +1399,21 @@ func (h *MessageHandler) handleHotRecords(ctx context.Context, parcel core.Parce
 		}
 	}
 
+	logger.Debugf("received request results of %d objects", len(msg.RequestResults))
+	for objID, results := range msg.RequestResults {
+		for reqID, buf := range results {
+			result, err := index.DecodeRequestResult(buf)
+			if err != nil {
+				logger.Error(err)
+				continue
+			}
+			err = h.ObjectStorage.SetRequestResult(ctx, jetID, objID, reqID, result)
+			if err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+
 	indexStorage := h.RecentStorageProvider.GetIndexStorage(ctx, jetID)
 	logger.Debugf("received %d recent objects", len(msg.RecentObjects))
 	for id, meta := range msg.RecentObjects {
@@ -702,6 +702,86 @@ func (s *handlerSuite) TestMessageHandler_HandleHasPendingRequests() {
 	assert.True(s.T(), has.Has)
 }
 
+func (s *handlerSuite) TestMessageHandler_HandleGetRequestResult() {
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+
+	jetID := *jet.NewID(0, nil)
+	object := *genRandomRef(0)
+	pendingRequest := *genRandomID(core.FirstPulseNumber)
+
+	pendingMock := recentstorage.NewPendingStorageMock(s.T())
+	pendingMock.GetRequestsForObjectMock.Return([]core.RecordID{pendingRequest})
+	provideMock := recentstorage.NewProviderMock(s.T())
+	provideMock.GetPendingStorageMock.Return(pendingMock)
+
+	certificate := testutils.NewCertificateMock(s.T())
+	certificate.GetRoleMock.Return(core.StaticRoleLightMaterial)
+
+	heavy := testutils.RandomRef()
+	jc := testutils.NewJetCoordinatorMock(mc)
+	jc.HeavyMock.Return(&heavy, nil)
+	jc.MeMock.Return(testutils.RandomRef())
+	mb := testutils.NewMessageBusMock(mc)
+	mb.MustRegisterMock.Return()
+
+	h := NewMessageHandler(&configuration.Ledger{}, certificate)
+	h.JetCoordinator = jc
+	h.Bus = mb
+	h.JetStorage = s.jetStorage
+	h.NodeStorage = s.nodeStorage
+	h.DBContext = s.db
+	h.PulseTracker = s.pulseTracker
+	h.ObjectStorage = s.objectStorage
+	h.RecentStorageProvider = provideMock
+
+	err := h.Init(s.ctx)
+	require.NoError(s.T(), err)
+
+	getResult := func(request core.RecordID) (core.Reply, error) {
+		return h.handleGetRequestResult(contextWithJet(s.ctx, jetID), &message.Parcel{
+			Msg: &message.GetRequestResult{
+				Object:  object,
+				Request: *core.NewRecordRef(core.RecordID{}, request),
+			},
+			PulseNumber: core.FirstPulseNumber + 1,
+		})
+	}
+
+	s.T().Run("returns indexed result", func(t *testing.T) {
+		request := *genRandomID(core.FirstPulseNumber)
+		result := *genRandomID(core.FirstPulseNumber)
+		err := s.objectStorage.SetRequestResult(
+			s.ctx, jetID, *object.Record(), request, &index.RequestResult{Result: result, Payload: []byte{1}},
+		)
+		require.NoError(t, err)
+
+		rep, err := getResult(request)
+		require.NoError(t, err)
+		assert.Equal(t, &reply.RequestResult{Result: &result, Payload: []byte{1}}, rep)
+	})
+
+	s.T().Run("returns pending request", func(t *testing.T) {
+		rep, err := getResult(pendingRequest)
+		require.NoError(t, err)
+		assert.Equal(t, &reply.RequestResult{Pending: true}, rep)
+	})
+
+	s.T().Run("fetches result from heavy", func(t *testing.T) {
+		request := *genRandomID(core.FirstPulseNumber)
+		heavyReply := &reply.RequestResult{Result: genRandomID(core.FirstPulseNumber)}
+		mb.SendFunc = func(ctx context.Context, m core.Message, o *core.MessageSendOptions) (core.Reply, error) {
+			require.Equal(t, heavy, *o.Receiver)
+			require.Equal(t, request, *m.(*message.GetRequestResult).Request.Record())
+			return heavyReply, nil
+		}
+
+		rep, err := getResult(request)
+		require.NoError(t, err)
+		assert.Equal(t, heavyReply, rep)
+	})
+}
+
 func (s *handlerSuite) TestMessageHandler_HandleGetCode_Redirects() {
 	mc := minimock.NewController(s.T())
 	defer mc.Finish()
@@ -1000,6 +1080,13 @@ func (s *handlerSuite) TestMessageHandler_HandleHotRecords() {
 		DropJet:            jetID,
 		JetDropSizeHistory: dropSizeHistory,
 	}
+	request := testutils.RandomID()
+	result := &index.RequestResult{Result: testutils.RandomID(), Payload: []byte{1, 2, 3}}
+	encodedResult, err := index.EncodeRequestResult(result)
+	require.NoError(s.T(), err)
+	hotIndexes.RequestResults = map[core.RecordID]map[core.RecordID][]byte{
+		*firstID: {request: encodedResult},
+	}
 
 	indexMock := recentstorage.NewRecentIndexStorageMock(s.T())
 	pendingMock := recentstorage.NewPendingStorageMock(s.T())
@@ -1049,6 +1136,10 @@ func (s *handlerSuite) TestMessageHandler_HandleHotRecords() {
 	require.Equal(s.T(), jetID, dropSizeHistory[0].JetID)
 	require.Equal(s.T(), core.FirstPulseNumber, int(dropSizeHistory[0].PulseNo))
 
+	savedResult, err := s.objectStorage.GetRequestResult(s.ctx, jetID, *firstID, request)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), result, savedResult)
+
 	indexMock.MinimockFinish()
 	pendingMock.MinimockFinish()
 }
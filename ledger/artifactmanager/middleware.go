@@ -27,6 +27,7 @@ import (
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/jetload"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/jet"
 )
@@ -38,6 +39,7 @@ type middleware struct {
 	messageBus     core.MessageBus
 	pulseStorage   core.PulseStorage
 	hotDataWaiter  HotDataWaiter
+	jetLoadMeter   jetload.Meter
 	conf           *configuration.Ledger
 	handler        *MessageHandler
 }
@@ -52,6 +54,7 @@ func newMiddleware(
 		messageBus:     h.Bus,
 		pulseStorage:   h.PulseStorage,
 		hotDataWaiter:  h.HotDataWaiter,
+		jetLoadMeter:   h.JetLoadMeter,
 		handler:        h,
 		conf:           h.conf,
 	}
@@ -124,6 +127,11 @@ func (m *middleware) checkJet(handler core.MessageHandler) core.MessageHandler {
 					return nil, errors.New("fetching children without child pointer is forbidden")
 				}
 				pulse = tm.FromChild.Pulse()
+			case *message.GetObjectHistory:
+				if tm.FromState == nil {
+					return nil, errors.New("fetching history without state pointer is forbidden")
+				}
+				pulse = tm.FromState.Pulse()
 			case *message.GetRequest:
 				pulse = tm.Request.Pulse()
 			}
@@ -174,6 +182,18 @@ func (m *middleware) checkJet(handler core.MessageHandler) core.MessageHandler {
 	}
 }
 
+// measureJetLoad registers handling time of request in jet load meter. Collected metrics are used to decide on split.
+func (m *middleware) measureJetLoad(handler core.MessageHandler) core.MessageHandler {
+	return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+		start := time.Now()
+		defer func() {
+			m.jetLoadMeter.Observe(jetFromContext(ctx), time.Since(start))
+		}()
+
+		return handler(ctx, parcel)
+	}
+}
+
 func (m *middleware) saveParcel(handler core.MessageHandler) core.MessageHandler {
 	return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
 		logger := inslogger.FromContext(ctx)
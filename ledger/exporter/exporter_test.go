@@ -191,3 +191,56 @@ func (s *exporterSuite) TestExporter_Export() {
 	_, err = s.exporter.Export(s.ctx, 60000, 2)
 	require.NoError(s.T(), err, "From-pulse should be smaller (or equal) current-pulse")
 }
+
+func (s *exporterSuite) TestExporter_ExportStream() {
+	for i := 1; i <= 3; i++ {
+		err := s.pulseTracker.AddPulse(
+			s.ctx,
+			core.Pulse{
+				PulseNumber:     core.FirstPulseNumber + 10*core.PulseNumber(i),
+				PrevPulseNumber: core.FirstPulseNumber + 10*core.PulseNumber(i-1),
+				PulseTimestamp:  10 * int64(i+1),
+			},
+		)
+		require.NoError(s.T(), err)
+	}
+	objectID, err := s.objectStorage.SetRecord(s.ctx, s.jetID, core.FirstPulseNumber+10, &record.ObjectActivateRecord{})
+	require.NoError(s.T(), err)
+
+	var chunks []*core.StorageExportChunk
+	collect := func(chunk *core.StorageExportChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	}
+
+	cursor, err := s.exporter.ExportStream(s.ctx, "", 10, collect)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 2, len(chunks))
+	assert.Equal(s.T(), cursor, chunks[1].Cursor)
+	data := chunks[1].Data.(*pulseData)
+	assert.Equal(s.T(), core.FirstPulseNumber+10, int(data.Pulse.PulseNumber))
+	_, ok := data.Records[base58.Encode(objectID[:])]
+	assert.True(s.T(), ok, "object not found by ID")
+
+	chunks = nil
+	next, err := s.exporter.ExportStream(s.ctx, "", 1, collect)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, len(chunks))
+	assert.Equal(s.T(), core.FirstPulseNumber, int(chunks[0].Data.(*pulseData).Pulse.PulseNumber))
+
+	chunks = nil
+	next, err = s.exporter.ExportStream(s.ctx, next, 10, collect)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, len(chunks))
+	assert.Equal(s.T(), core.FirstPulseNumber+10, int(chunks[0].Data.(*pulseData).Pulse.PulseNumber))
+	assert.Equal(s.T(), cursor, next)
+
+	chunks = nil
+	next, err = s.exporter.ExportStream(s.ctx, next, 10, collect)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), chunks)
+	assert.Equal(s.T(), cursor, next)
+
+	_, err = s.exporter.ExportStream(s.ctx, "bad cursor", 10, collect)
+	require.Error(s.T(), err)
+}
@@ -0,0 +1,134 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	base58 "github.com/jbenet/go-base58"
+	"github.com/pkg/errors"
+)
+
+const cursorSize = core.PulseNumberSize + core.RecordIDSize
+
+// ExportStream calls handler for every jet of at most size pulses, starting right after cursor.
+//
+// Jets of a pulse are exported in ascending order of their ids, so cursor (pulse and jet of the last exported chunk)
+// identifies position in the stream. Empty cursor means export from the first pulse.
+func (e *Exporter) ExportStream(
+	ctx context.Context, cursor string, size int, handler func(*core.StorageExportChunk) error,
+) (string, error) {
+	inslog := inslogger.FromContext(ctx)
+	inslog.Debugf("[ API ExportStream ] start")
+
+	fromPulse, lastJet, err := decodeCursor(cursor)
+	if err != nil {
+		return "", errors.Wrap(err, "[ ExportStream ] failed to decode cursor")
+	}
+
+	jetIDs, err := e.JetStorage.GetJets(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "[ ExportStream ] failed to get jets")
+	}
+	jets := sortedJets(jetIDs)
+
+	currentPulse, err := e.PulseStorage.Current(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "[ ExportStream ] failed to get current pulse data")
+	}
+
+	resume := cursor != ""
+	if !resume {
+		fromPulse = core.GenesisPulse.PulseNumber
+	}
+	iterPulse := &fromPulse
+
+	counter := 0
+	for iterPulse != nil && counter < size {
+		pulse, err := e.PulseTracker.GetPulse(ctx, *iterPulse)
+		if err != nil {
+			return "", errors.Wrap(err, "[ ExportStream ] failed to fetch pulse data")
+		}
+
+		// Same as for Export, data of the latest pulses is not persisted yet.
+		if pulse.Pulse.PulseNumber >= (currentPulse.PrevPulseNumber - core.PulseNumber(e.cfg.ExportLag)) {
+			break
+		}
+
+		exported := false
+		for _, jetID := range jets {
+			// Skip jets exported before the cursor.
+			if resume && pulse.Pulse.PulseNumber == fromPulse && bytes.Compare(jetID[:], lastJet[:]) <= 0 {
+				continue
+			}
+
+			data, err := e.exportPulse(ctx, jetID, &pulse.Pulse)
+			if err != nil {
+				return "", errors.Wrap(err, "[ ExportStream ]")
+			}
+
+			next := encodeCursor(pulse.Pulse.PulseNumber, jetID)
+			err = handler(&core.StorageExportChunk{Data: data, Cursor: next})
+			if err != nil {
+				return "", errors.Wrap(err, "[ ExportStream ] handler failed")
+			}
+			cursor = next
+			exported = true
+		}
+		if exported {
+			counter++
+		}
+
+		iterPulse = pulse.Next
+	}
+
+	return cursor, nil
+}
+
+func sortedJets(set jet.IDSet) []core.RecordID {
+	jets := make([]core.RecordID, 0, len(set))
+	for id := range set {
+		jets = append(jets, id)
+	}
+	sort.Slice(jets, func(i, j int) bool {
+		return bytes.Compare(jets[i][:], jets[j][:]) < 0
+	})
+	return jets
+}
+
+func encodeCursor(pulse core.PulseNumber, jetID core.RecordID) string {
+	return base58.Encode(append(pulse.Bytes(), jetID[:]...))
+}
+
+func decodeCursor(cursor string) (core.PulseNumber, core.RecordID, error) {
+	var jetID core.RecordID
+	if cursor == "" {
+		return 0, jetID, nil
+	}
+
+	buf := base58.Decode(cursor)
+	if len(buf) != cursorSize {
+		return 0, jetID, errors.New("bad cursor size")
+	}
+	copy(jetID[:], buf[core.PulseNumberSize:])
+	return core.NewPulseNumber(buf[:core.PulseNumberSize]), jetID, nil
+}
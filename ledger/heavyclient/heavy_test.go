@@ -32,8 +32,10 @@ import (
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/eventbus"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger/artifactmanager"
+	"github.com/insolar/insolar/ledger/jetload"
 	"github.com/insolar/insolar/ledger/pulsemanager"
 	"github.com/insolar/insolar/ledger/recentstorage"
 	"github.com/insolar/insolar/ledger/storage"
@@ -263,6 +265,9 @@ func sendToHeavy(s *heavySuite, withretry bool) {
 	pm.PulseTracker = s.pulseTracker
 	pm.ReplicaStorage = s.replicaStorage
 	pm.StorageCleaner = s.storageCleaner
+	pm.EventBus = eventbus.NewEventBus()
+	pm.JetLoadMeter = jetload.NewMeter()
+	pm.SplitPolicy = jetload.NewDefaultPolicy(pmconf)
 	pm.ObjectStorage = s.objectStorage
 	pm.DropStorage = s.dropStorage
 
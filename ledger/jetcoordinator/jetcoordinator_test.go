@@ -127,6 +127,66 @@ func (s *jetCoordinatorSuite) TestJetCoordinator_QueryRole() {
 	assert.Equal(s.T(), []core.RecordRef{nodeRefs[16], nodeRefs[21], nodeRefs[78]}, selected)
 }
 
+func (s *jetCoordinatorSuite) TestJetCoordinator_LightExecutorForObject_AcrossMerge() {
+	pulse := core.PulseNumber(core.FirstPulseNumber + 1)
+	mergePulse := pulse + 1
+	for _, pn := range []core.PulseNumber{pulse, mergePulse} {
+		err := s.pulseTracker.AddPulse(s.ctx, core.Pulse{PulseNumber: pn, Entropy: core.Entropy{byte(pn)}})
+		require.NoError(s.T(), err)
+		var nodes []core.Node
+		for i := 0; i < 100; i++ {
+			ref := *core.NewRecordRef(core.DomainID, *core.NewRecordID(0, []byte{byte(i)}))
+			nodes = append(nodes, storage.Node{FID: ref, FRole: core.StaticRoleLightMaterial})
+		}
+		err = s.nodeStorages.SetActiveNodes(pn, nodes)
+		require.NoError(s.T(), err)
+	}
+	s.pulseStorage.Set(&core.Pulse{PulseNumber: mergePulse, Entropy: core.Entropy{byte(mergePulse)}})
+
+	root := jet.NewID(0, nil)
+	left, right, err := s.jetStorage.SplitJetTree(s.ctx, pulse, *root)
+	require.NoError(s.T(), err)
+	err = s.jetStorage.UpdateJetTree(s.ctx, pulse, true, *left, *right)
+	require.NoError(s.T(), err)
+	objID := core.NewRecordID(pulse, []byte{0xF0})
+
+	expected, err := s.coordinator.LightExecutorForJet(s.ctx, *right, pulse)
+	require.NoError(s.T(), err)
+	executor, err := s.coordinator.LightExecutorForObject(s.ctx, *objID, pulse)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), expected, executor)
+
+	// Node that hasn't seen the merge yet routes to the stale jet.
+	_, err = s.jetStorage.CloneJetTree(s.ctx, pulse, mergePulse)
+	require.NoError(s.T(), err)
+	expected, err = s.coordinator.LightExecutorForJet(s.ctx, *right, mergePulse)
+	require.NoError(s.T(), err)
+	executor, err = s.coordinator.LightExecutorForObject(s.ctx, *objID, mergePulse)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), expected, executor)
+
+	// Actual merged jet is learned from other nodes.
+	err = s.jetStorage.UpdateJetTree(s.ctx, mergePulse, true, *root)
+	require.NoError(s.T(), err)
+	tree, err := s.jetStorage.GetJetTree(s.ctx, mergePulse)
+	require.NoError(s.T(), err)
+	jetID, actual := tree.Find(*objID)
+	assert.Equal(s.T(), root, jetID)
+	assert.True(s.T(), actual)
+	expected, err = s.coordinator.LightExecutorForJet(s.ctx, *root, mergePulse)
+	require.NoError(s.T(), err)
+	executor, err = s.coordinator.LightExecutorForObject(s.ctx, *objID, mergePulse)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), expected, executor)
+
+	// Previous pulse keeps routing to the split jets.
+	expected, err = s.coordinator.LightExecutorForJet(s.ctx, *right, pulse)
+	require.NoError(s.T(), err)
+	executor, err = s.coordinator.LightExecutorForObject(s.ctx, *objID, pulse)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), expected, executor)
+}
+
 func TestJetCoordinator_Me(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -0,0 +1,83 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jetload
+
+import (
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+)
+
+// Meter collects number of handled requests and their latency per jet.
+type Meter interface {
+	// Observe registers request to provided jet handled in provided time.
+	Observe(jetID core.RecordID, latency time.Duration)
+	// Requests returns number of registered requests and their average latency for provided jet.
+	Requests(jetID core.RecordID) (int, time.Duration)
+	// Reset clears all collected metrics.
+	Reset()
+}
+
+type counter struct {
+	requests int
+	latency  time.Duration
+}
+
+type meter struct {
+	lock     sync.RWMutex
+	counters map[core.RecordID]*counter
+}
+
+// NewMeter creates new Meter instance.
+func NewMeter() Meter {
+	return &meter{counters: map[core.RecordID]*counter{}}
+}
+
+// Observe registers request to provided jet handled in provided time.
+func (m *meter) Observe(jetID core.RecordID, latency time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c, ok := m.counters[jetID]
+	if !ok {
+		c = &counter{}
+		m.counters[jetID] = c
+	}
+	c.requests++
+	c.latency += latency
+}
+
+// Requests returns number of registered requests and their average latency for provided jet.
+func (m *meter) Requests(jetID core.RecordID) (int, time.Duration) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	c, ok := m.counters[jetID]
+	if !ok || c.requests == 0 {
+		return 0, 0
+	}
+	return c.requests, c.latency / time.Duration(c.requests)
+}
+
+// Reset clears all collected metrics.
+func (m *meter) Reset() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.counters = map[core.RecordID]*counter{}
+}
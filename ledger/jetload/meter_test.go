@@ -0,0 +1,48 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jetload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeter(t *testing.T) {
+	m := NewMeter()
+	first := *jet.NewID(1, []byte{0x80})
+	second := *jet.NewID(1, nil)
+
+	m.Observe(first, time.Millisecond)
+	m.Observe(first, 3*time.Millisecond)
+	m.Observe(second, time.Second)
+
+	requests, latency := m.Requests(first)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 2*time.Millisecond, latency)
+
+	requests, latency = m.Requests(second)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, time.Second, latency)
+
+	m.Reset()
+	requests, latency = m.Requests(first)
+	assert.Equal(t, 0, requests)
+	assert.Equal(t, time.Duration(0), latency)
+}
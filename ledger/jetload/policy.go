@@ -0,0 +1,103 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jetload
+
+import (
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+)
+
+// Policy decides if jet should be split by its load.
+type Policy interface {
+	ShouldSplit(load core.JetLoad) bool
+}
+
+// DropSizePolicy splits jets with big drops.
+type DropSizePolicy struct {
+	Threshold uint64
+}
+
+// ShouldSplit returns true if the last drop of the jet exceeds threshold.
+func (p *DropSizePolicy) ShouldSplit(load core.JetLoad) bool {
+	return load.DropSize > p.Threshold
+}
+
+// RequestsPolicy splits jets receiving too many requests.
+type RequestsPolicy struct {
+	Threshold int
+}
+
+// ShouldSplit returns true if number of requests to the jet exceeds threshold.
+func (p *RequestsPolicy) ShouldSplit(load core.JetLoad) bool {
+	return load.Requests > p.Threshold
+}
+
+// PendingPolicy splits jets with too many not finished requests.
+type PendingPolicy struct {
+	Threshold int
+}
+
+// ShouldSplit returns true if number of pending requests in the jet exceeds threshold.
+func (p *PendingPolicy) ShouldSplit(load core.JetLoad) bool {
+	return load.PendingRequests > p.Threshold
+}
+
+// LatencyPolicy splits jets which requests are handled too slowly.
+type LatencyPolicy struct {
+	Threshold time.Duration
+}
+
+// ShouldSplit returns true if average request latency in the jet exceeds threshold.
+func (p *LatencyPolicy) ShouldSplit(load core.JetLoad) bool {
+	return load.Requests > 0 && load.Latency > p.Threshold
+}
+
+// CompositePolicy splits jet if any of nested policies decides to split it.
+type CompositePolicy struct {
+	Policies []Policy
+}
+
+// ShouldSplit returns true if any of nested policies returns true.
+func (p *CompositePolicy) ShouldSplit(load core.JetLoad) bool {
+	for _, policy := range p.Policies {
+		if policy.ShouldSplit(load) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDefaultPolicy creates policy checking all load metrics with thresholds from configuration. Checks with zero
+// threshold are skipped.
+func NewDefaultPolicy(conf configuration.PulseManager) *CompositePolicy {
+	policy := &CompositePolicy{}
+	if conf.SplitThreshold > 0 {
+		policy.Policies = append(policy.Policies, &DropSizePolicy{Threshold: conf.SplitThreshold})
+	}
+	if conf.SplitRequestsThreshold > 0 {
+		policy.Policies = append(policy.Policies, &RequestsPolicy{Threshold: conf.SplitRequestsThreshold})
+	}
+	if conf.SplitPendingThreshold > 0 {
+		policy.Policies = append(policy.Policies, &PendingPolicy{Threshold: conf.SplitPendingThreshold})
+	}
+	if conf.SplitLatencyThreshold > 0 {
+		policy.Policies = append(policy.Policies, &LatencyPolicy{Threshold: conf.SplitLatencyThreshold})
+	}
+	return policy
+}
@@ -0,0 +1,53 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package jetload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDefaultPolicy(t *testing.T) {
+	policy := NewDefaultPolicy(configuration.PulseManager{
+		SplitThreshold:         100,
+		SplitRequestsThreshold: 10,
+		SplitPendingThreshold:  5,
+	})
+	assert.Len(t, policy.Policies, 3)
+
+	assert.False(t, policy.ShouldSplit(core.JetLoad{DropSize: 100, Requests: 10, PendingRequests: 5, Latency: time.Hour}))
+	assert.True(t, policy.ShouldSplit(core.JetLoad{DropSize: 101}))
+	assert.True(t, policy.ShouldSplit(core.JetLoad{Requests: 11}))
+	assert.True(t, policy.ShouldSplit(core.JetLoad{PendingRequests: 6}))
+}
+
+func TestLatencyPolicy(t *testing.T) {
+	policy := &LatencyPolicy{Threshold: time.Millisecond}
+
+	assert.False(t, policy.ShouldSplit(core.JetLoad{Latency: time.Second}))
+	assert.False(t, policy.ShouldSplit(core.JetLoad{Requests: 1, Latency: time.Millisecond}))
+	assert.True(t, policy.ShouldSplit(core.JetLoad{Requests: 1, Latency: time.Second}))
+}
+
+func TestCompositePolicy_Empty(t *testing.T) {
+	policy := &CompositePolicy{}
+	assert.False(t, policy.ShouldSplit(core.JetLoad{DropSize: 1 << 40, Requests: 1 << 20}))
+}
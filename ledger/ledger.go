@@ -27,6 +27,7 @@ import (
 	"github.com/insolar/insolar/ledger/exporter"
 	"github.com/insolar/insolar/ledger/heavyserver"
 	"github.com/insolar/insolar/ledger/jetcoordinator"
+	"github.com/insolar/insolar/ledger/jetload"
 	"github.com/insolar/insolar/ledger/localstorage"
 	"github.com/insolar/insolar/ledger/pulsemanager"
 	"github.com/insolar/insolar/ledger/storage"
@@ -110,11 +111,14 @@ func GetLedgerComponents(conf configuration.Ledger, certificate core.Certificate
 		artifactmanager.NewHotDataWaiterConcrete(),
 		artifactmanager.NewArtifactManger(),
 		jetcoordinator.NewJetCoordinator(conf.LightChainLimit),
+		jetload.NewMeter(),
+		jetload.NewDefaultPolicy(conf.PulseManager),
 		pulsemanager.NewPulseManager(conf),
 		artifactmanager.NewMessageHandler(&conf, certificate),
 		localstorage.NewLocalStorage(db),
 		heavyserver.NewSync(db),
 		exporter.NewExporter(conf.Exporter),
+		storage.NewBackuper(conf.Storage),
 	}
 }
 
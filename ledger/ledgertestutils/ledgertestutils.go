@@ -24,9 +24,11 @@ import (
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/eventbus"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/ledger"
 	"github.com/insolar/insolar/ledger/artifactmanager"
+	"github.com/insolar/insolar/ledger/jetload"
 	"github.com/insolar/insolar/ledger/localstorage"
 	"github.com/insolar/insolar/ledger/pulsemanager"
 	"github.com/insolar/insolar/ledger/recentstorage"
@@ -66,6 +68,8 @@ func TmpLedger(t *testing.T, dir string, handlersRole core.StaticRole, c core.Co
 	ds := storage.NewDropStorage(10)
 	rs := storage.NewReplicaStorage()
 	cl := storage.NewCleaner()
+	eb := eventbus.NewEventBus()
+	jlm := jetload.NewMeter()
 
 	am := artifactmanager.NewArtifactManger()
 	am.PlatformCryptographyScheme = pcs
@@ -109,6 +113,7 @@ func TmpLedger(t *testing.T, dir string, handlersRole core.StaticRole, c core.Co
 	handler.DBContext = db
 	handler.ObjectStorage = os
 	handler.DropStorage = ds
+	handler.JetLoadMeter = jlm
 
 	handler.PlatformCryptographyScheme = pcs
 	handler.JetCoordinator = jc
@@ -166,6 +171,9 @@ func TmpLedger(t *testing.T, dir string, handlersRole core.StaticRole, c core.Co
 	pm.PulseTracker = pt
 	pm.ReplicaStorage = rs
 	pm.StorageCleaner = cl
+	pm.EventBus = eb
+	pm.JetLoadMeter = jlm
+	pm.SplitPolicy = jetload.NewDefaultPolicy(conf.PulseManager)
 
 	hdw := artifactmanager.NewHotDataWaiterConcrete()
 
@@ -17,8 +17,8 @@
 package pulsemanager
 
 import (
+	"bytes"
 	"context"
-	"math/rand"
 	"sync"
 	"time"
 
@@ -35,6 +35,7 @@ import (
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/ledger/artifactmanager"
 	"github.com/insolar/insolar/ledger/heavyclient"
+	"github.com/insolar/insolar/ledger/jetload"
 	"github.com/insolar/insolar/ledger/recentstorage"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/index"
@@ -67,6 +68,9 @@ type PulseManager struct {
 	ReplicaStorage             storage.ReplicaStorage          `inject:""`
 	DBContext                  storage.DBContext               `inject:""`
 	StorageCleaner             storage.Cleaner                 `inject:""`
+	EventBus                   core.EventBus                   `inject:""`
+	JetLoadMeter               jetload.Meter                   `inject:""`
+	SplitPolicy                jetload.Policy                  `inject:""`
 
 	// TODO: move clients pool to component - @nordicdyno - 18.Dec.2018
 	syncClientsPool *heavyclient.Pool
@@ -78,6 +82,10 @@ type PulseManager struct {
 	// saves PM stopping mode
 	stopped bool
 
+	// forcedSplits holds jets requested to split manually on the next pulse.
+	forcedSplits     jet.IDSet
+	forcedSplitsLock sync.Mutex
+
 	// stores pulse manager options
 	options pmOptions
 }
@@ -87,12 +95,17 @@ type jetInfo struct {
 	mineNext bool
 	left     *jetInfo
 	right    *jetInfo
+	// merged is set when left and right jets were merged into this one. Otherwise left and right are set on split.
+	merged bool
 }
 
 // TODO: @andreyromancev. 15.01.19. Just store ledger configuration in PM. This is not required.
 type pmOptions struct {
 	enableSync            bool
 	splitThreshold        uint64
+	mergeThreshold        uint64
+	mergeAfterPulses      int
+	splitCooldownPulses   int
 	dropHistorySize       int
 	storeLightPulses      int
 	heavySyncMessageLimit int
@@ -105,9 +118,13 @@ func NewPulseManager(conf configuration.Ledger) *PulseManager {
 
 	pm := &PulseManager{
 		currentPulse: *core.GenesisPulse,
+		forcedSplits: jet.IDSet{},
 		options: pmOptions{
 			enableSync:            pmconf.HeavySyncEnabled,
 			splitThreshold:        pmconf.SplitThreshold,
+			mergeThreshold:        pmconf.MergeThreshold,
+			mergeAfterPulses:      pmconf.MergeAfterPulses,
+			splitCooldownPulses:   pmconf.SplitCooldownPulses,
 			dropHistorySize:       conf.JetSizesHistoryDepth,
 			storeLightPulses:      conf.LightChainLimit,
 			heavySyncMessageLimit: pmconf.HeavySyncMessageLimit,
@@ -132,10 +149,21 @@ func (m *PulseManager) processEndPulse(
 		info := i
 
 		g.Go(func() error {
-			drop, dropSerialized, _, err := m.createDrop(ctx, info.id, prevPulseNumber, currentPulse.PulseNumber)
-			logger.Debugf("[jet]: %v create drop. Pulse: %v, Error: %s", info.id.DebugString(), currentPulse.PulseNumber, err)
-			if err != nil {
-				return errors.Wrapf(err, "create drop on pulse %v failed", currentPulse.PulseNumber)
+			dropJets := []core.RecordID{info.id}
+			if info.merged {
+				// Merged jets were executed in the current pulse. Left jet shares prefix with the parent, so its drop
+				// is created last and continues the parent's chain.
+				dropJets = []core.RecordID{info.right.id, info.left.id}
+			}
+			var drop *jet.JetDrop
+			var dropSerialized []byte
+			for _, dropJet := range dropJets {
+				var err error
+				drop, dropSerialized, _, err = m.createDrop(ctx, dropJet, prevPulseNumber, currentPulse.PulseNumber)
+				logger.Debugf("[jet]: %v create drop. Pulse: %v, Error: %s", dropJet.DebugString(), currentPulse.PulseNumber, err)
+				if err != nil {
+					return errors.Wrapf(err, "create drop on pulse %v failed", currentPulse.PulseNumber)
+				}
 			}
 
 			logger := inslogger.FromContext(ctx)
@@ -160,14 +188,14 @@ func (m *PulseManager) processEndPulse(
 				logger.Debugf("[jet]: %v send hot. Pulse: %v, DropJet: %v, Success", jetID.DebugString(), currentPulse.PulseNumber, msg.DropJet.DebugString())
 			}
 
-			if info.left == nil && info.right == nil {
+			if info.left == nil && info.right == nil || info.merged {
 				msg, err := m.getExecutorHotData(
 					ctx, info.id, newPulse.PulseNumber, drop, dropSerialized,
 				)
 				if err != nil {
 					return errors.Wrapf(err, "getExecutorData failed for jet id %v", info.id)
 				}
-				// No split happened.
+				// No split happened. Hot data of merged jets is already consolidated in the parent.
 				if !info.mineNext {
 					go sender(*msg, info.id)
 				}
@@ -377,6 +405,13 @@ func (m *PulseManager) getExecutorHotData(
 
 	recentObjects := map[core.RecordID]*message.HotIndex{}
 	pendingRequests := map[core.RecordID]map[core.RecordID]struct{}{}
+	requestResults := map[core.RecordID]map[core.RecordID][]byte{}
+
+	// Results synced to heavy are fetched from it, so only newer ones are passed.
+	syncedPulse, err := m.ReplicaStorage.GetHeavySyncedPulse(ctx, jetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ getExecutorHotData ] Can't GetHeavySyncedPulse")
+	}
 
 	for id, ttl := range recentObjectsIds {
 		lifeline, err := m.ObjectStorage.GetObjectIndex(ctx, jetID, &id, false)
@@ -393,6 +428,25 @@ func (m *PulseManager) getExecutorHotData(
 			TTL:   ttl,
 			Index: encoded,
 		}
+
+		err = m.ObjectStorage.IterateRequestResults(ctx, jetID, id,
+			func(request core.RecordID, result *index.RequestResult) error {
+				if result.Result.Pulse() <= syncedPulse {
+					return nil
+				}
+				encoded, err := index.EncodeRequestResult(result)
+				if err != nil {
+					return err
+				}
+				if _, ok := requestResults[id]; !ok {
+					requestResults[id] = map[core.RecordID][]byte{}
+				}
+				requestResults[id][request] = encoded
+				return nil
+			})
+		if err != nil {
+			logger.Error(err)
+		}
 	}
 
 	requestCount := 0
@@ -423,14 +477,12 @@ func (m *PulseManager) getExecutorHotData(
 		PulseNumber:        pulse,
 		RecentObjects:      recentObjects,
 		PendingRequests:    pendingRequests,
+		RequestResults:     requestResults,
 		JetDropSizeHistory: dropSizeHistory,
 	}
 	return msg, nil
 }
 
-// TODO: @andreyromancev. 12.01.19. Remove when dynamic split is working.
-var splitCount = 5
-
 func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse core.PulseNumber) ([]jetInfo, error) {
 	ctx, span := instracer.StartSpan(ctx, "jets.process")
 	defer span.End()
@@ -446,10 +498,19 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 
 	var results []jetInfo
 	jetIDs := tree.LeafIDs()
+	leaves := jet.IDSet{}
+	for _, jetID := range jetIDs {
+		leaves[jetID] = struct{}{}
+	}
+	merged := jet.IDSet{}
 	me := m.JetCoordinator.Me()
 	logger := inslogger.FromContext(ctx)
-	indexToSplit := rand.Intn(len(jetIDs))
-	for i, jetID := range jetIDs {
+	// Metrics are collected for a single pulse.
+	defer m.resetJetLoads()
+	for _, jetID := range jetIDs {
+		if merged.Has(jetID) {
+			continue
+		}
 		executor, err := m.JetCoordinator.LightExecutorForJet(ctx, jetID, currentPulse)
 		if err != nil {
 			return nil, err
@@ -460,10 +521,37 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 			continue
 		}
 
-		info := jetInfo{id: jetID}
-		if indexToSplit == i && splitCount > 0 {
-			splitCount--
+		sibling, err := m.mergeCandidate(ctx, jetID, leaves, currentPulse)
+		if err != nil {
+			return nil, err
+		}
+		if sibling != nil {
+			merged[*sibling] = struct{}{}
+			info, err := m.mergeJets(ctx, jetID, *sibling, newPulse)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, *info)
+			continue
+		}
 
+		split, err := m.shouldSplit(ctx, jetID, currentPulse)
+		if err != nil {
+			return nil, err
+		}
+		if split {
+			cooldown, err := m.inSplitCooldown(ctx, jetID)
+			if err != nil {
+				return nil, err
+			}
+			if cooldown {
+				logger.Debugf("[jet]: %v split is postponed, jet is too young. Pulse: %v", jetID.DebugString(), currentPulse)
+				split = false
+			}
+		}
+
+		info := jetInfo{id: jetID}
+		if split {
 			leftJetID, rightJetID, err := m.JetStorage.SplitJetTree(
 				ctx,
 				newPulse,
@@ -481,6 +569,13 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to update tree")
 			}
+			// Children may have stale history from the time before merge.
+			for _, id := range []core.RecordID{*leftJetID, *rightJetID} {
+				err = m.DropStorage.SetDropSizeHistory(ctx, id, jet.DropSizeHistory{})
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to reset drop size history")
+				}
+			}
 
 			info.left = &jetInfo{id: *leftJetID}
 			info.right = &jetInfo{id: *rightJetID}
@@ -490,7 +585,7 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 			}
 			if *nextLeftExecutor == me {
 				info.left.mineNext = true
-				err := m.rewriteHotData(ctx, jetID, *leftJetID)
+				err := m.rewriteHotData(ctx, *leftJetID, jetID)
 				logger.Debugf("[jet]: %v rewrite hot left. Pulse: %v, Error: %s", info.left.id.DebugString(), currentPulse, err)
 				if err != nil {
 					return nil, err
@@ -502,7 +597,7 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 			}
 			if *nextRightExecutor == me {
 				info.right.mineNext = true
-				err := m.rewriteHotData(ctx, jetID, *rightJetID)
+				err := m.rewriteHotData(ctx, *rightJetID, jetID)
 				logger.Debugf("[jet]: %v rewrite hot right. Pulse: %v, Error: %s", info.right.id.DebugString(), currentPulse, err)
 				if err != nil {
 					return nil, err
@@ -536,23 +631,307 @@ func (m *PulseManager) processJets(ctx context.Context, currentPulse, newPulse c
 	return results, nil
 }
 
-func (m *PulseManager) rewriteHotData(ctx context.Context, fromJetID, toJetID core.RecordID) error {
-	indexStorage := m.RecentStorageProvider.GetIndexStorage(ctx, fromJetID)
+// mergeCandidate returns sibling of provided jet if current node executes both of them and their drops were small
+// enough for the last pulses. Otherwise nil is returned.
+func (m *PulseManager) mergeCandidate(
+	ctx context.Context, jetID core.RecordID, leaves jet.IDSet, currentPulse core.PulseNumber,
+) (*core.RecordID, error) {
+	if m.options.mergeThreshold == 0 {
+		return nil, nil
+	}
+	sibling := jet.Sibling(jetID)
+	if sibling == jetID || !leaves.Has(sibling) {
+		return nil, nil
+	}
+
+	executor, err := m.JetCoordinator.LightExecutorForJet(ctx, sibling, currentPulse)
+	if err != nil {
+		return nil, err
+	}
+	if *executor != m.JetCoordinator.Me() {
+		return nil, nil
+	}
+
+	for _, id := range []core.RecordID{jetID, sibling} {
+		small, err := m.hasSmallDrops(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !small {
+			return nil, nil
+		}
+		// Small drops don't mean low load, jet could be busy with requests.
+		split, err := m.shouldSplit(ctx, id, currentPulse)
+		if err != nil {
+			return nil, err
+		}
+		if split {
+			return nil, nil
+		}
+	}
+	return &sibling, nil
+}
+
+// shouldSplit checks load of provided jet with split policy.
+func (m *PulseManager) shouldSplit(ctx context.Context, jetID core.RecordID, pulse core.PulseNumber) (bool, error) {
+	load, err := m.jetLoad(ctx, jetID, pulse)
+	if err != nil {
+		return false, err
+	}
+	return load.ForceSplit || m.SplitPolicy.ShouldSplit(*load), nil
+}
+
+// inSplitCooldown checks if the jet was created by split or merge too recently to be split by load. Drop size history
+// is reset on split and merge, so its length is the jet age. Forced splits are not postponed.
+func (m *PulseManager) inSplitCooldown(ctx context.Context, jetID core.RecordID) (bool, error) {
+	if m.options.mergeThreshold == 0 || m.options.splitCooldownPulses < 1 {
+		return false, nil
+	}
+
+	m.forcedSplitsLock.Lock()
+	forced := m.forcedSplits.Has(jetID)
+	m.forcedSplitsLock.Unlock()
+	if forced {
+		return false, nil
+	}
+
+	history, err := m.DropStorage.GetDropSizeHistory(ctx, jetID)
+	if err != nil {
+		return false, errors.Wrap(err, "[ inSplitCooldown ] Can't GetDropSizeHistory")
+	}
+	pulses := m.options.splitCooldownPulses
+	if m.options.dropHistorySize > 0 && pulses > m.options.dropHistorySize {
+		pulses = m.options.dropHistorySize
+	}
+	return len(history) < pulses, nil
+}
+
+// jetLoad collects load metrics of provided jet for the pulse.
+func (m *PulseManager) jetLoad(ctx context.Context, jetID core.RecordID, pulse core.PulseNumber) (*core.JetLoad, error) {
+	load := &core.JetLoad{JetID: jetID, Pulse: pulse}
+	load.Requests, load.Latency = m.JetLoadMeter.Requests(jetID)
+
+	for _, requests := range m.RecentStorageProvider.GetPendingStorage(ctx, jetID).GetRequests() {
+		load.PendingRequests += len(requests)
+	}
+
+	history, err := m.DropStorage.GetDropSizeHistory(ctx, jetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ jetLoad ] Can't GetDropSizeHistory")
+	}
+	if len(history) > 0 {
+		load.DropSize = history[len(history)-1].DropSize
+	}
+
+	m.forcedSplitsLock.Lock()
+	load.ForceSplit = m.forcedSplits.Has(jetID)
+	m.forcedSplitsLock.Unlock()
+
+	return load, nil
+}
+
+func (m *PulseManager) resetJetLoads() {
+	m.JetLoadMeter.Reset()
+
+	m.forcedSplitsLock.Lock()
+	m.forcedSplits = jet.IDSet{}
+	m.forcedSplitsLock.Unlock()
+}
+
+// JetLoads returns load of jets executed by current node in current pulse.
+func (m *PulseManager) JetLoads(ctx context.Context) ([]core.JetLoad, error) {
+	if m.NodeNet.GetOrigin().Role() != core.StaticRoleLightMaterial {
+		return nil, errors.New("[ JetLoads ] jets are executed only by light material nodes")
+	}
+
+	m.setLock.RLock()
+	defer m.setLock.RUnlock()
+
+	pulse := m.currentPulse.PulseNumber
+	jetIDs, err := m.executedJets(ctx, pulse)
+	if err != nil {
+		return nil, err
+	}
 
-	for id := range indexStorage.GetObjects() {
-		idx, err := m.ObjectStorage.GetObjectIndex(ctx, fromJetID, &id, false)
+	loads := make([]core.JetLoad, 0, len(jetIDs))
+	for _, jetID := range jetIDs {
+		load, err := m.jetLoad(ctx, jetID, pulse)
 		if err != nil {
-			return errors.Wrap(err, "failed to rewrite index")
+			return nil, err
+		}
+		loads = append(loads, *load)
+	}
+	return loads, nil
+}
+
+// ForceSplit marks jet to be split on the next pulse. Jet should be executed by current node in current pulse.
+func (m *PulseManager) ForceSplit(ctx context.Context, jetID core.RecordID) error {
+	if m.NodeNet.GetOrigin().Role() != core.StaticRoleLightMaterial {
+		return errors.New("[ ForceSplit ] jets are executed only by light material nodes")
+	}
+
+	m.setLock.RLock()
+	defer m.setLock.RUnlock()
+
+	jetIDs, err := m.executedJets(ctx, m.currentPulse.PulseNumber)
+	if err != nil {
+		return err
+	}
+	for _, id := range jetIDs {
+		if id == jetID {
+			m.forcedSplitsLock.Lock()
+			m.forcedSplits[jetID] = struct{}{}
+			m.forcedSplitsLock.Unlock()
+			return nil
 		}
-		err = m.ObjectStorage.SetObjectIndex(ctx, toJetID, &id, idx)
+	}
+	return errors.Errorf("[ ForceSplit ] jet %v is not executed by current node", jetID.DebugString())
+}
+
+// executedJets returns leaf jets of the pulse executed by current node.
+func (m *PulseManager) executedJets(ctx context.Context, pulse core.PulseNumber) ([]core.RecordID, error) {
+	tree, err := m.JetStorage.GetJetTree(ctx, pulse)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ executedJets ] Can't GetJetTree")
+	}
+
+	me := m.JetCoordinator.Me()
+	var result []core.RecordID
+	for _, jetID := range tree.LeafIDs() {
+		executor, err := m.JetCoordinator.LightExecutorForJet(ctx, jetID, pulse)
 		if err != nil {
-			return errors.Wrap(err, "failed to rewrite index")
+			return nil, errors.Wrap(err, "[ executedJets ] Can't calculate executor")
+		}
+		if *executor == me {
+			result = append(result, jetID)
+		}
+	}
+	return result, nil
+}
+
+// hasSmallDrops checks if the last drops of the jet are smaller than merge threshold.
+func (m *PulseManager) hasSmallDrops(ctx context.Context, jetID core.RecordID) (bool, error) {
+	history, err := m.DropStorage.GetDropSizeHistory(ctx, jetID)
+	if err != nil {
+		return false, errors.Wrap(err, "[ hasSmallDrops ] Can't GetDropSizeHistory")
+	}
+
+	pulses := m.options.mergeAfterPulses
+	if pulses < 1 {
+		pulses = 1
+	}
+	if len(history) < pulses {
+		return false, nil
+	}
+	for _, size := range history[len(history)-pulses:] {
+		if size.DropSize >= m.options.mergeThreshold {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// mergeJets collapses provided sibling jets into their parent in the new pulse tree and consolidates their hot data
+// in the parent.
+func (m *PulseManager) mergeJets(
+	ctx context.Context, jetID, sibling core.RecordID, newPulse core.PulseNumber,
+) (*jetInfo, error) {
+	leftJetID, rightJetID := jetID, sibling
+	if bytes.Compare(leftJetID[:], rightJetID[:]) > 0 {
+		leftJetID, rightJetID = rightJetID, leftJetID
+	}
+
+	// Hot data is consolidated even if parent is not ours, because it will be sent to the next executor.
+	err := m.rewriteHotData(ctx, jet.Parent(leftJetID), leftJetID, rightJetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to consolidate hot data")
+	}
+	parentJetID, err := m.JetStorage.MergeJetTree(ctx, newPulse, leftJetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to merge jet tree")
+	}
+	err = m.JetStorage.AddJets(ctx, *parentJetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add jets")
+	}
+	// Set actual because we are the last executor for both merged jets.
+	err = m.JetStorage.UpdateJetTree(ctx, newPulse, true, *parentJetID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update tree")
+	}
+	// Parent may have stale history from the time before split.
+	err = m.DropStorage.SetDropSizeHistory(ctx, *parentJetID, jet.DropSizeHistory{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reset drop size history")
+	}
+
+	info := &jetInfo{
+		id:     *parentJetID,
+		merged: true,
+		left:   &jetInfo{id: leftJetID},
+		right:  &jetInfo{id: rightJetID},
+	}
+	nextExecutor, err := m.JetCoordinator.LightExecutorForJet(ctx, *parentJetID, newPulse)
+	if err != nil {
+		return nil, err
+	}
+	if *nextExecutor == m.JetCoordinator.Me() {
+		info.mineNext = true
+	}
+
+	inslogger.FromContext(ctx).Debugf(
+		"MERGE HAPPENED parent: %v, left: %v, right: %v",
+		parentJetID.DebugString(),
+		leftJetID.DebugString(),
+		rightJetID.DebugString(),
+	)
+	return info, nil
+}
+
+// rewriteHotData copies hot indexes, their request results and pending requests of provided jets to another jet. Data of several jets is
+// consolidated on merge.
+func (m *PulseManager) rewriteHotData(ctx context.Context, toJetID core.RecordID, fromJetIDs ...core.RecordID) error {
+	for _, fromJetID := range fromJetIDs {
+		indexStorage := m.RecentStorageProvider.GetIndexStorage(ctx, fromJetID)
+
+		for id := range indexStorage.GetObjects() {
+			idx, err := m.ObjectStorage.GetObjectIndex(ctx, fromJetID, &id, false)
+			if err != nil {
+				return errors.Wrap(err, "failed to rewrite index")
+			}
+			err = m.ObjectStorage.SetObjectIndex(ctx, toJetID, &id, idx)
+			if err != nil {
+				return errors.Wrap(err, "failed to rewrite index")
+			}
+			err = m.ObjectStorage.IterateRequestResults(ctx, fromJetID, id,
+				func(request core.RecordID, result *index.RequestResult) error {
+					return m.ObjectStorage.SetRequestResult(ctx, toJetID, id, request, result)
+				})
+			if err != nil {
+				return errors.Wrap(err, "failed to rewrite request results")
+			}
 		}
 	}
 
-	inslogger.FromContext(ctx).Debugf("CloneStorage from - %v, to - %v", fromJetID, toJetID)
-	m.RecentStorageProvider.CloneIndexStorage(ctx, fromJetID, toJetID)
-	m.RecentStorageProvider.ClonePendingStorage(ctx, fromJetID, toJetID)
+	inslogger.FromContext(ctx).Debugf("CloneStorage from - %v, to - %v", fromJetIDs, toJetID)
+	if len(fromJetIDs) == 1 {
+		m.RecentStorageProvider.CloneIndexStorage(ctx, fromJetIDs[0], toJetID)
+		m.RecentStorageProvider.ClonePendingStorage(ctx, fromJetIDs[0], toJetID)
+		return nil
+	}
+
+	toIndexStorage := m.RecentStorageProvider.GetIndexStorage(ctx, toJetID)
+	toPendingStorage := m.RecentStorageProvider.GetPendingStorage(ctx, toJetID)
+	for _, fromJetID := range fromJetIDs {
+		for id, ttl := range m.RecentStorageProvider.GetIndexStorage(ctx, fromJetID).GetObjects() {
+			toIndexStorage.AddObjectWithTLL(ctx, id, ttl)
+		}
+		for objID, requests := range m.RecentStorageProvider.GetPendingStorage(ctx, fromJetID).GetRequests() {
+			for reqID := range requests {
+				toPendingStorage.AddPendingRequest(ctx, objID, reqID)
+			}
+		}
+	}
 
 	return nil
 }
@@ -600,6 +979,8 @@ func (m *PulseManager) Set(ctx context.Context, newPulse core.Pulse, persist boo
 		inslogger.FromContext(ctx).Error(errors.Wrap(err, "MessageBus OnPulse() returns error"))
 	}
 
+	m.EventBus.Publish(ctx, core.Event{Type: core.EventPulse, Pulse: &newPulse})
+
 	if m.NodeNet.GetOrigin().Role() == core.StaticRoleVirtual {
 		err = m.LR.OnPulse(ctx, newPulse)
 	}
@@ -685,6 +1066,12 @@ func (m *PulseManager) addSync(ctx context.Context, jets []jetInfo, pulse core.P
 	}
 
 	for _, jInfo := range jets {
+		if jInfo.merged {
+			// Records of the pulse are stored in merged jets.
+			m.syncClientsPool.AddPulsesToSyncClient(ctx, jInfo.left.id, true, pulse)
+			m.syncClientsPool.AddPulsesToSyncClient(ctx, jInfo.right.id, true, pulse)
+			continue
+		}
 		m.syncClientsPool.AddPulsesToSyncClient(ctx, jInfo.id, true, pulse)
 	}
 }
@@ -697,6 +1084,11 @@ func (m *PulseManager) postProcessJets(ctx context.Context, newPulse core.Pulse,
 	defer span.End()
 
 	for _, jetInfo := range jets {
+		if jetInfo.merged {
+			// Pending requests of merged jets were moved to the parent.
+			m.RecentStorageProvider.RemovePendingStorage(ctx, jetInfo.left.id)
+			m.RecentStorageProvider.RemovePendingStorage(ctx, jetInfo.right.id)
+		}
 		if !jetInfo.mineNext {
 			logger.Debugf("[postProcessJets] clear pending storage for jet - %v, pulse - %v", jetInfo.id, newPulse.PulseNumber)
 			m.RecentStorageProvider.RemovePendingStorage(ctx, jetInfo.id)
@@ -752,7 +1144,7 @@ func (m *PulseManager) prepareArtifactManagerMessageHandlerForNextPulse(ctx cont
 
 	for _, jetInfo := range jets {
 
-		if jetInfo.left == nil && jetInfo.right == nil {
+		if jetInfo.left == nil && jetInfo.right == nil || jetInfo.merged {
 			// No split happened.
 			if jetInfo.mineNext {
 				logger.Debugf("[breakermiddleware] [prepareHandlerForNextPulse] fetch jetInfo root %v, pulse - %v", jetInfo.id.DebugString(), newPulse.PulseNumber)
@@ -19,6 +19,7 @@ package pulsemanager
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/gojuno/minimock"
 	"github.com/insolar/insolar/component"
@@ -27,6 +28,7 @@ import (
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/jetload"
 	"github.com/insolar/insolar/ledger/recentstorage"
 	"github.com/insolar/insolar/ledger/storage"
 	"github.com/insolar/insolar/ledger/storage/index"
@@ -282,3 +284,245 @@ func TestPulseManager_Set_SendAbandonedRequests(t *testing.T) {
 	require.Equal(t, uint64(1), mb.SendCounter)
 
 }
+
+func TestPulseManager_processJets_MergesJetsWithSmallDrops(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	jetStorage := storage.NewJetStorage()
+	dropStorage := storage.NewDropStorage(10)
+	objectStorage := storage.NewObjectStorage()
+	cm := &component.Manager{}
+	cm.Inject(platformpolicy.NewPlatformCryptographyScheme(), db, jetStorage, dropStorage, objectStorage)
+	require.NoError(t, cm.Init(ctx))
+
+	currentPulse := core.PulseNumber(core.FirstPulseNumber + 1)
+	newPulse := currentPulse + 1
+	root := jet.NewID(0, nil)
+	left, right, err := jetStorage.SplitJetTree(ctx, currentPulse, *root)
+	require.NoError(t, err)
+	err = jetStorage.UpdateJetTree(ctx, currentPulse, true, *left, *right)
+	require.NoError(t, err)
+	for _, jetID := range []core.RecordID{*left, *right} {
+		err = dropStorage.SetDropSizeHistory(ctx, jetID, jet.DropSizeHistory{
+			{JetID: jetID, PulseNo: currentPulse - 2, DropSize: 100},
+			{JetID: jetID, PulseNo: currentPulse - 1, DropSize: 1},
+			{JetID: jetID, PulseNo: currentPulse, DropSize: 2},
+		})
+		require.NoError(t, err)
+	}
+
+	provider := storage.NewRecentStorageProvider(10)
+	objID := core.NewRecordID(currentPulse, []byte{0xF0})
+	requestID := core.NewRecordID(currentPulse, []byte{0x10})
+	idx := index.ObjectLifeline{LatestState: objID}
+	err = objectStorage.SetObjectIndex(ctx, *right, objID, &idx)
+	require.NoError(t, err)
+	provider.GetIndexStorage(ctx, *right).AddObjectWithTLL(ctx, *objID, 3)
+	provider.GetPendingStorage(ctx, *left).AddPendingRequest(ctx, *requestID, *requestID)
+
+	me := testutils.RandomRef()
+	jetCoordinator := testutils.NewJetCoordinatorMock(t)
+	jetCoordinator.LightExecutorForJetMock.Return(&me, nil)
+	jetCoordinator.MeMock.Return(me)
+	node := network.NewNodeMock(t)
+	node.RoleMock.Return(core.StaticRoleLightMaterial)
+	nodeNet := network.NewNodeNetworkMock(t)
+	nodeNet.GetOriginMock.Return(node)
+
+	pm := NewPulseManager(configuration.Ledger{
+		PulseManager: configuration.PulseManager{MergeThreshold: 10, MergeAfterPulses: 3},
+	})
+	pm.JetStorage = jetStorage
+	pm.DropStorage = dropStorage
+	pm.ObjectStorage = objectStorage
+	pm.RecentStorageProvider = provider
+	pm.JetCoordinator = jetCoordinator
+	pm.NodeNet = nodeNet
+	pm.JetLoadMeter = jetload.NewMeter()
+	pm.SplitPolicy = &jetload.CompositePolicy{}
+
+	jets, err := pm.processJets(ctx, currentPulse, newPulse)
+	require.NoError(t, err)
+	require.Len(t, jets, 2, "one of the last drops is too big")
+	require.False(t, jets[0].merged)
+	require.False(t, jets[1].merged)
+
+	pm.options.mergeAfterPulses = 2
+	jets, err = pm.processJets(ctx, currentPulse, newPulse)
+	require.NoError(t, err)
+	require.Len(t, jets, 1)
+	require.Equal(t, *root, jets[0].id)
+	require.True(t, jets[0].merged)
+	require.True(t, jets[0].mineNext)
+	require.Equal(t, *left, jets[0].left.id)
+	require.Equal(t, *right, jets[0].right.id)
+
+	tree, err := jetStorage.GetJetTree(ctx, newPulse)
+	require.NoError(t, err)
+	jetID, actual := tree.Find(*objID)
+	require.Equal(t, root, jetID)
+	require.True(t, actual)
+
+	require.Equal(t, map[core.RecordID]int{*objID: 3}, provider.GetIndexStorage(ctx, *root).GetObjects())
+	require.Equal(t, []core.RecordID{*requestID}, provider.GetPendingStorage(ctx, *root).GetRequestsForObject(*requestID))
+	savedIndex, err := objectStorage.GetObjectIndex(ctx, *root, objID, false)
+	require.NoError(t, err)
+	require.Equal(t, idx, *savedIndex)
+}
+
+func TestPulseManager_processJets_SplitsLoadedJets(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	jetStorage := storage.NewJetStorage()
+	dropStorage := storage.NewDropStorage(10)
+	objectStorage := storage.NewObjectStorage()
+	cm := &component.Manager{}
+	cm.Inject(platformpolicy.NewPlatformCryptographyScheme(), db, jetStorage, dropStorage, objectStorage)
+	require.NoError(t, cm.Init(ctx))
+
+	currentPulse := core.PulseNumber(core.FirstPulseNumber + 1)
+	newPulse := currentPulse + 1
+	root := jet.NewID(0, nil)
+	left, right, err := jetStorage.SplitJetTree(ctx, currentPulse, *root)
+	require.NoError(t, err)
+	err = jetStorage.UpdateJetTree(ctx, currentPulse, true, *left, *right)
+	require.NoError(t, err)
+
+	me := testutils.RandomRef()
+	jetCoordinator := testutils.NewJetCoordinatorMock(t)
+	jetCoordinator.LightExecutorForJetMock.Return(&me, nil)
+	jetCoordinator.MeMock.Return(me)
+	node := network.NewNodeMock(t)
+	node.RoleMock.Return(core.StaticRoleLightMaterial)
+	nodeNet := network.NewNodeNetworkMock(t)
+	nodeNet.GetOriginMock.Return(node)
+
+	pm := NewPulseManager(configuration.Ledger{})
+	pm.currentPulse = core.Pulse{PulseNumber: currentPulse}
+	pm.JetStorage = jetStorage
+	pm.DropStorage = dropStorage
+	pm.ObjectStorage = objectStorage
+	pm.RecentStorageProvider = storage.NewRecentStorageProvider(10)
+	pm.JetCoordinator = jetCoordinator
+	pm.NodeNet = nodeNet
+	pm.JetLoadMeter = jetload.NewMeter()
+	pm.SplitPolicy = &jetload.RequestsPolicy{Threshold: 2}
+
+	for i := 0; i < 3; i++ {
+		pm.JetLoadMeter.Observe(*left, time.Millisecond)
+	}
+	loads, err := pm.JetLoads(ctx)
+	require.NoError(t, err)
+	require.Len(t, loads, 2)
+	for _, load := range loads {
+		if load.JetID == *left {
+			require.Equal(t, 3, load.Requests)
+			require.Equal(t, time.Millisecond, load.Latency)
+		} else {
+			require.Equal(t, *right, load.JetID)
+			require.Equal(t, 0, load.Requests)
+		}
+	}
+
+	require.Error(t, pm.ForceSplit(ctx, *root), "root is not a leaf")
+	require.NoError(t, pm.ForceSplit(ctx, *right))
+
+	jets, err := pm.processJets(ctx, currentPulse, newPulse)
+	require.NoError(t, err)
+	require.Len(t, jets, 2)
+	for _, info := range jets {
+		require.NotNil(t, info.left, "jet %v is not split", info.id.DebugString())
+		require.NotNil(t, info.right, "jet %v is not split", info.id.DebugString())
+	}
+
+	requests, _ := pm.JetLoadMeter.Requests(*left)
+	require.Equal(t, 0, requests, "metrics are reset after pulse")
+	require.Empty(t, pm.forcedSplits)
+}
+
+func TestPulseManager_processJets_PostponesSplitAfterMerge(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+
+	jetStorage := storage.NewJetStorage()
+	dropStorage := storage.NewDropStorage(10)
+	objectStorage := storage.NewObjectStorage()
+	cm := &component.Manager{}
+	cm.Inject(platformpolicy.NewPlatformCryptographyScheme(), db, jetStorage, dropStorage, objectStorage)
+	require.NoError(t, cm.Init(ctx))
+
+	currentPulse := core.PulseNumber(core.FirstPulseNumber + 1)
+	newPulse := currentPulse + 1
+	root := jet.NewID(0, nil)
+	err := jetStorage.UpdateJetTree(ctx, currentPulse, true, *root)
+	require.NoError(t, err)
+	// Root was merged one pulse ago.
+	err = dropStorage.SetDropSizeHistory(ctx, *root, jet.DropSizeHistory{
+		{JetID: *root, PulseNo: currentPulse, DropSize: 1},
+	})
+	require.NoError(t, err)
+
+	me := testutils.RandomRef()
+	jetCoordinator := testutils.NewJetCoordinatorMock(t)
+	jetCoordinator.LightExecutorForJetMock.Return(&me, nil)
+	jetCoordinator.MeMock.Return(me)
+	node := network.NewNodeMock(t)
+	node.RoleMock.Return(core.StaticRoleLightMaterial)
+	nodeNet := network.NewNodeNetworkMock(t)
+	nodeNet.GetOriginMock.Return(node)
+
+	pm := NewPulseManager(configuration.Ledger{
+		PulseManager:         configuration.PulseManager{MergeThreshold: 10, MergeAfterPulses: 2, SplitCooldownPulses: 2},
+		JetSizesHistoryDepth: 10,
+	})
+	pm.currentPulse = core.Pulse{PulseNumber: currentPulse}
+	pm.JetStorage = jetStorage
+	pm.DropStorage = dropStorage
+	pm.ObjectStorage = objectStorage
+	pm.RecentStorageProvider = storage.NewRecentStorageProvider(10)
+	pm.JetCoordinator = jetCoordinator
+	pm.NodeNet = nodeNet
+	pm.JetLoadMeter = jetload.NewMeter()
+	pm.SplitPolicy = &jetload.RequestsPolicy{Threshold: 2}
+
+	loadRoot := func() {
+		for i := 0; i < 3; i++ {
+			pm.JetLoadMeter.Observe(*root, time.Millisecond)
+		}
+	}
+
+	loadRoot()
+	jets, err := pm.processJets(ctx, currentPulse, newPulse)
+	require.NoError(t, err)
+	require.Len(t, jets, 1)
+	require.Nil(t, jets[0].left, "loaded jet is not split during cooldown")
+
+	loadRoot()
+	require.NoError(t, pm.ForceSplit(ctx, *root))
+	jets, err = pm.processJets(ctx, currentPulse, newPulse)
+	require.NoError(t, err)
+	require.Len(t, jets, 1)
+	require.NotNil(t, jets[0].left, "forced split ignores cooldown")
+
+	err = dropStorage.SetDropSizeHistory(ctx, *root, jet.DropSizeHistory{
+		{JetID: *root, PulseNo: currentPulse - 1, DropSize: 1},
+		{JetID: *root, PulseNo: currentPulse, DropSize: 1},
+	})
+	require.NoError(t, err)
+	loadRoot()
+	jets, err = pm.processJets(ctx, currentPulse, newPulse)
+	require.NoError(t, err)
+	require.Len(t, jets, 1)
+	require.NotNil(t, jets[0].left, "loaded jet is split after cooldown")
+
+	for _, child := range []core.RecordID{jets[0].left.id, jets[0].right.id} {
+		history, err := dropStorage.GetDropSizeHistory(ctx, child)
+		require.NoError(t, err)
+		require.Empty(t, history, "history of split jets is reset")
+	}
+}
@@ -0,0 +1,360 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/badger/protos"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/pkg/errors"
+)
+
+const (
+	backupMagic   = "insolar-ledger-backup"
+	backupVersion = 1
+	// backupMaxHeaderSize limits memory allocated for header of untrusted backup file.
+	backupMaxHeaderSize = 64 << 20
+)
+
+// BackupHeader describes ledger snapshot. It is written in front of Badger backup stream.
+type BackupHeader struct {
+	Version int
+	// Pulse is the latest pulse of the node at the moment of snapshot. All data up to this pulse is in the snapshot.
+	Pulse core.PulseNumber
+	// Drops are all jet drops of the snapshot. They are used to validate restored database.
+	Drops []BackupDrop
+}
+
+// BackupDrop is a jet drop hash saved in backup header.
+type BackupDrop struct {
+	JetPrefix []byte
+	Pulse     core.PulseNumber
+	Hash      []byte
+}
+
+// Backup writes consistent snapshot of the database to provided writer.
+//
+// Snapshot consists of BackupHeader and Badger backup stream. Header and stream are read from the same Badger
+// transaction, so every drop from header is present in the stream even if new pulses are written while backup is in
+// progress.
+func Backup(ctx context.Context, db DBContext, w io.Writer) (*BackupHeader, error) {
+	// Wait for inflight updates so all drops of the latest pulse are stored.
+	db.waitingFlight()
+
+	header := BackupHeader{Version: backupVersion}
+	err := db.GetBadgerDB().View(func(txn *badger.Txn) error {
+		pulse, err := latestPulse(txn)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch latest pulse")
+		}
+		header.Pulse = pulse
+
+		header.Drops, err = dropHashes(txn)
+		if err != nil {
+			return err
+		}
+
+		err = writeBackupHeader(w, &header)
+		if err != nil {
+			return errors.Wrap(err, "failed to write header")
+		}
+
+		err = writeBackupEntries(txn, w)
+		if err != nil {
+			return errors.Wrap(err, "failed to write database")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Backup ]")
+	}
+
+	inslogger.FromContext(ctx).Infof("ledger backup is done. pulse: %v, drops: %v", header.Pulse, len(header.Drops))
+	return &header, nil
+}
+
+// Restore loads snapshot made by Backup into empty database and validates restored jet drops against snapshot header.
+func Restore(ctx context.Context, db DBContext, r io.Reader) (*BackupHeader, error) {
+	empty, err := isEmpty(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Restore ]")
+	}
+	if !empty {
+		return nil, errors.New("[ Restore ] database is not empty")
+	}
+
+	br := bufio.NewReader(r)
+	header, err := readBackupHeader(br)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Restore ] failed to read header")
+	}
+
+	err = db.GetBadgerDB().Load(br)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Restore ] failed to load database")
+	}
+
+	err = verifyDrops(ctx, db, header.Drops)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Restore ] restored database is inconsistent")
+	}
+
+	inslogger.FromContext(ctx).Infof("ledger restore is done. pulse: %v, drops: %v", header.Pulse, len(header.Drops))
+	return header, nil
+}
+
+// Backuper makes ledger snapshots into configured directory.
+type Backuper struct {
+	DB DBContext `inject:""`
+
+	dir string
+	// lock serializes backups, concurrent ones would race for the same snapshot file.
+	lock sync.Mutex
+}
+
+// NewBackuper creates new Backuper instance.
+func NewBackuper(conf configuration.Storage) *Backuper {
+	return &Backuper{dir: conf.BackupDirectory}
+}
+
+// Backup writes snapshot of the database into backup directory and returns file path and pulse of the snapshot.
+func (b *Backuper) Backup(ctx context.Context) (string, core.PulseNumber, error) {
+	if b.dir == "" {
+		return "", 0, errors.New("[ Backuper.Backup ] backup directory is not configured")
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	err := os.MkdirAll(b.dir, 0700)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "[ Backuper.Backup ] failed to create backup directory")
+	}
+
+	tmp, err := ioutil.TempFile(b.dir, "backup-*.tmp")
+	if err != nil {
+		return "", 0, errors.Wrap(err, "[ Backuper.Backup ] failed to create backup file")
+	}
+	bw := bufio.NewWriter(tmp)
+	header, err := Backup(ctx, b.DB, bw)
+	if err == nil {
+		err = bw.Flush()
+	}
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", 0, errors.Wrap(err, "[ Backuper.Backup ]")
+	}
+
+	path := filepath.Join(b.dir, fmt.Sprintf("ledger-%d.backup", header.Pulse))
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "[ Backuper.Backup ] failed to rename backup file")
+	}
+	return path, header.Pulse, nil
+}
+
+func latestPulse(txn *badger.Txn) (core.PulseNumber, error) {
+	item, err := txn.Get(prefixkey(scopeIDSystem, []byte{sysLatestPulse}))
+	if err == badger.ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	buf, err := item.Value()
+	if err != nil {
+		return 0, err
+	}
+	pulse, err := toPulse(buf)
+	if err != nil {
+		return 0, err
+	}
+	return pulse.Pulse.PulseNumber, nil
+}
+
+func dropHashes(txn *badger.Txn) ([]BackupDrop, error) {
+	var drops []BackupDrop
+	prefix := []byte{scopeIDJetDrop}
+	it := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer it.Close()
+
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		k := it.Item().KeyCopy(nil)[len(prefix):]
+		v, err := it.Item().Value()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to collect drops")
+		}
+		drop, err := jet.Decode(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode drop %v", bytes2hex(k))
+		}
+		drops = append(drops, BackupDrop{
+			JetPrefix: k[:len(k)-core.PulseNumberSize],
+			Pulse:     drop.Pulse,
+			Hash:      drop.Hash,
+		})
+	}
+	return drops, nil
+}
+
+// writeBackupEntries writes all versions of all keys visible in transaction in the format of badger.DB.Backup.
+func writeBackupEntries(txn *badger.Txn, w io.Writer) error {
+	opts := badger.DefaultIteratorOptions
+	opts.AllVersions = true
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		item := it.Item()
+		val, err := item.ValueCopy(nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read value of key %v", bytes2hex(item.Key()))
+		}
+		entry := &protos.KVPair{
+			Key:       item.KeyCopy(nil),
+			Value:     val,
+			UserMeta:  []byte{item.UserMeta()},
+			Version:   item.Version(),
+			ExpiresAt: item.ExpiresAt(),
+		}
+		buf, err := entry.Marshal()
+		if err != nil {
+			return err
+		}
+		err = binary.Write(w, binary.LittleEndian, uint64(len(buf)))
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyDrops(ctx context.Context, db DBContext, drops []BackupDrop) error {
+	var restored []BackupDrop
+	err := db.GetBadgerDB().View(func(txn *badger.Txn) error {
+		var err error
+		restored, err = dropHashes(txn)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(restored) != len(drops) {
+		return fmt.Errorf("expected %v drops, restored %v", len(drops), len(restored))
+	}
+
+	for _, expected := range drops {
+		buf, err := db.get(ctx, prefixkey(scopeIDJetDrop, expected.JetPrefix, expected.Pulse.Bytes()))
+		if err != nil {
+			return errors.Wrapf(err, "drop for pulse %v is not restored", expected.Pulse)
+		}
+		drop, err := jet.Decode(buf)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode drop for pulse %v", expected.Pulse)
+		}
+		if drop.Pulse != expected.Pulse || !bytes.Equal(drop.Hash, expected.Hash) {
+			return fmt.Errorf("drop hash mismatch for pulse %v. jet prefix: %v", expected.Pulse, bytes2hex(expected.JetPrefix))
+		}
+	}
+	return nil
+}
+
+func isEmpty(db DBContext) (bool, error) {
+	empty := true
+	err := db.GetBadgerDB().View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		it.Rewind()
+		empty = !it.Valid()
+		return nil
+	})
+	return empty, err
+}
+
+func writeBackupHeader(w io.Writer, header *BackupHeader) error {
+	buf, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte(backupMagic))
+	if err != nil {
+		return err
+	}
+	err = binary.Write(w, binary.LittleEndian, uint32(len(buf)))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+func readBackupHeader(r io.Reader) (*BackupHeader, error) {
+	magic := make([]byte, len(backupMagic))
+	_, err := io.ReadFull(r, magic)
+	if err != nil {
+		return nil, err
+	}
+	if string(magic) != backupMagic {
+		return nil, errors.New("not a ledger backup")
+	}
+
+	var size uint32
+	err = binary.Read(r, binary.LittleEndian, &size)
+	if err != nil {
+		return nil, err
+	}
+	if size > backupMaxHeaderSize {
+		return nil, fmt.Errorf("header size %v exceeds limit %v", size, backupMaxHeaderSize)
+	}
+	buf := make([]byte, size)
+	_, err = io.ReadFull(r, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var header BackupHeader
+	err = json.Unmarshal(buf, &header)
+	if err != nil {
+		return nil, err
+	}
+	if header.Version != backupVersion {
+		return nil, fmt.Errorf("unsupported backup version %v", header.Version)
+	}
+	return &header, nil
+}
@@ -0,0 +1,180 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/storage"
+	"github.com/insolar/insolar/ledger/storage/jet"
+	"github.com/insolar/insolar/ledger/storage/storagetest"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func backupStorages(ctx context.Context, t *testing.T, db storage.DBContext) (storage.ObjectStorage, storage.DropStorage) {
+	objectStorage := storage.NewObjectStorage()
+	dropStorage := storage.NewDropStorage(10)
+
+	cm := &component.Manager{}
+	cm.Inject(
+		platformpolicy.NewPlatformCryptographyScheme(),
+		db,
+		objectStorage,
+		dropStorage,
+	)
+	require.NoError(t, cm.Init(ctx))
+	return objectStorage, dropStorage
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	jetID := *jet.NewID(0, nil)
+
+	srcDB, srcCleaner := storagetest.TmpDB(ctx, t)
+	defer srcCleaner()
+	defer srcDB.Close()
+	srcObjects, srcDrops := backupStorages(ctx, t, srcDB)
+
+	recID, err := storagetest.AddRandRecord(ctx, srcObjects, jetID, core.FirstPulseNumber+1)
+	require.NoError(t, err)
+	drop, err := storagetest.AddRandDrop(ctx, srcDrops, jetID, core.FirstPulseNumber+1)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	header, err := storage.Backup(ctx, srcDB, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, core.FirstPulseNumber, int(header.Pulse))
+	// Genesis drop and the added one.
+	require.Len(t, header.Drops, 2)
+	assert.Equal(t, drop.Hash, header.Drops[1].Hash)
+	snapshot := buf.Bytes()
+
+	t.Run("restores into empty database", func(t *testing.T) {
+		dstDB, dstCleaner := storagetest.TmpDB(ctx, t, storagetest.DisableBootstrap())
+		defer dstCleaner()
+		defer dstDB.Close()
+		dstObjects, dstDrops := backupStorages(ctx, t, dstDB)
+
+		restored, err := storage.Restore(ctx, dstDB, bytes.NewReader(snapshot))
+		require.NoError(t, err)
+		assert.Equal(t, header, restored)
+
+		restoredDrop, err := dstDrops.GetDrop(ctx, jetID, core.FirstPulseNumber+1)
+		require.NoError(t, err)
+		assert.Equal(t, drop, restoredDrop)
+		_, err = dstObjects.GetRecord(ctx, jetID, recID)
+		require.NoError(t, err)
+	})
+
+	t.Run("fails on not empty database", func(t *testing.T) {
+		_, err := storage.Restore(ctx, srcDB, bytes.NewReader(snapshot))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database is not empty")
+	})
+
+	t.Run("fails when drop hash differs from header", func(t *testing.T) {
+		dstDB, dstCleaner := storagetest.TmpDB(ctx, t, storagetest.DisableBootstrap())
+		defer dstCleaner()
+		defer dstDB.Close()
+
+		wrongHash := make([]byte, len(drop.Hash))
+		corrupted := bytes.Replace(
+			snapshot,
+			[]byte(base64.StdEncoding.EncodeToString(drop.Hash)),
+			[]byte(base64.StdEncoding.EncodeToString(wrongHash)),
+			1,
+		)
+		_, err := storage.Restore(ctx, dstDB, bytes.NewReader(corrupted))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "drop hash mismatch")
+	})
+
+	t.Run("fails on not a backup", func(t *testing.T) {
+		dstDB, dstCleaner := storagetest.TmpDB(ctx, t, storagetest.DisableBootstrap())
+		defer dstCleaner()
+		defer dstDB.Close()
+
+		_, err := storage.Restore(ctx, dstDB, bytes.NewReader([]byte("definitely not a ledger backup")))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a ledger backup")
+	})
+	t.Run("fails on oversized header", func(t *testing.T) {
+		dstDB, dstCleaner := storagetest.TmpDB(ctx, t, storagetest.DisableBootstrap())
+		defer dstCleaner()
+		defer dstDB.Close()
+
+		oversized := append([]byte("insolar-ledger-backup"), 0xff, 0xff, 0xff, 0xff)
+		_, err := storage.Restore(ctx, dstDB, bytes.NewReader(oversized))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds limit")
+	})
+}
+
+func TestBackuper_Concurrent(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	db, cleaner := storagetest.TmpDB(ctx, t)
+	defer cleaner()
+	defer db.Close()
+	_, drops := backupStorages(ctx, t, db)
+	_, err := storagetest.AddRandDrop(ctx, drops, *jet.NewID(0, nil), core.FirstPulseNumber+1)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	backuper := storage.NewBackuper(configuration.Storage{BackupDirectory: dir})
+	backuper.DB = db
+
+	var wg sync.WaitGroup
+	paths := make([]string, 4)
+	errs := make([]error, 4)
+	for i := range paths {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			paths[i], _, errs[i] = backuper.Backup(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range paths {
+		require.NoError(t, errs[i])
+		f, err := os.Open(paths[i])
+		require.NoError(t, err)
+		dstDB, dstCleaner := storagetest.TmpDB(ctx, t, storagetest.DisableBootstrap())
+		_, err = storage.Restore(ctx, dstDB, f)
+		dstDB.Close()
+		dstCleaner()
+		f.Close()
+		require.NoError(t, err, "snapshot is not corrupted by concurrent backup")
+	}
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1, "temporary files are renamed")
+}
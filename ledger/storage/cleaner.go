@@ -180,6 +180,9 @@ func (c *cleaner) CleanJetIndexes(
 			stat.Scanned++
 			key := prefixkey(scopeIDLifeline, prefix, recID[:])
 			err := c.DB.GetBadgerDB().Update(func(txn *badger.Txn) error {
+				if err := removeRequestResults(txn, prefixkey(scopeIDRequestResult, prefix, recID[:])); err != nil {
+					return err
+				}
 				return txn.Delete(key)
 			})
 			if err != nil {
@@ -198,3 +201,20 @@ func (c *cleaner) CleanJetIndexes(
 	)
 	return stat, nil
 }
+
+// removeRequestResults removes request result indexes of object with provided key prefix.
+func removeRequestResults(txn *badger.Txn, prefix []byte) error {
+	it := txn.NewIterator(badger.IteratorOptions{})
+	var keys [][]byte
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		keys = append(keys, it.Item().KeyCopy(nil))
+	}
+	it.Close()
+
+	for _, key := range keys {
+		if err := txn.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
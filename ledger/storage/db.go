@@ -31,14 +31,15 @@ import (
 )
 
 const (
-	scopeIDLifeline byte = 1
-	scopeIDRecord   byte = 2
-	scopeIDJetDrop  byte = 3
-	scopeIDPulse    byte = 4
-	scopeIDSystem   byte = 5
-	scopeIDMessage  byte = 6
-	scopeIDBlob     byte = 7
-	scopeIDLocal    byte = 8
+	scopeIDLifeline      byte = 1
+	scopeIDRecord        byte = 2
+	scopeIDJetDrop       byte = 3
+	scopeIDPulse         byte = 4
+	scopeIDSystem        byte = 5
+	scopeIDMessage       byte = 6
+	scopeIDBlob          byte = 7
+	scopeIDLocal         byte = 8
+	scopeIDRequestResult byte = 9
 
 	sysGenesis                byte = 1
 	sysLatestPulse            byte = 2
@@ -0,0 +1,52 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package index
+
+import (
+	"bytes"
+
+	"github.com/insolar/insolar/core"
+	"github.com/ugorji/go/codec"
+)
+
+// RequestResult represents meta information about executed request of object.
+type RequestResult struct {
+	Result  core.RecordID // Result record.
+	Payload []byte        // Method result saved with result record.
+}
+
+// EncodeRequestResult converts request result index into binary format.
+func EncodeRequestResult(result *RequestResult) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := codec.NewEncoder(&buf, &codec.CborHandle{})
+	err := enc.Encode(result)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeRequestResult converts byte array into request result index struct.
+func DecodeRequestResult(buf []byte) (*RequestResult, error) {
+	dec := codec.NewDecoder(bytes.NewReader(buf), &codec.CborHandle{})
+	var result RequestResult
+	err := dec.Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -69,3 +69,17 @@ func Parent(id core.RecordID) core.RecordID {
 
 	return *NewID(depth-1, ResetBits(prefix, depth-1))
 }
+
+// Sibling returns the other child of provided jet's parent. Root jet is returned as is.
+func Sibling(id core.RecordID) core.RecordID {
+	depth, prefix := Jet(id)
+	if depth == 0 {
+		return id
+	}
+
+	sibling := ResetBits(prefix, depth-1)
+	if !getBit(prefix, depth-1) {
+		setBit(sibling, depth-1)
+	}
+	return *NewID(depth, sibling)
+}
@@ -54,7 +54,10 @@ func (j *jet) Find(val []byte, depth uint8) (*jet, uint8) {
 func (j *jet) Update(prefix []byte, setActual bool, maxDepth, depth uint8) {
 	if depth == maxDepth {
 		if setActual {
+			// Actual jet is always a leaf. Branches can be left here by the tree cloned before jet merge.
 			j.Actual = true
+			j.Left = nil
+			j.Right = nil
 		}
 		return
 	}
@@ -84,6 +87,10 @@ func (j *jet) Clone(keep bool) *jet {
 	return res
 }
 
+func (j *jet) isLeaf() bool {
+	return j.Left == nil && j.Right == nil
+}
+
 func (j *jet) ExtractLeafIDs(ids *[]core.RecordID, path []byte, depth uint8) {
 	if j == nil {
 		return
@@ -288,6 +295,35 @@ func (t *Tree) Split(jetID core.RecordID) (*core.RecordID, *core.RecordID, error
 	return NewID(depth+1, leftPrefix), NewID(depth+1, rightPrefix), nil
 }
 
+// Collapse looks for provided jet and removes it with its sibling from the tree, so their parent becomes a leaf.
+// Parent id is returned. If provided jet or its sibling is not a leaf, an error will be returned.
+func (t *Tree) Collapse(jetID core.RecordID) (*core.RecordID, error) {
+	depth, prefix := Jet(jetID)
+	if depth == 0 {
+		return nil, errors.New("failed to collapse: root jet has no sibling")
+	}
+
+	parent := t.Head
+	for i := uint8(0); i < depth-1 && parent != nil; i++ {
+		if getBit(prefix, i) {
+			parent = parent.Right
+		} else {
+			parent = parent.Left
+		}
+	}
+	if parent == nil || parent.Left == nil || parent.Right == nil {
+		return nil, errors.New("failed to collapse: incorrect jet provided")
+	}
+	if !parent.Left.isLeaf() || !parent.Right.isLeaf() {
+		return nil, errors.New("failed to collapse: jet or its sibling is split")
+	}
+	parent.Left = nil
+	parent.Right = nil
+
+	parentID := Parent(jetID)
+	return &parentID, nil
+}
+
 func (t *Tree) LeafIDs() []core.RecordID {
 	var ids []core.RecordID
 	t.Head.ExtractLeafIDs(&ids, make([]byte, core.RecordHashSize), 0)
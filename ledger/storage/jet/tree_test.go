@@ -128,6 +128,70 @@ func TestTree_Split(t *testing.T) {
 	})
 }
 
+func TestTree_Collapse(t *testing.T) {
+	tree := Tree{
+		Head: &jet{
+			Right: &jet{
+				Right: &jet{},
+				Left: &jet{
+					Left:  &jet{},
+					Right: &jet{},
+				},
+			},
+			Left: &jet{},
+		},
+	}
+
+	t.Run("root jet returns error", func(t *testing.T) {
+		_, err := tree.Collapse(*NewID(0, nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("not existing jet returns error", func(t *testing.T) {
+		_, err := tree.Collapse(*NewID(3, []byte{0x20})) // 001
+		assert.Error(t, err)
+	})
+
+	t.Run("split sibling returns error", func(t *testing.T) {
+		_, err := tree.Collapse(*NewID(2, []byte{0xC0})) // 11
+		assert.Error(t, err)
+	})
+
+	t.Run("collapses jet", func(t *testing.T) {
+		parent, err := tree.Collapse(*NewID(3, []byte{0xA0})) // 101
+		require.NoError(t, err)
+		assert.Equal(t, NewID(2, []byte{0x80}), parent)
+
+		parent, err = tree.Collapse(*NewID(2, []byte{0xC0})) // 11
+		require.NoError(t, err)
+		assert.Equal(t, NewID(1, []byte{0x80}), parent)
+
+		assert.Equal(t, []core.RecordID{*NewID(1, nil), *NewID(1, []byte{0x80})}, tree.LeafIDs())
+	})
+}
+
+func TestTree_Update_ActualCollapsesBranches(t *testing.T) {
+	tree := NewTree(false)
+	_, _, err := tree.Split(*NewID(0, nil))
+	require.NoError(t, err)
+
+	lookup := core.NewRecordID(0, []byte{0xD5}) // 11010101
+	id, actual := tree.Find(*lookup)
+	assert.Equal(t, NewID(1, []byte{0x80}), id)
+	assert.False(t, actual)
+
+	tree.Update(*NewID(0, nil), true)
+	id, actual = tree.Find(*lookup)
+	assert.Equal(t, NewID(0, nil), id)
+	assert.True(t, actual)
+}
+
+func TestSibling(t *testing.T) {
+	assert.Equal(t, *NewID(0, nil), Sibling(*NewID(0, nil)))
+	assert.Equal(t, *NewID(3, []byte{0xC0}), Sibling(*NewID(3, []byte{0xE0})))
+	assert.Equal(t, *NewID(3, []byte{0xE0}), Sibling(*NewID(3, []byte{0xC0})))
+}
+
 func TestTree_String(t *testing.T) {
 	tree := Tree{
 		Head: &jet{
@@ -46,6 +46,11 @@ type JetStorageMock struct {
 	GetJetsPreCounter uint64
 	GetJetsMock       mJetStorageMockGetJets
 
+	MergeJetTreeFunc       func(p context.Context, p1 core.PulseNumber, p2 core.RecordID) (r *core.RecordID, r1 error)
+	MergeJetTreeCounter    uint64
+	MergeJetTreePreCounter uint64
+	MergeJetTreeMock       mJetStorageMockMergeJetTree
+
 	SplitJetTreeFunc       func(p context.Context, p1 core.PulseNumber, p2 core.RecordID) (r *core.RecordID, r1 *core.RecordID, r2 error)
 	SplitJetTreeCounter    uint64
 	SplitJetTreePreCounter uint64
@@ -70,6 +75,7 @@ func NewJetStorageMock(t minimock.Tester) *JetStorageMock {
 	m.DeleteJetTreeMock = mJetStorageMockDeleteJetTree{mock: m}
 	m.GetJetTreeMock = mJetStorageMockGetJetTree{mock: m}
 	m.GetJetsMock = mJetStorageMockGetJets{mock: m}
+	m.MergeJetTreeMock = mJetStorageMockMergeJetTree{mock: m}
 	m.SplitJetTreeMock = mJetStorageMockSplitJetTree{mock: m}
 	m.UpdateJetTreeMock = mJetStorageMockUpdateJetTree{mock: m}
 
@@ -801,6 +807,158 @@ func (m *JetStorageMock) GetJetsFinished() bool {
 	return true
 }
 
+type mJetStorageMockMergeJetTree struct {
+	mock              *JetStorageMock
+	mainExpectation   *JetStorageMockMergeJetTreeExpectation
+	expectationSeries []*JetStorageMockMergeJetTreeExpectation
+}
+
+type JetStorageMockMergeJetTreeExpectation struct {
+	input  *JetStorageMockMergeJetTreeInput
+	result *JetStorageMockMergeJetTreeResult
+}
+
+type JetStorageMockMergeJetTreeInput struct {
+	p  context.Context
+	p1 core.PulseNumber
+	p2 core.RecordID
+}
+
+type JetStorageMockMergeJetTreeResult struct {
+	r  *core.RecordID
+	r1 error
+}
+
+//Expect specifies that invocation of JetStorage.MergeJetTree is expected from 1 to Infinity times
+func (m *mJetStorageMockMergeJetTree) Expect(p context.Context, p1 core.PulseNumber, p2 core.RecordID) *mJetStorageMockMergeJetTree {
+	m.mock.MergeJetTreeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &JetStorageMockMergeJetTreeExpectation{}
+	}
+	m.mainExpectation.input = &JetStorageMockMergeJetTreeInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of JetStorage.MergeJetTree
+func (m *mJetStorageMockMergeJetTree) Return(r *core.RecordID, r1 error) *JetStorageMock {
+	m.mock.MergeJetTreeFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &JetStorageMockMergeJetTreeExpectation{}
+	}
+	m.mainExpectation.result = &JetStorageMockMergeJetTreeResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of JetStorage.MergeJetTree is expected once
+func (m *mJetStorageMockMergeJetTree) ExpectOnce(p context.Context, p1 core.PulseNumber, p2 core.RecordID) *JetStorageMockMergeJetTreeExpectation {
+	m.mock.MergeJetTreeFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &JetStorageMockMergeJetTreeExpectation{}
+	expectation.input = &JetStorageMockMergeJetTreeInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *JetStorageMockMergeJetTreeExpectation) Return(r *core.RecordID, r1 error) {
+	e.result = &JetStorageMockMergeJetTreeResult{r, r1}
+}
+
+//Set uses given function f as a mock of JetStorage.MergeJetTree method
+func (m *mJetStorageMockMergeJetTree) Set(f func(p context.Context, p1 core.PulseNumber, p2 core.RecordID) (r *core.RecordID, r1 error)) *JetStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.MergeJetTreeFunc = f
+	return m.mock
+}
+
+//MergeJetTree implements github.com/insolar/insolar/ledger/storage.JetStorage.JetStorage interface
+func (m *JetStorageMock) MergeJetTree(p context.Context, p1 core.PulseNumber, p2 core.RecordID) (r *core.RecordID, r1 error) {
+	counter := atomic.AddUint64(&m.MergeJetTreePreCounter, 1)
+	defer atomic.AddUint64(&m.MergeJetTreeCounter, 1)
+
+	if len(m.MergeJetTreeMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.MergeJetTreeMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to JetStorageMock.MergeJetTree. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.MergeJetTreeMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, JetStorageMockMergeJetTreeInput{p, p1, p2}, "JetStorage.MergeJetTree got unexpected parameters")
+
+		result := m.MergeJetTreeMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the JetStorageMock.MergeJetTree")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.MergeJetTreeMock.mainExpectation != nil {
+
+		input := m.MergeJetTreeMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, JetStorageMockMergeJetTreeInput{p, p1, p2}, "JetStorage.MergeJetTree got unexpected parameters")
+		}
+
+		result := m.MergeJetTreeMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the JetStorageMock.MergeJetTree")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.MergeJetTreeFunc == nil {
+		m.t.Fatalf("Unexpected call to JetStorageMock.MergeJetTree. %v %v %v", p, p1, p2)
+		return
+	}
+
+	return m.MergeJetTreeFunc(p, p1, p2)
+}
+
+//MergeJetTreeMinimockCounter returns a count of JetStorageMock.MergeJetTreeFunc invocations
+func (m *JetStorageMock) MergeJetTreeMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.MergeJetTreeCounter)
+}
+
+//MergeJetTreeMinimockPreCounter returns the value of JetStorageMock.MergeJetTree invocations
+func (m *JetStorageMock) MergeJetTreeMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.MergeJetTreePreCounter)
+}
+
+//MergeJetTreeFinished returns true if mock invocations count is ok
+func (m *JetStorageMock) MergeJetTreeFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.MergeJetTreeMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.MergeJetTreeCounter) == uint64(len(m.MergeJetTreeMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.MergeJetTreeMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.MergeJetTreeCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.MergeJetTreeFunc != nil {
+		return atomic.LoadUint64(&m.MergeJetTreeCounter) > 0
+	}
+
+	return true
+}
+
 type mJetStorageMockSplitJetTree struct {
 	mock              *JetStorageMock
 	mainExpectation   *JetStorageMockSplitJetTreeExpectation
@@ -1130,6 +1288,10 @@ func (m *JetStorageMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to JetStorageMock.GetJets")
 	}
 
+	if !m.MergeJetTreeFinished() {
+		m.t.Fatal("Expected call to JetStorageMock.MergeJetTree")
+	}
+
 	if !m.SplitJetTreeFinished() {
 		m.t.Fatal("Expected call to JetStorageMock.SplitJetTree")
 	}
@@ -1175,6 +1337,10 @@ func (m *JetStorageMock) MinimockFinish() {
 		m.t.Fatal("Expected call to JetStorageMock.GetJets")
 	}
 
+	if !m.MergeJetTreeFinished() {
+		m.t.Fatal("Expected call to JetStorageMock.MergeJetTree")
+	}
+
 	if !m.SplitJetTreeFinished() {
 		m.t.Fatal("Expected call to JetStorageMock.SplitJetTree")
 	}
@@ -1202,6 +1368,7 @@ func (m *JetStorageMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.DeleteJetTreeFinished()
 		ok = ok && m.GetJetTreeFinished()
 		ok = ok && m.GetJetsFinished()
+		ok = ok && m.MergeJetTreeFinished()
 		ok = ok && m.SplitJetTreeFinished()
 		ok = ok && m.UpdateJetTreeFinished()
 
@@ -1232,6 +1399,10 @@ func (m *JetStorageMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to JetStorageMock.GetJets")
 			}
 
+			if !m.MergeJetTreeFinished() {
+				m.t.Error("Expected call to JetStorageMock.MergeJetTree")
+			}
+
 			if !m.SplitJetTreeFinished() {
 				m.t.Error("Expected call to JetStorageMock.SplitJetTree")
 			}
@@ -1272,6 +1443,10 @@ func (m *JetStorageMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.MergeJetTreeFinished() {
+		return false
+	}
+
 	if !m.SplitJetTreeFinished() {
 		return false
 	}
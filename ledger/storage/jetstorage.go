@@ -21,6 +21,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/ugorji/go/codec"
 
 	"github.com/insolar/insolar/core"
@@ -34,6 +35,7 @@ type JetStorage interface {
 	GetJetTree(ctx context.Context, pulse core.PulseNumber) (*jet.Tree, error)
 	SplitJetTree(ctx context.Context, pulse core.PulseNumber, jetID core.RecordID) (*core.RecordID, *core.RecordID, error)
 	CloneJetTree(ctx context.Context, from, to core.PulseNumber) (*jet.Tree, error)
+	MergeJetTree(ctx context.Context, pulse core.PulseNumber, jetID core.RecordID) (*core.RecordID, error)
 	DeleteJetTree(ctx context.Context, pulse core.PulseNumber)
 
 	AddJets(ctx context.Context, jetIDs ...core.RecordID) error
@@ -123,6 +125,34 @@ func (js *jetStorage) CloneJetTree(
 	return res, nil
 }
 
+// MergeJetTree collapses provided jet with its sibling and returns their parent. Object indexes of both jets are
+// moved under the parent's prefix, so they can be found by the parent's executor.
+func (js *jetStorage) MergeJetTree(
+	ctx context.Context, pulse core.PulseNumber, jetID core.RecordID,
+) (*core.RecordID, error) {
+	js.treesLock.Lock()
+	defer js.treesLock.Unlock()
+
+	tree, err := js.getJetTree(ctx, pulse)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := tree.Collapse(jetID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range []core.RecordID{jetID, jet.Sibling(jetID)} {
+		err = js.migrateIndexes(ctx, child, *parent)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ MergeJetTree ] failed to migrate indexes of jet %v", child.DebugString())
+		}
+	}
+
+	return parent, nil
+}
+
 func (js *jetStorage) DeleteJetTree(
 	ctx context.Context, pulse core.PulseNumber,
 ) {
@@ -142,6 +172,61 @@ func (js *jetStorage) getJetTree(ctx context.Context, pulse core.PulseNumber) (*
 	return tree, nil
 }
 
+// migrateIndexes moves object indexes (lifelines and request results) from one jet prefix to another.
+func (js *jetStorage) migrateIndexes(ctx context.Context, from, to core.RecordID) error {
+	for _, scope := range []byte{scopeIDLifeline, scopeIDRequestResult} {
+		err := js.migrateScope(ctx, scope, from, to)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (js *jetStorage) migrateScope(ctx context.Context, scope byte, from, to core.RecordID) error {
+	_, fromPrefix := jet.Jet(from)
+	_, toPrefix := jet.Jet(to)
+	if bytes.Equal(fromPrefix, toPrefix) {
+		return nil
+	}
+
+	var indexes []keyval
+	err := js.DB.iterate(ctx, prefixkey(scope, fromPrefix), func(k, v []byte) error {
+		indexes = append(indexes, keyval{k: k, v: v})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	err = js.DB.Update(ctx, func(tx *TransactionManager) error {
+		for _, idx := range indexes {
+			err := tx.set(ctx, prefixkey(scope, toPrefix, idx.k), idx.v)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Old indexes are removed only after new ones are committed.
+	return js.DB.Update(ctx, func(tx *TransactionManager) error {
+		for _, idx := range indexes {
+			err := tx.remove(ctx, prefixkey(scope, fromPrefix, idx.k))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // AddJets stores a list of jets of the current node.
 func (js *jetStorage) AddJets(ctx context.Context, jetIDs ...core.RecordID) error {
 	js.addJetLock.Lock()
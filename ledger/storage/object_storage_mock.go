@@ -37,11 +37,21 @@ type ObjectStorageMock struct {
 	GetRecordPreCounter uint64
 	GetRecordMock       mObjectStorageMockGetRecord
 
+	GetRequestResultFunc       func(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 core.RecordID) (r *index.RequestResult, r1 error)
+	GetRequestResultCounter    uint64
+	GetRequestResultPreCounter uint64
+	GetRequestResultMock       mObjectStorageMockGetRequestResult
+
 	IterateIndexIDsFunc       func(p context.Context, p1 core.RecordID, p2 func(p core.RecordID) (r error)) (r error)
 	IterateIndexIDsCounter    uint64
 	IterateIndexIDsPreCounter uint64
 	IterateIndexIDsMock       mObjectStorageMockIterateIndexIDs
 
+	IterateRequestResultsFunc       func(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 func(request core.RecordID, result *index.RequestResult) error) (r error)
+	IterateRequestResultsCounter    uint64
+	IterateRequestResultsPreCounter uint64
+	IterateRequestResultsMock       mObjectStorageMockIterateRequestResults
+
 	RemoveObjectIndexFunc       func(p context.Context, p1 core.RecordID, p2 *core.RecordID) (r error)
 	RemoveObjectIndexCounter    uint64
 	RemoveObjectIndexPreCounter uint64
@@ -66,6 +76,11 @@ type ObjectStorageMock struct {
 	SetRecordCounter    uint64
 	SetRecordPreCounter uint64
 	SetRecordMock       mObjectStorageMockSetRecord
+
+	SetRequestResultFunc       func(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 core.RecordID, p4 *index.RequestResult) (r error)
+	SetRequestResultCounter    uint64
+	SetRequestResultPreCounter uint64
+	SetRequestResultMock       mObjectStorageMockSetRequestResult
 }
 
 //NewObjectStorageMock returns a mock for github.com/insolar/insolar/ledger/storage.ObjectStorage
@@ -79,12 +94,15 @@ func NewObjectStorageMock(t minimock.Tester) *ObjectStorageMock {
 	m.GetBlobMock = mObjectStorageMockGetBlob{mock: m}
 	m.GetObjectIndexMock = mObjectStorageMockGetObjectIndex{mock: m}
 	m.GetRecordMock = mObjectStorageMockGetRecord{mock: m}
+	m.GetRequestResultMock = mObjectStorageMockGetRequestResult{mock: m}
 	m.IterateIndexIDsMock = mObjectStorageMockIterateIndexIDs{mock: m}
+	m.IterateRequestResultsMock = mObjectStorageMockIterateRequestResults{mock: m}
 	m.RemoveObjectIndexMock = mObjectStorageMockRemoveObjectIndex{mock: m}
 	m.SetBlobMock = mObjectStorageMockSetBlob{mock: m}
 	m.SetMessageMock = mObjectStorageMockSetMessage{mock: m}
 	m.SetObjectIndexMock = mObjectStorageMockSetObjectIndex{mock: m}
 	m.SetRecordMock = mObjectStorageMockSetRecord{mock: m}
+	m.SetRequestResultMock = mObjectStorageMockSetRequestResult{mock: m}
 
 	return m
 }
@@ -546,6 +564,159 @@ func (m *ObjectStorageMock) GetRecordFinished() bool {
 	return true
 }
 
+type mObjectStorageMockGetRequestResult struct {
+	mock              *ObjectStorageMock
+	mainExpectation   *ObjectStorageMockGetRequestResultExpectation
+	expectationSeries []*ObjectStorageMockGetRequestResultExpectation
+}
+
+type ObjectStorageMockGetRequestResultExpectation struct {
+	input  *ObjectStorageMockGetRequestResultInput
+	result *ObjectStorageMockGetRequestResultResult
+}
+
+type ObjectStorageMockGetRequestResultInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 core.RecordID
+	p3 core.RecordID
+}
+
+type ObjectStorageMockGetRequestResultResult struct {
+	r  *index.RequestResult
+	r1 error
+}
+
+//Expect specifies that invocation of ObjectStorage.GetRequestResult is expected from 1 to Infinity times
+func (m *mObjectStorageMockGetRequestResult) Expect(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 core.RecordID) *mObjectStorageMockGetRequestResult {
+	m.mock.GetRequestResultFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectStorageMockGetRequestResultExpectation{}
+	}
+	m.mainExpectation.input = &ObjectStorageMockGetRequestResultInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of ObjectStorage.GetRequestResult
+func (m *mObjectStorageMockGetRequestResult) Return(r *index.RequestResult, r1 error) *ObjectStorageMock {
+	m.mock.GetRequestResultFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectStorageMockGetRequestResultExpectation{}
+	}
+	m.mainExpectation.result = &ObjectStorageMockGetRequestResultResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ObjectStorage.GetRequestResult is expected once
+func (m *mObjectStorageMockGetRequestResult) ExpectOnce(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 core.RecordID) *ObjectStorageMockGetRequestResultExpectation {
+	m.mock.GetRequestResultFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ObjectStorageMockGetRequestResultExpectation{}
+	expectation.input = &ObjectStorageMockGetRequestResultInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ObjectStorageMockGetRequestResultExpectation) Return(r *index.RequestResult, r1 error) {
+	e.result = &ObjectStorageMockGetRequestResultResult{r, r1}
+}
+
+//Set uses given function f as a mock of ObjectStorage.GetRequestResult method
+func (m *mObjectStorageMockGetRequestResult) Set(f func(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 core.RecordID) (r *index.RequestResult, r1 error)) *ObjectStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetRequestResultFunc = f
+	return m.mock
+}
+
+//GetRequestResult implements github.com/insolar/insolar/ledger/storage.ObjectStorage.ObjectStorage interface
+func (m *ObjectStorageMock) GetRequestResult(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 core.RecordID) (r *index.RequestResult, r1 error) {
+	counter := atomic.AddUint64(&m.GetRequestResultPreCounter, 1)
+	defer atomic.AddUint64(&m.GetRequestResultCounter, 1)
+
+	if len(m.GetRequestResultMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetRequestResultMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ObjectStorageMock.GetRequestResult. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.GetRequestResultMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ObjectStorageMockGetRequestResultInput{p, p1, p2, p3}, "ObjectStorage.GetRequestResult got unexpected parameters")
+
+		result := m.GetRequestResultMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectStorageMock.GetRequestResult")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetRequestResultMock.mainExpectation != nil {
+
+		input := m.GetRequestResultMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ObjectStorageMockGetRequestResultInput{p, p1, p2, p3}, "ObjectStorage.GetRequestResult got unexpected parameters")
+		}
+
+		result := m.GetRequestResultMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectStorageMock.GetRequestResult")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetRequestResultFunc == nil {
+		m.t.Fatalf("Unexpected call to ObjectStorageMock.GetRequestResult. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.GetRequestResultFunc(p, p1, p2, p3)
+}
+
+//GetRequestResultMinimockCounter returns a count of ObjectStorageMock.GetRequestResultFunc invocations
+func (m *ObjectStorageMock) GetRequestResultMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetRequestResultCounter)
+}
+
+//GetRequestResultMinimockPreCounter returns the value of ObjectStorageMock.GetRequestResult invocations
+func (m *ObjectStorageMock) GetRequestResultMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetRequestResultPreCounter)
+}
+
+//GetRequestResultFinished returns true if mock invocations count is ok
+func (m *ObjectStorageMock) GetRequestResultFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetRequestResultMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetRequestResultCounter) == uint64(len(m.GetRequestResultMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetRequestResultMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetRequestResultCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetRequestResultFunc != nil {
+		return atomic.LoadUint64(&m.GetRequestResultCounter) > 0
+	}
+
+	return true
+}
+
 type mObjectStorageMockIterateIndexIDs struct {
 	mock              *ObjectStorageMock
 	mainExpectation   *ObjectStorageMockIterateIndexIDsExpectation
@@ -695,6 +866,156 @@ func (m *ObjectStorageMock) IterateIndexIDsFinished() bool {
 	return true
 }
 
+type mObjectStorageMockIterateRequestResults struct {
+	mock              *ObjectStorageMock
+	mainExpectation   *ObjectStorageMockIterateRequestResultsExpectation
+	expectationSeries []*ObjectStorageMockIterateRequestResultsExpectation
+}
+
+type ObjectStorageMockIterateRequestResultsExpectation struct {
+	input  *ObjectStorageMockIterateRequestResultsInput
+	result *ObjectStorageMockIterateRequestResultsResult
+}
+
+type ObjectStorageMockIterateRequestResultsInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 core.RecordID
+	p3 func(request core.RecordID, result *index.RequestResult) error
+}
+
+type ObjectStorageMockIterateRequestResultsResult struct {
+	r error
+}
+
+//Expect specifies that invocation of ObjectStorage.IterateRequestResults is expected from 1 to Infinity times
+func (m *mObjectStorageMockIterateRequestResults) Expect(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 func(request core.RecordID, result *index.RequestResult) error) *mObjectStorageMockIterateRequestResults {
+	m.mock.IterateRequestResultsFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectStorageMockIterateRequestResultsExpectation{}
+	}
+	m.mainExpectation.input = &ObjectStorageMockIterateRequestResultsInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of ObjectStorage.IterateRequestResults
+func (m *mObjectStorageMockIterateRequestResults) Return(r error) *ObjectStorageMock {
+	m.mock.IterateRequestResultsFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectStorageMockIterateRequestResultsExpectation{}
+	}
+	m.mainExpectation.result = &ObjectStorageMockIterateRequestResultsResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ObjectStorage.IterateRequestResults is expected once
+func (m *mObjectStorageMockIterateRequestResults) ExpectOnce(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 func(request core.RecordID, result *index.RequestResult) error) *ObjectStorageMockIterateRequestResultsExpectation {
+	m.mock.IterateRequestResultsFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ObjectStorageMockIterateRequestResultsExpectation{}
+	expectation.input = &ObjectStorageMockIterateRequestResultsInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ObjectStorageMockIterateRequestResultsExpectation) Return(r error) {
+	e.result = &ObjectStorageMockIterateRequestResultsResult{r}
+}
+
+//Set uses given function f as a mock of ObjectStorage.IterateRequestResults method
+func (m *mObjectStorageMockIterateRequestResults) Set(f func(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 func(request core.RecordID, result *index.RequestResult) error) (r error)) *ObjectStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.IterateRequestResultsFunc = f
+	return m.mock
+}
+
+//IterateRequestResults implements github.com/insolar/insolar/ledger/storage.ObjectStorage.ObjectStorage interface
+func (m *ObjectStorageMock) IterateRequestResults(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 func(request core.RecordID, result *index.RequestResult) error) (r error) {
+	counter := atomic.AddUint64(&m.IterateRequestResultsPreCounter, 1)
+	defer atomic.AddUint64(&m.IterateRequestResultsCounter, 1)
+
+	if len(m.IterateRequestResultsMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.IterateRequestResultsMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ObjectStorageMock.IterateRequestResults. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.IterateRequestResultsMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ObjectStorageMockIterateRequestResultsInput{p, p1, p2, p3}, "ObjectStorage.IterateRequestResults got unexpected parameters")
+
+		result := m.IterateRequestResultsMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectStorageMock.IterateRequestResults")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.IterateRequestResultsMock.mainExpectation != nil {
+
+		input := m.IterateRequestResultsMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ObjectStorageMockIterateRequestResultsInput{p, p1, p2, p3}, "ObjectStorage.IterateRequestResults got unexpected parameters")
+		}
+
+		result := m.IterateRequestResultsMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectStorageMock.IterateRequestResults")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.IterateRequestResultsFunc == nil {
+		m.t.Fatalf("Unexpected call to ObjectStorageMock.IterateRequestResults. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.IterateRequestResultsFunc(p, p1, p2, p3)
+}
+
+//IterateRequestResultsMinimockCounter returns a count of ObjectStorageMock.IterateRequestResultsFunc invocations
+func (m *ObjectStorageMock) IterateRequestResultsMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.IterateRequestResultsCounter)
+}
+
+//IterateRequestResultsMinimockPreCounter returns the value of ObjectStorageMock.IterateRequestResults invocations
+func (m *ObjectStorageMock) IterateRequestResultsMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.IterateRequestResultsPreCounter)
+}
+
+//IterateRequestResultsFinished returns true if mock invocations count is ok
+func (m *ObjectStorageMock) IterateRequestResultsFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.IterateRequestResultsMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.IterateRequestResultsCounter) == uint64(len(m.IterateRequestResultsMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.IterateRequestResultsMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.IterateRequestResultsCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.IterateRequestResultsFunc != nil {
+		return atomic.LoadUint64(&m.IterateRequestResultsCounter) > 0
+	}
+
+	return true
+}
+
 type mObjectStorageMockRemoveObjectIndex struct {
 	mock              *ObjectStorageMock
 	mainExpectation   *ObjectStorageMockRemoveObjectIndexExpectation
@@ -1450,6 +1771,157 @@ func (m *ObjectStorageMock) SetRecordFinished() bool {
 	return true
 }
 
+type mObjectStorageMockSetRequestResult struct {
+	mock              *ObjectStorageMock
+	mainExpectation   *ObjectStorageMockSetRequestResultExpectation
+	expectationSeries []*ObjectStorageMockSetRequestResultExpectation
+}
+
+type ObjectStorageMockSetRequestResultExpectation struct {
+	input  *ObjectStorageMockSetRequestResultInput
+	result *ObjectStorageMockSetRequestResultResult
+}
+
+type ObjectStorageMockSetRequestResultInput struct {
+	p  context.Context
+	p1 core.RecordID
+	p2 core.RecordID
+	p3 core.RecordID
+	p4 *index.RequestResult
+}
+
+type ObjectStorageMockSetRequestResultResult struct {
+	r error
+}
+
+//Expect specifies that invocation of ObjectStorage.SetRequestResult is expected from 1 to Infinity times
+func (m *mObjectStorageMockSetRequestResult) Expect(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 core.RecordID, p4 *index.RequestResult) *mObjectStorageMockSetRequestResult {
+	m.mock.SetRequestResultFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectStorageMockSetRequestResultExpectation{}
+	}
+	m.mainExpectation.input = &ObjectStorageMockSetRequestResultInput{p, p1, p2, p3, p4}
+	return m
+}
+
+//Return specifies results of invocation of ObjectStorage.SetRequestResult
+func (m *mObjectStorageMockSetRequestResult) Return(r error) *ObjectStorageMock {
+	m.mock.SetRequestResultFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectStorageMockSetRequestResultExpectation{}
+	}
+	m.mainExpectation.result = &ObjectStorageMockSetRequestResultResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ObjectStorage.SetRequestResult is expected once
+func (m *mObjectStorageMockSetRequestResult) ExpectOnce(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 core.RecordID, p4 *index.RequestResult) *ObjectStorageMockSetRequestResultExpectation {
+	m.mock.SetRequestResultFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ObjectStorageMockSetRequestResultExpectation{}
+	expectation.input = &ObjectStorageMockSetRequestResultInput{p, p1, p2, p3, p4}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ObjectStorageMockSetRequestResultExpectation) Return(r error) {
+	e.result = &ObjectStorageMockSetRequestResultResult{r}
+}
+
+//Set uses given function f as a mock of ObjectStorage.SetRequestResult method
+func (m *mObjectStorageMockSetRequestResult) Set(f func(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 core.RecordID, p4 *index.RequestResult) (r error)) *ObjectStorageMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.SetRequestResultFunc = f
+	return m.mock
+}
+
+//SetRequestResult implements github.com/insolar/insolar/ledger/storage.ObjectStorage.ObjectStorage interface
+func (m *ObjectStorageMock) SetRequestResult(p context.Context, p1 core.RecordID, p2 core.RecordID, p3 core.RecordID, p4 *index.RequestResult) (r error) {
+	counter := atomic.AddUint64(&m.SetRequestResultPreCounter, 1)
+	defer atomic.AddUint64(&m.SetRequestResultCounter, 1)
+
+	if len(m.SetRequestResultMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.SetRequestResultMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ObjectStorageMock.SetRequestResult. %v %v %v %v %v", p, p1, p2, p3, p4)
+			return
+		}
+
+		input := m.SetRequestResultMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ObjectStorageMockSetRequestResultInput{p, p1, p2, p3, p4}, "ObjectStorage.SetRequestResult got unexpected parameters")
+
+		result := m.SetRequestResultMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectStorageMock.SetRequestResult")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SetRequestResultMock.mainExpectation != nil {
+
+		input := m.SetRequestResultMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ObjectStorageMockSetRequestResultInput{p, p1, p2, p3, p4}, "ObjectStorage.SetRequestResult got unexpected parameters")
+		}
+
+		result := m.SetRequestResultMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectStorageMock.SetRequestResult")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.SetRequestResultFunc == nil {
+		m.t.Fatalf("Unexpected call to ObjectStorageMock.SetRequestResult. %v %v %v %v %v", p, p1, p2, p3, p4)
+		return
+	}
+
+	return m.SetRequestResultFunc(p, p1, p2, p3, p4)
+}
+
+//SetRequestResultMinimockCounter returns a count of ObjectStorageMock.SetRequestResultFunc invocations
+func (m *ObjectStorageMock) SetRequestResultMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SetRequestResultCounter)
+}
+
+//SetRequestResultMinimockPreCounter returns the value of ObjectStorageMock.SetRequestResult invocations
+func (m *ObjectStorageMock) SetRequestResultMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SetRequestResultPreCounter)
+}
+
+//SetRequestResultFinished returns true if mock invocations count is ok
+func (m *ObjectStorageMock) SetRequestResultFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.SetRequestResultMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.SetRequestResultCounter) == uint64(len(m.SetRequestResultMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.SetRequestResultMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.SetRequestResultCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.SetRequestResultFunc != nil {
+		return atomic.LoadUint64(&m.SetRequestResultCounter) > 0
+	}
+
+	return true
+}
+
 //ValidateCallCounters checks that all mocked methods of the interface have been called at least once
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *ObjectStorageMock) ValidateCallCounters() {
@@ -1466,10 +1938,18 @@ func (m *ObjectStorageMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ObjectStorageMock.GetRecord")
 	}
 
+	if !m.GetRequestResultFinished() {
+		m.t.Fatal("Expected call to ObjectStorageMock.GetRequestResult")
+	}
+
 	if !m.IterateIndexIDsFinished() {
 		m.t.Fatal("Expected call to ObjectStorageMock.IterateIndexIDs")
 	}
 
+	if !m.IterateRequestResultsFinished() {
+		m.t.Fatal("Expected call to ObjectStorageMock.IterateRequestResults")
+	}
+
 	if !m.RemoveObjectIndexFinished() {
 		m.t.Fatal("Expected call to ObjectStorageMock.RemoveObjectIndex")
 	}
@@ -1490,6 +1970,10 @@ func (m *ObjectStorageMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ObjectStorageMock.SetRecord")
 	}
 
+	if !m.SetRequestResultFinished() {
+		m.t.Fatal("Expected call to ObjectStorageMock.SetRequestResult")
+	}
+
 }
 
 //CheckMocksCalled checks that all mocked methods of the interface have been called at least once
@@ -1519,10 +2003,18 @@ func (m *ObjectStorageMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ObjectStorageMock.GetRecord")
 	}
 
+	if !m.GetRequestResultFinished() {
+		m.t.Fatal("Expected call to ObjectStorageMock.GetRequestResult")
+	}
+
 	if !m.IterateIndexIDsFinished() {
 		m.t.Fatal("Expected call to ObjectStorageMock.IterateIndexIDs")
 	}
 
+	if !m.IterateRequestResultsFinished() {
+		m.t.Fatal("Expected call to ObjectStorageMock.IterateRequestResults")
+	}
+
 	if !m.RemoveObjectIndexFinished() {
 		m.t.Fatal("Expected call to ObjectStorageMock.RemoveObjectIndex")
 	}
@@ -1543,6 +2035,10 @@ func (m *ObjectStorageMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ObjectStorageMock.SetRecord")
 	}
 
+	if !m.SetRequestResultFinished() {
+		m.t.Fatal("Expected call to ObjectStorageMock.SetRequestResult")
+	}
+
 }
 
 //Wait waits for all mocked methods to be called at least once
@@ -1560,12 +2056,15 @@ func (m *ObjectStorageMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.GetBlobFinished()
 		ok = ok && m.GetObjectIndexFinished()
 		ok = ok && m.GetRecordFinished()
+		ok = ok && m.GetRequestResultFinished()
 		ok = ok && m.IterateIndexIDsFinished()
+		ok = ok && m.IterateRequestResultsFinished()
 		ok = ok && m.RemoveObjectIndexFinished()
 		ok = ok && m.SetBlobFinished()
 		ok = ok && m.SetMessageFinished()
 		ok = ok && m.SetObjectIndexFinished()
 		ok = ok && m.SetRecordFinished()
+		ok = ok && m.SetRequestResultFinished()
 
 		if ok {
 			return
@@ -1586,10 +2085,18 @@ func (m *ObjectStorageMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ObjectStorageMock.GetRecord")
 			}
 
+			if !m.GetRequestResultFinished() {
+				m.t.Error("Expected call to ObjectStorageMock.GetRequestResult")
+			}
+
 			if !m.IterateIndexIDsFinished() {
 				m.t.Error("Expected call to ObjectStorageMock.IterateIndexIDs")
 			}
 
+			if !m.IterateRequestResultsFinished() {
+				m.t.Error("Expected call to ObjectStorageMock.IterateRequestResults")
+			}
+
 			if !m.RemoveObjectIndexFinished() {
 				m.t.Error("Expected call to ObjectStorageMock.RemoveObjectIndex")
 			}
@@ -1610,6 +2117,10 @@ func (m *ObjectStorageMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ObjectStorageMock.SetRecord")
 			}
 
+			if !m.SetRequestResultFinished() {
+				m.t.Error("Expected call to ObjectStorageMock.SetRequestResult")
+			}
+
 			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
 			return
 		default:
@@ -1634,10 +2145,18 @@ func (m *ObjectStorageMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetRequestResultFinished() {
+		return false
+	}
+
 	if !m.IterateIndexIDsFinished() {
 		return false
 	}
 
+	if !m.IterateRequestResultsFinished() {
+		return false
+	}
+
 	if !m.RemoveObjectIndexFinished() {
 		return false
 	}
@@ -1658,5 +2177,9 @@ func (m *ObjectStorageMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.SetRequestResultFinished() {
+		return false
+	}
+
 	return true
 }
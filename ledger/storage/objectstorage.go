@@ -62,6 +62,28 @@ type ObjectStorage interface {
 		jetID core.RecordID,
 		ref *core.RecordID,
 	) error
+
+	GetRequestResult(
+		ctx context.Context,
+		jetID core.RecordID,
+		object core.RecordID,
+		request core.RecordID,
+	) (*index.RequestResult, error)
+
+	SetRequestResult(
+		ctx context.Context,
+		jetID core.RecordID,
+		object core.RecordID,
+		request core.RecordID,
+		result *index.RequestResult,
+	) error
+
+	IterateRequestResults(
+		ctx context.Context,
+		jetID core.RecordID,
+		object core.RecordID,
+		handler func(request core.RecordID, result *index.RequestResult) error,
+	) error
 }
 
 type objectStorage struct {
@@ -220,3 +242,58 @@ func (os *objectStorage) RemoveObjectIndex(
 		return tx.RemoveObjectIndex(ctx, jetID, ref)
 	})
 }
+
+// GetRequestResult returns result index of object request.
+func (os *objectStorage) GetRequestResult(
+	ctx context.Context,
+	jetID core.RecordID,
+	object core.RecordID,
+	request core.RecordID,
+) (*index.RequestResult, error) {
+	_, prefix := jet.Jet(jetID)
+	buf, err := os.DB.get(ctx, prefixkey(scopeIDRequestResult, prefix, object[:], request[:]))
+	if err != nil {
+		return nil, err
+	}
+	return index.DecodeRequestResult(buf)
+}
+
+// SetRequestResult saves result index of object request.
+//
+// Indexes are stored by object like lifelines, so they are replicated, moved on jet split and cleaned together
+// with object lifeline.
+func (os *objectStorage) SetRequestResult(
+	ctx context.Context,
+	jetID core.RecordID,
+	object core.RecordID,
+	request core.RecordID,
+	result *index.RequestResult,
+) error {
+	_, prefix := jet.Jet(jetID)
+	encoded, err := index.EncodeRequestResult(result)
+	if err != nil {
+		return err
+	}
+	return os.DB.set(ctx, prefixkey(scopeIDRequestResult, prefix, object[:], request[:]), encoded)
+}
+
+// IterateRequestResults iterates over result indexes of object requests on provided Jet ID.
+func (os *objectStorage) IterateRequestResults(
+	ctx context.Context,
+	jetID core.RecordID,
+	object core.RecordID,
+	handler func(request core.RecordID, result *index.RequestResult) error,
+) error {
+	_, jetPrefix := jet.Jet(jetID)
+	prefix := prefixkey(scopeIDRequestResult, jetPrefix, object[:])
+
+	return os.DB.iterate(ctx, prefix, func(k, v []byte) error {
+		var request core.RecordID
+		copy(request[:], k)
+		result, err := index.DecodeRequestResult(v)
+		if err != nil {
+			return err
+		}
+		return handler(request, result)
+	})
+}
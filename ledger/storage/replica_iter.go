@@ -37,7 +37,8 @@ type iterstate struct {
 // required for replication to Heavy Material node in provided pulses range.
 //
 // "Required KV pairs" are all keys with namespace 'scopeIDRecord' (TODO: 'add scopeIDBlob')
-// in provided pulses range and all indexes from zero pulse to the end of provided range.
+// in provided pulses range and all indexes (object lifelines and request results) from zero pulse
+// to the end of provided range.
 //
 // "Partial" means it fetches data in chunks of the specified size.
 // After a chunk has been fetched, an iterator saves current position.
@@ -87,6 +88,7 @@ func NewReplicaIter(
 			newit(scopeIDRecord, jetID, start, end),
 			newit(scopeIDBlob, jetID, start, end),
 			newit(scopeIDLifeline, jetID, core.FirstPulseNumber, end),
+			newit(scopeIDRequestResult, jetID, core.FirstPulseNumber, end),
 			newit(scopeIDJetDrop, jetID, start, end),
 		},
 	}
@@ -49,6 +49,7 @@ type storageSuite struct {
 
 	objectStorage storage.ObjectStorage
 	dropStorage   storage.DropStorage
+	jetStorage    storage.JetStorage
 	pulseTracker  storage.PulseTracker
 
 	jetID core.RecordID
@@ -75,6 +76,7 @@ func (s *storageSuite) BeforeTest(suiteName, testName string) {
 
 	s.objectStorage = storage.NewObjectStorage()
 	s.dropStorage = storage.NewDropStorage(10)
+	s.jetStorage = storage.NewJetStorage()
 	s.pulseTracker = storage.NewPulseTracker()
 	s.jetID = testutils.RandomJet()
 
@@ -83,6 +85,7 @@ func (s *storageSuite) BeforeTest(suiteName, testName string) {
 		s.db,
 		s.objectStorage,
 		s.dropStorage,
+		s.jetStorage,
 		s.pulseTracker,
 	)
 
@@ -163,6 +166,74 @@ func (s *storageSuite) TestDB_SetObjectIndex_SaveLastUpdate() {
 	assert.Equal(s.T(), 1239, int(idx.LatestUpdate))
 }
 
+func (s *storageSuite) TestDB_MergeJetTree_MigratesIndexes() {
+	pulse := core.PulseNumber(core.FirstPulseNumber)
+	left, right, err := s.jetStorage.SplitJetTree(s.ctx, pulse, *jet.NewID(0, nil))
+	require.NoError(s.T(), err)
+
+	leftID := core.NewRecordID(pulse, hexhash("10"))
+	leftIdx := index.ObjectLifeline{LatestState: core.NewRecordID(pulse, hexhash("11"))}
+	err = s.objectStorage.SetObjectIndex(s.ctx, *left, leftID, &leftIdx)
+	require.NoError(s.T(), err)
+	rightID := core.NewRecordID(pulse, hexhash("f0"))
+	rightIdx := index.ObjectLifeline{LatestState: core.NewRecordID(pulse, hexhash("f1"))}
+	err = s.objectStorage.SetObjectIndex(s.ctx, *right, rightID, &rightIdx)
+	require.NoError(s.T(), err)
+	request := *core.NewRecordID(pulse, hexhash("f2"))
+	result := index.RequestResult{Result: *core.NewRecordID(pulse, hexhash("f3")), Payload: []byte{1}}
+	err = s.objectStorage.SetRequestResult(s.ctx, *right, *rightID, request, &result)
+	require.NoError(s.T(), err)
+
+	parent, err := s.jetStorage.MergeJetTree(s.ctx, pulse, *right)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), jet.NewID(0, nil), parent)
+
+	tree, err := s.jetStorage.GetJetTree(s.ctx, pulse)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []core.RecordID{*parent}, tree.LeafIDs())
+
+	for id, expected := range map[*core.RecordID]index.ObjectLifeline{leftID: leftIdx, rightID: rightIdx} {
+		idx, err := s.objectStorage.GetObjectIndex(s.ctx, *parent, id, false)
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), expected, *idx)
+	}
+	_, err = s.objectStorage.GetObjectIndex(s.ctx, *right, rightID, false)
+	require.Equal(s.T(), storage.ErrNotFound, err)
+
+	res, err := s.objectStorage.GetRequestResult(s.ctx, *parent, *rightID, request)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), result, *res)
+	_, err = s.objectStorage.GetRequestResult(s.ctx, *right, *rightID, request)
+	require.Equal(s.T(), storage.ErrNotFound, err)
+}
+
+func (s *storageSuite) TestDB_IterateRequestResults() {
+	object := *core.NewRecordID(core.FirstPulseNumber, hexhash("10"))
+	other := *core.NewRecordID(core.FirstPulseNumber, hexhash("20"))
+	results := map[core.RecordID]index.RequestResult{
+		*core.NewRecordID(core.FirstPulseNumber, hexhash("11")): {Result: *core.NewRecordID(core.FirstPulseNumber, hexhash("12"))},
+		*core.NewRecordID(core.FirstPulseNumber, hexhash("13")): {Result: *core.NewRecordID(core.FirstPulseNumber, hexhash("14"))},
+	}
+	for request, result := range results {
+		result := result
+		err := s.objectStorage.SetRequestResult(s.ctx, s.jetID, object, request, &result)
+		require.NoError(s.T(), err)
+	}
+	err := s.objectStorage.SetRequestResult(
+		s.ctx, s.jetID, other, *core.NewRecordID(core.FirstPulseNumber, hexhash("21")), &index.RequestResult{},
+	)
+	require.NoError(s.T(), err)
+
+	iterated := map[core.RecordID]index.RequestResult{}
+	err = s.objectStorage.IterateRequestResults(s.ctx, s.jetID, object,
+		func(request core.RecordID, result *index.RequestResult) error {
+			iterated[request] = *result
+			return nil
+		})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), results, iterated)
+}
+
 func (s *storageSuite) TestDB_GetDrop_ReturnsNotFoundIfNoDrop() {
 	drop, err := s.dropStorage.GetDrop(s.ctx, testutils.RandomJet(), 1)
 	assert.Equal(s.T(), err, storage.ErrNotFound)
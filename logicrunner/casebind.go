@@ -48,13 +48,21 @@ func NewCaseBind() *CaseBind {
 }
 
 func NewCaseBindFromValidateMessage(ctx context.Context, mb core.MessageBus, msg *message.ValidateCaseBind) *CaseBind {
+	res, err := caseBindFromValidateMessage(ctx, mb, msg)
+	if err != nil {
+		panic(err.Error())
+	}
+	return res
+}
+
+func caseBindFromValidateMessage(ctx context.Context, mb core.MessageBus, msg *message.ValidateCaseBind) (*CaseBind, error) {
 	res := &CaseBind{
 		Requests: make([]CaseRequest, len(msg.Requests)),
 	}
 	for i, req := range msg.Requests {
 		mb, err := mb.NewPlayer(ctx, bytes.NewReader(req.MessageBusTape))
 		if err != nil {
-			panic("couldn't read tape: " + err.Error())
+			return nil, errors.Wrap(err, "couldn't read tape")
 		}
 		res.Requests[i] = CaseRequest{
 			Parcel:     req.Parcel,
@@ -64,7 +72,7 @@ func NewCaseBindFromValidateMessage(ctx context.Context, mb core.MessageBus, msg
 			Error:      req.Error,
 		}
 	}
-	return res
+	return res, nil
 }
 
 func NewCaseBindFromExecutorResultsMessage(msg *message.ExecutorResults) *CaseBind {
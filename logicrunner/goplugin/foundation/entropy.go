@@ -0,0 +1,139 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package foundation
+
+import (
+	"fmt"
+
+	"github.com/insolar/insolar/core"
+)
+
+// VerifyPulseEntropy checks that entropy of pulse is combined from entropies revealed by trusted pulsars
+// and returns it. Pulsars are identified by PEM public keys. At least threshold of them must confirm the pulse entropy
+// in pulse signs, and the proof must contain reveals of exactly the confirming pulsars, so pulse sender can't choose
+// a subset of reveals.
+func VerifyPulseEntropy(pulse core.Pulse, pulsarKeys []string, threshold int) (core.Entropy, error) {
+	if threshold <= 0 || threshold > len(pulsarKeys) {
+		return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] invalid threshold %d for %d pulsars", threshold, len(pulsarKeys))
+	}
+	if len(pulse.EntropyProof) == 0 {
+		return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] pulse %d has no entropy proof", pulse.PulseNumber)
+	}
+
+	trusted := map[string]bool{}
+	for _, key := range pulsarKeys {
+		normalized, err := normalizePublicKey(key)
+		if err != nil {
+			return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] bad pulsar key: %s", err.Error())
+		}
+		trusted[normalized] = true
+	}
+
+	confirmed, err := confirmedPulsars(pulse, trusted)
+	if err != nil {
+		return core.Entropy{}, err
+	}
+	if len(confirmed) < threshold {
+		return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] entropy confirmed by %d pulsars, %d required", len(confirmed), threshold)
+	}
+
+	revealed := map[string]bool{}
+	for _, reveal := range pulse.EntropyProof {
+		normalized, err := normalizePublicKey(reveal.PublicKey)
+		if err != nil {
+			return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] bad key in proof: %s", err.Error())
+		}
+		if !trusted[normalized] {
+			return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] entropy revealed by unknown pulsar")
+		}
+		if !confirmed[normalized] {
+			return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] entropy revealed by pulsar that didn't confirm pulse")
+		}
+		if revealed[normalized] {
+			return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] entropy revealed twice by the same pulsar")
+		}
+		revealed[normalized] = true
+
+		publicKey, err := ImportPublicKey(normalized)
+		if err != nil {
+			return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] bad key in proof: %s", err.Error())
+		}
+		if !Verify(core.EntropyCommitment(pulse.PulseNumber, reveal.Entropy), reveal.Signature, publicKey) {
+			return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] invalid entropy signature")
+		}
+	}
+
+	if len(revealed) != len(confirmed) {
+		return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] entropy revealed by %d of %d confirming pulsars", len(revealed), len(confirmed))
+	}
+	if core.CombineEntropy(pulse.EntropyProof) != pulse.Entropy {
+		return core.Entropy{}, fmt.Errorf("[ VerifyPulseEntropy ] pulse entropy doesn't match proof")
+	}
+
+	return pulse.Entropy, nil
+}
+
+// confirmedPulsars returns normalized keys of trusted pulsars which signs of pulse confirm its entropy.
+func confirmedPulsars(pulse core.Pulse, trusted map[string]bool) (map[string]bool, error) {
+	confirmed := map[string]bool{}
+	for key, sign := range pulse.Signs {
+		normalized, err := normalizePublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("[ VerifyPulseEntropy ] bad key in signs: %s", err.Error())
+		}
+		if !trusted[normalized] || sign.PulseNumber != pulse.PulseNumber || sign.Entropy != pulse.Entropy {
+			continue
+		}
+		publicKey, err := ImportPublicKey(normalized)
+		if err != nil {
+			return nil, fmt.Errorf("[ VerifyPulseEntropy ] bad key in signs: %s", err.Error())
+		}
+		hash, err := confirmationHash(sign)
+		if err != nil {
+			return nil, fmt.Errorf("[ VerifyPulseEntropy ] failed to hash sign: %s", err.Error())
+		}
+		if Verify(hash, sign.Signature, publicKey) {
+			confirmed[normalized] = true
+		}
+	}
+	return confirmed, nil
+}
+
+// confirmationHash returns hash signed by pulsar in pulse sender confirmation. It is the hash of pulsar's
+// PulseSenderConfirmationPayload without signature.
+func confirmationHash(sign core.PulseSenderConfirmation) ([]byte, error) {
+	hasher := platformCryptographyScheme.IntegrityHasher()
+	for _, data := range [][]byte{sign.PulseNumber.Bytes(), []byte(sign.ChosenPublicKey), sign.Entropy[:]} {
+		if _, err := hasher.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	return hasher.Sum(nil), nil
+}
+
+// GetVerifiedEntropy returns entropy of current pulse verified by VerifyPulseEntropy.
+func GetVerifiedEntropy(pulsarKeys []string, threshold int) (core.Entropy, error) {
+	return VerifyPulseEntropy(GetContext().Pulse, pulsarKeys, threshold)
+}
+
+func normalizePublicKey(key string) (string, error) {
+	publicKey, err := ImportPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	return ExportPublicKey(publicKey)
+}
@@ -0,0 +1,129 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package foundation
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/require"
+)
+
+type testPulsar struct {
+	key        string
+	privateKey crypto.PrivateKey
+}
+
+func makeReveal(t *testing.T, pn core.PulseNumber, seed byte) (core.EntropyReveal, testPulsar) {
+	privateKey, err := GeneratePrivateKey()
+	require.NoError(t, err)
+	publicKey, err := ExportPublicKey(ExtractPublicKey(privateKey))
+	require.NoError(t, err)
+
+	var entropy core.Entropy
+	for i := range entropy {
+		entropy[i] = seed + byte(i)
+	}
+	sign, err := Sign(core.EntropyCommitment(pn, entropy), privateKey)
+	require.NoError(t, err)
+
+	return core.EntropyReveal{PublicKey: publicKey, Entropy: entropy, Signature: sign}, testPulsar{publicKey, privateKey}
+}
+
+// confirmPulse returns pulse signs of pulsars as pulsar makes them.
+func confirmPulse(t *testing.T, pulse core.Pulse, pulsars ...testPulsar) map[string]core.PulseSenderConfirmation {
+	signs := map[string]core.PulseSenderConfirmation{}
+	for _, p := range pulsars {
+		sign := core.PulseSenderConfirmation{PulseNumber: pulse.PulseNumber, ChosenPublicKey: pulsars[0].key, Entropy: pulse.Entropy}
+		hash, err := confirmationHash(sign)
+		require.NoError(t, err)
+		sign.Signature, err = Sign(hash, p.privateKey)
+		require.NoError(t, err)
+		signs[p.key] = sign
+	}
+	return signs
+}
+
+func TestVerifyPulseEntropy(t *testing.T) {
+	pn := core.PulseNumber(core.FirstPulseNumber + 10)
+	first, firstPulsar := makeReveal(t, pn, 1)
+	second, secondPulsar := makeReveal(t, pn, 100)
+	third, thirdPulsar := makeReveal(t, pn, 200)
+	keys := []string{firstPulsar.key, secondPulsar.key, thirdPulsar.key}
+
+	proof := []core.EntropyReveal{first, second}
+	pulse := core.Pulse{PulseNumber: pn, Entropy: core.CombineEntropy(proof), EntropyProof: proof}
+	pulse.Signs = confirmPulse(t, pulse, firstPulsar, secondPulsar)
+
+	entropy, err := VerifyPulseEntropy(pulse, keys, 2)
+	require.NoError(t, err)
+	require.Equal(t, pulse.Entropy, entropy)
+
+	_, err = VerifyPulseEntropy(pulse, keys, 3)
+	require.Error(t, err, "not enough pulsars")
+
+	_, err = VerifyPulseEntropy(pulse, keys[1:], 1)
+	require.Error(t, err, "unknown pulsar")
+
+	_, err = VerifyPulseEntropy(core.Pulse{PulseNumber: pn, Entropy: pulse.Entropy, Signs: pulse.Signs}, keys, 1)
+	require.Error(t, err, "no proof")
+
+	tampered := pulse
+	tampered.Entropy[0] ^= 1
+	_, err = VerifyPulseEntropy(tampered, keys, 2)
+	require.Error(t, err, "entropy doesn't match proof")
+
+	replayed := pulse
+	replayed.PulseNumber++
+	_, err = VerifyPulseEntropy(replayed, keys, 2)
+	require.Error(t, err, "signature of other pulse")
+
+	duplicated := pulse
+	duplicated.EntropyProof = []core.EntropyReveal{first, first}
+	duplicated.Entropy = core.CombineEntropy(duplicated.EntropyProof)
+	_, err = VerifyPulseEntropy(duplicated, keys, 2)
+	require.Error(t, err, "same pulsar twice")
+
+	unsigned := pulse
+	unsigned.Signs = nil
+	_, err = VerifyPulseEntropy(unsigned, keys, 1)
+	require.Error(t, err, "entropy is not confirmed")
+
+	// All three pulsars confirmed entropy of all reveals, sender drops one reveal to choose other entropy.
+	full := []core.EntropyReveal{first, second, third}
+	honest := core.Pulse{PulseNumber: pn, Entropy: core.CombineEntropy(full), EntropyProof: full}
+	honest.Signs = confirmPulse(t, honest, firstPulsar, secondPulsar, thirdPulsar)
+	_, err = VerifyPulseEntropy(honest, keys, 2)
+	require.NoError(t, err)
+
+	ground := honest
+	ground.EntropyProof = proof
+	ground.Entropy = pulse.Entropy
+	_, err = VerifyPulseEntropy(ground, keys, 2)
+	require.Error(t, err, "signs confirm other entropy")
+
+	ground.Signs = confirmPulse(t, ground, firstPulsar, secondPulsar)
+	ground.Signs[thirdPulsar.key] = honest.Signs[thirdPulsar.key]
+	_, err = VerifyPulseEntropy(ground, keys, 3)
+	require.Error(t, err, "honest pulsar didn't confirm chosen entropy")
+
+	dropped := honest
+	dropped.EntropyProof = full[:2]
+	_, err = VerifyPulseEntropy(dropped, keys, 2)
+	require.Error(t, err, "reveal of confirming pulsar is dropped")
+}
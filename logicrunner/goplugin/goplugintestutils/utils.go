@@ -170,6 +170,11 @@ func (t *TestArtifactManager) GetChildren(ctx context.Context, parent core.Recor
 	panic("implement me")
 }
 
+// GetObjectHistory implementation for tests
+func (t *TestArtifactManager) GetObjectHistory(ctx context.Context, head core.RecordRef, from *core.RecordID, amount int) (*core.ObjectHistory, error) {
+	panic("implement me")
+}
+
 // NewTestArtifactManager implementation for tests
 func NewTestArtifactManager() *TestArtifactManager {
 	return &TestArtifactManager{
@@ -195,6 +200,11 @@ func (t *TestArtifactManager) RegisterResult(
 	panic("implement me")
 }
 
+// GetRequestResult implementation for tests
+func (t *TestArtifactManager) GetRequestResult(ctx context.Context, object, request core.RecordRef) (*core.RequestResult, error) {
+	panic("implement me")
+}
+
 // GetObject implementation for tests
 func (t *TestArtifactManager) GetObject(ctx context.Context, object core.RecordRef, state *core.RecordID, approved bool) (core.ObjectDescriptor, error) {
 	res, ok := t.Objects[object]
@@ -25,6 +25,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/insolar/insolar/instrumentation/instracer"
@@ -43,6 +44,9 @@ import (
 
 const maxQueueLength = 10
 
+// drainCheckPeriod is a period of checking whether executions are finished while draining.
+const drainCheckPeriod = 100 * time.Millisecond
+
 type Ref = core.RecordRef
 
 // Context of one contract execution
@@ -212,6 +216,7 @@ type LogicRunner struct {
 	PulseStorage               core.PulseStorage               `inject:""`
 	ArtifactManager            core.ArtifactManager            `inject:""`
 	JetCoordinator             core.JetCoordinator             `inject:""`
+	EventBus                   core.EventBus                   `inject:""`
 
 	Executors    [core.MachineTypesLastID]core.MachineLogicExecutor
 	machinePrefs []core.MachineType
@@ -220,6 +225,9 @@ type LogicRunner struct {
 	state      map[Ref]*ObjectState // if object exists, we are validating or executing it right now
 	stateMutex sync.RWMutex
 
+	// sending is a number of goroutines sending results of executions to the next executors
+	sending int32
+
 	sock net.Listener
 }
 
@@ -556,12 +564,7 @@ func (lr *LogicRunner) ProcessExecutionQueue(ctx context.Context, es *ExecutionS
 
 		res := ExecutionQueueResult{}
 
-		recordingBus := lr.MessageBus
-		//recordingBus, err := lr.MessageBus.NewRecorder(qe.ctx, *lr.pulse(qe.ctx))
-		//if err != nil {
-		//	res.err = err
-		//	continue
-		//}
+		recordingBus := lr.recordingBus(qe.ctx)
 
 		current.Context = core.ContextWithMessageBus(qe.ctx, recordingBus)
 
@@ -575,6 +578,7 @@ func (lr *LogicRunner) ProcessExecutionQueue(ctx context.Context, es *ExecutionS
 		if err != nil {
 			res.err = err
 		}
+		lr.saveCaseTape(qe.ctx, *qe.parcel.Message().DefaultTarget(), es.Behaviour.(*ValidationSaver).current)
 
 		lr.finishPendingIfNeeded(ctx, es, *qe.parcel.Message().DefaultTarget())
 	}
@@ -600,7 +604,9 @@ func (lr *LogicRunner) finishPendingIfNeeded(ctx context.Context, es *ExecutionS
 	)
 	if !meCurrent {
 		es.objectbody = nil
+		atomic.AddInt32(&lr.sending, 1)
 		go func() {
+			defer atomic.AddInt32(&lr.sending, -1)
 			msg := message.PendingFinished{Reference: currentRef}
 			_, err := lr.MessageBus.Send(ctx, &msg, nil)
 			if err != nil {
@@ -804,12 +810,13 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 
 	am := lr.ArtifactManager
 	if es.deactivate {
-		_, err := am.DeactivateObject(
+		state, err := am.DeactivateObject(
 			ctx, Ref{}, *current.Request, es.objectbody.objDescriptor,
 		)
 		if err != nil {
 			return nil, es.WrapError(err, "couldn't deactivate object")
 		}
+		lr.publishObjectState(ctx, m.ObjectRef, state, true)
 	} else if !bytes.Equal(es.objectbody.Object, newData) {
 		od, err := am.UpdateObject(ctx, Ref{}, *current.Request, es.objectbody.objDescriptor, newData)
 		if err != nil {
@@ -819,17 +826,42 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 			return nil, es.WrapError(err, "couldn't update object")
 		}
 		es.objectbody.objDescriptor = od
+		lr.publishObjectState(ctx, m.ObjectRef, od.StateID(), false)
 	}
-	_, err = am.RegisterResult(ctx, m.ObjectRef, *current.Request, result)
+	resultID, err := am.RegisterResult(ctx, m.ObjectRef, *current.Request, result)
 	if err != nil {
 		return nil, es.WrapError(err, "couldn't save results")
 	}
+	lr.publishRequestResult(ctx, m.ObjectRef, *current.Request, resultID, result)
 
 	es.objectbody.Object = newData
 
 	return &reply.CallMethod{Result: result, Request: *current.Request}, nil
 }
 
+// publishObjectState notifies local subscribers about object state saved by this executor.
+func (lr *LogicRunner) publishObjectState(ctx context.Context, object Ref, state *core.RecordID, deactivated bool) {
+	lr.EventBus.Publish(ctx, core.Event{
+		Type:        core.EventObjectState,
+		Object:      object.Record(),
+		State:       state,
+		Deactivated: deactivated,
+	})
+}
+
+// publishRequestResult notifies local subscribers about request result saved by this executor.
+func (lr *LogicRunner) publishRequestResult(
+	ctx context.Context, object, request Ref, result *core.RecordID, payload []byte,
+) {
+	lr.EventBus.Publish(ctx, core.Event{
+		Type:    core.EventRequestResult,
+		Object:  object.Record(),
+		Request: &request,
+		Result:  result,
+		Payload: payload,
+	})
+}
+
 func (lr *LogicRunner) getDescriptorsByPrototypeRef(
 	ctx context.Context, protoRef Ref,
 ) (
@@ -908,14 +940,19 @@ func (lr *LogicRunner) executeConstructorCall(
 
 	switch m.SaveAs {
 	case message.Child, message.Delegate:
-		_, err = lr.ArtifactManager.ActivateObject(
+		od, err := lr.ArtifactManager.ActivateObject(
 			ctx,
 			Ref{}, *current.Request, m.ParentRef, m.PrototypeRef, m.SaveAs == message.Delegate, newData,
 		)
-		_, err = lr.ArtifactManager.RegisterResult(ctx, *current.Request, *current.Request, nil)
+		if err != nil {
+			return nil, es.WrapError(err, "couldn't activate object")
+		}
+		lr.publishObjectState(ctx, *current.Request, od.StateID(), false)
+		resultID, err := lr.ArtifactManager.RegisterResult(ctx, *current.Request, *current.Request, nil)
 		if err != nil {
 			return nil, es.WrapError(err, "couldn't save results")
 		}
+		lr.publishRequestResult(ctx, *current.Request, *current.Request, resultID, nil)
 		return &reply.CallConstructor{Object: current.Request}, err
 
 	default:
@@ -1039,12 +1076,56 @@ func (lr *LogicRunner) OnPulse(ctx context.Context, pulse core.Pulse) error {
 	lr.stateMutex.Unlock()
 
 	if len(messages) > 0 {
+		atomic.AddInt32(&lr.sending, 1)
 		go lr.sendOnPulseMessagesAsync(ctx, messages)
 	}
 
 	return nil
 }
 
+// Drain waits until executions started by the node are finished and their results
+// are sent to the next executors. Queued requests are handed off on pulse, so Drain
+// should be called after the node stops being executor.
+func (lr *LogicRunner) Drain(ctx context.Context) error {
+	ticker := time.NewTicker(drainCheckPeriod)
+	defer ticker.Stop()
+
+	for !lr.isIdle() {
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "[ Drain ] executions are not finished")
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func (lr *LogicRunner) isIdle() bool {
+	if atomic.LoadInt32(&lr.sending) > 0 {
+		return false
+	}
+
+	lr.stateMutex.RLock()
+	defer lr.stateMutex.RUnlock()
+
+	for _, state := range lr.state {
+		state.Lock()
+		es := state.ExecutionState
+		busy := false
+		if es != nil {
+			es.Lock()
+			busy = es.QueueProcessorActive || es.Current != nil
+			es.Unlock()
+		}
+		state.Unlock()
+
+		if busy {
+			return false
+		}
+	}
+	return true
+}
+
 func (lr *LogicRunner) HandleStillExecutingMessage(
 	ctx context.Context, parcel core.Parcel,
 ) (
@@ -1120,6 +1201,7 @@ func (lr *LogicRunner) HandleAbandonedRequestsNotificationMessage(
 }
 
 func (lr *LogicRunner) sendOnPulseMessagesAsync(ctx context.Context, messages []core.Message) {
+	defer atomic.AddInt32(&lr.sending, -1)
 	ctx, spanMessages := instracer.StartSpan(ctx, "pulse.logicrunner sending messages")
 	spanMessages.AddAttributes(trace.StringAttribute("numMessages", strconv.Itoa(len(messages))))
 
@@ -17,7 +17,10 @@
 package logicrunner
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"io/ioutil"
 	"testing"
 	"time"
 
@@ -30,6 +33,7 @@ import (
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/core/message"
 	"github.com/insolar/insolar/core/reply"
+	"github.com/insolar/insolar/eventbus"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/testutils"
 )
@@ -68,6 +72,7 @@ func (suite *LogicRunnerCommonTestSuite) SetupLogicRunner() {
 	suite.lr.MessageBus = suite.mb
 	suite.lr.JetCoordinator = suite.jc
 	suite.lr.PulseStorage = suite.ps
+	suite.lr.EventBus = eventbus.NewEventBus()
 }
 
 func (suite *LogicRunnerCommonTestSuite) AfterTest(suiteName, testName string) {
@@ -376,7 +381,10 @@ func TestReleaseQueue(t *testing.T) {
 }
 
 func (suite *LogicRunnerTestSuite) TestNoExcessiveAmends() {
-	suite.am.UpdateObjectMock.Return(nil, nil)
+	od := testutils.NewObjectDescriptorMock(suite.mc)
+	state := testutils.RandomID()
+	od.StateIDMock.Return(&state)
+	suite.am.UpdateObjectMock.Return(od, nil)
 
 	randRef := testutils.RandomRef()
 
@@ -417,6 +425,45 @@ func (suite *LogicRunnerTestSuite) TestNoExcessiveAmends() {
 	suite.Require().Equal(uint64(1), suite.am.UpdateObjectCounter)
 }
 
+func (suite *LogicRunnerTestSuite) TestExecuteMethodCall_PublishesEvents() {
+	object := testutils.RandomRef()
+	request := testutils.RandomRef()
+	state := testutils.RandomID()
+	resultID := testutils.RandomID()
+	result := []byte{1, 2, 3}
+
+	od := testutils.NewObjectDescriptorMock(suite.mc)
+	od.StateIDMock.Return(&state)
+	suite.am.UpdateObjectMock.Return(od, nil)
+	suite.am.RegisterResultMock.Return(&resultID, nil)
+
+	mle := testutils.NewMachineLogicExecutorMock(suite.mc)
+	suite.lr.Executors[core.MachineTypeBuiltin] = mle
+	mle.CallMethodMock.Return([]byte{4}, result, nil)
+
+	es := &ExecutionState{ArtifactManager: suite.am}
+	es.objectbody = &ObjectBody{Object: []byte{5}, CodeMachineType: core.MachineTypeBuiltin, CodeRef: &object}
+	es.Current = &CurrentExecution{LogicContext: &core.LogicCallContext{}, Request: &request}
+
+	events, cancel := suite.lr.EventBus.Subscribe(suite.ctx, 2)
+	defer cancel()
+
+	_, err := suite.lr.executeMethodCall(suite.ctx, es, &message.CallMethod{ObjectRef: object, Method: "some"})
+	suite.Require().NoError(err)
+
+	stateEvent := <-events
+	suite.Equal(core.EventObjectState, stateEvent.Type)
+	suite.Equal(object.Record(), stateEvent.Object)
+	suite.Equal(&state, stateEvent.State)
+
+	resultEvent := <-events
+	suite.Equal(core.EventRequestResult, resultEvent.Type)
+	suite.Equal(object.Record(), resultEvent.Object)
+	suite.Equal(&request, resultEvent.Request)
+	suite.Equal(&resultID, resultEvent.Result)
+	suite.Equal(result, resultEvent.Payload)
+}
+
 func (suite *LogicRunnerTestSuite) TestHandleAbandonedRequestsNotificationMessage() {
 	objectId := testutils.RandomID()
 	msg := &message.AbandonedRequestsNotification{Object: objectId}
@@ -494,6 +541,61 @@ func (suite *LogicRunnerTestSuite) TestPrepareObjectStateChangeLedgerHasMoreRequ
 	}
 }
 
+type tapeBusMock struct {
+	*testutils.MessageBusMock
+	tape []byte
+}
+
+func (b *tapeBusMock) WriteTape(ctx context.Context, writer io.Writer) error {
+	_, err := writer.Write(b.tape)
+	return err
+}
+
+func (suite *LogicRunnerTestSuite) TestCaseTape() {
+	objectRef := testutils.RandomRef()
+	pulse := core.Pulse{PulseNumber: core.FirstPulseNumber}
+	parcel := &message.Parcel{Msg: &message.CallMethod{Method: "Test"}}
+	requests := []CaseRequest{
+		{
+			Parcel:     parcel,
+			Request:    testutils.RandomRef(),
+			MessageBus: &tapeBusMock{tape: []byte{1, 2, 3}},
+			Reply:      &reply.CallMethod{Result: []byte{4, 5, 6}},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteCaseBind(suite.ctx, &buf, objectRef, pulse, requests)
+	suite.Require().NoError(err)
+
+	msg, err := ReadCaseBind(bytes.NewReader(buf.Bytes()))
+	suite.Require().NoError(err)
+	suite.Equal(objectRef, msg.RecordRef)
+	suite.Equal(pulse, msg.Pulse)
+	suite.Require().Len(msg.Requests, 1)
+	suite.Equal(requests[0].Request, msg.Requests[0].Request)
+	suite.Equal(parcel.Msg, msg.Requests[0].Parcel.Message())
+	suite.Equal(requests[0].Reply, msg.Requests[0].Reply)
+
+	player := testutils.NewMessageBusMock(suite.mc)
+	suite.mb.NewPlayerFunc = func(ctx context.Context, reader io.Reader) (core.MessageBus, error) {
+		tape, err := ioutil.ReadAll(reader)
+		suite.Require().NoError(err)
+		suite.Equal([]byte{1, 2, 3}, tape)
+		return player, nil
+	}
+	cb, err := caseBindFromValidateMessage(suite.ctx, suite.mb, msg)
+	suite.Require().NoError(err)
+	suite.Require().Len(cb.Requests, 1)
+	suite.Equal(player, cb.Requests[0].MessageBus)
+
+	_, err = ReadCaseBind(bytes.NewReader([]byte("INSTAPE\x01")))
+	suite.Require().Error(err)
+
+	err = WriteCaseBind(suite.ctx, &buf, objectRef, pulse, []CaseRequest{{MessageBus: suite.mb}})
+	suite.Require().Error(err)
+}
+
 func TestLogicRunner(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(LogicRunnerTestSuite))
@@ -619,6 +721,37 @@ func (s *LogicRunnerOnPulseTestSuite) TestWithEmptyQueue() {
 	s.Equal(message.InPending, s.lr.state[s.objectRef].ExecutionState.pending)
 }
 
+// We aren't next executor but we're currently executing.
+// Expecting Drain to wait until the execution is finished and results are sent.
+func (s *LogicRunnerOnPulseTestSuite) TestDrain() {
+	s.jc.MeMock.Return(core.RecordRef{})
+	s.jc.IsAuthorizedMock.Return(false, nil)
+	s.mb.SendMock.Return(&reply.ID{}, nil)
+
+	es := &ExecutionState{
+		Behaviour: &ValidationSaver{},
+		Current:   &CurrentExecution{},
+		Queue:     make([]ExecutionQueueElement, 0),
+		pending:   message.NotPending,
+	}
+	s.lr.state[s.objectRef] = &ObjectState{ExecutionState: es}
+
+	err := s.lr.OnPulse(s.ctx, s.pulse)
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(s.ctx, 10*drainCheckPeriod)
+	defer cancel()
+	err = s.lr.Drain(ctx)
+	s.Require().Error(err)
+
+	es.Lock()
+	es.Current = nil
+	es.Unlock()
+
+	err = s.lr.Drain(s.ctx)
+	s.Require().NoError(err)
+}
+
 // Executor is on the same node and we're currently executing
 // Expecting task to be moved to NotPending
 func (s *LogicRunnerOnPulseTestSuite) TestExecutorSameNode() {
@@ -0,0 +1,154 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logicrunner
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// CaseTapeMagic starts execution tape written by WriteCaseBind. It is followed by format version byte and gob encoded
+// ValidateCaseBind message.
+const CaseTapeMagic = "INSCASE"
+
+const caseTapeVersion = 1
+
+// recordingBus returns message bus for execution of request. If tape directory is configured, the bus records replies
+// to the tape, so execution can be replayed later.
+func (lr *LogicRunner) recordingBus(ctx context.Context) core.MessageBus {
+	if lr.Cfg.TapeDirectory == "" {
+		return lr.MessageBus
+	}
+	recorder, err := lr.MessageBus.NewRecorder(ctx, *lr.pulse(ctx))
+	if err != nil {
+		inslogger.FromContext(ctx).Error("can't create message bus recorder: ", err)
+		return lr.MessageBus
+	}
+	return recorder
+}
+
+// saveCaseTape writes executed request with its tape to tape directory. File can be replayed by ReplayCaseBind.
+func (lr *LogicRunner) saveCaseTape(ctx context.Context, ref Ref, request *CaseRequest) {
+	if lr.Cfg.TapeDirectory == "" || request == nil {
+		return
+	}
+	if _, ok := request.MessageBus.(core.TapeWriter); !ok {
+		return
+	}
+
+	pulse := *lr.pulse(ctx)
+	name := fmt.Sprintf("%d_%s.tape", pulse.PulseNumber, request.Request.Record())
+	err := writeCaseTapeFile(ctx, filepath.Join(lr.Cfg.TapeDirectory, name), ref, pulse, []CaseRequest{*request})
+	if err != nil {
+		inslogger.FromContext(ctx).Error("can't save execution tape: ", err)
+	}
+}
+
+func writeCaseTapeFile(ctx context.Context, path string, ref Ref, pulse core.Pulse, requests []CaseRequest) error {
+	var buf bytes.Buffer
+	err := WriteCaseBind(ctx, &buf, ref, pulse, requests)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return errors.Wrap(err, "[ writeCaseTapeFile ] can't create tape directory")
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0600)
+}
+
+// WriteCaseBind writes executed requests of object with tapes recorded by their message buses.
+func WriteCaseBind(ctx context.Context, w io.Writer, ref Ref, pulse core.Pulse, requests []CaseRequest) error {
+	msg := &message.ValidateCaseBind{
+		RecordRef: ref,
+		Pulse:     pulse,
+		Requests:  make([]message.CaseBindRequest, 0, len(requests)),
+	}
+	for _, req := range requests {
+		tw, ok := req.MessageBus.(core.TapeWriter)
+		if !ok {
+			return errors.Errorf("[ WriteCaseBind ] message bus of request %s doesn't record tape", req.Request)
+		}
+		var tape bytes.Buffer
+		err := tw.WriteTape(ctx, &tape)
+		if err != nil {
+			return errors.Wrap(err, "[ WriteCaseBind ] couldn't write tape")
+		}
+		msg.Requests = append(msg.Requests, message.CaseBindRequest{
+			Parcel:         req.Parcel,
+			Request:        req.Request,
+			MessageBusTape: tape.Bytes(),
+			Reply:          req.Reply,
+			Error:          req.Error,
+		})
+	}
+
+	_, err := w.Write(append([]byte(CaseTapeMagic), caseTapeVersion))
+	if err != nil {
+		return errors.Wrap(err, "[ WriteCaseBind ] couldn't write header")
+	}
+	err = gob.NewEncoder(w).Encode(msg)
+	if err != nil {
+		return errors.Wrap(err, "[ WriteCaseBind ] couldn't serialize case bind")
+	}
+	return nil
+}
+
+// ReadCaseBind reads case bind written by WriteCaseBind.
+func ReadCaseBind(r io.Reader) (*message.ValidateCaseBind, error) {
+	header := make([]byte, len(CaseTapeMagic)+1)
+	_, err := io.ReadFull(r, header)
+	if err != nil || string(header[:len(CaseTapeMagic)]) != CaseTapeMagic {
+		return nil, errors.New("[ ReadCaseBind ] not an execution tape")
+	}
+	if header[len(CaseTapeMagic)] != caseTapeVersion {
+		return nil, errors.Errorf("[ ReadCaseBind ] unsupported execution tape version %d", header[len(CaseTapeMagic)])
+	}
+
+	msg := &message.ValidateCaseBind{}
+	err = gob.NewDecoder(r).Decode(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadCaseBind ] couldn't deserialize case bind")
+	}
+	return msg, nil
+}
+
+// ReplayCaseBind validates execution written by WriteCaseBind against local logic runner. Replies of other nodes are
+// taken from recorded tapes, so divergent validation results can be debugged offline.
+func (lr *LogicRunner) ReplayCaseBind(ctx context.Context, r io.Reader) (int, error) {
+	msg, err := ReadCaseBind(r)
+	if err != nil {
+		return 0, err
+	}
+	cb, err := caseBindFromValidateMessage(ctx, lr.MessageBus, msg)
+	if err != nil {
+		return 0, errors.Wrap(err, "[ ReplayCaseBind ]")
+	}
+	return lr.Validate(ctx, msg.RecordRef, msg.Pulse, *cb)
+}
@@ -21,5 +21,16 @@ Usage:
 
 	TODO:
 
+Middlewares
+
+Cross-cutting concerns are plugged into MessageBus with UseSendMiddleware and UseDeliverMiddleware instead of wrapping
+every handler. Send middlewares wrap SendParcel, deliver middlewares wrap message handlers of parcels received from the
+network and sent to the node itself. Package provides Tracing, AuditLog, SenderQuota and payload limit middlewares:
+
+	bus.UseDeliverMiddleware(messagebus.Tracing())
+	bus.UseDeliverMiddleware(messagebus.SenderQuota(1000, time.Second))
+	bus.UseDeliverMiddleware(messagebus.DeliverPayloadLimit(10 << 20))
+	bus.UseSendMiddleware(messagebus.SendPayloadLimit(10 << 20))
+
 */
 package messagebus
@@ -23,4 +23,8 @@ import (
 var (
 	// ErrNoReply is returned from player when there is no stored reply for provided message.
 	ErrNoReply = errors.New("no such reply")
+	// ErrPayloadTooLarge is returned from payload limit middlewares when message exceeds the limit.
+	ErrPayloadTooLarge = errors.New("message payload is too large")
+	// ErrQuotaExceeded is returned from sender quota middleware when sender exceeds its quota.
+	ErrQuotaExceeded = errors.New("sender quota exceeded")
 )
@@ -55,6 +55,10 @@ type MessageBus struct {
 	handlers     map[core.MessageType]core.MessageHandler
 	signmessages bool
 
+	middlewareLock     sync.RWMutex
+	sendMiddlewares    []core.SendMiddleware
+	deliverMiddlewares []core.DeliverMiddleware
+
 	globalLock                  sync.RWMutex
 	NextPulseMessagePoolChan    chan interface{}
 	NextPulseMessagePoolCounter uint32
@@ -166,10 +170,19 @@ func (mb *MessageBus) SendParcel(
 	currentPulse core.Pulse,
 	options *core.MessageSendOptions,
 ) (core.Reply, error) {
-	parcelType := parcel.Type().String()
-	ctx, span := instracer.StartSpan(ctx, "MessageBus.SendParcel "+parcelType)
+	ctx, span := instracer.StartSpan(ctx, "MessageBus.SendParcel "+parcel.Type().String())
 	defer span.End()
 
+	return mb.sendChain(mb.doSendParcel)(ctx, parcel, currentPulse, options)
+}
+
+func (mb *MessageBus) doSendParcel(
+	ctx context.Context,
+	parcel core.Parcel,
+	currentPulse core.Pulse,
+	options *core.MessageSendOptions,
+) (core.Reply, error) {
+	parcelType := parcel.Type().String()
 	readBarrier(ctx, &mb.globalLock)
 
 	var (
@@ -266,7 +279,7 @@ func (mb *MessageBus) doDeliver(ctx context.Context, msg core.Parcel) (core.Repl
 	}
 	// TODO: sergey.morozov 2018-12-21 there is potential race condition because of readBarrier. We must implement correct locking.
 
-	resp, err := handler(ctx, msg)
+	resp, err := mb.deliverChain(handler)(ctx, msg)
 	if err != nil {
 		return nil, &serializableError{
 			S: err.Error(),
@@ -302,6 +315,8 @@ func (mb *MessageBus) checkPulse(ctx context.Context, parcel core.Parcel, locked
 			*message.GetObject,
 			*message.GetDelegate,
 			*message.GetChildren,
+			*message.GetObjectHistory,
+			*message.GetRequestResult,
 			*message.SetRecord,
 			*message.UpdateObject,
 			*message.RegisterChild,
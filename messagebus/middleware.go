@@ -0,0 +1,194 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"context"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/instracer"
+)
+
+// UseSendMiddleware appends middleware to the send path. Middlewares are called in order they were added.
+func (mb *MessageBus) UseSendMiddleware(m core.SendMiddleware) {
+	mb.middlewareLock.Lock()
+	defer mb.middlewareLock.Unlock()
+
+	mb.sendMiddlewares = append(mb.sendMiddlewares, m)
+}
+
+// UseDeliverMiddleware appends middleware to the deliver path. Middlewares are called in order they were added,
+// the last one calls registered message handler.
+func (mb *MessageBus) UseDeliverMiddleware(m core.DeliverMiddleware) {
+	mb.middlewareLock.Lock()
+	defer mb.middlewareLock.Unlock()
+
+	mb.deliverMiddlewares = append(mb.deliverMiddlewares, m)
+}
+
+func (mb *MessageBus) sendChain(sender core.ParcelSender) core.ParcelSender {
+	mb.middlewareLock.RLock()
+	defer mb.middlewareLock.RUnlock()
+
+	for i := len(mb.sendMiddlewares) - 1; i >= 0; i-- {
+		sender = mb.sendMiddlewares[i](sender)
+	}
+	return sender
+}
+
+func (mb *MessageBus) deliverChain(handler core.MessageHandler) core.MessageHandler {
+	mb.middlewareLock.RLock()
+	defer mb.middlewareLock.RUnlock()
+
+	for i := len(mb.deliverMiddlewares) - 1; i >= 0; i-- {
+		handler = mb.deliverMiddlewares[i](handler)
+	}
+	return handler
+}
+
+// SendPayloadLimit returns send middleware that fails sending of messages larger than limit bytes.
+func SendPayloadLimit(limit int) core.SendMiddleware {
+	return func(next core.ParcelSender) core.ParcelSender {
+		return func(ctx context.Context, parcel core.Parcel, currentPulse core.Pulse, options *core.MessageSendOptions) (core.Reply, error) {
+			if err := checkPayloadSize(parcel, limit); err != nil {
+				return nil, errors.Wrap(err, "[ SendPayloadLimit ] can't send message")
+			}
+			return next(ctx, parcel, currentPulse, options)
+		}
+	}
+}
+
+// DeliverPayloadLimit returns deliver middleware that rejects messages larger than limit bytes.
+func DeliverPayloadLimit(limit int) core.DeliverMiddleware {
+	return func(next core.MessageHandler) core.MessageHandler {
+		return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+			if err := checkPayloadSize(parcel, limit); err != nil {
+				return nil, errors.Wrap(err, "[ DeliverPayloadLimit ] message rejected")
+			}
+			return next(ctx, parcel)
+		}
+	}
+}
+
+func checkPayloadSize(parcel core.Parcel, limit int) error {
+	buff, err := message.Serialize(parcel.Message())
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize message")
+	}
+	payload, err := ioutil.ReadAll(buff)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize message")
+	}
+	if len(payload) > limit {
+		return errors.Wrapf(ErrPayloadTooLarge, "%s message is %d bytes, limit is %d", parcel.Type(), len(payload), limit)
+	}
+	return nil
+}
+
+// SenderQuota returns deliver middleware that accepts at most limit messages from every sender during interval.
+// Messages over the quota are rejected without calling handler.
+func SenderQuota(limit int, interval time.Duration) core.DeliverMiddleware {
+	quota := &senderQuota{
+		limit:    limit,
+		interval: interval,
+		counters: map[core.RecordRef]int{},
+	}
+	return func(next core.MessageHandler) core.MessageHandler {
+		return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+			sender := parcel.GetSender()
+			if !quota.take(sender, time.Now()) {
+				return nil, errors.Wrapf(ErrQuotaExceeded, "sender %s sent more than %d messages in %s", sender, limit, interval)
+			}
+			return next(ctx, parcel)
+		}
+	}
+}
+
+type senderQuota struct {
+	limit    int
+	interval time.Duration
+
+	lock        sync.Mutex
+	windowStart time.Time
+	counters    map[core.RecordRef]int
+}
+
+func (q *senderQuota) take(sender core.RecordRef, now time.Time) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if now.Sub(q.windowStart) >= q.interval {
+		q.windowStart = now
+		q.counters = map[core.RecordRef]int{}
+	}
+	if q.counters[sender] >= q.limit {
+		return false
+	}
+	q.counters[sender]++
+	return true
+}
+
+// Tracing returns deliver middleware that starts trace span for every handled message.
+func Tracing() core.DeliverMiddleware {
+	return func(next core.MessageHandler) core.MessageHandler {
+		return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+			ctx, span := instracer.StartSpan(ctx, "MessageBus.handle "+parcel.Type().String())
+			span.AddAttributes(
+				trace.StringAttribute("sender", parcel.GetSender().String()),
+				trace.Int64Attribute("pulse", int64(parcel.Pulse())),
+			)
+			defer span.End()
+
+			rep, err := next(ctx, parcel)
+			if err != nil {
+				span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+			}
+			return rep, err
+		}
+	}
+}
+
+// AuditLog returns deliver middleware that logs every handled message with its sender, result and handling time.
+func AuditLog() core.DeliverMiddleware {
+	return func(next core.MessageHandler) core.MessageHandler {
+		return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+			start := time.Now()
+			rep, err := next(ctx, parcel)
+
+			logger := inslogger.FromContext(ctx).WithFields(map[string]interface{}{
+				"msg_type": parcel.Type().String(),
+				"sender":   parcel.GetSender().String(),
+				"pulse":    parcel.Pulse(),
+				"duration": time.Since(start).String(),
+			})
+			if err != nil {
+				logger.Infof("message handling failed: %s", err)
+			} else {
+				logger.Info("message handled")
+			}
+			return rep, err
+		}
+	}
+}
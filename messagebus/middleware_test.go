@@ -0,0 +1,144 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestMessageBus_DeliverMiddlewareOrder(t *testing.T) {
+	ctx := context.Background()
+	mb, _, parcel := prepare(t, ctx, 100, 100)
+
+	var calls []string
+	middleware := func(name string) core.DeliverMiddleware {
+		return func(next core.MessageHandler) core.MessageHandler {
+			return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+				calls = append(calls, name)
+				return next(ctx, parcel)
+			}
+		}
+	}
+	mb.UseDeliverMiddleware(middleware("first"))
+	mb.UseDeliverMiddleware(middleware("second"))
+
+	result, err := mb.doDeliver(ctx, parcel)
+	require.NoError(t, err)
+	require.Equal(t, testReply, result)
+	require.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestMessageBus_DeliverMiddlewareRejects(t *testing.T) {
+	ctx := context.Background()
+	mb, _, parcel := prepare(t, ctx, 100, 100)
+
+	mb.UseDeliverMiddleware(func(next core.MessageHandler) core.MessageHandler {
+		return func(ctx context.Context, parcel core.Parcel) (core.Reply, error) {
+			return nil, errors.New("rejected")
+		}
+	})
+
+	result, err := mb.doDeliver(ctx, parcel)
+	require.EqualError(t, err, "rejected")
+	require.Nil(t, result)
+}
+
+func TestMessageBus_SendMiddleware(t *testing.T) {
+	ctx := context.Background()
+	mb, ps, parcel := prepare(t, ctx, 100, 100)
+	pulse, err := ps.Current(ctx)
+	require.NoError(t, err)
+
+	receiver := testutils.RandomRef()
+	var calls []string
+	mb.UseSendMiddleware(func(next core.ParcelSender) core.ParcelSender {
+		return func(ctx context.Context, parcel core.Parcel, currentPulse core.Pulse, options *core.MessageSendOptions) (core.Reply, error) {
+			calls = append(calls, "first")
+			return next(ctx, parcel, currentPulse, &core.MessageSendOptions{Receiver: &receiver})
+		}
+	})
+	mb.UseSendMiddleware(func(next core.ParcelSender) core.ParcelSender {
+		return func(ctx context.Context, parcel core.Parcel, currentPulse core.Pulse, options *core.MessageSendOptions) (core.Reply, error) {
+			calls = append(calls, "second")
+			require.Equal(t, receiver, *options.Receiver)
+			return testReply, nil
+		}
+	})
+
+	result, err := mb.SendParcel(ctx, parcel, *pulse, nil)
+	require.NoError(t, err)
+	require.Equal(t, testReply, result)
+	require.Equal(t, []string{"first", "second"}, calls)
+}
+
+func TestSenderQuota(t *testing.T) {
+	ctx := context.Background()
+	sender := testutils.RandomRef()
+	parcel := testutils.NewParcelMock(t)
+	parcel.GetSenderMock.Return(sender)
+	parcel.TypeMock.Return(testType)
+
+	handler := SenderQuota(2, time.Minute)(testHandler)
+	for i := 0; i < 2; i++ {
+		_, err := handler(ctx, parcel)
+		require.NoError(t, err)
+	}
+	_, err := handler(ctx, parcel)
+	require.Equal(t, ErrQuotaExceeded, errors.Cause(err))
+
+	other := testutils.NewParcelMock(t)
+	other.GetSenderMock.Return(testutils.RandomRef())
+	_, err = handler(ctx, other)
+	require.NoError(t, err)
+}
+
+func TestSenderQuota_ResetsWindow(t *testing.T) {
+	quota := &senderQuota{limit: 1, interval: time.Second, counters: map[core.RecordRef]int{}}
+	sender := testutils.RandomRef()
+	now := time.Now()
+
+	require.True(t, quota.take(sender, now))
+	require.False(t, quota.take(sender, now.Add(time.Second/2)))
+	require.True(t, quota.take(sender, now.Add(time.Second)))
+}
+
+func TestPayloadLimit(t *testing.T) {
+	ctx := context.Background()
+	parcel := testutils.NewParcelMock(t)
+	parcel.MessageMock.Return(&message.SetBlob{Memory: make([]byte, 1024)})
+	parcel.TypeMock.Return(core.TypeSetBlob)
+
+	_, err := DeliverPayloadLimit(2048)(testHandler)(ctx, parcel)
+	require.NoError(t, err)
+	_, err = DeliverPayloadLimit(512)(testHandler)(ctx, parcel)
+	require.Equal(t, ErrPayloadTooLarge, errors.Cause(err))
+
+	sender := func(context.Context, core.Parcel, core.Pulse, *core.MessageSendOptions) (core.Reply, error) {
+		return testReply, nil
+	}
+	_, err = SendPayloadLimit(512)(sender)(ctx, parcel, core.Pulse{}, nil)
+	require.Equal(t, ErrPayloadTooLarge, errors.Cause(err))
+}
@@ -60,6 +60,16 @@ type senderMock struct {
 	SendParcelCounter    uint64
 	SendParcelPreCounter uint64
 	SendParcelMock       msenderMockSendParcel
+
+	UseDeliverMiddlewareFunc       func(p core.DeliverMiddleware)
+	UseDeliverMiddlewareCounter    uint64
+	UseDeliverMiddlewarePreCounter uint64
+	UseDeliverMiddlewareMock       msenderMockUseDeliverMiddleware
+
+	UseSendMiddlewareFunc       func(p core.SendMiddleware)
+	UseSendMiddlewareCounter    uint64
+	UseSendMiddlewarePreCounter uint64
+	UseSendMiddlewareMock       msenderMockUseSendMiddleware
 }
 
 //NewsenderMock returns a mock for github.com/insolar/insolar/messagebus.sender
@@ -78,6 +88,8 @@ func NewsenderMock(t minimock.Tester) *senderMock {
 	m.RegisterMock = msenderMockRegister{mock: m}
 	m.SendMock = msenderMockSend{mock: m}
 	m.SendParcelMock = msenderMockSendParcel{mock: m}
+	m.UseDeliverMiddlewareMock = msenderMockUseDeliverMiddleware{mock: m}
+	m.UseSendMiddlewareMock = msenderMockUseSendMiddleware{mock: m}
 
 	return m
 }
@@ -1262,6 +1274,252 @@ func (m *senderMock) SendParcelFinished() bool {
 	return true
 }
 
+type msenderMockUseDeliverMiddleware struct {
+	mock              *senderMock
+	mainExpectation   *senderMockUseDeliverMiddlewareExpectation
+	expectationSeries []*senderMockUseDeliverMiddlewareExpectation
+}
+
+type senderMockUseDeliverMiddlewareExpectation struct {
+	input *senderMockUseDeliverMiddlewareInput
+}
+
+type senderMockUseDeliverMiddlewareInput struct {
+	p core.DeliverMiddleware
+}
+
+//Expect specifies that invocation of sender.UseDeliverMiddleware is expected from 1 to Infinity times
+func (m *msenderMockUseDeliverMiddleware) Expect(p core.DeliverMiddleware) *msenderMockUseDeliverMiddleware {
+	m.mock.UseDeliverMiddlewareFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &senderMockUseDeliverMiddlewareExpectation{}
+	}
+	m.mainExpectation.input = &senderMockUseDeliverMiddlewareInput{p}
+	return m
+}
+
+//Return specifies results of invocation of sender.UseDeliverMiddleware
+func (m *msenderMockUseDeliverMiddleware) Return() *senderMock {
+	m.mock.UseDeliverMiddlewareFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &senderMockUseDeliverMiddlewareExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of sender.UseDeliverMiddleware is expected once
+func (m *msenderMockUseDeliverMiddleware) ExpectOnce(p core.DeliverMiddleware) *senderMockUseDeliverMiddlewareExpectation {
+	m.mock.UseDeliverMiddlewareFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &senderMockUseDeliverMiddlewareExpectation{}
+	expectation.input = &senderMockUseDeliverMiddlewareInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of sender.UseDeliverMiddleware method
+func (m *msenderMockUseDeliverMiddleware) Set(f func(p core.DeliverMiddleware)) *senderMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.UseDeliverMiddlewareFunc = f
+	return m.mock
+}
+
+//UseDeliverMiddleware implements github.com/insolar/insolar/messagebus.sender interface
+func (m *senderMock) UseDeliverMiddleware(p core.DeliverMiddleware) {
+	counter := atomic.AddUint64(&m.UseDeliverMiddlewarePreCounter, 1)
+	defer atomic.AddUint64(&m.UseDeliverMiddlewareCounter, 1)
+
+	if len(m.UseDeliverMiddlewareMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.UseDeliverMiddlewareMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to senderMock.UseDeliverMiddleware. %v", p)
+			return
+		}
+
+		input := m.UseDeliverMiddlewareMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, senderMockUseDeliverMiddlewareInput{p}, "sender.UseDeliverMiddleware got unexpected parameters")
+
+		return
+	}
+
+	if m.UseDeliverMiddlewareMock.mainExpectation != nil {
+
+		input := m.UseDeliverMiddlewareMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, senderMockUseDeliverMiddlewareInput{p}, "sender.UseDeliverMiddleware got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.UseDeliverMiddlewareFunc == nil {
+		m.t.Fatalf("Unexpected call to senderMock.UseDeliverMiddleware. %v", p)
+		return
+	}
+
+	m.UseDeliverMiddlewareFunc(p)
+}
+
+//UseDeliverMiddlewareMinimockCounter returns a count of senderMock.UseDeliverMiddlewareFunc invocations
+func (m *senderMock) UseDeliverMiddlewareMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.UseDeliverMiddlewareCounter)
+}
+
+//UseDeliverMiddlewareMinimockPreCounter returns the value of senderMock.UseDeliverMiddleware invocations
+func (m *senderMock) UseDeliverMiddlewareMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.UseDeliverMiddlewarePreCounter)
+}
+
+//UseDeliverMiddlewareFinished returns true if mock invocations count is ok
+func (m *senderMock) UseDeliverMiddlewareFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.UseDeliverMiddlewareMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.UseDeliverMiddlewareCounter) == uint64(len(m.UseDeliverMiddlewareMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.UseDeliverMiddlewareMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.UseDeliverMiddlewareCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.UseDeliverMiddlewareFunc != nil {
+		return atomic.LoadUint64(&m.UseDeliverMiddlewareCounter) > 0
+	}
+
+	return true
+}
+
+type msenderMockUseSendMiddleware struct {
+	mock              *senderMock
+	mainExpectation   *senderMockUseSendMiddlewareExpectation
+	expectationSeries []*senderMockUseSendMiddlewareExpectation
+}
+
+type senderMockUseSendMiddlewareExpectation struct {
+	input *senderMockUseSendMiddlewareInput
+}
+
+type senderMockUseSendMiddlewareInput struct {
+	p core.SendMiddleware
+}
+
+//Expect specifies that invocation of sender.UseSendMiddleware is expected from 1 to Infinity times
+func (m *msenderMockUseSendMiddleware) Expect(p core.SendMiddleware) *msenderMockUseSendMiddleware {
+	m.mock.UseSendMiddlewareFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &senderMockUseSendMiddlewareExpectation{}
+	}
+	m.mainExpectation.input = &senderMockUseSendMiddlewareInput{p}
+	return m
+}
+
+//Return specifies results of invocation of sender.UseSendMiddleware
+func (m *msenderMockUseSendMiddleware) Return() *senderMock {
+	m.mock.UseSendMiddlewareFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &senderMockUseSendMiddlewareExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of sender.UseSendMiddleware is expected once
+func (m *msenderMockUseSendMiddleware) ExpectOnce(p core.SendMiddleware) *senderMockUseSendMiddlewareExpectation {
+	m.mock.UseSendMiddlewareFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &senderMockUseSendMiddlewareExpectation{}
+	expectation.input = &senderMockUseSendMiddlewareInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of sender.UseSendMiddleware method
+func (m *msenderMockUseSendMiddleware) Set(f func(p core.SendMiddleware)) *senderMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.UseSendMiddlewareFunc = f
+	return m.mock
+}
+
+//UseSendMiddleware implements github.com/insolar/insolar/messagebus.sender interface
+func (m *senderMock) UseSendMiddleware(p core.SendMiddleware) {
+	counter := atomic.AddUint64(&m.UseSendMiddlewarePreCounter, 1)
+	defer atomic.AddUint64(&m.UseSendMiddlewareCounter, 1)
+
+	if len(m.UseSendMiddlewareMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.UseSendMiddlewareMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to senderMock.UseSendMiddleware. %v", p)
+			return
+		}
+
+		input := m.UseSendMiddlewareMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, senderMockUseSendMiddlewareInput{p}, "sender.UseSendMiddleware got unexpected parameters")
+
+		return
+	}
+
+	if m.UseSendMiddlewareMock.mainExpectation != nil {
+
+		input := m.UseSendMiddlewareMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, senderMockUseSendMiddlewareInput{p}, "sender.UseSendMiddleware got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.UseSendMiddlewareFunc == nil {
+		m.t.Fatalf("Unexpected call to senderMock.UseSendMiddleware. %v", p)
+		return
+	}
+
+	m.UseSendMiddlewareFunc(p)
+}
+
+//UseSendMiddlewareMinimockCounter returns a count of senderMock.UseSendMiddlewareFunc invocations
+func (m *senderMock) UseSendMiddlewareMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.UseSendMiddlewareCounter)
+}
+
+//UseSendMiddlewareMinimockPreCounter returns the value of senderMock.UseSendMiddleware invocations
+func (m *senderMock) UseSendMiddlewareMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.UseSendMiddlewarePreCounter)
+}
+
+//UseSendMiddlewareFinished returns true if mock invocations count is ok
+func (m *senderMock) UseSendMiddlewareFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.UseSendMiddlewareMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.UseSendMiddlewareCounter) == uint64(len(m.UseSendMiddlewareMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.UseSendMiddlewareMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.UseSendMiddlewareCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.UseSendMiddlewareFunc != nil {
+		return atomic.LoadUint64(&m.UseSendMiddlewareCounter) > 0
+	}
+
+	return true
+}
+
 //ValidateCallCounters checks that all mocked methods of the interface have been called at least once
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *senderMock) ValidateCallCounters() {
@@ -1298,6 +1556,14 @@ func (m *senderMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to senderMock.SendParcel")
 	}
 
+	if !m.UseDeliverMiddlewareFinished() {
+		m.t.Fatal("Expected call to senderMock.UseDeliverMiddleware")
+	}
+
+	if !m.UseSendMiddlewareFinished() {
+		m.t.Fatal("Expected call to senderMock.UseSendMiddleware")
+	}
+
 }
 
 //CheckMocksCalled checks that all mocked methods of the interface have been called at least once
@@ -1347,6 +1613,14 @@ func (m *senderMock) MinimockFinish() {
 		m.t.Fatal("Expected call to senderMock.SendParcel")
 	}
 
+	if !m.UseDeliverMiddlewareFinished() {
+		m.t.Fatal("Expected call to senderMock.UseDeliverMiddleware")
+	}
+
+	if !m.UseSendMiddlewareFinished() {
+		m.t.Fatal("Expected call to senderMock.UseSendMiddleware")
+	}
+
 }
 
 //Wait waits for all mocked methods to be called at least once
@@ -1369,6 +1643,8 @@ func (m *senderMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.RegisterFinished()
 		ok = ok && m.SendFinished()
 		ok = ok && m.SendParcelFinished()
+		ok = ok && m.UseDeliverMiddlewareFinished()
+		ok = ok && m.UseSendMiddlewareFinished()
 
 		if ok {
 			return
@@ -1409,6 +1685,14 @@ func (m *senderMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to senderMock.SendParcel")
 			}
 
+			if !m.UseDeliverMiddlewareFinished() {
+				m.t.Error("Expected call to senderMock.UseDeliverMiddleware")
+			}
+
+			if !m.UseSendMiddlewareFinished() {
+				m.t.Error("Expected call to senderMock.UseSendMiddleware")
+			}
+
 			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
 			return
 		default:
@@ -1453,5 +1737,13 @@ func (m *senderMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.UseDeliverMiddlewareFinished() {
+		return false
+	}
+
+	if !m.UseSendMiddlewareFinished() {
+		return false
+	}
+
 	return true
 }
@@ -17,16 +17,12 @@
 package messagebus
 
 import (
-	"bytes"
 	"context"
-	"fmt"
 	"io"
 
 	"github.com/pkg/errors"
-	"github.com/ugorji/go/codec"
 
 	"github.com/insolar/insolar/core"
-	"github.com/insolar/insolar/core/reply"
 )
 
 // Tape is an abstraction for saving replies for messages and restoring them.
@@ -47,114 +43,54 @@ type TapeItem struct {
 
 // memoryTape saves and fetches message reply/error pairs to/from memory array.
 //
-// It uses <storageTape id> + <message hash> for Value keys.
+// Records are fetched by message hash through index, records of the same message are fetched in order they were saved.
 type memoryTape struct {
 	pulse   core.PulseNumber
-	storage []memoryTapeMessage
-}
-
-type memoryTapeMessage struct {
-	MsgHash []byte
-	Item    TapeItem
-}
-
-type itemBlob struct {
-	MsgHash []byte
-	ReplyB  []byte
-	ErrorB  []byte
+	storage []TapeRecord
+	// index holds positions of records that are not fetched yet by message hash.
+	index map[string][]int
 }
 
 func newMemoryTape(pulse core.PulseNumber) *memoryTape {
 	return &memoryTape{
 		pulse: pulse,
+		index: map[string][]int{},
 	}
 }
 
 func newMemoryTapeFromReader(ctx context.Context, r io.Reader) (*memoryTape, error) {
-	t := memoryTape{}
-	ch := new(codec.CborHandle)
-	decoder := codec.NewDecoder(r, ch)
-	err := decoder.Decode(&t.pulse)
+	file, err := ReadTapeFile(r)
 	if err != nil {
-		return nil, errors.Wrap(err, "[ MemoryTape ] can't read pulse")
+		return nil, errors.Wrap(err, "[ MemoryTape ] can't read tape")
 	}
-
-	var storageBlobs []itemBlob
-	err = decoder.Decode(&storageBlobs)
-	if err != nil {
-		return nil, errors.Wrap(err, "[ MemoryTape ] can't read storage")
-	}
-	storage := make([]memoryTapeMessage, 0, len(storageBlobs))
-	for _, blob := range storageBlobs {
-		item := TapeItem{}
-		if blob.ReplyB != nil {
-			rep, err := reply.Deserialize(bytes.NewReader(blob.ReplyB))
-			if err != nil {
-				return nil, err
-			}
-			item.Reply = rep
-		}
-		if blob.ErrorB != nil {
-			item.Error = fmt.Errorf(string(blob.ErrorB))
-		}
-		storage = append(storage, memoryTapeMessage{
-			MsgHash: blob.MsgHash,
-			Item:    item,
-		})
-	}
-	t.storage = storage
-	return &t, nil
+	return &memoryTape{
+		pulse:   file.Pulse,
+		storage: file.Records,
+		index:   file.index,
+	}, nil
 }
 
 func (t *memoryTape) Write(ctx context.Context, w io.Writer) error {
-	encoder := codec.NewEncoder(w, new(codec.CborHandle))
-
-	err := encoder.Encode(t.pulse)
-	if err != nil {
-		return errors.Wrap(err, "[ MemoryTape ] can't write pulse")
-	}
-
-	// TODO: remove once https://github.com/ugorji/go/issues/278
-	// is resolved
-	encoder.Reset(w)
-
-	storageBlobs := make([]itemBlob, 0, len(t.storage))
-	for _, record := range t.storage {
-		blob := itemBlob{
-			MsgHash: record.MsgHash,
-		}
-		if record.Item.Reply != nil {
-			blob.ReplyB = reply.ToBytes(record.Item.Reply)
-		}
-		if record.Item.Error != nil {
-			// TODO: preserve error type (use gob?) - 24.Dec.2018 - @nordicdyno
-			blob.ErrorB = []byte(record.Item.Error.Error())
-		}
-		storageBlobs = append(storageBlobs, blob)
-	}
-	err = encoder.Encode(storageBlobs)
+	err := writeTapeFile(w, t.pulse, t.storage)
 	if err != nil {
-		return errors.Wrap(err, "[ MemoryTape ] can't write storage")
+		return errors.Wrap(err, "[ MemoryTape ] can't write tape")
 	}
 	return nil
 }
 
 func (t *memoryTape) Get(ctx context.Context, msgHash []byte) (*TapeItem, error) {
-	if len(t.storage) == 0 {
+	positions := t.index[string(msgHash)]
+	if len(positions) == 0 {
 		return nil, errors.New("Validation error. Message is not expected")
 	}
+	t.index[string(msgHash)] = positions[1:]
 
-	tapeMsg := t.storage[0]
-	if !bytes.Equal(msgHash, tapeMsg.MsgHash) {
-		return nil, errors.New("Validation error. Message mismatch")
-	}
-	t.storage = t.storage[1:]
-
-	return &tapeMsg.Item, nil
+	return &t.storage[positions[0]].Item, nil
 }
 
 func (t *memoryTape) Set(ctx context.Context, msgHash []byte, rep core.Reply, gotError error) error {
-	t.storage = append(t.storage, memoryTapeMessage{
+	t.index[string(msgHash)] = append(t.index[string(msgHash)], len(t.storage))
+	t.storage = append(t.storage, TapeRecord{
 		MsgHash: msgHash,
 		Item: TapeItem{
 			Reply: rep,
@@ -132,3 +132,32 @@ func TestTape_Write(t *testing.T) {
 		// fmt.Printf("gotItem => %+v\n", gotItem)
 	}
 }
+
+func TestTape_GetByIndex(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	tp := newMemoryTape(core.PulseNumber(1))
+
+	require.NoError(t, tp.Set(ctx, []byte{1}, &reply.OK{}, nil))
+	require.NoError(t, tp.Set(ctx, []byte{2}, nil, errors.New("first")))
+	require.NoError(t, tp.Set(ctx, []byte{2}, nil, errors.New("second")))
+
+	var buf bytes.Buffer
+	require.NoError(t, tp.Write(ctx, &buf))
+	rTape, err := newMemoryTapeFromReader(ctx, &buf)
+	require.NoError(t, err)
+
+	item, err := rTape.Get(ctx, []byte{2})
+	require.NoError(t, err)
+	assert.EqualError(t, item.Error, "first")
+	item, err = rTape.Get(ctx, []byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, &reply.OK{}, item.Reply)
+	item, err = rTape.Get(ctx, []byte{2})
+	require.NoError(t, err)
+	assert.EqualError(t, item.Error, "second")
+
+	_, err = rTape.Get(ctx, []byte{2})
+	assert.Error(t, err, "all records of message are fetched")
+	_, err = rTape.Get(ctx, []byte{3})
+	assert.Error(t, err, "message is not recorded")
+}
@@ -0,0 +1,236 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/ugorji/go/codec"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+)
+
+// TapeFormatVersion is the version of tape format written by recorder.
+//
+// Tape starts with magic and format version byte followed by CBOR encoded header, records and index of records by
+// message hash. Legacy tapes (version 0) have no magic, header and index, they start with CBOR encoded pulse number.
+const TapeFormatVersion = 1
+
+const tapeMagic = "INSTAPE"
+
+// TapeRecord is a reply/error pair recorded for message.
+type TapeRecord struct {
+	MsgHash []byte
+	Item    TapeItem
+}
+
+// TapeFile is a decoded tape.
+type TapeFile struct {
+	Version byte
+	Pulse   core.PulseNumber
+	Records []TapeRecord
+
+	index map[string][]int
+}
+
+// Lookup returns items recorded for message hash in order they were recorded.
+func (f *TapeFile) Lookup(msgHash []byte) []TapeItem {
+	positions := f.index[string(msgHash)]
+	items := make([]TapeItem, 0, len(positions))
+	for _, pos := range positions {
+		items = append(items, f.Records[pos].Item)
+	}
+	return items
+}
+
+type tapeHeader struct {
+	Pulse core.PulseNumber
+	Count int
+}
+
+type itemBlob struct {
+	MsgHash []byte
+	ReplyB  []byte
+	ErrorB  []byte
+}
+
+type tapeIndexEntry struct {
+	MsgHash   []byte
+	Positions []int
+}
+
+// ReadTapeFile reads tape written by recorder. Tapes of all format versions are supported.
+func ReadTapeFile(r io.Reader) (*TapeFile, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(tapeMagic) + 1)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "[ ReadTapeFile ] can't read tape")
+	}
+	if !bytes.HasPrefix(magic, []byte(tapeMagic)) {
+		return readLegacyTape(br)
+	}
+
+	version := magic[len(tapeMagic)]
+	if version > TapeFormatVersion {
+		return nil, errors.Errorf("[ ReadTapeFile ] unsupported tape format version %d", version)
+	}
+	_, err = br.Discard(len(magic))
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadTapeFile ] can't read tape")
+	}
+
+	decoder := codec.NewDecoder(br, new(codec.CborHandle))
+	var header tapeHeader
+	err = decoder.Decode(&header)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadTapeFile ] can't read header")
+	}
+	var blobs []itemBlob
+	err = decoder.Decode(&blobs)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadTapeFile ] can't read records")
+	}
+	if len(blobs) != header.Count {
+		return nil, errors.Errorf("[ ReadTapeFile ] tape is truncated: got %d records of %d", len(blobs), header.Count)
+	}
+	var index []tapeIndexEntry
+	err = decoder.Decode(&index)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadTapeFile ] can't read index")
+	}
+
+	file := &TapeFile{
+		Version: version,
+		Pulse:   header.Pulse,
+		index:   map[string][]int{},
+	}
+	file.Records, err = blobsToRecords(blobs)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadTapeFile ] can't read records")
+	}
+	for _, entry := range index {
+		for _, pos := range entry.Positions {
+			if pos < 0 || pos >= len(file.Records) || !bytes.Equal(file.Records[pos].MsgHash, entry.MsgHash) {
+				return nil, errors.Errorf("[ ReadTapeFile ] broken index entry for message %s", hex.EncodeToString(entry.MsgHash))
+			}
+		}
+		file.index[string(entry.MsgHash)] = entry.Positions
+	}
+	return file, nil
+}
+
+func readLegacyTape(r io.Reader) (*TapeFile, error) {
+	decoder := codec.NewDecoder(r, new(codec.CborHandle))
+	file := &TapeFile{}
+	err := decoder.Decode(&file.Pulse)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadTapeFile ] can't read pulse")
+	}
+	var blobs []itemBlob
+	err = decoder.Decode(&blobs)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadTapeFile ] can't read storage")
+	}
+	file.Records, err = blobsToRecords(blobs)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ReadTapeFile ] can't read storage")
+	}
+	file.index = map[string][]int{}
+	for pos, record := range file.Records {
+		file.index[string(record.MsgHash)] = append(file.index[string(record.MsgHash)], pos)
+	}
+	return file, nil
+}
+
+func writeTapeFile(w io.Writer, pulse core.PulseNumber, records []TapeRecord) error {
+	_, err := w.Write(append([]byte(tapeMagic), TapeFormatVersion))
+	if err != nil {
+		return errors.Wrap(err, "can't write version")
+	}
+
+	encoder := codec.NewEncoder(w, new(codec.CborHandle))
+	err = encoder.Encode(tapeHeader{Pulse: pulse, Count: len(records)})
+	if err != nil {
+		return errors.Wrap(err, "can't write header")
+	}
+	// TODO: remove once https://github.com/ugorji/go/issues/278
+	// is resolved
+	encoder.Reset(w)
+
+	blobs := make([]itemBlob, 0, len(records))
+	positions := map[string][]int{}
+	for pos, record := range records {
+		blob := itemBlob{
+			MsgHash: record.MsgHash,
+		}
+		if record.Item.Reply != nil {
+			blob.ReplyB = reply.ToBytes(record.Item.Reply)
+		}
+		if record.Item.Error != nil {
+			// TODO: preserve error type (use gob?) - 24.Dec.2018 - @nordicdyno
+			blob.ErrorB = []byte(record.Item.Error.Error())
+		}
+		blobs = append(blobs, blob)
+		positions[string(record.MsgHash)] = append(positions[string(record.MsgHash)], pos)
+	}
+	err = encoder.Encode(blobs)
+	if err != nil {
+		return errors.Wrap(err, "can't write records")
+	}
+	encoder.Reset(w)
+
+	index := make([]tapeIndexEntry, 0, len(positions))
+	for hash, pos := range positions {
+		index = append(index, tapeIndexEntry{MsgHash: []byte(hash), Positions: pos})
+	}
+	sort.Slice(index, func(i, j int) bool {
+		return bytes.Compare(index[i].MsgHash, index[j].MsgHash) < 0
+	})
+	err = encoder.Encode(index)
+	if err != nil {
+		return errors.Wrap(err, "can't write index")
+	}
+	return nil
+}
+
+func blobsToRecords(blobs []itemBlob) ([]TapeRecord, error) {
+	records := make([]TapeRecord, 0, len(blobs))
+	for _, blob := range blobs {
+		item := TapeItem{}
+		if blob.ReplyB != nil {
+			rep, err := reply.Deserialize(bytes.NewReader(blob.ReplyB))
+			if err != nil {
+				return nil, err
+			}
+			item.Reply = rep
+		}
+		if blob.ErrorB != nil {
+			item.Error = errors.New(string(blob.ErrorB))
+		}
+		records = append(records, TapeRecord{
+			MsgHash: blob.MsgHash,
+			Item:    item,
+		})
+	}
+	return records, nil
+}
@@ -0,0 +1,87 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package messagebus
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/reply"
+)
+
+func TestTapeFile_WriteRead(t *testing.T) {
+	pn := core.PulseNumber(core.FirstPulseNumber + 10)
+	records := []TapeRecord{
+		{MsgHash: []byte{1}, Item: TapeItem{Reply: &reply.Object{Memory: []byte{1}}}},
+		{MsgHash: []byte{2}, Item: TapeItem{Error: errors.New("send failed")}},
+		{MsgHash: []byte{1}, Item: TapeItem{Reply: &reply.Object{Memory: []byte{2}}}},
+	}
+
+	var buf bytes.Buffer
+	err := writeTapeFile(&buf, pn, records)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte(tapeMagic)))
+
+	file, err := ReadTapeFile(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, byte(TapeFormatVersion), file.Version)
+	assert.Equal(t, pn, file.Pulse)
+	require.Len(t, file.Records, 3)
+
+	items := file.Lookup([]byte{1})
+	require.Len(t, items, 2)
+	assert.Equal(t, &reply.Object{Memory: []byte{1}}, items[0].Reply)
+	assert.Equal(t, &reply.Object{Memory: []byte{2}}, items[1].Reply)
+
+	items = file.Lookup([]byte{2})
+	require.Len(t, items, 1)
+	assert.EqualError(t, items[0].Error, "send failed")
+
+	assert.Empty(t, file.Lookup([]byte{3}))
+}
+
+func TestTapeFile_ReadLegacy(t *testing.T) {
+	pn := core.PulseNumber(core.FirstPulseNumber + 10)
+
+	var buf bytes.Buffer
+	encoder := codec.NewEncoder(&buf, new(codec.CborHandle))
+	require.NoError(t, encoder.Encode(pn))
+	encoder.Reset(&buf)
+	require.NoError(t, encoder.Encode([]itemBlob{
+		{MsgHash: []byte{1}, ReplyB: reply.ToBytes(&reply.OK{})},
+		{MsgHash: []byte{2}, ErrorB: []byte("send failed")},
+	}))
+
+	file, err := ReadTapeFile(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, byte(0), file.Version)
+	assert.Equal(t, pn, file.Pulse)
+	require.Len(t, file.Lookup([]byte{1}), 1)
+	assert.Equal(t, &reply.OK{}, file.Lookup([]byte{1})[0].Reply)
+	assert.EqualError(t, file.Lookup([]byte{2})[0].Error, "send failed")
+}
+
+func TestTapeFile_UnsupportedVersion(t *testing.T) {
+	_, err := ReadTapeFile(bytes.NewReader(append([]byte(tapeMagic), TapeFormatVersion+1)))
+	require.Error(t, err)
+}
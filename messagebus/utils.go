@@ -21,7 +21,8 @@ import (
 	"github.com/insolar/insolar/core/message"
 )
 
-// GetMessageHash calculates message hash.
+// GetMessageHash calculates message hash. Sender, pulse and signature of the parcel are not hashed, so tape recorded by
+// one node can be replayed by another node or offline.
 func GetMessageHash(scheme core.PlatformCryptographyScheme, msg core.Parcel) []byte {
-	return scheme.IntegrityHasher().Hash(message.ParcelToBytes(msg))
+	return scheme.IntegrityHasher().Hash(message.ToBytes(msg.Message()))
 }
@@ -0,0 +1,109 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package controller
+
+import (
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+)
+
+// RPC packets are sent on every message between nodes, so they are encoded natively by binary packet codec.
+func init() {
+	packet.RegisterBinaryPayload(types.RPC, false, func() packet.BinaryPayload { return &RequestRPC{} })
+	packet.RegisterBinaryPayload(types.RPC, true, func() packet.BinaryPayload { return &ResponseRPC{} })
+	packet.RegisterBinaryPayload(types.Cascade, false, func() packet.BinaryPayload { return &RequestCascade{} })
+	packet.RegisterBinaryPayload(types.Cascade, true, func() packet.BinaryPayload { return &ResponseCascade{} })
+}
+
+// MarshalBinaryPayload implements packet.BinaryPayload.
+func (r *RequestRPC) MarshalBinaryPayload(w *packet.BinaryWriter) {
+	w.WriteString(r.Method)
+	w.WriteUvarint(uint64(len(r.Data)))
+	for _, data := range r.Data {
+		w.WriteBytes(data)
+	}
+}
+
+// UnmarshalBinaryPayload implements packet.BinaryPayload.
+func (r *RequestRPC) UnmarshalBinaryPayload(reader *packet.BinaryReader) {
+	r.Method = reader.ReadString()
+	count := reader.ReadUvarint()
+	if count == 0 || reader.Err() != nil {
+		return
+	}
+	r.Data = make([][]byte, 0, count)
+	for i := uint64(0); i < count && reader.Err() == nil; i++ {
+		r.Data = append(r.Data, reader.ReadBytes())
+	}
+}
+
+// MarshalBinaryPayload implements packet.BinaryPayload.
+func (r *ResponseRPC) MarshalBinaryPayload(w *packet.BinaryWriter) {
+	w.WriteBool(r.Success)
+	w.WriteBytes(r.Result)
+	w.WriteString(r.Error)
+}
+
+// UnmarshalBinaryPayload implements packet.BinaryPayload.
+func (r *ResponseRPC) UnmarshalBinaryPayload(reader *packet.BinaryReader) {
+	r.Success = reader.ReadBool()
+	r.Result = reader.ReadBytes()
+	r.Error = reader.ReadString()
+}
+
+// MarshalBinaryPayload implements packet.BinaryPayload.
+func (r *RequestCascade) MarshalBinaryPayload(w *packet.BinaryWriter) {
+	w.WriteString(r.TraceID)
+	r.RPC.MarshalBinaryPayload(w)
+	w.WriteUvarint(uint64(len(r.Cascade.NodeIds)))
+	for _, ref := range r.Cascade.NodeIds {
+		w.WriteFixed(ref[:])
+	}
+	w.WriteFixed(r.Cascade.Entropy[:])
+	w.WriteUvarint(uint64(r.Cascade.ReplicationFactor))
+}
+
+// UnmarshalBinaryPayload implements packet.BinaryPayload.
+func (r *RequestCascade) UnmarshalBinaryPayload(reader *packet.BinaryReader) {
+	r.TraceID = reader.ReadString()
+	r.RPC.UnmarshalBinaryPayload(reader)
+	count := reader.ReadUvarint()
+	if count > 0 && reader.Err() == nil {
+		r.Cascade.NodeIds = make([]core.RecordRef, 0, count)
+		for i := uint64(0); i < count && reader.Err() == nil; i++ {
+			var ref core.RecordRef
+			copy(ref[:], reader.ReadFixed(core.RecordRefSize))
+			r.Cascade.NodeIds = append(r.Cascade.NodeIds, ref)
+		}
+	}
+	copy(r.Cascade.Entropy[:], reader.ReadFixed(core.EntropySize))
+	r.Cascade.ReplicationFactor = uint(reader.ReadUvarint())
+}
+
+// MarshalBinaryPayload implements packet.BinaryPayload.
+func (r *ResponseCascade) MarshalBinaryPayload(w *packet.BinaryWriter) {
+	w.WriteBool(r.Success)
+	w.WriteString(r.Error)
+}
+
+// UnmarshalBinaryPayload implements packet.BinaryPayload.
+func (r *ResponseCascade) UnmarshalBinaryPayload(reader *packet.BinaryReader) {
+	r.Success = reader.ReadBool()
+	r.Error = reader.ReadString()
+}
@@ -19,6 +19,7 @@ package hostnetwork
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"time"
 
@@ -153,7 +154,12 @@ func (h *hostTransport) BuildResponse(ctx context.Context, request network.Reque
 }
 
 func NewInternalTransport(conf configuration.Configuration, nodeRef string) (network.InternalTransport, error) {
-	tp, err := transport.NewTransport(conf.Host.Transport, relay.NewProxy())
+	return NewSecureInternalTransport(conf, nodeRef, nil)
+}
+
+// NewSecureInternalTransport creates internal transport that uses mutual TLS if it is enabled in configuration.
+func NewSecureInternalTransport(conf configuration.Configuration, nodeRef string, tlsConfig *tls.Config) (network.InternalTransport, error) {
+	tp, err := transport.NewSecureTransport(conf.Host.Transport, relay.NewProxy(), tlsConfig)
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating transport")
 	}
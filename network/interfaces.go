@@ -167,6 +167,8 @@ type NodeKeeper interface {
 	NodesJoinedDuringPreviousPulse() bool
 	// AddPendingClaim add pending claim to the internal queue of claims
 	AddPendingClaim(consensus.ReferendumClaim) bool
+	// Leave add NodeLeaveClaim to the internal queue of claims. Returns channel that is closed when origin is removed from active list.
+	Leave() <-chan struct{}
 	// GetClaimQueue get the internal queue of claims
 	GetClaimQueue() ClaimQueue
 	// GetUnsyncList get unsync list for current pulse. Has copy of active node list from nodekeeper as internal state.
@@ -190,7 +192,11 @@ type UnsyncList interface {
 	RemoveClaims(core.RecordRef)
 	// AddClaims
 	AddClaims(map[core.RecordRef][]consensus.ReferendumClaim, map[core.RecordRef]string)
-	// CalculateHash calculate node list hash based on active node list and claims
+	// AddClaim add claim produced during consensus by node with provided reference
+	AddClaim(core.RecordRef, consensus.ReferendumClaim)
+	// ExcludeNodes excludes nodes from the next active list by consensus decision
+	ExcludeNodes([]core.RecordRef)
+	// CalculateHash calculate node list hash based on active node list, claims and excluded nodes
 	CalculateHash() ([]byte, error)
 	// GetActiveNode get active node by reference ID for current consensus
 	GetActiveNode(ref core.RecordRef) core.Node
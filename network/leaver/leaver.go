@@ -0,0 +1,185 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package leaver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/pkg/errors"
+)
+
+// pulseCheckPeriod is a period of checking whether a new pulse is set.
+const pulseCheckPeriod = 100 * time.Millisecond
+
+// ErrNotConfirmed is returned when the node's work is handed off, but the network has not confirmed the node leave.
+var ErrNotConfirmed = errors.New("node leave is not confirmed by network")
+
+// Leaver announces planned leave of the node to the network and waits until the node's work is handed off.
+type Leaver struct {
+	NodeKeeper   network.NodeKeeper `inject:""`
+	LogicRunner  core.LogicRunner   `inject:""`
+	PulseStorage core.PulseStorage  `inject:""`
+
+	timeout        time.Duration
+	confirm        bool
+	confirmTimeout time.Duration
+	once           sync.Once
+	left           chan struct{}
+	err            error
+}
+
+// New creates new Leaver.
+func New(conf configuration.ServiceNetwork) *Leaver {
+	return &Leaver{
+		timeout:        time.Duration(conf.LeaveTimeout) * time.Second,
+		confirm:        conf.LeaveConfirm,
+		confirmTimeout: time.Duration(conf.LeaveConfirmTimeout) * time.Second,
+		left:           make(chan struct{}),
+	}
+}
+
+// Leave broadcasts NodeLeaveClaim, waits until NodeKeeper confirms removal of the node from active list
+// and the node's requests are handed off to the next executors.
+// If removal is not confirmed in confirm timeout, the work is handed off anyway and ErrNotConfirmed is returned.
+// If confirmation is disabled (see WaitsConfirmation), the work is handed off without waiting for it.
+// Concurrent and repeated calls wait for the first one and return its result.
+func (l *Leaver) Leave(ctx context.Context) error {
+	l.once.Do(func() {
+		defer close(l.left)
+
+		if l.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, l.timeout)
+			defer cancel()
+		}
+		l.err = l.leave(ctx)
+	})
+	<-l.left
+	return l.err
+}
+
+// Left returns channel that is closed when leave is finished.
+func (l *Leaver) Left() <-chan struct{} {
+	return l.left
+}
+
+// WaitsConfirmation returns true if Leave waits for the network to confirm node leave.
+// Confirmation is reachable only when consensus is run on pulse, so it is enabled by configuration.
+func (l *Leaver) WaitsConfirmation() bool {
+	return l.confirm
+}
+
+func (l *Leaver) leave(ctx context.Context) error {
+	logger := inslogger.FromContext(ctx)
+
+	origin := l.NodeKeeper.GetOrigin()
+	if l.NodeKeeper.GetActiveNode(origin.ID()) == nil {
+		logger.Info("[ Leave ] node is not in active list, nothing to hand off")
+		return nil
+	}
+	if len(l.NodeKeeper.GetActiveNodes()) == 1 {
+		logger.Info("[ Leave ] node is the only active node, nothing to hand off")
+		return nil
+	}
+
+	confirmed := false
+	if l.confirm {
+		logger.Info("[ Leave ] waiting for the network to confirm node leave")
+		var err error
+		confirmed, err = l.waitConfirmation(ctx)
+		if err != nil {
+			return errors.Wrap(err, "[ Leave ] failed to wait for confirmation")
+		}
+	}
+	if confirmed {
+		// Requests are handed off to the next executors on the first pulse without the node in active list.
+		logger.Info("[ Leave ] node is removed from active list, waiting for the next pulse")
+	} else {
+		// The node stays in active list of other nodes, so only requests of objects the node doesn't execute
+		// in the next pulse are handed off. Callers of other objects have to retry after the network excludes
+		// the stopped node.
+		logger.Warn("[ Leave ] node leave is not confirmed by network, handing off work without confirmation")
+	}
+	err := l.waitNextPulse(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ Leave ] failed to wait for the next pulse")
+	}
+
+	logger.Info("[ Leave ] waiting for executions to finish")
+	err = l.LogicRunner.Drain(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ Leave ] failed to drain LogicRunner")
+	}
+
+	if l.confirm && !confirmed {
+		return errors.Wrap(ErrNotConfirmed, "[ Leave ] work is handed off")
+	}
+	logger.Info("[ Leave ] node has left the network")
+	return nil
+}
+
+// waitConfirmation waits for removal of the node from active list for confirmTimeout at most.
+func (l *Leaver) waitConfirmation(ctx context.Context) (bool, error) {
+	confirmCtx := ctx
+	if l.confirmTimeout > 0 {
+		var cancel context.CancelFunc
+		confirmCtx, cancel = context.WithTimeout(ctx, l.confirmTimeout)
+		defer cancel()
+	}
+
+	select {
+	case <-l.NodeKeeper.Leave():
+		return true, nil
+	case <-confirmCtx.Done():
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return false, nil
+	}
+}
+
+func (l *Leaver) waitNextPulse(ctx context.Context) error {
+	current, err := l.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current pulse")
+	}
+
+	ticker := time.NewTicker(pulseCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		pulse, err := l.PulseStorage.Current(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get current pulse")
+		}
+		if pulse.PulseNumber > current.PulseNumber {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+package leaver
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gojuno/minimock"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type leaverMocks struct {
+	logicRunner  *testutils.LogicRunnerMock
+	pulseStorage *testutils.PulseStorageMock
+}
+
+func newTestLeaver(t *testing.T, mc *minimock.Controller, activeCount int, conf configuration.ServiceNetwork) (*Leaver, *leaverMocks) {
+	active := make([]core.Node, activeCount)
+	for i := range active {
+		active[i] = nodenetwork.NewNode(testutils.RandomRef(), core.StaticRoleVirtual, nil, "127.0.0.1:5432", "")
+	}
+	nodeKeeper := nodenetwork.NewNodeKeeper(active[0])
+	nodeKeeper.AddActiveNodes(active)
+
+	mocks := &leaverMocks{
+		logicRunner:  testutils.NewLogicRunnerMock(mc),
+		pulseStorage: testutils.NewPulseStorageMock(mc),
+	}
+
+	l := New(conf)
+	l.NodeKeeper = nodeKeeper
+	l.LogicRunner = mocks.logicRunner
+	l.PulseStorage = mocks.pulseStorage
+	return l, mocks
+}
+
+// runPulses makes every call of PulseStorage.Current return the next pulse.
+func runPulses(mocks *leaverMocks) {
+	var pulseNumber uint32 = core.FirstPulseNumber
+	mocks.pulseStorage.CurrentFunc = func(context.Context) (*core.Pulse, error) {
+		pn := atomic.AddUint32(&pulseNumber, 1)
+		return &core.Pulse{PulseNumber: core.PulseNumber(pn)}, nil
+	}
+}
+
+// runConsensus emulates consensus that merges claims from claim queue of the origin.
+func runConsensus(nodeKeeper network.NodeKeeper) {
+	for nodeKeeper.GetClaimQueue().Length() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	list := nodeKeeper.GetUnsyncList()
+	for nodeKeeper.GetClaimQueue().Length() > 0 {
+		list.AddClaim(nodeKeeper.GetOrigin().ID(), nodeKeeper.GetClaimQueue().Pop())
+	}
+	nodeKeeper.Sync(list)
+	nodeKeeper.MoveSyncToActive()
+}
+
+func TestLeaver_Leave(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	l, mocks := newTestLeaver(t, mc, 2, configuration.ServiceNetwork{LeaveTimeout: 10, LeaveConfirm: true, LeaveConfirmTimeout: 10})
+	runPulses(mocks)
+	mocks.logicRunner.DrainMock.Return(nil)
+
+	go runConsensus(l.NodeKeeper)
+	err := l.Leave(ctx)
+	require.NoError(t, err)
+	<-l.Left()
+	require.Nil(t, l.NodeKeeper.GetActiveNode(l.NodeKeeper.GetOrigin().ID()))
+	require.Len(t, l.NodeKeeper.GetActiveNodes(), 1)
+
+	err = l.Leave(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), mocks.logicRunner.DrainCounter)
+}
+
+func TestLeaver_Leave_NotConfirmed(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	l, mocks := newTestLeaver(t, mc, 2, configuration.ServiceNetwork{LeaveTimeout: 10, LeaveConfirm: true})
+	l.confirmTimeout = 10 * time.Millisecond
+	runPulses(mocks)
+	mocks.logicRunner.DrainMock.Return(nil)
+
+	err := l.Leave(ctx)
+	require.Equal(t, ErrNotConfirmed, errors.Cause(err))
+	<-l.Left()
+	require.Equal(t, 1, l.NodeKeeper.GetClaimQueue().Length(), "leave claim is not delivered")
+	require.Equal(t, uint64(1), mocks.logicRunner.DrainCounter, "work is handed off without confirmation")
+}
+
+func TestLeaver_Leave_ConfirmationDisabled(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	l, mocks := newTestLeaver(t, mc, 2, configuration.ServiceNetwork{LeaveTimeout: 10, LeaveConfirmTimeout: 10})
+	runPulses(mocks)
+	mocks.logicRunner.DrainMock.Return(nil)
+
+	require.False(t, l.WaitsConfirmation())
+	err := l.Leave(ctx)
+	require.NoError(t, err)
+	<-l.Left()
+	require.Equal(t, 0, l.NodeKeeper.GetClaimQueue().Length(), "leave claim is not sent without confirmation")
+	require.Equal(t, uint64(1), mocks.logicRunner.DrainCounter, "work is handed off without confirmation")
+}
+
+func TestLeaver_Leave_Timeout(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	l, mocks := newTestLeaver(t, mc, 2, configuration.ServiceNetwork{LeaveConfirm: true})
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	err := l.Leave(ctx)
+	require.Error(t, err)
+	require.NotEqual(t, ErrNotConfirmed, errors.Cause(err))
+	<-l.Left()
+	require.Equal(t, uint64(0), mocks.logicRunner.DrainCounter)
+}
+
+func TestLeaver_Leave_SingleNode(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	l, _ := newTestLeaver(t, mc, 1, configuration.ServiceNetwork{LeaveTimeout: 10, LeaveConfirmTimeout: 10})
+
+	err := l.Leave(ctx)
+	require.NoError(t, err)
+	<-l.Left()
+	require.Equal(t, 0, l.NodeKeeper.GetClaimQueue().Length())
+}
@@ -94,6 +94,7 @@ func NewNodeKeeper(origin core.Node) network.NodeKeeper {
 		active:       make(map[core.RecordRef]core.Node),
 		indexNode:    make(map[core.StaticRole]*recordRefSet),
 		indexShortID: make(map[core.ShortNodeID]core.Node),
+		left:         make(chan struct{}),
 	}
 }
 
@@ -119,7 +120,12 @@ type nodekeeper struct {
 	isBootstrap     bool
 	isBootstrapLock sync.RWMutex
 
-	Cryptography core.CryptographyService `inject:""`
+	leaving   bool
+	leaveLock sync.Mutex
+	left      chan struct{}
+
+	Cryptography core.CryptographyService        `inject:""`
+	Scheme       core.PlatformCryptographyScheme `inject:""`
 }
 
 // IsBootstrapped method returns true when bootstrapNodes are connected to each other
@@ -254,14 +260,7 @@ func (nk *nodekeeper) addActiveNode(node core.Node) {
 
 func (nk *nodekeeper) delActiveNode(ref core.RecordRef) {
 	if ref.Equal(nk.origin.ID()) {
-		// we received acknowledge to leave, can gracefully stop
-
-		// graceful stop instead of panic
-		err := coreutils.SendGracefulStopSignal()
-		if err != nil {
-			// we tried :(
-			panic("Node leave acknowledged by network. Goodbye!")
-		}
+		nk.originLeft()
 	}
 	active, ok := nk.active[ref]
 	if !ok {
@@ -294,6 +293,47 @@ func (nk *nodekeeper) AddPendingClaim(claim consensus.ReferendumClaim) bool {
 	return true
 }
 
+func (nk *nodekeeper) Leave() <-chan struct{} {
+	nk.leaveLock.Lock()
+	defer nk.leaveLock.Unlock()
+
+	if nk.leaving {
+		return nk.left
+	}
+	nk.leaving = true
+	select {
+	case <-nk.left:
+		// origin is already removed from active list
+	default:
+		nk.claimQueue.Push(&consensus.NodeLeaveClaim{})
+		log.Info("[ Leave ] NodeLeaveClaim is added to claim queue")
+	}
+	return nk.left
+}
+
+func (nk *nodekeeper) originLeft() {
+	nk.leaveLock.Lock()
+	defer nk.leaveLock.Unlock()
+
+	select {
+	case <-nk.left:
+		return
+	default:
+		close(nk.left)
+	}
+	if nk.leaving {
+		// we received acknowledge to leave, leave initiator will stop the node
+		return
+	}
+
+	// origin is removed by the network without leave request, graceful stop instead of panic
+	err := coreutils.SendGracefulStopSignal()
+	if err != nil {
+		// we tried :(
+		panic("Node is removed from active list by network. Goodbye!")
+	}
+}
+
 func (nk *nodekeeper) GetClaimQueue() network.ClaimQueue {
 	return nk.claimQueue
 }
@@ -303,11 +343,11 @@ func (nk *nodekeeper) NodesJoinedDuringPreviousPulse() bool {
 }
 
 func (nk *nodekeeper) GetUnsyncList() network.UnsyncList {
-	return newUnsyncList(nk.GetActiveNodes())
+	return newUnsyncList(nk.Scheme, nk.GetActiveNodes())
 }
 
 func (nk *nodekeeper) GetSparseUnsyncList(length int) network.UnsyncList {
-	return newSparseUnsyncList(length)
+	return newSparseUnsyncList(nk.Scheme, length)
 }
 
 func (nk *nodekeeper) Sync(list network.UnsyncList) {
@@ -342,15 +382,15 @@ func (nk *nodekeeper) nodeToClaim() (*consensus.NodeJoinClaim, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "[ nodeToClaim ] failed to get a public key")
 	}
-	keyProc := platformpolicy.NewKeyProcessor()
-	exportedKey, err := keyProc.ExportPublicKeyPEM(key)
+	exportedKey, err := platformpolicy.NewKeyProcessor().ExportPublicKeyBinary(key)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ nodeToClaim ] failed to export a public key")
 	}
-	var keyData [consensus.PublicKeyLength]byte
-	copy(keyData[:], exportedKey[:consensus.PublicKeyLength])
+	keyData, err := consensus.NewPublicKeyField(exportedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodeToClaim ] failed to set a public key")
+	}
 
-	var s [consensus.SignatureLength]byte
 	claim := consensus.NodeJoinClaim{
 		ShortNodeID:             nk.origin.ShortID(),
 		RelayNodeID:             nk.origin.ShortID(),
@@ -359,7 +399,6 @@ func (nk *nodekeeper) nodeToClaim() (*consensus.NodeJoinClaim, error) {
 		NodeRoleRecID:           0, // TODO: how to get a role as int?
 		NodeRef:                 nk.origin.ID(),
 		NodePK:                  keyData,
-		Signature:               s,
 	}
 
 	dataToSign, err := claim.SerializeWithoutSign()
@@ -371,7 +410,10 @@ func (nk *nodekeeper) nodeToClaim() (*consensus.NodeJoinClaim, error) {
 		return nil, errors.Wrap(err, "[ nodeToClaim ] failed to sign a claim")
 	}
 
-	copy(claim.Signature[:], sign[:consensus.SignatureLength])
+	claim.Signature, err = consensus.NewSignatureField(sign)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ nodeToClaim ] failed to set a sign")
+	}
 	return &claim, nil
 }
 
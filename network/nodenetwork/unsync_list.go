@@ -36,15 +36,17 @@ func copyMap(m map[core.RecordRef]core.Node) map[core.RecordRef]core.Node {
 }
 
 type unsyncList struct {
+	scheme      core.PlatformCryptographyScheme
 	activeNodes map[core.RecordRef]core.Node
 	addressMap  map[core.RecordRef]string
 	claims      map[core.RecordRef][]consensus.ReferendumClaim
+	excluded    []core.RecordRef
 	refToIndex  map[core.RecordRef]int
 	indexToRef  map[int]core.RecordRef
 	cache       []byte
 }
 
-func newUnsyncList(activeNodesSorted []core.Node) *unsyncList {
+func newUnsyncList(scheme core.PlatformCryptographyScheme, activeNodesSorted []core.Node) *unsyncList {
 	indexToRef := make(map[int]core.RecordRef, len(activeNodesSorted))
 	refToIndex := make(map[core.RecordRef]int, len(activeNodesSorted))
 	activeNodes := make(map[core.RecordRef]core.Node, len(activeNodesSorted))
@@ -55,7 +57,13 @@ func newUnsyncList(activeNodesSorted []core.Node) *unsyncList {
 	}
 	claims := make(map[core.RecordRef][]consensus.ReferendumClaim)
 
-	return &unsyncList{activeNodes: activeNodes, claims: claims, refToIndex: refToIndex, indexToRef: indexToRef}
+	return &unsyncList{
+		scheme:      scheme,
+		activeNodes: activeNodes,
+		claims:      claims,
+		refToIndex:  refToIndex,
+		indexToRef:  indexToRef,
+	}
 }
 
 func (ul *unsyncList) RemoveClaims(from core.RecordRef) {
@@ -69,6 +77,16 @@ func (ul *unsyncList) AddClaims(claims map[core.RecordRef][]consensus.Referendum
 	ul.cache = nil
 }
 
+func (ul *unsyncList) AddClaim(from core.RecordRef, claim consensus.ReferendumClaim) {
+	ul.claims[from] = append(ul.claims[from], claim)
+	ul.cache = nil
+}
+
+func (ul *unsyncList) ExcludeNodes(refs []core.RecordRef) {
+	ul.excluded = append(ul.excluded, refs...)
+	ul.cache = nil
+}
+
 func (ul *unsyncList) CalculateHash() ([]byte, error) {
 	if ul.cache != nil {
 		return ul.cache, nil
@@ -77,7 +95,7 @@ func (ul *unsyncList) CalculateHash() ([]byte, error) {
 	ul.merge(m, ul.claims)
 	sorted := sortedNodeList(m)
 	var err error
-	ul.cache, err = CalculateHash(nil, sorted)
+	ul.cache, err = CalculateHash(ul.scheme, sorted)
 	return ul.cache, err
 }
 
@@ -103,26 +121,29 @@ func (ul *unsyncList) merge(nodes map[core.RecordRef]core.Node, claims map[core.
 }
 
 func (ul *unsyncList) mergeWith(claims map[core.RecordRef][]consensus.ReferendumClaim, addFunc adder, delFunc deleter) {
-	for _, claimList := range claims {
+	for from, claimList := range claims {
 		for _, claim := range claimList {
-			ul.mergeClaim(claim, addFunc, delFunc)
+			ul.mergeClaim(from, claim, addFunc, delFunc)
 		}
 	}
+	for _, ref := range ul.excluded {
+		delFunc(ref)
+	}
 }
 
-func (ul *unsyncList) mergeClaim(claim consensus.ReferendumClaim, addFunc adder, delFunc deleter) {
+func (ul *unsyncList) mergeClaim(from core.RecordRef, claim consensus.ReferendumClaim, addFunc adder, delFunc deleter) {
 	switch t := claim.(type) {
 	case *consensus.NodeJoinClaim:
 		// TODO: fix version
-		node, err := claimToNode(ul.addressMap[t.NodeRef], "", t)
+		node, err := claimToNode(ul.scheme, ul.addressMap[t.NodeRef], "", t)
 		if err != nil {
-			log.Error("[ mergeClaim ] failed to convert Claim -> Node")
+			log.Error("[ mergeClaim ] failed to convert Claim -> Node: ", err)
+			break
 		}
 		addFunc(node)
 	case *consensus.NodeLeaveClaim:
-		// TODO: add node ID to node leave claim (only to struct, not packet)
-		// delFunc()
-		break
+		// leave claim can be issued only by the leaving node itself
+		delFunc(from)
 	}
 }
 
@@ -167,8 +188,8 @@ type sparseUnsyncList struct {
 	capacity int
 }
 
-func newSparseUnsyncList(capacity int) *sparseUnsyncList {
-	return &sparseUnsyncList{unsyncList: *newUnsyncList(nil), capacity: capacity}
+func newSparseUnsyncList(scheme core.PlatformCryptographyScheme, capacity int) *sparseUnsyncList {
+	return &sparseUnsyncList{unsyncList: *newUnsyncList(scheme, nil), capacity: capacity}
 }
 
 func (ul *sparseUnsyncList) Length() int {
@@ -190,18 +211,22 @@ func (ul *sparseUnsyncList) AddClaims(claims map[core.RecordRef][]consensus.Refe
 			}
 
 			// TODO: fix version
-			node, err := claimToNode(ul.addressMap[c.NodeRef], "", &c.NodeJoinClaim)
+			node, err := claimToNode(ul.scheme, ul.addressMap[c.NodeRef], "", &c.NodeJoinClaim)
 			if err != nil {
-				log.Error("[ AddClaims ] failed to convert Claim -> Node")
+				log.Error("[ AddClaims ] failed to convert Claim -> Node: ", err)
+				continue
 			}
 			ul.activeNodes[node.ID()] = node
 		}
 	}
 }
 
-func claimToNode(address, version string, claim *consensus.NodeJoinClaim) (core.Node, error) {
-	keyProc := platformpolicy.NewKeyProcessor()
-	key, err := keyProc.ImportPublicKeyPEM(claim.NodePK[:])
+func claimToNode(scheme core.PlatformCryptographyScheme, address, version string, claim *consensus.NodeJoinClaim) (core.Node, error) {
+	keyData, err := consensus.PublicKeyFromField(claim.NodePK[:], scheme.PublicKeySize())
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ClaimToNode ] failed to get a public key")
+	}
+	key, err := platformpolicy.NewKeyProcessor().ImportPublicKeyBinary(keyData)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ClaimToNode ] failed to import a public key")
 	}
@@ -0,0 +1,117 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package nodenetwork
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNode(t *testing.T, role core.StaticRole, address string) core.Node {
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	privateKey, err := keyProcessor.GeneratePrivateKey()
+	require.NoError(t, err)
+	return NewNode(testutils.RandomRef(), role, keyProcessor.ExtractPublicKey(privateKey), address, "")
+}
+
+func TestUnsyncList_ExcludeNodes(t *testing.T) {
+	origin := newTestNode(t, core.StaticRoleVirtual, "127.0.0.1:5432")
+	excluded := newTestNode(t, core.StaticRoleLightMaterial, "127.0.0.1:5433")
+	nk := NewNodeKeeper(origin)
+	nk.(*nodekeeper).Scheme = platformpolicy.NewPlatformCryptographyScheme()
+	nk.AddActiveNodes([]core.Node{origin, excluded})
+
+	list := nk.GetUnsyncList()
+	hash, err := list.CalculateHash()
+	require.NoError(t, err)
+
+	list.ExcludeNodes([]core.RecordRef{excluded.ID()})
+	excludedHash, err := list.CalculateHash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash, excludedHash)
+	require.Len(t, list.GetActiveNodes(), 2, "current active list is not changed")
+
+	nk.Sync(list)
+	nk.MoveSyncToActive()
+	require.Nil(t, nk.GetActiveNode(excluded.ID()))
+	require.Equal(t, []core.Node{origin}, nk.GetActiveNodes())
+}
+
+func TestUnsyncList_IgnoresBlames(t *testing.T) {
+	origin := newTestNode(t, core.StaticRoleVirtual, "127.0.0.1:5432")
+	blamed := newTestNode(t, core.StaticRoleLightMaterial, "127.0.0.1:5433")
+	nk := NewNodeKeeper(origin)
+	nk.AddActiveNodes([]core.Node{origin, blamed})
+
+	list := nk.GetUnsyncList()
+	list.AddClaim(origin.ID(), &packets.NodeViolationBlame{
+		BlameNodeID:   uint32(blamed.ShortID()),
+		TypeViolation: packets.ViolationMissingVote,
+	})
+	nk.Sync(list)
+	nk.MoveSyncToActive()
+	require.NotNil(t, nk.GetActiveNode(blamed.ID()), "blame alone doesn't exclude node")
+}
+
+func TestUnsyncList_NodeLeaveClaim(t *testing.T) {
+	origin := newTestNode(t, core.StaticRoleVirtual, "127.0.0.1:5432")
+	leaving := newTestNode(t, core.StaticRoleLightMaterial, "127.0.0.1:5433")
+	nk := NewNodeKeeper(origin)
+	nk.AddActiveNodes([]core.Node{origin, leaving})
+
+	list := nk.GetUnsyncList()
+	list.AddClaim(leaving.ID(), &packets.NodeLeaveClaim{})
+
+	nk.Sync(list)
+	nk.MoveSyncToActive()
+	require.Nil(t, nk.GetActiveNode(leaving.ID()))
+	require.Equal(t, []core.Node{origin}, nk.GetActiveNodes())
+}
+
+func TestNodeKeeper_Leave(t *testing.T) {
+	origin := newTestNode(t, core.StaticRoleVirtual, "127.0.0.1:5432")
+	other := newTestNode(t, core.StaticRoleLightMaterial, "127.0.0.1:5433")
+	nk := NewNodeKeeper(origin)
+	nk.AddActiveNodes([]core.Node{origin, other})
+
+	left := nk.Leave()
+	require.Equal(t, left, nk.Leave())
+	require.Equal(t, 1, nk.GetClaimQueue().Length(), "leave claim is added only once")
+	claim := nk.GetClaimQueue().Pop()
+	require.Equal(t, packets.TypeNodeLeaveClaim, claim.Type())
+
+	select {
+	case <-left:
+		t.Fatal("node has not left yet")
+	default:
+	}
+
+	list := nk.GetUnsyncList()
+	list.AddClaim(origin.ID(), claim)
+	nk.Sync(list)
+	nk.MoveSyncToActive()
+
+	<-left
+	require.Nil(t, nk.GetActiveNode(origin.ID()))
+	require.Equal(t, []core.Node{other}, nk.GetActiveNodes())
+}
@@ -126,7 +126,15 @@ func initCertificate(t *testing.T, nodes []certificate.BootstrapNode, key crypto
 	return mngr
 }
 
-func initCrypto(t *testing.T, nodes []certificate.BootstrapNode, ref core.RecordRef) (*certificate.CertificateManager, core.CryptographyService) {
+type inPlaceKeyStore struct {
+	privateKey crypto.PrivateKey
+}
+
+func (ks *inPlaceKeyStore) GetPrivateKey(string) (crypto.PrivateKey, error) {
+	return ks.privateKey, nil
+}
+
+func initCrypto(t *testing.T, nodes []certificate.BootstrapNode, ref core.RecordRef) (*certificate.CertificateManager, core.CryptographyService, core.KeyStore) {
 	key, err := platformpolicy.NewKeyProcessor().GeneratePrivateKey()
 	assert.NoError(t, err)
 	require.NotNil(t, key)
@@ -135,7 +143,7 @@ func initCrypto(t *testing.T, nodes []certificate.BootstrapNode, ref core.Record
 	assert.NoError(t, err)
 	mngr := initCertificate(t, nodes, pubKey, ref)
 
-	return mngr, cs
+	return mngr, cs, &inPlaceKeyStore{privateKey: key}
 }
 
 func (s *testSuite) getBootstrapNodes(t *testing.T) []certificate.BootstrapNode {
@@ -173,7 +181,7 @@ func (s *testSuite) createNetworkNode(t *testing.T) networkNode {
 
 	amMock := testutils.NewArtifactManagerMock(t)
 
-	certManager, cryptographyService := initCrypto(t, s.getBootstrapNodes(t), origin.ID())
+	certManager, cryptographyService, keyStore := initCrypto(t, s.getBootstrapNodes(t), origin.ID())
 	netSwitcher := testutils.NewNetworkSwitcherMock(t)
 
 	realKeeper := nodenetwork.NewNodeKeeper(origin)
@@ -181,7 +189,7 @@ func (s *testSuite) createNetworkNode(t *testing.T) networkNode {
 
 	cm := &component.Manager{}
 	cm.Register(keeper, pulseManagerMock, netCoordinator, amMock, realKeeper)
-	cm.Register(certManager, cryptographyService)
+	cm.Register(certManager, cryptographyService, keyStore)
 	cm.Inject(netSwitcher)
 
 	scheme := platformpolicy.NewPlatformCryptographyScheme()
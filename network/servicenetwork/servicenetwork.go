@@ -19,6 +19,7 @@ package servicenetwork
 
 import (
 	"context"
+	"crypto/tls"
 	"strconv"
 	"strings"
 
@@ -56,11 +57,15 @@ type ServiceNetwork struct {
 	CryptographyScheme  core.PlatformCryptographyScheme `inject:""`
 	NodeKeeper          network.NodeKeeper              `inject:""`
 	NetworkSwitcher     core.NetworkSwitcher            `inject:""`
+	KeyStore            core.KeyStore                   `inject:""`
 
 	// subcomponents
 	PhaseManager phases.PhaseManager `inject:"subcomponent"`
 	Controller   network.Controller  `inject:"subcomponent"`
 
+	// TransportFactory creates host and consensus transports, it can be replaced before Init.
+	TransportFactory TransportFactory
+
 	// fakePulsar *fakepulsar.FakePulsar
 	isGenesis bool
 	skip      int
@@ -68,7 +73,14 @@ type ServiceNetwork struct {
 
 // NewServiceNetwork returns a new ServiceNetwork.
 func NewServiceNetwork(conf configuration.Configuration, scheme core.PlatformCryptographyScheme, rootCm *component.Manager, isGenesis bool) (*ServiceNetwork, error) {
-	serviceNetwork := &ServiceNetwork{cm: component.NewManager(rootCm), cfg: conf, CryptographyScheme: scheme, isGenesis: isGenesis, skip: conf.Service.Skip}
+	serviceNetwork := &ServiceNetwork{
+		cm:                 component.NewManager(rootCm),
+		cfg:                conf,
+		CryptographyScheme: scheme,
+		TransportFactory:   hostTransportFactory{},
+		isGenesis:          isGenesis,
+		skip:               conf.Service.Skip,
+	}
 	return serviceNetwork, nil
 }
 
@@ -109,7 +121,19 @@ func incrementPort(address string) (string, error) {
 // Start implements component.Initer
 func (n *ServiceNetwork) Init(ctx context.Context) error {
 	n.routingTable = &routing.Table{}
-	internalTransport, err := hostnetwork.NewInternalTransport(n.cfg, n.CertificateManager.GetCertificate().GetNodeRef().String())
+
+	var tlsConfig *tls.Config
+	if n.cfg.Host.Transport.TLS {
+		var err error
+		tlsConfig, err = n.newTLSConfig()
+		if err != nil {
+			return errors.Wrap(err, "Failed to create TLS config")
+		}
+	}
+
+	internalTransport, err := n.TransportFactory.NewInternalTransport(
+		n.cfg, n.CertificateManager.GetCertificate().GetNodeRef().String(), tlsConfig,
+	)
 	if err != nil {
 		return errors.Wrap(err, "Failed to create internal transport")
 	}
@@ -120,7 +144,7 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 		return errors.Wrap(err, "failed to increment port.")
 	}
 
-	consensusNetwork, err := hostnetwork.NewConsensusNetwork(
+	consensusNetwork, err := n.TransportFactory.NewConsensusNetwork(
 		n.cfg.Host.Transport.Address,
 		n.CertificateManager.GetCertificate().GetNodeRef().String(),
 		n.NodeKeeper.GetOrigin().ShortID(),
@@ -243,6 +267,7 @@ func (n *ServiceNetwork) HandlePulse(ctx context.Context, pulse core.Pulse) {
 		}
 
 		logger.Infof("Set new current pulse number: %d", pulse.PulseNumber)
+		// Consensus is not run on pulse yet, so blames and exclusion of nodes by PhaseManager take effect in tests only.
 		// go func(logger core.Logger, network *ServiceNetwork) {
 		// 	TODO: make PhaseManager works and uncomment this (after NETD18-75)
 		// 	err = n.PhaseManager.OnPulse(ctx, &pulse)
@@ -0,0 +1,131 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package servicenetwork
+
+import (
+	"crypto"
+	"crypto/tls"
+	"encoding/json"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network/transport"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
+// tlsAuthorization is embedded into TLS certificate of the node. It carries signs of the node certificate made by
+// discovery nodes, so nodes that don't know the peer yet (e.g. joining one) trust only the key certified by discovery.
+type tlsAuthorization struct {
+	PublicKey      string            `json:"public_key"`
+	Reference      string            `json:"reference"`
+	Role           string            `json:"role"`
+	DiscoverySigns map[string][]byte `json:"discovery_signs"`
+}
+
+// nodePart returns data signed by discovery nodes, see AuthorizationCertificate.SerializeNodePart.
+func (a *tlsAuthorization) nodePart() []byte {
+	return []byte(a.PublicKey + a.Reference + a.Role)
+}
+
+// newTLSConfig creates mutual TLS config from node keys. Peers are verified against active nodes and discovery nodes,
+// other peers must present authorization signed by every discovery node.
+func (n *ServiceNetwork) newTLSConfig() (*tls.Config, error) {
+	privateKey, err := n.KeyStore.GetPrivateKey("")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get node private key")
+	}
+
+	cert := n.CertificateManager.GetCertificate()
+	authorization, err := newTLSAuthorization(cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create node authorization")
+	}
+
+	discovery := make(map[core.RecordRef]crypto.PublicKey)
+	for _, node := range cert.GetDiscoveryNodes() {
+		discovery[*node.GetNodeRef()] = node.GetPublicKey()
+	}
+
+	peerKey := func(ref core.RecordRef, authorization []byte) (crypto.PublicKey, error) {
+		if node := n.NodeKeeper.GetActiveNode(ref); node != nil {
+			return node.PublicKey(), nil
+		}
+		if key, ok := discovery[ref]; ok {
+			return key, nil
+		}
+		return n.authorizedKey(ref, authorization)
+	}
+
+	return transport.NewTLSConfig(*cert.GetNodeRef(), privateKey, authorization, peerKey)
+}
+
+func newTLSAuthorization(cert core.Certificate) ([]byte, error) {
+	publicKey, err := platformpolicy.NewKeyProcessor().ExportPublicKeyPEM(cert.GetPublicKey())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to export public key")
+	}
+
+	authorization := tlsAuthorization{
+		PublicKey:      string(publicKey),
+		Reference:      cert.GetNodeRef().String(),
+		Role:           cert.GetRole().String(),
+		DiscoverySigns: make(map[string][]byte),
+	}
+	for ref, sign := range cert.GetDiscoverySigns() {
+		if ref != nil {
+			authorization.DiscoverySigns[ref.String()] = sign
+		}
+	}
+	return json.Marshal(authorization)
+}
+
+// authorizedKey returns key of unknown node if its authorization is signed by every discovery node.
+func (n *ServiceNetwork) authorizedKey(ref core.RecordRef, data []byte) (crypto.PublicKey, error) {
+	if len(data) == 0 {
+		return nil, errors.New("unknown node without authorization")
+	}
+	var authorization tlsAuthorization
+	err := json.Unmarshal(data, &authorization)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse authorization")
+	}
+	if authorization.Reference != ref.String() {
+		return nil, errors.New("authorization is issued for another node")
+	}
+
+	discoveryNodes := n.CertificateManager.GetCertificate().GetDiscoveryNodes()
+	if len(discoveryNodes) == 0 {
+		return nil, errors.New("no discovery nodes to verify authorization")
+	}
+	nodePart := authorization.nodePart()
+	for _, node := range discoveryNodes {
+		sign, ok := authorization.DiscoverySigns[node.GetNodeRef().String()]
+		if !ok {
+			return nil, errors.Errorf("authorization is not signed by discovery node %s", node.GetNodeRef())
+		}
+		if !n.CryptographyService.Verify(node.GetPublicKey(), core.SignatureFromBytes(sign), nodePart) {
+			return nil, errors.Errorf("wrong sign of discovery node %s", node.GetNodeRef())
+		}
+	}
+
+	key, err := platformpolicy.NewKeyProcessor().ImportPublicKeyPEM([]byte(authorization.PublicKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to import public key")
+	}
+	return key, nil
+}
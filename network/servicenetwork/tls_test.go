@@ -0,0 +1,89 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package servicenetwork
+
+import (
+	"crypto"
+	"encoding/json"
+	"testing"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceNetwork_authorizedKey(t *testing.T) {
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	discoveryKey, err := keyProcessor.GeneratePrivateKey()
+	require.NoError(t, err)
+	discoveryRef := testutils.RandomRef()
+	discovery := testutils.NewDiscoveryNodeMock(t)
+	discovery.GetNodeRefMock.Return(&discoveryRef)
+	discovery.GetPublicKeyMock.Return(keyProcessor.ExtractPublicKey(discoveryKey))
+
+	nodeKey, err := keyProcessor.GeneratePrivateKey()
+	require.NoError(t, err)
+	nodePublicKey := keyProcessor.ExtractPublicKey(nodeKey)
+	nodeKeyPEM, err := keyProcessor.ExportPublicKeyPEM(nodePublicKey)
+	require.NoError(t, err)
+	nodeRef := testutils.RandomRef()
+	nodePart := []byte(string(nodeKeyPEM) + nodeRef.String() + core.StaticRoleVirtual.String())
+	sign, err := cryptography.NewKeyBoundCryptographyService(discoveryKey).Sign(nodePart)
+	require.NoError(t, err)
+
+	nodeCert := testutils.NewCertificateMock(t)
+	nodeCert.GetPublicKeyMock.Return(nodePublicKey)
+	nodeCert.GetNodeRefMock.Return(&nodeRef)
+	nodeCert.GetRoleMock.Return(core.StaticRoleVirtual)
+	nodeCert.GetDiscoverySignsMock.Return(map[*core.RecordRef][]byte{&discoveryRef: sign.Bytes()})
+	authorization, err := newTLSAuthorization(nodeCert)
+	require.NoError(t, err)
+
+	cert := testutils.NewCertificateMock(t)
+	cert.GetDiscoveryNodesMock.Return([]core.DiscoveryNode{discovery})
+	certManager := testutils.NewCertificateManagerMock(t)
+	certManager.GetCertificateMock.Return(cert)
+	n := &ServiceNetwork{
+		CertificateManager:  certManager,
+		CryptographyService: cryptography.NewKeyBoundCryptographyService(discoveryKey),
+	}
+
+	key, err := n.authorizedKey(nodeRef, authorization)
+	require.NoError(t, err)
+	require.Equal(t, nodePublicKey, key)
+
+	_, err = n.authorizedKey(testutils.RandomRef(), authorization)
+	require.Error(t, err, "authorization of another node")
+
+	_, err = n.authorizedKey(nodeRef, nil)
+	require.Error(t, err, "no authorization")
+
+	var forged tlsAuthorization
+	require.NoError(t, json.Unmarshal(authorization, &forged))
+	otherKey, err := keyProcessor.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKeyPEM, err := keyProcessor.ExportPublicKeyPEM(otherKey.(crypto.Signer).Public())
+	require.NoError(t, err)
+	forged.PublicKey = string(otherKeyPEM)
+	forgedData, err := json.Marshal(forged)
+	require.NoError(t, err)
+	_, err = n.authorizedKey(nodeRef, forgedData)
+	require.Error(t, err, "key is not signed by discovery")
+}
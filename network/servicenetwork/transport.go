@@ -0,0 +1,46 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package servicenetwork
+
+import (
+	"crypto/tls"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/hostnetwork"
+)
+
+// TransportFactory creates transports of ServiceNetwork. Network simulator replaces it to run nodes over in-memory network.
+type TransportFactory interface {
+	// NewInternalTransport creates transport of host network, tlsConfig is nil if TLS is disabled.
+	NewInternalTransport(conf configuration.Configuration, nodeRef string, tlsConfig *tls.Config) (network.InternalTransport, error)
+	// NewConsensusNetwork creates transport of consensus listening on address.
+	NewConsensusNetwork(address, nodeRef string, shortID core.ShortNodeID, resolver network.RoutingTable) (network.ConsensusNetwork, error)
+}
+
+// hostTransportFactory creates TCP/UDP transports of hostnetwork.
+type hostTransportFactory struct{}
+
+func (hostTransportFactory) NewInternalTransport(conf configuration.Configuration, nodeRef string, tlsConfig *tls.Config) (network.InternalTransport, error) {
+	return hostnetwork.NewSecureInternalTransport(conf, nodeRef, tlsConfig)
+}
+
+func (hostTransportFactory) NewConsensusNetwork(address, nodeRef string, shortID core.ShortNodeID, resolver network.RoutingTable) (network.ConsensusNetwork, error) {
+	return hostnetwork.NewConsensusNetwork(address, nodeRef, shortID, resolver)
+}
@@ -106,6 +106,10 @@ func (n *nodeKeeperWrapper) AddPendingClaim(claim consensus.ReferendumClaim) boo
 	return n.original.AddPendingClaim(claim)
 }
 
+func (n *nodeKeeperWrapper) Leave() <-chan struct{} {
+	return n.original.Leave()
+}
+
 func (n *nodeKeeperWrapper) GetClaimQueue() network.ClaimQueue {
 	return n.original.GetClaimQueue()
 }
@@ -0,0 +1,147 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package simulator
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Clock is a virtual clock. Time moves only when Advance is called, timers fire in order of their deadlines
+// and timers with equal deadlines fire in order of creation.
+type Clock struct {
+	lock   sync.Mutex
+	now    time.Time
+	seq    uint64
+	timers timerHeap
+}
+
+// Timer is a callback scheduled on virtual clock.
+type Timer struct {
+	clock *Clock
+	at    time.Time
+	seq   uint64
+	f     func()
+	index int
+}
+
+// NewClock creates virtual clock that shows start time.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns current virtual time.
+func (c *Clock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// AfterFunc schedules f to be called in goroutine of Advance when virtual time reaches now + d.
+func (c *Clock) AfterFunc(d time.Duration, f func()) *Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if d < 0 {
+		d = 0
+	}
+	c.seq++
+	t := &Timer{clock: c, at: c.now.Add(d), seq: c.seq, f: f}
+	heap.Push(&c.timers, t)
+	return t
+}
+
+// Stop cancels timer, it returns false if timer has already fired or has been stopped.
+func (t *Timer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	if t.index < 0 {
+		return false
+	}
+	heap.Remove(&t.clock.timers, t.index)
+	return true
+}
+
+// Advance moves virtual time forward by d and fires all timers which deadlines are reached.
+func (c *Clock) Advance(d time.Duration) {
+	target := c.Now().Add(d)
+	for c.step(target) {
+	}
+	c.set(target)
+}
+
+// step fires the earliest timer with deadline not after until, it returns false if there is no such timer.
+func (c *Clock) step(until time.Time) bool {
+	c.lock.Lock()
+	if len(c.timers) == 0 || c.timers[0].at.After(until) {
+		c.lock.Unlock()
+		return false
+	}
+	t := heap.Pop(&c.timers).(*Timer)
+	if t.at.After(c.now) {
+		c.now = t.at
+	}
+	c.lock.Unlock()
+
+	t.f()
+	return true
+}
+
+func (c *Clock) set(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if now.After(c.now) {
+		c.now = now
+	}
+}
+
+type timerHeap []*Timer
+
+func (h timerHeap) Len() int {
+	return len(h)
+}
+
+func (h timerHeap) Less(i, j int) bool {
+	if h[i].at.Equal(h[j].at) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].at.Before(h[j].at)
+}
+
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x interface{}) {
+	t := x.(*Timer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *timerHeap) Pop() interface{} {
+	old := *h
+	t := old[len(old)-1]
+	old[len(old)-1] = nil
+	t.index = -1
+	*h = old[:len(old)-1]
+	return t
+}
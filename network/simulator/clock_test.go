@@ -0,0 +1,61 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Unix(100, 0)
+	clock := NewClock(start)
+
+	var fired []int
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, 3) })
+	clock.AfterFunc(time.Second, func() { fired = append(fired, 1) })
+	clock.AfterFunc(time.Second, func() { fired = append(fired, 2) })
+	stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, 0) })
+	require.True(t, stopped.Stop())
+	require.False(t, stopped.Stop())
+
+	clock.Advance(time.Second)
+	require.Equal(t, []int{1, 2}, fired)
+	require.Equal(t, start.Add(time.Second), clock.Now())
+
+	clock.Advance(time.Minute)
+	require.Equal(t, []int{1, 2, 3}, fired)
+	require.Equal(t, start.Add(time.Minute+time.Second), clock.Now())
+}
+
+func TestClock_AfterFuncInCallback(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+
+	var at []time.Time
+	clock.AfterFunc(time.Second, func() {
+		at = append(at, clock.Now())
+		clock.AfterFunc(time.Second, func() {
+			at = append(at, clock.Now())
+		})
+	})
+
+	clock.Advance(5 * time.Second)
+	require.Equal(t, []time.Time{time.Unix(1, 0), time.Unix(2, 0)}, at)
+}
@@ -0,0 +1,160 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package simulator
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/sequence"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+// consensusAddress returns address of consensus transport of the node, ServiceNetwork listens to it on port+1.
+func consensusAddress(address string) (string, error) {
+	a, err := host.NewAddress(address)
+	if err != nil {
+		return "", errors.Wrap(err, "[ consensusAddress ] failed to parse address")
+	}
+	return a.IP.String() + ":" + strconv.Itoa(a.Port+1), nil
+}
+
+// memoryConsensus implements network.ConsensusNetwork over simulated network.
+type memoryConsensus struct {
+	hub               *hub
+	origin            *host.Host
+	resolver          network.RoutingTable
+	sequenceGenerator sequence.Generator
+
+	lock     sync.Mutex
+	started  bool
+	handlers map[types.PacketType]network.ConsensusRequestHandler
+	err      error
+}
+
+func newMemoryConsensus(hub *hub, origin *host.Host, resolver network.RoutingTable) *memoryConsensus {
+	return &memoryConsensus{
+		hub:               hub,
+		origin:            origin,
+		resolver:          resolver,
+		sequenceGenerator: sequence.NewGeneratorImpl(),
+		handlers:          map[types.PacketType]network.ConsensusRequestHandler{},
+	}
+}
+
+// Start connects transport to simulated network.
+func (c *memoryConsensus) Start(ctx context.Context) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.started {
+		inslogger.FromContext(ctx).Warn("double listen initiated")
+		return
+	}
+	c.started = true
+	c.hub.connect(c.PublicAddress(), c)
+}
+
+// Stop disconnects transport from simulated network.
+func (c *memoryConsensus) Stop() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.started {
+		return
+	}
+	c.started = false
+	c.hub.disconnect(c.PublicAddress())
+}
+
+// PublicAddress returns public address that can be published for all nodes.
+func (c *memoryConsensus) PublicAddress() string {
+	return c.origin.Address.String()
+}
+
+// GetNodeID get current node ID.
+func (c *memoryConsensus) GetNodeID() core.RecordRef {
+	return c.origin.NodeID
+}
+
+// SendRequest send request to consensus transport of a remote node.
+func (c *memoryConsensus) SendRequest(request network.Request, receiver core.RecordRef) error {
+	receiverHost, err := c.resolver.Resolve(receiver)
+	if err != nil {
+		return errors.Wrapf(err, "[ SendRequest ] failed to resolve node %s", receiver.String())
+	}
+	address, err := consensusAddress(receiverHost.Address.String())
+	if err != nil {
+		return errors.Wrap(err, "[ SendRequest ] failed to get consensus address")
+	}
+	p := packet.NewBuilder(c.origin).Receiver(receiverHost).Type(request.GetType()).RequestID(request.GetRequestID()).
+		Request(request.GetData()).Build()
+	return c.hub.send(c.PublicAddress(), address, p)
+}
+
+// RegisterRequestHandler register a handler function to process incoming requests of a specific type.
+// Duplicate registration is returned by registrationError.
+func (c *memoryConsensus) RegisterRequestHandler(packetType types.PacketType, handler network.ConsensusRequestHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, exists := c.handlers[packetType]; exists {
+		c.err = errors.Errorf("multiple handlers for packet type %s are not supported", packetType.String())
+		return
+	}
+	c.handlers[packetType] = handler
+}
+
+// NewRequestBuilder create packet builder for an outgoing request with sender set to current node.
+func (c *memoryConsensus) NewRequestBuilder() network.RequestBuilder {
+	return &requestBuilder{sender: c.origin, id: network.RequestID(c.sequenceGenerator.Generate())}
+}
+
+func (c *memoryConsensus) registrationError() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.err
+}
+
+func (c *memoryConsensus) receive(p *packet.Packet) {
+	logger := inslogger.FromContext(context.Background())
+	sender, err := c.resolver.ResolveS(p.Sender.ShortID)
+	if err != nil {
+		logger.Errorf("Failed to resolve sender of %s request: %s", p.Type.String(), err)
+		return
+	}
+	p.Sender = sender
+
+	c.lock.Lock()
+	handler, exists := c.handlers[p.Type]
+	c.lock.Unlock()
+	if !exists {
+		logger.Errorf("No handler set for packet type %s from node %s", p.Type.String(), p.Sender.NodeID.String())
+		return
+	}
+
+	c.hub.handlerStarted()
+	go func() {
+		defer c.hub.handlerFinished()
+		handler((*packetWrapper)(p))
+	}()
+}
@@ -0,0 +1,266 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package simulator
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+// Event is a packet sent over simulated network.
+type Event struct {
+	At        time.Time
+	From      string
+	To        string
+	Type      types.PacketType
+	RequestID network.RequestID
+	Response  bool
+	// Dropped is true if packet was lost, sent across partition or to crashed node.
+	Dropped bool
+}
+
+// endpoint receives packets from simulated network.
+type endpoint interface {
+	receive(p *packet.Packet)
+}
+
+type linkKey struct {
+	from, to string
+}
+
+// hub delivers packets between endpoints. Delays and losses of packets are derived from seed and number of packet
+// in its link, so they don't depend on goroutines scheduling.
+type hub struct {
+	config Config
+	clock  *Clock
+
+	lock      sync.Mutex
+	endpoints map[string]endpoint
+	groups    map[string]int
+	counters  map[linkKey]int
+	trace     []Event
+
+	activityLock sync.Mutex
+	activity     *sync.Cond
+	running      int
+	blocked      int
+}
+
+func newHub(config Config, clock *Clock) *hub {
+	h := &hub{
+		config:    config,
+		clock:     clock,
+		endpoints: map[string]endpoint{},
+		counters:  map[linkKey]int{},
+	}
+	h.activity = sync.NewCond(&h.activityLock)
+	return h
+}
+
+func (h *hub) connect(address string, e endpoint) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.endpoints[address] = e
+}
+
+func (h *hub) disconnect(address string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.endpoints, address)
+}
+
+// partition splits endpoints to groups, endpoints without group are connected only to each other.
+// Addresses in always are reachable from every group.
+func (h *hub) partition(groups [][]string, always ...string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.groups = map[string]int{}
+	for i, group := range groups {
+		for _, address := range group {
+			h.groups[address] = i + 1
+		}
+	}
+	for _, address := range always {
+		h.groups[address] = -1
+	}
+}
+
+func (h *hub) heal() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.groups = nil
+}
+
+// send copies packet as it is sent over the wire and schedules its delivery.
+func (h *hub) send(from, to string, p *packet.Packet) error {
+	data, err := packet.SerializePacket(p)
+	if err != nil {
+		return errors.Wrap(err, "[ send ] failed to serialize packet")
+	}
+
+	key := linkKey{from: from, to: to}
+	h.lock.Lock()
+	sequence := h.counters[key]
+	h.counters[key]++
+	h.lock.Unlock()
+
+	lost := h.random(key, sequence, "loss") < h.config.Loss
+	delay := h.config.Latency + time.Duration(h.random(key, sequence, "latency")*float64(h.config.Jitter))
+	event := Event{From: from, To: to, Type: p.Type, RequestID: p.RequestID, Response: p.IsResponse}
+
+	if delay == 0 {
+		h.deliver(event, data, lost)
+		return nil
+	}
+	h.clock.AfterFunc(delay, func() {
+		h.deliver(event, data, lost)
+	})
+	return nil
+}
+
+func (h *hub) deliver(event Event, data []byte, lost bool) {
+	h.lock.Lock()
+	receiver, ok := h.endpoints[event.To]
+	event.At = h.clock.Now()
+	event.Dropped = lost || !ok || !h.reachable(event.From, event.To)
+	h.trace = append(h.trace, event)
+	h.lock.Unlock()
+
+	if event.Dropped {
+		return
+	}
+	p, err := packet.DeserializePacket(bytes.NewReader(data))
+	if err != nil {
+		log.Errorf("[ deliver ] failed to deserialize packet from %s: %s", event.From, err)
+		return
+	}
+	receiver.receive(p)
+}
+
+func (h *hub) reachable(from, to string) bool {
+	if h.groups == nil {
+		return true
+	}
+	fromGroup, toGroup := h.groups[from], h.groups[to]
+	return fromGroup == toGroup || fromGroup < 0 || toGroup < 0
+}
+
+// random returns number in [0, 1) range defined by seed and packet.
+func (h *hub) random(key linkKey, sequence int, purpose string) float64 {
+	hash := fnv.New64a()
+	_, _ = fmt.Fprintf(hash, "%d/%s/%s/%d/%s", h.config.Seed, key.from, key.to, sequence, purpose)
+	return float64(hash.Sum64()>>11) / float64(1<<53)
+}
+
+// events returns trace sorted by time, packets delivered at the same time are sorted by link and request.
+func (h *hub) events() []Event {
+	h.lock.Lock()
+	result := make([]Event, len(h.trace))
+	copy(result, h.trace)
+	h.lock.Unlock()
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if !a.At.Equal(b.At) {
+			return a.At.Before(b.At)
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		if a.RequestID != b.RequestID {
+			return a.RequestID < b.RequestID
+		}
+		return !a.Response && b.Response
+	})
+	return result
+}
+
+// delivered checks if request has been delivered.
+func (h *hub) delivered(from, to string, id network.RequestID) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, event := range h.trace {
+		if event.From == from && event.To == to && event.RequestID == id && !event.Response {
+			return !event.Dropped
+		}
+	}
+	return false
+}
+
+// handlerStarted and handlerFinished track handlers of incoming requests.
+func (h *hub) handlerStarted() {
+	h.changeActivity(1, 0)
+}
+
+func (h *hub) handlerFinished() {
+	h.changeActivity(-1, 0)
+}
+
+// handlerBlocked and handlerUnblocked track requests waiting for responses, they wait for virtual time.
+func (h *hub) handlerBlocked() {
+	h.changeActivity(0, 1)
+}
+
+func (h *hub) handlerUnblocked() {
+	h.changeActivity(0, -1)
+}
+
+func (h *hub) changeActivity(running, blocked int) {
+	h.activityLock.Lock()
+	defer h.activityLock.Unlock()
+	h.running += running
+	h.blocked += blocked
+	h.activity.Broadcast()
+}
+
+// waitSettled waits until running handlers don't outnumber requests waiting for responses: handlers and goroutines
+// they start wait for virtual time then. It returns false if timeout is reached.
+func (h *hub) waitSettled(timeout time.Duration) bool {
+	expired := false
+	timer := time.AfterFunc(timeout, func() {
+		h.activityLock.Lock()
+		defer h.activityLock.Unlock()
+		expired = true
+		h.activity.Broadcast()
+	})
+	defer timer.Stop()
+
+	h.activityLock.Lock()
+	defer h.activityLock.Unlock()
+	for h.running > h.blocked {
+		if expired {
+			return false
+		}
+		h.activity.Wait()
+	}
+	return true
+}
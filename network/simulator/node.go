@@ -0,0 +1,339 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/tls"
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus/phases"
+	"github.com/insolar/insolar/contractrequester"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/delegationtoken"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/eventbus"
+	"github.com/insolar/insolar/genesisdataprovider"
+	"github.com/insolar/insolar/ledger"
+	"github.com/insolar/insolar/logicrunner"
+	"github.com/insolar/insolar/messagebus"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/leaver"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/network/servicenetwork"
+	"github.com/insolar/insolar/network/state"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/networkcoordinator"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
+// Node is a simulated node. It runs components of insolard except API and metrics: service network with consensus,
+// message bus, ledger on its own storage and logic runner with builtin executor. Only transports of service network
+// are replaced by in-memory ones. Every node considers all nodes of the simulator active.
+type Node struct {
+	hub       *hub
+	origin    core.Node
+	keeper    network.NodeKeeper
+	transport *memoryTransport
+	consensus *memoryConsensus
+	cm        *component.Manager
+
+	network           *servicenetwork.ServiceNetwork
+	logicRunner       *logicrunner.LogicRunner
+	messageBus        *messagebus.MessageBus
+	contractRequester core.ContractRequester
+
+	pulseLock sync.Mutex
+	pulseCond *sync.Cond
+	pulses    []core.Pulse
+}
+
+// keyStore keeps private key of simulated node in memory.
+type keyStore struct {
+	privateKey crypto.PrivateKey
+}
+
+func (ks *keyStore) GetPrivateKey(string) (crypto.PrivateKey, error) {
+	return ks.privateKey, nil
+}
+
+// pulseHandler passes pulses to service network. Pulse controller handles pulses in separate goroutine,
+// memoryTransport counts it as running handler when pulse is delivered and pulseHandler finishes it.
+type pulseHandler struct {
+	node *Node
+}
+
+func (h *pulseHandler) HandlePulse(ctx context.Context, pulse core.Pulse) {
+	defer h.node.hub.handlerFinished()
+	h.node.network.HandlePulse(ctx, pulse)
+
+	h.node.pulseLock.Lock()
+	defer h.node.pulseLock.Unlock()
+	h.node.pulses = append(h.node.pulses, pulse)
+	h.node.pulseCond.Broadcast()
+}
+
+func newCertificate(origin core.Node) (*certificate.CertificateManager, error) {
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	publicKey, err := keyProcessor.ExportPublicKeyPEM(origin.PublicKey())
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newCertificate ] failed to export public key")
+	}
+	data, err := json.Marshal(map[string]string{
+		"public_key": string(publicKey),
+		"reference":  origin.ID().String(),
+		"role":       origin.Role().String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newCertificate ] failed to marshal certificate")
+	}
+	return certificate.NewManagerReadCertificateFromReader(origin.PublicKey(), keyProcessor, bytes.NewReader(data))
+}
+
+func newNode(s *Simulator, index int) (*Node, error) {
+	origin := s.origins[index]
+	keeper := nodenetwork.NewNodeKeeper(origin)
+	keeper.AddActiveNodes(s.origins)
+	keeper.SetState(network.Ready)
+	keeper.SetIsBootstrapped(true)
+
+	certManager, err := newCertificate(origin)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newNode ] failed to create certificate")
+	}
+
+	cfg := configuration.NewConfiguration()
+	cfg.Host.Transport.Address = origin.PhysicalAddress()
+	cfg.Ledger.Storage.DataDirectory = filepath.Join(s.dir, strconv.Itoa(index))
+	cfg.LogicRunner = configuration.LogicRunner{BuiltIn: &configuration.BuiltIn{}}
+
+	n := &Node{hub: s.hub, origin: origin, keeper: keeper, cm: &component.Manager{}}
+	n.pulseCond = sync.NewCond(&n.pulseLock)
+	n.network, err = servicenetwork.NewServiceNetwork(cfg, s.scheme, n.cm, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newNode ] failed to create service network")
+	}
+	n.network.TransportFactory = n
+	n.logicRunner, err = logicrunner.NewLogicRunner(&cfg.LogicRunner)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newNode ] failed to create logic runner")
+	}
+	n.messageBus, err = messagebus.NewMessageBus(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newNode ] failed to create message bus")
+	}
+	n.contractRequester, err = contractrequester.New()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newNode ] failed to create contract requester")
+	}
+	genesisDataProvider, err := genesisdataprovider.New()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newNode ] failed to create genesis data provider")
+	}
+	networkSwitcher, err := state.NewNetworkSwitcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newNode ] failed to create network switcher")
+	}
+	networkCoordinator, err := networkcoordinator.New()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newNode ] failed to create network coordinator")
+	}
+
+	cryptographyService := cryptography.NewCryptographyService()
+	// pulseHandler is registered before service network to be injected into pulse controller instead of it.
+	n.cm.Register(
+		&pulseHandler{node: n},
+		s.scheme,
+		&keyStore{privateKey: s.keys[index]},
+		cryptographyService,
+		platformpolicy.NewKeyProcessor(),
+		certManager,
+		keeper,
+		n.network,
+	)
+
+	components := ledger.GetLedgerComponents(cfg.Ledger, certManager.GetCertificate())
+	components = append(components,
+		n.messageBus,
+		eventbus.NewEventBus(),
+		n.contractRequester,
+		&ledger.Ledger{},
+		n.logicRunner,
+		delegationtoken.NewDelegationTokenFactory(),
+		messagebus.NewParcelFactory(),
+		genesisDataProvider,
+		networkSwitcher,
+		networkCoordinator,
+		leaver.New(cfg.Service),
+		phases.NewPhaseManager(),
+		cryptographyService,
+	)
+	n.cm.Inject(components...)
+	return n, nil
+}
+
+// NewInternalTransport implements servicenetwork.TransportFactory.
+func (n *Node) NewInternalTransport(conf configuration.Configuration, nodeRef string, tlsConfig *tls.Config) (network.InternalTransport, error) {
+	h, err := host.NewHostNS(conf.Host.Transport.Address, n.origin.ID(), n.origin.ShortID())
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewInternalTransport ] failed to create host")
+	}
+	n.transport = newMemoryTransport(n.hub, h)
+	return n.transport, nil
+}
+
+// NewConsensusNetwork implements servicenetwork.TransportFactory.
+func (n *Node) NewConsensusNetwork(address, nodeRef string, shortID core.ShortNodeID, resolver network.RoutingTable) (network.ConsensusNetwork, error) {
+	h, err := host.NewHostNS(address, n.origin.ID(), shortID)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewConsensusNetwork ] failed to create host")
+	}
+	n.consensus = newMemoryConsensus(n.hub, h, resolver)
+	return n.consensus, nil
+}
+
+func (n *Node) start(ctx context.Context) error {
+	if err := n.cm.Init(ctx); err != nil {
+		return errors.Wrap(err, "[ start ] failed to init components")
+	}
+	if err := n.cm.Start(ctx); err != nil {
+		return errors.Wrap(err, "[ start ] failed to start components")
+	}
+	if err := n.transport.registrationError(); err != nil {
+		return errors.Wrap(err, "[ start ] failed to register packet handler")
+	}
+	if err := n.consensus.registrationError(); err != nil {
+		return errors.Wrap(err, "[ start ] failed to register consensus handler")
+	}
+	// insolard doesn't start consensus transport while phase manager is disabled, it is started to deliver
+	// packets of phases run by tests.
+	n.consensus.Start(ctx)
+	return nil
+}
+
+func (n *Node) stop(ctx context.Context) error {
+	// components wait for requests on stop, transports are stopped first to fail them without virtual time
+	n.consensus.Stop()
+	n.transport.Stop()
+	return n.cm.Stop(ctx)
+}
+
+// ID returns reference of the node.
+func (n *Node) ID() core.RecordRef {
+	return n.origin.ID()
+}
+
+// Address returns simulated address of the node.
+func (n *Node) Address() string {
+	return n.origin.PhysicalAddress()
+}
+
+// NodeKeeper returns node keeper of the node.
+func (n *Node) NodeKeeper() network.NodeKeeper {
+	return n.keeper
+}
+
+// Network returns service network of the node.
+func (n *Node) Network() core.Network {
+	return n.network
+}
+
+// MessageBus returns message bus of the node.
+func (n *Node) MessageBus() core.MessageBus {
+	return n.messageBus
+}
+
+// PulseStorage returns storage of the current pulse of the node.
+func (n *Node) PulseStorage() core.PulseStorage {
+	return n.network.PulseStorage
+}
+
+// ArtifactManager returns ledger client of the node.
+func (n *Node) ArtifactManager() core.ArtifactManager {
+	return n.logicRunner.ArtifactManager
+}
+
+// LogicRunner returns logic runner of the node.
+func (n *Node) LogicRunner() core.LogicRunner {
+	return n.logicRunner
+}
+
+// ContractRequester returns contract requester of the node.
+func (n *Node) ContractRequester() core.ContractRequester {
+	return n.contractRequester
+}
+
+// RemoteProcedureRegister registers procedure that other nodes call by SendMessage and SendCascadeMessage.
+func (n *Node) RemoteProcedureRegister(name string, method core.RemoteProcedure) {
+	n.network.RemoteProcedureRegister(name, method)
+}
+
+// SendMessage calls remote procedure on other node. Response timeout is measured by virtual clock.
+func (n *Node) SendMessage(nodeID core.RecordRef, method string, msg core.Parcel) ([]byte, error) {
+	return n.network.SendMessage(nodeID, method, msg)
+}
+
+// SendCascadeMessage calls remote procedure on nodes of cascade.
+func (n *Node) SendCascadeMessage(data core.Cascade, method string, msg core.Parcel) error {
+	return n.network.SendCascadeMessage(data, method, msg)
+}
+
+// Pulses returns pulses handled by the node since its start.
+func (n *Node) Pulses() []core.Pulse {
+	n.pulseLock.Lock()
+	defer n.pulseLock.Unlock()
+	result := make([]core.Pulse, len(n.pulses))
+	copy(result, n.pulses)
+	return result
+}
+
+// waitPulse waits until pulse is handled. Handling may outlast settle if other nodes wait for responses meanwhile.
+func (n *Node) waitPulse(pulseNumber core.PulseNumber, timeout time.Duration) bool {
+	expired := false
+	timer := time.AfterFunc(timeout, func() {
+		n.pulseLock.Lock()
+		defer n.pulseLock.Unlock()
+		expired = true
+		n.pulseCond.Broadcast()
+	})
+	defer timer.Stop()
+
+	n.pulseLock.Lock()
+	defer n.pulseLock.Unlock()
+	for {
+		for _, pulse := range n.pulses {
+			if pulse.PulseNumber == pulseNumber {
+				return true
+			}
+		}
+		if expired {
+			return false
+		}
+		n.pulseCond.Wait()
+	}
+}
@@ -0,0 +1,423 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+// Package simulator runs network of nodes in one process over simulated transport with virtual clock.
+//
+// Simulated nodes run components of insolard: service network with consensus, message bus, ledger on temporary
+// storage and logic runner with builtin executor. Only transports of service network are replaced by in-memory ones.
+// Transport copies every packet as it is sent over the wire, delays it and may lose it; delays and losses depend
+// only on seed. Time moves only when Advance or Pulse is called, so timeouts of requests are virtual too.
+// Pulses are sent by deterministic fake pulsar.
+//
+// Nodes don't bootstrap, every node considers all nodes active. Usage:
+//
+//	sim, err := simulator.New(ctx, simulator.Config{Nodes: 5, Latency: 10 * time.Millisecond, Loss: 0.1, Seed: 42})
+//	result, err := sim.Pulse(ctx)
+//	sim.Partition([]int{0, 1}, []int{2, 3, 4})
+//	err = sim.Run(func() error { return call(sim.Node(2)) }, time.Minute)
+//	sim.Heal()
+//	sim.Crash(ctx, 2)
+//	err = sim.Restart(ctx, 2)
+//	sim.Stop(ctx)
+package simulator
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultPulseDuration = 10 * time.Second
+	// basePort is a port of the first node, consensus transport of the node listens to port+1.
+	basePort      = 20000
+	pulsarAddress = "127.0.0.1:19999"
+
+	defaultSettleTimeout = 10 * time.Second
+)
+
+// Config describes simulated network.
+type Config struct {
+	// Nodes is a number of nodes in network.
+	Nodes int
+	// Roles maps indexes of nodes to their roles. Node 0 is heavy material, node 1 is light material
+	// and other nodes are virtual by default.
+	Roles map[int]core.StaticRole
+
+	// Latency is a delay of every packet, random delay up to Jitter is added to it.
+	Latency time.Duration
+	Jitter  time.Duration
+	// Loss is a probability of losing packet.
+	Loss float64
+	// Seed selects delays, lost packets, references of nodes and entropy of pulses.
+	Seed int64
+
+	// PulseDuration is virtual time between pulses, 10 seconds by default.
+	PulseDuration time.Duration
+	// SettleTimeout limits real time of waiting for handlers of delivered packets, 10 seconds by default.
+	// Step of virtual time fails if handlers don't finish or start waiting for responses in it.
+	SettleTimeout time.Duration
+}
+
+// PulseResult is an outcome of pulse distribution.
+type PulseResult struct {
+	Pulse core.Pulse
+	// Received is true for nodes that have handled the pulse.
+	Received []bool
+}
+
+// Simulator is a network of simulated nodes.
+type Simulator struct {
+	config  Config
+	clock   *Clock
+	hub     *hub
+	scheme  core.PlatformCryptographyScheme
+	origins []core.Node
+	keys    []crypto.PrivateKey
+	pulsar  *pulsar
+	// dir contains storages of nodes.
+	dir string
+
+	lock  sync.RWMutex
+	nodes []*Node
+}
+
+// New creates network of nodes and starts them.
+func New(ctx context.Context, config Config) (*Simulator, error) {
+	if config.Nodes <= 0 {
+		return nil, errors.New("[ New ] at least one node is required")
+	}
+	if config.Loss < 0 || config.Loss >= 1 {
+		return nil, errors.Errorf("[ New ] loss %v is not in [0, 1) range", config.Loss)
+	}
+	for index := range config.Roles {
+		if index < 0 || index >= config.Nodes {
+			return nil, errors.Errorf("[ New ] role for unknown node %v", index)
+		}
+	}
+	if config.PulseDuration <= 0 {
+		config.PulseDuration = defaultPulseDuration
+	}
+	if config.SettleTimeout <= 0 {
+		config.SettleTimeout = defaultSettleTimeout
+	}
+
+	clock := NewClock(time.Unix(core.GenesisPulse.PulseTimestamp, 0))
+	s := &Simulator{
+		config: config,
+		clock:  clock,
+		hub:    newHub(config, clock),
+		scheme: platformpolicy.NewPlatformCryptographyScheme(),
+		nodes:  make([]*Node, config.Nodes),
+	}
+
+	dir, err := ioutil.TempDir("", "simulator")
+	if err != nil {
+		return nil, errors.Wrap(err, "[ New ] failed to create storage directory")
+	}
+	s.dir = dir
+
+	pulsarHost, err := host.NewHost(pulsarAddress)
+	if err != nil {
+		s.Stop(ctx)
+		return nil, errors.Wrap(err, "[ New ] failed to create pulsar host")
+	}
+	s.pulsar = &pulsar{host: pulsarHost, last: *core.GenesisPulse}
+	s.hub.connect(pulsarAddress, s.pulsar)
+
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	random := rand.New(rand.NewSource(config.Seed))
+	for i := 0; i < config.Nodes; i++ {
+		privateKey, err := keyProcessor.GeneratePrivateKey()
+		if err != nil {
+			s.Stop(ctx)
+			return nil, errors.Wrap(err, "[ New ] failed to generate key")
+		}
+		var ref core.RecordRef
+		_, _ = random.Read(ref[:])
+
+		address := fmt.Sprintf("127.0.0.1:%d", basePort+2*i)
+		origin := nodenetwork.NewNode(ref, defaultRole(config, i), keyProcessor.ExtractPublicKey(privateKey), address, "")
+		s.origins = append(s.origins, origin)
+		s.keys = append(s.keys, privateKey)
+	}
+
+	for i := range s.nodes {
+		if err := s.Restart(ctx, i); err != nil {
+			s.Stop(ctx)
+			return nil, errors.Wrap(err, "[ New ] failed to start node")
+		}
+	}
+	return s, nil
+}
+
+func defaultRole(config Config, index int) core.StaticRole {
+	if role, ok := config.Roles[index]; ok {
+		return role
+	}
+	switch index {
+	case 0:
+		return core.StaticRoleHeavyMaterial
+	case 1:
+		return core.StaticRoleLightMaterial
+	}
+	return core.StaticRoleVirtual
+}
+
+// Clock returns virtual clock of the network.
+func (s *Simulator) Clock() *Clock {
+	return s.clock
+}
+
+// Node returns simulated node by index, nil if node is crashed. Restarted node is a new Node.
+func (s *Simulator) Node(index int) *Node {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.nodes[index]
+}
+
+// Origins returns all nodes of the network, including crashed ones.
+func (s *Simulator) Origins() []core.Node {
+	return s.origins
+}
+
+// Trace returns packets sent over the network sorted by delivery time.
+func (s *Simulator) Trace() []Event {
+	return s.hub.events()
+}
+
+// Requests returns number of requests sent by running nodes that wait for responses.
+func (s *Simulator) Requests() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	count := 0
+	for _, n := range s.nodes {
+		if n != nil {
+			count += n.transport.waiting()
+		}
+	}
+	return count
+}
+
+// Advance moves virtual time forward by d. Packets and timeouts are processed in order of their time,
+// before every next one simulator waits until handlers of previous ones finish or wait for responses.
+// It fails if handlers don't finish in real time limit, virtual time stays at the failed step then.
+func (s *Simulator) Advance(d time.Duration) error {
+	target := s.clock.Now().Add(d)
+	if err := s.settle(); err != nil {
+		return errors.Wrap(err, "[ Advance ] failed to process step")
+	}
+	for s.clock.step(target) {
+		if err := s.settle(); err != nil {
+			return errors.Wrap(err, "[ Advance ] failed to process step")
+		}
+	}
+	s.clock.set(target)
+	return nil
+}
+
+// Run calls f in separate goroutine and advances virtual time until f returns. It fails if f doesn't return
+// in limit of virtual time.
+func (s *Simulator) Run(f func() error, limit time.Duration) error {
+	done := make(chan error, 1)
+	s.hub.handlerStarted()
+	go func() {
+		defer s.hub.handlerFinished()
+		done <- f()
+	}()
+
+	step := s.config.Latency
+	if step <= 0 {
+		step = time.Millisecond
+	}
+	deadline := s.clock.Now().Add(limit)
+	for {
+		if err := s.Advance(step); err != nil {
+			return errors.Wrap(err, "[ Run ] failed to advance time")
+		}
+		select {
+		case err := <-done:
+			return err
+		default:
+		}
+		if !s.clock.Now().Before(deadline) {
+			return errors.Errorf("[ Run ] function hasn't returned in %v of virtual time", limit)
+		}
+	}
+}
+
+// Pulse sends next pulse from pulsar to every running node and advances virtual time by pulse duration.
+func (s *Simulator) Pulse(ctx context.Context) (*PulseResult, error) {
+	pulse := s.pulsar.next(s.config, s.clock.Now())
+
+	s.lock.RLock()
+	nodes := make([]*Node, len(s.nodes))
+	copy(nodes, s.nodes)
+	s.lock.RUnlock()
+
+	requests := make([]network.RequestID, len(nodes))
+	for i, n := range nodes {
+		if n == nil {
+			continue
+		}
+		requests[i] = s.pulsar.nextRequestID()
+		p := packet.NewBuilder(s.pulsar.host).Receiver(n.transport.origin).Type(types.Pulse).
+			RequestID(requests[i]).Request(&packet.RequestPulse{Pulse: pulse}).TraceID(inslogger.TraceID(ctx)).Build()
+		if err := s.hub.send(pulsarAddress, n.Address(), p); err != nil {
+			return nil, errors.Wrap(err, "[ Pulse ] failed to send pulse")
+		}
+	}
+
+	if err := s.Advance(s.config.PulseDuration); err != nil {
+		return nil, errors.Wrap(err, "[ Pulse ] failed to distribute pulse")
+	}
+
+	result := &PulseResult{Pulse: pulse, Received: make([]bool, len(nodes))}
+	for i, n := range nodes {
+		result.Received[i] = n != nil && s.hub.delivered(pulsarAddress, n.Address(), requests[i]) &&
+			n.waitPulse(pulse.PulseNumber, s.config.SettleTimeout)
+	}
+	return result, nil
+}
+
+// Partition splits nodes to groups by indexes, packets between groups are lost. Nodes not mentioned in groups
+// are connected only to each other. Pulsar is reachable from all groups.
+func (s *Simulator) Partition(groups ...[]int) {
+	var addresses [][]string
+	for _, group := range groups {
+		var addressGroup []string
+		for _, index := range group {
+			addressGroup = append(addressGroup, s.origins[index].PhysicalAddress())
+		}
+		addresses = append(addresses, addressGroup)
+	}
+	s.hub.partition(addresses, pulsarAddress)
+}
+
+// Heal removes partitions.
+func (s *Simulator) Heal() {
+	s.hub.heal()
+}
+
+// Crash stops node, packets sent to it are lost and its requests waiting for responses fail.
+func (s *Simulator) Crash(ctx context.Context, index int) {
+	s.lock.Lock()
+	n := s.nodes[index]
+	s.nodes[index] = nil
+	s.lock.Unlock()
+
+	if n == nil {
+		return
+	}
+	if err := n.stop(ctx); err != nil {
+		inslogger.FromContext(ctx).Warnf("[ Crash ] failed to stop node %v: %v", index, err)
+	}
+}
+
+// Restart starts crashed node with the same reference, address and ledger storage. State in memory is lost:
+// the node has not handled pulses and remote procedures have to be registered again.
+func (s *Simulator) Restart(ctx context.Context, index int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.nodes[index] != nil {
+		return errors.Errorf("[ Restart ] node %v is running", index)
+	}
+	n, err := newNode(s, index)
+	if err != nil {
+		return errors.Wrap(err, "[ Restart ] failed to create node")
+	}
+	if err := n.start(ctx); err != nil {
+		return errors.Wrap(err, "[ Restart ] failed to start node")
+	}
+	s.nodes[index] = n
+	return nil
+}
+
+// Stop stops all nodes and removes their storages.
+func (s *Simulator) Stop(ctx context.Context) {
+	for i := range s.nodes {
+		s.Crash(ctx, i)
+	}
+	if err := os.RemoveAll(s.dir); err != nil {
+		inslogger.FromContext(ctx).Warnf("[ Stop ] failed to remove storages: %v", err)
+	}
+}
+
+// settle waits until handlers of delivered packets finish or wait for responses.
+func (s *Simulator) settle() error {
+	if !s.hub.waitSettled(s.config.SettleTimeout) {
+		return errors.Errorf("handlers haven't finished in %v", s.config.SettleTimeout)
+	}
+	return nil
+}
+
+// pulsar is a fake pulsar, entropy of its pulses is derived from seed.
+type pulsar struct {
+	host *host.Host
+
+	lock      sync.Mutex
+	last      core.Pulse
+	requestID network.RequestID
+}
+
+func (p *pulsar) next(config Config, now time.Time) core.Pulse {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delta := core.PulseNumber(config.PulseDuration / time.Second)
+	if delta == 0 {
+		delta = 1
+	}
+	pulse := core.Pulse{
+		PulseNumber:      p.last.PulseNumber + delta,
+		PrevPulseNumber:  p.last.PulseNumber,
+		NextPulseNumber:  p.last.PulseNumber + 2*delta,
+		PulseTimestamp:   now.Unix(),
+		EpochPulseNumber: p.last.EpochPulseNumber,
+	}
+	_, _ = rand.New(rand.NewSource(config.Seed + int64(pulse.PulseNumber))).Read(pulse.Entropy[:])
+	p.last = pulse
+	return pulse
+}
+
+func (p *pulsar) nextRequestID() network.RequestID {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.requestID++
+	return p.requestID
+}
+
+// receive drops responses of nodes, pulsar doesn't wait for them.
+func (p *pulsar) receive(*packet.Packet) {
+}
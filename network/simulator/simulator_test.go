@@ -0,0 +1,270 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/core/message"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newSimulator(t *testing.T, config Config) (context.Context, *Simulator) {
+	ctx := inslogger.TestContext(t)
+	sim, err := New(ctx, config)
+	require.NoError(t, err)
+	return ctx, sim
+}
+
+func registerPong(sim *Simulator) {
+	for i := range sim.Origins() {
+		if n := sim.Node(i); n != nil {
+			n.RemoteProcedureRegister("ping", func(ctx context.Context, args [][]byte) ([]byte, error) {
+				return []byte("pong"), nil
+			})
+		}
+	}
+}
+
+// call sends message and advances virtual time until response or timeout.
+func call(t *testing.T, sim *Simulator, from, to int) error {
+	parcel := &message.Parcel{
+		Msg:           &message.CallMethod{Method: "ping"},
+		TraceSpanData: instracer.MustSerialize(context.Background()),
+	}
+	sender := sim.Node(from)
+	return sim.Run(func() error {
+		result, err := sender.SendMessage(sim.Origins()[to].ID(), "ping", parcel)
+		if err == nil && string(result) != "pong" {
+			err = errors.Errorf("unexpected result %s", result)
+		}
+		return err
+	}, time.Minute)
+}
+
+func TestSimulator_Pulse(t *testing.T) {
+	ctx, sim := newSimulator(t, Config{Nodes: 4, Latency: 10 * time.Millisecond, Jitter: 20 * time.Millisecond})
+	defer sim.Stop(ctx)
+
+	var previous core.Pulse
+	for i := 0; i < 3; i++ {
+		start := sim.Clock().Now()
+		result, err := sim.Pulse(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []bool{true, true, true, true}, result.Received)
+		require.Equal(t, start.Unix(), result.Pulse.PulseTimestamp)
+		require.Equal(t, start.Add(defaultPulseDuration), sim.Clock().Now())
+		if i > 0 {
+			require.Equal(t, previous.PulseNumber, result.Pulse.PrevPulseNumber)
+			require.Equal(t, previous.NextPulseNumber, result.Pulse.PulseNumber)
+		}
+		previous = result.Pulse
+	}
+
+	pulses := sim.Node(2).Pulses()
+	require.Len(t, pulses, 3)
+	require.Equal(t, previous, pulses[2])
+	for i := range sim.Origins() {
+		current, err := sim.Node(i).PulseStorage().Current(ctx)
+		require.NoError(t, err)
+		require.Equal(t, previous.PulseNumber, current.PulseNumber, "pulse is set by pulse manager of node %v", i)
+	}
+}
+
+func TestSimulator_SendMessage(t *testing.T) {
+	ctx, sim := newSimulator(t, Config{Nodes: 3, Latency: 50 * time.Millisecond})
+	defer sim.Stop(ctx)
+	registerPong(sim)
+
+	start := sim.Clock().Now()
+	require.NoError(t, call(t, sim, 0, 2))
+	require.True(t, sim.Clock().Now().Sub(start) < 10*time.Second)
+}
+
+func TestSimulator_Partition(t *testing.T) {
+	ctx, sim := newSimulator(t, Config{Nodes: 4, Latency: time.Millisecond})
+	defer sim.Stop(ctx)
+	registerPong(sim)
+
+	sim.Partition([]int{0, 1}, []int{2, 3})
+	require.NoError(t, call(t, sim, 0, 1))
+	require.Error(t, call(t, sim, 0, 2))
+
+	result, err := sim.Pulse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, true, true, true}, result.Received, "pulsar is reachable from all groups")
+
+	sim.Heal()
+	require.NoError(t, call(t, sim, 0, 2))
+}
+
+func TestSimulator_CrashRestart(t *testing.T) {
+	ctx, sim := newSimulator(t, Config{Nodes: 3})
+	defer sim.Stop(ctx)
+	registerPong(sim)
+
+	sim.Crash(ctx, 1)
+	require.Nil(t, sim.Node(1))
+	require.Error(t, call(t, sim, 0, 1))
+
+	result, err := sim.Pulse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, false, true}, result.Received)
+
+	require.NoError(t, sim.Restart(ctx, 1))
+	require.Error(t, sim.Restart(ctx, 1), "node is running")
+	require.Equal(t, sim.Origins()[1].ID(), sim.Node(1).ID())
+
+	result, err = sim.Pulse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, true, true}, result.Received)
+	require.Len(t, sim.Node(1).Pulses(), 1)
+
+	require.Error(t, call(t, sim, 0, 1), "procedures are lost on restart")
+	registerPong(sim)
+	require.NoError(t, call(t, sim, 0, 1))
+
+	current, err := sim.Node(1).PulseStorage().Current(ctx)
+	require.NoError(t, err)
+	require.Equal(t, result.Pulse.PulseNumber, current.PulseNumber)
+	sim.Crash(ctx, 1)
+	require.NoError(t, sim.Restart(ctx, 1))
+	current, err = sim.Node(1).PulseStorage().Current(ctx)
+	require.NoError(t, err)
+	require.Equal(t, result.Pulse.PulseNumber, current.PulseNumber, "ledger storage is kept on restart")
+}
+
+func TestSimulator_Ledger(t *testing.T) {
+	ctx, sim := newSimulator(t, Config{Nodes: 4, Latency: 10 * time.Millisecond})
+	defer sim.Stop(ctx)
+
+	for i := 0; i < 2; i++ {
+		result, err := sim.Pulse(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []bool{true, true, true, true}, result.Received)
+	}
+
+	var codeID *core.RecordID
+	deployer := sim.Node(2).ArtifactManager()
+	err := sim.Run(func() error {
+		var err error
+		codeID, err = deployer.DeployCode(ctx, *deployer.GenesisRef(), testutils.RandomRef(), []byte("code"), core.MachineTypeBuiltin)
+		return err
+	}, time.Minute)
+	require.NoError(t, err)
+
+	var code []byte
+	reader := sim.Node(3).ArtifactManager()
+	err = sim.Run(func() error {
+		desc, err := reader.GetCode(ctx, *core.NewRecordRef(*reader.GenesisRef().Record(), *codeID))
+		if err != nil {
+			return err
+		}
+		code, err = desc.Code()
+		return err
+	}, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []byte("code"), code, "code is stored by light material node")
+}
+
+func TestSimulator_SettleTimeout(t *testing.T) {
+	ctx, sim := newSimulator(t, Config{Nodes: 1, SettleTimeout: 100 * time.Millisecond})
+	defer sim.Stop(ctx)
+
+	release := make(chan struct{})
+	defer close(release)
+	err := sim.Run(func() error {
+		<-release
+		return nil
+	}, time.Minute)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "handlers haven't finished")
+}
+
+func TestSimulator_DuplicateHandler(t *testing.T) {
+	ctx, sim := newSimulator(t, Config{Nodes: 1})
+	defer sim.Stop(ctx)
+
+	n := sim.Node(0)
+	require.NoError(t, n.transport.registrationError())
+	require.NoError(t, n.consensus.registrationError())
+	require.NotPanics(t, func() {
+		n.transport.RegisterPacketHandler(types.RPC, nil)
+		n.consensus.RegisterRequestHandler(types.Phase1, nil)
+	})
+	require.Error(t, n.transport.registrationError())
+	require.Error(t, n.consensus.registrationError())
+}
+
+func TestSimulator_Deterministic(t *testing.T) {
+	run := func(seed int64) ([][]bool, []core.Pulse, []Event) {
+		ctx, sim := newSimulator(t, Config{
+			Nodes:   4,
+			Latency: 10 * time.Millisecond,
+			Jitter:  time.Second,
+			Loss:    0.3,
+			Seed:    seed,
+			Roles: map[int]core.StaticRole{
+				0: core.StaticRoleVirtual,
+				1: core.StaticRoleVirtual,
+			},
+		})
+		defer sim.Stop(ctx)
+
+		var received [][]bool
+		var pulses []core.Pulse
+		for i := 0; i < 5; i++ {
+			result, err := sim.Pulse(ctx)
+			require.NoError(t, err)
+			received = append(received, result.Received)
+			pulses = append(pulses, result.Pulse)
+		}
+		return received, pulses, sim.Trace()
+	}
+
+	received, pulses, trace := run(42)
+	otherReceived, otherPulses, otherTrace := run(42)
+	require.Equal(t, received, otherReceived)
+	require.Equal(t, pulses, otherPulses)
+	require.Equal(t, trace, otherTrace)
+
+	_, otherPulses, otherTrace = run(43)
+	require.NotEqual(t, pulses[0].Entropy, otherPulses[0].Entropy)
+	require.NotEqual(t, trace, otherTrace)
+}
+
+func TestNew_WrongConfig(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	_, err := New(ctx, Config{})
+	require.Error(t, err)
+
+	_, err = New(ctx, Config{Nodes: 2, Loss: 1})
+	require.Error(t, err)
+
+	_, err = New(ctx, Config{Nodes: 2, Roles: map[int]core.StaticRole{2: core.StaticRoleHeavyMaterial}})
+	require.Error(t, err)
+}
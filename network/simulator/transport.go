@@ -0,0 +1,355 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package simulator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/sequence"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	errTimeout          = errors.New("timeout")
+	errTransportStopped = errors.New("transport is stopped")
+)
+
+type packetWrapper packet.Packet
+
+func (p *packetWrapper) GetSender() core.RecordRef {
+	return p.Sender.NodeID
+}
+
+func (p *packetWrapper) GetSenderHost() *host.Host {
+	return p.Sender
+}
+
+func (p *packetWrapper) GetType() types.PacketType {
+	return p.Type
+}
+
+func (p *packetWrapper) GetData() interface{} {
+	return p.Data
+}
+
+func (p *packetWrapper) GetRequestID() network.RequestID {
+	return p.RequestID
+}
+
+// requestBuilder implements network.RequestBuilder.
+type requestBuilder struct {
+	sender *host.Host
+	t      types.PacketType
+	data   interface{}
+	id     network.RequestID
+}
+
+func (b *requestBuilder) Type(packetType types.PacketType) network.RequestBuilder {
+	b.t = packetType
+	return b
+}
+
+func (b *requestBuilder) Data(data interface{}) network.RequestBuilder {
+	b.data = data
+	return b
+}
+
+func (b *requestBuilder) Build() network.Request {
+	return &packetWrapper{Sender: b.sender, Type: b.t, Data: b.data, RequestID: b.id}
+}
+
+// future waits for response on virtual clock.
+type future struct {
+	transport *memoryTransport
+	request   network.Request
+
+	result    chan *packet.Packet
+	cancel    chan struct{}
+	closeOnce sync.Once
+
+	waitLock sync.Mutex
+	waiting  bool
+	woken    bool
+}
+
+func (f *future) resolve(p *packet.Packet) {
+	f.wake()
+	f.result <- p
+}
+
+func (f *future) close() {
+	f.closeOnce.Do(func() {
+		f.wake()
+		close(f.cancel)
+	})
+}
+
+// wait marks GetResponse as waiting for virtual time.
+func (f *future) wait() {
+	f.waitLock.Lock()
+	defer f.waitLock.Unlock()
+	if f.woken {
+		return
+	}
+	f.waiting = true
+	f.transport.hub.handlerBlocked()
+}
+
+// wake counts waiting GetResponse as running before it is scheduled, so simulator doesn't settle in between.
+func (f *future) wake() {
+	f.waitLock.Lock()
+	defer f.waitLock.Unlock()
+	f.woken = true
+	if f.waiting {
+		f.waiting = false
+		f.transport.hub.handlerUnblocked()
+	}
+}
+
+// Response get channel that receives response to sent request.
+func (f *future) Response() <-chan network.Response {
+	out := make(chan network.Response, 1)
+	go func() {
+		select {
+		case p := <-f.result:
+			out <- (*packetWrapper)(p)
+		case <-f.cancel:
+		}
+		close(out)
+	}()
+	return out
+}
+
+// GetResponse get response to sent request with `duration` timeout measured by virtual clock.
+func (f *future) GetResponse(duration time.Duration) (network.Response, error) {
+	timeout := make(chan struct{})
+	timer := f.transport.hub.clock.AfterFunc(duration, func() {
+		f.wake()
+		close(timeout)
+	})
+	defer timer.Stop()
+
+	f.wait()
+	defer f.wake()
+
+	select {
+	case p := <-f.result:
+		return (*packetWrapper)(p), nil
+	case <-timeout:
+		f.transport.removeFuture(f.request.GetRequestID())
+		return nil, errTimeout
+	case <-f.cancel:
+		return nil, errTransportStopped
+	}
+}
+
+// GetRequest get initiating request.
+func (f *future) GetRequest() network.Request {
+	return f.request
+}
+
+// memoryTransport implements network.InternalTransport over simulated network.
+type memoryTransport struct {
+	hub               *hub
+	origin            *host.Host
+	sequenceGenerator sequence.Generator
+	handlers          map[types.PacketType]network.RequestHandler
+
+	lock    sync.Mutex
+	started bool
+	futures map[network.RequestID]*future
+	err     error
+}
+
+func newMemoryTransport(hub *hub, origin *host.Host) *memoryTransport {
+	return &memoryTransport{
+		hub:               hub,
+		origin:            origin,
+		sequenceGenerator: sequence.NewGeneratorImpl(),
+		handlers:          map[types.PacketType]network.RequestHandler{},
+		futures:           map[network.RequestID]*future{},
+	}
+}
+
+// Start connects transport to simulated network.
+func (t *memoryTransport) Start(ctx context.Context) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.started {
+		inslogger.FromContext(ctx).Warn("double listen initiated")
+		return
+	}
+	t.started = true
+	t.hub.connect(t.PublicAddress(), t)
+}
+
+// Stop disconnects transport from simulated network and cancels requests waiting for responses.
+func (t *memoryTransport) Stop() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !t.started {
+		return
+	}
+	t.started = false
+	t.hub.disconnect(t.PublicAddress())
+	for id, f := range t.futures {
+		f.close()
+		delete(t.futures, id)
+	}
+}
+
+// PublicAddress returns public address that can be published for all nodes.
+func (t *memoryTransport) PublicAddress() string {
+	return t.origin.Address.String()
+}
+
+// GetNodeID get current node ID.
+func (t *memoryTransport) GetNodeID() core.RecordRef {
+	return t.origin.NodeID
+}
+
+// SendRequestPacket send request packet to a remote node.
+func (t *memoryTransport) SendRequestPacket(ctx context.Context, request network.Request, receiver *host.Host) (network.Future, error) {
+	inslogger.FromContext(ctx).Debugf("Send %s request to host %s", request.GetType().String(), receiver.String())
+	p := packet.NewBuilder(t.origin).Receiver(receiver).Type(request.GetType()).RequestID(request.GetRequestID()).
+		Request(request.GetData()).TraceID(inslogger.TraceID(ctx)).Build()
+
+	f := &future{
+		transport: t,
+		request:   request,
+		result:    make(chan *packet.Packet, 1),
+		cancel:    make(chan struct{}),
+	}
+	t.lock.Lock()
+	if !t.started {
+		t.lock.Unlock()
+		return nil, errTransportStopped
+	}
+	t.futures[request.GetRequestID()] = f
+	t.lock.Unlock()
+
+	err := t.hub.send(t.PublicAddress(), receiver.Address.String(), p)
+	if err != nil {
+		t.removeFuture(request.GetRequestID())
+		return nil, errors.Wrap(err, "[ SendRequestPacket ] failed to send request")
+	}
+	return f, nil
+}
+
+// RegisterPacketHandler register a handler function to process incoming requests of a specific type.
+// Duplicate registration is returned by registrationError.
+func (t *memoryTransport) RegisterPacketHandler(packetType types.PacketType, handler network.RequestHandler) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, exists := t.handlers[packetType]; exists {
+		t.err = errors.Errorf("multiple handlers for packet type %s are not supported", packetType.String())
+		return
+	}
+	t.handlers[packetType] = handler
+}
+
+func (t *memoryTransport) registrationError() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.err
+}
+
+// NewRequestBuilder create packet builder for an outgoing request with sender set to current node.
+func (t *memoryTransport) NewRequestBuilder() network.RequestBuilder {
+	return &requestBuilder{sender: t.origin, id: network.RequestID(t.sequenceGenerator.Generate())}
+}
+
+// BuildResponse create response to an incoming request with Data set to responseData.
+func (t *memoryTransport) BuildResponse(ctx context.Context, request network.Request, responseData interface{}) network.Response {
+	sender := request.(*packetWrapper).Sender
+	p := packet.NewBuilder(t.origin).Type(request.GetType()).Receiver(sender).RequestID(request.GetRequestID()).
+		Response(responseData).TraceID(inslogger.TraceID(ctx)).Build()
+	return (*packetWrapper)(p)
+}
+
+func (t *memoryTransport) receive(p *packet.Packet) {
+	if p.IsResponse {
+		f := t.removeFuture(p.RequestID)
+		if f != nil {
+			f.resolve(p)
+		}
+		return
+	}
+
+	t.lock.Lock()
+	handler, exists := t.handlers[p.Type]
+	t.lock.Unlock()
+	if !exists {
+		inslogger.FromContext(context.Background()).Errorf("No handler set for packet type %s from node %s",
+			p.Type.String(), p.Sender.NodeID.String())
+		return
+	}
+
+	t.hub.handlerStarted()
+	if p.Type == types.Pulse {
+		// pulse controller handles pulse in separate goroutine, pulseHandler of the node finishes it
+		t.hub.handlerStarted()
+	}
+	go func() {
+		defer t.hub.handlerFinished()
+		t.handle(handler, p)
+	}()
+}
+
+func (t *memoryTransport) handle(handler network.RequestHandler, p *packet.Packet) {
+	ctx, logger := inslogger.WithTraceField(context.Background(), p.TraceID)
+	response, err := handler(ctx, (*packetWrapper)(p))
+	if err != nil {
+		logger.Errorf("Error handling request %s from node %s: %s",
+			p.Type.String(), p.Sender.NodeID.String(), err)
+		return
+	}
+
+	t.lock.Lock()
+	started := t.started
+	t.lock.Unlock()
+	if !started {
+		return
+	}
+	err = t.hub.send(t.PublicAddress(), p.Sender.Address.String(), (*packet.Packet)(response.(*packetWrapper)))
+	if err != nil {
+		logger.Error(err)
+	}
+}
+
+func (t *memoryTransport) waiting() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.futures)
+}
+
+func (t *memoryTransport) removeFuture(id network.RequestID) *future {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	f := t.futures[id]
+	delete(t.futures, id)
+	return f
+}
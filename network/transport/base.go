@@ -59,7 +59,7 @@ type baseTransport struct {
 	mutex *sync.RWMutex
 
 	publicAddress string
-	sendFunc      func(recvAddress string, data []byte) error
+	sendFunc      func(recvAddress string, p *packet.Packet) error
 }
 
 func newBaseTransport(proxy relay.Proxy, publicAddress string) baseTransport {
@@ -142,11 +142,6 @@ func (t *baseTransport) SendPacket(ctx context.Context, p *packet.Packet) error
 		recvAddress = p.Receiver.Address.String()
 	}
 
-	data, err := t.serializer.SerializePacket(p)
-	if err != nil {
-		return errors.Wrap(err, "Failed to serialize packet")
-	}
-
 	inslogger.FromContext(ctx).Debugf("Send %s packet to %s with RequestID = %d", p.Type, recvAddress, p.RequestID)
-	return t.sendFunc(recvAddress, data)
+	return t.sendFunc(recvAddress, p)
 }
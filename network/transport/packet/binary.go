@@ -0,0 +1,372 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package packet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"reflect"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+)
+
+// BinaryPayload is packet data that is encoded natively by binary codec.
+// Data of other types is encoded with gob inside binary packet.
+type BinaryPayload interface {
+	MarshalBinaryPayload(w *BinaryWriter)
+	UnmarshalBinaryPayload(r *BinaryReader)
+}
+
+type payloadKey struct {
+	packetType types.PacketType
+	isResponse bool
+}
+
+type payloadFactory struct {
+	payloadType reflect.Type
+	create      func() BinaryPayload
+}
+
+var binaryPayloads = map[payloadKey]payloadFactory{}
+
+// RegisterBinaryPayload registers native binary encoding of request or response data of packet type.
+// It must be called from init functions like gob.Register.
+func RegisterBinaryPayload(packetType types.PacketType, isResponse bool, create func() BinaryPayload) {
+	binaryPayloads[payloadKey{packetType, isResponse}] = payloadFactory{
+		payloadType: reflect.TypeOf(create()),
+		create:      create,
+	}
+}
+
+const (
+	flagIsResponse = 1 << iota
+	flagSender
+	flagReceiver
+	flagError
+)
+
+const (
+	payloadNone = iota
+	payloadGob
+	payloadBinary
+)
+
+// gobPayload wraps packet data of types without native binary encoding.
+type gobPayload struct {
+	Data interface{}
+}
+
+type binaryCodec struct{}
+
+func (binaryCodec) Version() CodecVersion {
+	return CodecBinaryV1
+}
+
+func (c binaryCodec) Encode(p *Packet) ([]byte, error) {
+	w := &BinaryWriter{}
+	w.buf.Write(frameHeader(c.Version(), 0))
+
+	var flags byte
+	if p.IsResponse {
+		flags |= flagIsResponse
+	}
+	if p.Sender != nil {
+		flags |= flagSender
+	}
+	if p.Receiver != nil {
+		flags |= flagReceiver
+	}
+	if p.Error != nil {
+		flags |= flagError
+	}
+
+	w.WriteUvarint(uint64(p.Type))
+	w.WriteUvarint(uint64(p.RequestID))
+	w.WriteUint8(flags)
+	if p.Sender != nil {
+		writeHost(w, p.Sender)
+	}
+	if p.Receiver != nil {
+		writeHost(w, p.Receiver)
+	}
+	w.WriteString(p.RemoteAddress)
+	w.WriteString(p.TraceID)
+	if p.Error != nil {
+		w.WriteString(p.Error.Error())
+	}
+
+	err := writePayload(w, p)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to serialize packet data")
+	}
+
+	result := w.Bytes()
+	binary.BigEndian.PutUint32(result[4:frameHeaderSize], uint32(len(result)-frameHeaderSize))
+	return result, nil
+}
+
+func (binaryCodec) Decode(body []byte) (*Packet, error) {
+	r := NewBinaryReader(body)
+	p := &Packet{}
+
+	p.Type = types.PacketType(r.ReadUvarint())
+	p.RequestID = network.RequestID(r.ReadUvarint())
+	flags := r.ReadUint8()
+	p.IsResponse = flags&flagIsResponse != 0
+	if flags&flagSender != 0 {
+		p.Sender = readHost(r)
+	}
+	if flags&flagReceiver != 0 {
+		p.Receiver = readHost(r)
+	}
+	p.RemoteAddress = r.ReadString()
+	p.TraceID = r.ReadString()
+	if flags&flagError != 0 {
+		p.Error = errors.New(r.ReadString())
+	}
+	if r.Err() != nil {
+		return nil, errors.Wrap(r.Err(), "Failed to deserialize packet")
+	}
+
+	err := readPayload(r, p)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to deserialize packet data")
+	}
+	return p, nil
+}
+
+func writeHost(w *BinaryWriter, h *host.Host) {
+	w.WriteFixed(h.NodeID[:])
+	w.WriteUvarint(uint64(h.ShortID))
+	if h.Address == nil {
+		w.WriteUint8(0)
+		return
+	}
+	w.WriteUint8(1)
+	w.WriteBytes(h.Address.IP)
+	w.WriteUvarint(uint64(h.Address.Port))
+	w.WriteString(h.Address.Zone)
+}
+
+func readHost(r *BinaryReader) *host.Host {
+	h := &host.Host{}
+	copy(h.NodeID[:], r.read(core.RecordRefSize))
+	h.ShortID = core.ShortNodeID(r.ReadUvarint())
+	if r.ReadUint8() == 0 {
+		return h
+	}
+	h.Address = &host.Address{}
+	h.Address.IP = r.ReadBytes()
+	h.Address.Port = int(r.ReadUvarint())
+	h.Address.Zone = r.ReadString()
+	return h
+}
+
+func writePayload(w *BinaryWriter, p *Packet) error {
+	if p.Data == nil {
+		w.WriteUint8(payloadNone)
+		return nil
+	}
+
+	factory, ok := binaryPayloads[payloadKey{p.Type, p.IsResponse}]
+	if ok && factory.payloadType == reflect.TypeOf(p.Data) {
+		w.WriteUint8(payloadBinary)
+		p.Data.(BinaryPayload).MarshalBinaryPayload(w)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(&gobPayload{Data: p.Data})
+	if err != nil {
+		return err
+	}
+	w.WriteUint8(payloadGob)
+	w.WriteBytes(buf.Bytes())
+	return nil
+}
+
+func readPayload(r *BinaryReader, p *Packet) error {
+	switch kind := r.ReadUint8(); kind {
+	case payloadNone:
+	case payloadBinary:
+		factory, ok := binaryPayloads[payloadKey{p.Type, p.IsResponse}]
+		if !ok {
+			return errors.Errorf("no binary payload registered for packet type %s", p.Type)
+		}
+		data := factory.create()
+		data.UnmarshalBinaryPayload(r)
+		p.Data = data
+	case payloadGob:
+		buf := r.ReadBytes()
+		if r.Err() != nil {
+			break
+		}
+		payload := &gobPayload{}
+		err := gob.NewDecoder(bytes.NewReader(buf)).Decode(payload)
+		if err != nil {
+			return err
+		}
+		p.Data = payload.Data
+	default:
+		return errors.Errorf("unknown payload kind %d", kind)
+	}
+	return r.Err()
+}
+
+// BinaryWriter writes primitives of binary codec.
+type BinaryWriter struct {
+	buf     bytes.Buffer
+	scratch [binary.MaxVarintLen64]byte
+}
+
+// Bytes returns written data.
+func (w *BinaryWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// WriteUvarint writes unsigned integer.
+func (w *BinaryWriter) WriteUvarint(v uint64) {
+	n := binary.PutUvarint(w.scratch[:], v)
+	w.buf.Write(w.scratch[:n])
+}
+
+// WriteUint8 writes single byte.
+func (w *BinaryWriter) WriteUint8(v byte) {
+	w.buf.WriteByte(v)
+}
+
+// WriteBool writes boolean.
+func (w *BinaryWriter) WriteBool(v bool) {
+	if v {
+		w.buf.WriteByte(1)
+		return
+	}
+	w.buf.WriteByte(0)
+}
+
+// WriteBytes writes length-prefixed byte slice.
+func (w *BinaryWriter) WriteBytes(b []byte) {
+	w.WriteUvarint(uint64(len(b)))
+	w.buf.Write(b)
+}
+
+// WriteString writes length-prefixed string.
+func (w *BinaryWriter) WriteString(s string) {
+	w.WriteUvarint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
+
+// WriteFixed writes byte slice of size known to reader.
+func (w *BinaryWriter) WriteFixed(b []byte) {
+	w.buf.Write(b)
+}
+
+// BinaryReader reads primitives written by BinaryWriter. The first error is kept and returned by Err, reads after error
+// return zero values.
+type BinaryReader struct {
+	buf []byte
+	err error
+}
+
+// NewBinaryReader creates BinaryReader for data.
+func NewBinaryReader(data []byte) *BinaryReader {
+	return &BinaryReader{buf: data}
+}
+
+// Err returns the first read error.
+func (r *BinaryReader) Err() error {
+	return r.err
+}
+
+// ReadUvarint reads unsigned integer.
+func (r *BinaryReader) ReadUvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errors.New("malformed varint")
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+// ReadUint8 reads single byte.
+func (r *BinaryReader) ReadUint8() byte {
+	b := r.read(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+// ReadBool reads boolean.
+func (r *BinaryReader) ReadBool() bool {
+	return r.ReadUint8() != 0
+}
+
+// ReadBytes reads length-prefixed byte slice. Empty slice is read as nil.
+func (r *BinaryReader) ReadBytes() []byte {
+	size := r.ReadUvarint()
+	if size == 0 {
+		return nil
+	}
+	b := r.read(int(size))
+	if b == nil {
+		return nil
+	}
+	result := make([]byte, len(b))
+	copy(result, b)
+	return result
+}
+
+// ReadString reads length-prefixed string.
+func (r *BinaryReader) ReadString() string {
+	size := r.ReadUvarint()
+	return string(r.read(int(size)))
+}
+
+// ReadFixed reads byte slice of provided size.
+func (r *BinaryReader) ReadFixed(size int) []byte {
+	b := r.read(size)
+	if b == nil {
+		return nil
+	}
+	result := make([]byte, size)
+	copy(result, b)
+	return result
+}
+
+func (r *BinaryReader) read(size int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if size < 0 || size > len(r.buf) {
+		r.err = errors.New("unexpected end of data")
+		return nil
+	}
+	b := r.buf[:size]
+	r.buf = r.buf[size:]
+	return b
+}
@@ -0,0 +1,175 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package packet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// CodecVersion is a version of packet wire format.
+type CodecVersion byte
+
+const (
+	// CodecGob is legacy gob wire format. It is understood by every node.
+	CodecGob CodecVersion = iota
+	// CodecBinaryV1 is compact binary wire format.
+	CodecBinaryV1
+
+	// CodecLatest is the latest wire format supported by the node.
+	CodecLatest = CodecBinaryV1
+)
+
+// Frame header is 8 bytes long.
+//
+// Gob frame header is uvarint body length padded with zeroes. Header of other codecs is magic, codec version and
+// big-endian uint32 body length. Varint of the magic ends on the second byte and third byte is not zero, so it can't be
+// confused with gob frame header.
+const (
+	frameHeaderSize = 8
+	frameMagic0     = 0xFF
+	frameMagic1     = 'I'
+	frameMagic2     = 'N'
+)
+
+// Codec converts packets to wire format and back.
+type Codec interface {
+	// Version returns wire format version of the codec.
+	Version() CodecVersion
+	// Encode converts packet to frame (header and body).
+	Encode(p *Packet) ([]byte, error)
+	// Decode converts frame body to packet.
+	Decode(body []byte) (*Packet, error)
+}
+
+// GetCodec returns codec for wire format version.
+func GetCodec(version CodecVersion) (Codec, error) {
+	switch version {
+	case CodecGob:
+		return gobCodec{}, nil
+	case CodecBinaryV1:
+		return binaryCodec{}, nil
+	}
+	return nil, errors.Errorf("unknown codec version %d", version)
+}
+
+// NegotiateCodec returns the latest codec supported by both local node and remote node with provided latest version.
+func NegotiateCodec(remote CodecVersion) Codec {
+	version := remote
+	if version > CodecLatest {
+		version = CodecLatest
+	}
+	codec, err := GetCodec(version)
+	if err != nil {
+		return gobCodec{}
+	}
+	return codec
+}
+
+// WriteHello writes the latest codec version supported by the node. Connecting side sends it first and accepting side
+// answers with its own hello, see AcceptHello. Legacy nodes never answer, so connecting side detects them by silence.
+func WriteHello(w io.Writer) error {
+	_, err := w.Write(frameHeader(CodecLatest, 0))
+	return err
+}
+
+// AcceptHello answers codec hello of connecting side. Legacy nodes start connection with a packet instead of hello,
+// the packet is left in reader and false is returned.
+func AcceptHello(r *bufio.Reader, w io.Writer) (bool, error) {
+	header, err := r.Peek(frameHeaderSize)
+	if err != nil {
+		return false, err
+	}
+	_, length, ok := parseFrameHeader(header)
+	if !ok || length != 0 {
+		return false, nil
+	}
+	if _, err := r.Discard(frameHeaderSize); err != nil {
+		return false, err
+	}
+	return true, WriteHello(w)
+}
+
+// ReadHello reads codec version written by WriteHello.
+func ReadHello(r io.Reader) (CodecVersion, error) {
+	header := make([]byte, frameHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return CodecGob, err
+	}
+	version, length, ok := parseFrameHeader(header)
+	if !ok || length != 0 {
+		return CodecGob, errors.New("not a codec hello")
+	}
+	return version, nil
+}
+
+func frameHeader(version CodecVersion, length int) []byte {
+	header := make([]byte, frameHeaderSize)
+	header[0], header[1], header[2] = frameMagic0, frameMagic1, frameMagic2
+	header[3] = byte(version)
+	binary.BigEndian.PutUint32(header[4:], uint32(length))
+	return header
+}
+
+func parseFrameHeader(header []byte) (CodecVersion, uint32, bool) {
+	if header[0] != frameMagic0 || header[1] != frameMagic1 || header[2] != frameMagic2 {
+		return CodecGob, 0, false
+	}
+	return CodecVersion(header[3]), binary.BigEndian.Uint32(header[4:]), true
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Version() CodecVersion {
+	return CodecGob
+}
+
+func (gobCodec) Encode(p *Packet) ([]byte, error) {
+	var msgBuffer bytes.Buffer
+	enc := gob.NewEncoder(&msgBuffer)
+	err := enc.Encode(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to serialize packet")
+	}
+
+	length := msgBuffer.Len()
+
+	var lengthBytes [frameHeaderSize]byte
+	binary.PutUvarint(lengthBytes[:], uint64(length))
+
+	var result []byte
+	result = append(result, lengthBytes[:]...)
+	result = append(result, msgBuffer.Bytes()...)
+
+	return result, nil
+}
+
+func (gobCodec) Decode(body []byte) (*Packet, error) {
+	msg := &Packet{}
+	dec := gob.NewDecoder(bytes.NewReader(body))
+	err := dec.Decode(msg)
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,172 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package packet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBinaryPacket = types.PacketType(1338)
+
+type requestBinaryTest struct {
+	Method string
+	Data   []byte
+}
+
+func (r *requestBinaryTest) MarshalBinaryPayload(w *BinaryWriter) {
+	w.WriteString(r.Method)
+	w.WriteBytes(r.Data)
+}
+
+func (r *requestBinaryTest) UnmarshalBinaryPayload(reader *BinaryReader) {
+	r.Method = reader.ReadString()
+	r.Data = reader.ReadBytes()
+}
+
+func init() {
+	gob.Register(&requestBinaryTest{})
+	RegisterBinaryPayload(testBinaryPacket, false, func() BinaryPayload { return &requestBinaryTest{} })
+}
+
+func testCodecPacket(t testing.TB, packetType types.PacketType, data interface{}) *Packet {
+	sender, err := host.NewHostNS("127.0.0.1:31337", testutils.RandomRef(), 42)
+	require.NoError(t, err)
+	receiver, err := host.NewHostN("127.0.0.2:31338", testutils.RandomRef())
+	require.NoError(t, err)
+	return NewBuilder(sender).Receiver(receiver).Type(packetType).Request(data).RequestID(123).TraceID("trace").Build()
+}
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	packets := map[string]*Packet{
+		"native payload": testCodecPacket(t, testBinaryPacket, &requestBinaryTest{Method: "m", Data: []byte{1, 2, 3}}),
+		"gob payload":    testCodecPacket(t, TestPacket, &RequestTest{[]byte{0, 1, 2, 3}}),
+		"no payload":     testCodecPacket(t, TestPacket, nil),
+	}
+	for _, version := range []CodecVersion{CodecGob, CodecBinaryV1} {
+		codec, err := GetCodec(version)
+		require.NoError(t, err)
+		for name, msg := range packets {
+			serialized, err := codec.Encode(msg)
+			require.NoError(t, err, name)
+
+			deserialized, err := DeserializePacket(bytes.NewReader(serialized))
+			require.NoError(t, err, name)
+			assert.Equal(t, msg, deserialized, name)
+		}
+	}
+}
+
+func TestBinaryCodec_Error(t *testing.T) {
+	msg := testCodecPacket(t, TestPacket, nil)
+	msg.IsResponse = true
+	msg.Error = errors.New("test error")
+
+	serialized, err := binaryCodec{}.Encode(msg)
+	require.NoError(t, err)
+	deserialized, err := DeserializePacket(bytes.NewReader(serialized))
+	require.NoError(t, err)
+	require.Error(t, deserialized.Error)
+	assert.Equal(t, "test error", deserialized.Error.Error())
+	assert.True(t, deserialized.IsResponse)
+}
+
+func TestBinaryCodec_Truncated(t *testing.T) {
+	msg := testCodecPacket(t, testBinaryPacket, &requestBinaryTest{Method: "m", Data: []byte{1, 2, 3}})
+	serialized, err := binaryCodec{}.Encode(msg)
+	require.NoError(t, err)
+
+	_, err = binaryCodec{}.Decode(serialized[frameHeaderSize : len(serialized)-2])
+	require.Error(t, err)
+}
+
+func TestHello(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteHello(&buf))
+	version, err := ReadHello(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, CodecLatest, version)
+
+	serialized, err := SerializePacket(testCodecPacket(t, TestPacket, nil))
+	require.NoError(t, err)
+	_, err = ReadHello(bytes.NewReader(serialized))
+	require.Error(t, err)
+
+	assert.Equal(t, CodecLatest, NegotiateCodec(CodecLatest+1).Version())
+	assert.Equal(t, CodecGob, NegotiateCodec(CodecGob).Version())
+}
+
+func TestAcceptHello(t *testing.T) {
+	var answer bytes.Buffer
+	var hello bytes.Buffer
+	require.NoError(t, WriteHello(&hello))
+	serialized, err := SerializePacket(testCodecPacket(t, TestPacket, nil))
+	require.NoError(t, err)
+
+	r := bufio.NewReader(bytes.NewReader(append(hello.Bytes(), serialized...)))
+	ok, err := AcceptHello(r, &answer)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	version, err := ReadHello(&answer)
+	require.NoError(t, err)
+	assert.Equal(t, CodecLatest, version)
+	_, err = DeserializePacket(r)
+	require.NoError(t, err)
+
+	answer.Reset()
+	r = bufio.NewReader(bytes.NewReader(serialized))
+	ok, err = AcceptHello(r, &answer)
+	require.NoError(t, err)
+	assert.False(t, ok, "legacy node starts with a packet")
+	assert.Zero(t, answer.Len())
+	_, err = DeserializePacket(r)
+	require.NoError(t, err)
+}
+
+func benchmarkCodec(b *testing.B, codec Codec) {
+	msg := testCodecPacket(b, testBinaryPacket, &requestBinaryTest{Method: "MessageBus.Deliver", Data: make([]byte, 512)})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		serialized, err := codec.Encode(msg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, err = DeserializePacket(bytes.NewReader(serialized))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGobCodec(b *testing.B) {
+	benchmarkCodec(b, gobCodec{})
+}
+
+func BenchmarkBinaryCodec(b *testing.B) {
+	benchmarkCodec(b, binaryCodec{})
+}
@@ -77,5 +77,13 @@ And deserialized therefore:
 
 	// do something with packet
 
+
+SerializePacket uses legacy gob codec. Compact binary codec is used on connections where both sides support it:
+
+	codec := packet.NegotiateCodec(remoteVersion) // remoteVersion is answered by packet.AcceptHello and read with packet.ReadHello
+	serialized, err := codec.Encode(msg)
+
+DeserializePacket detects codec by frame header, so it reads packets of every codec.
+
 */
 package packet
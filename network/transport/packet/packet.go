@@ -44,41 +44,38 @@ type Packet struct {
 	IsResponse bool
 }
 
-// SerializePacket converts packet to byte slice.
+// SerializePacket converts packet to byte slice using legacy gob codec.
 func SerializePacket(q *Packet) ([]byte, error) {
-	var msgBuffer bytes.Buffer
-	enc := gob.NewEncoder(&msgBuffer)
-	err := enc.Encode(q)
-	if err != nil {
-		return nil, errors.Wrap(err, "Failed to serialize packet")
-	}
-
-	length := msgBuffer.Len()
-
-	var lengthBytes [8]byte
-	binary.PutUvarint(lengthBytes[:], uint64(length))
-
-	var result []byte
-	result = append(result, lengthBytes[:]...)
-	result = append(result, msgBuffer.Bytes()...)
-
-	return result, nil
+	return gobCodec{}.Encode(q)
 }
 
-// DeserializePacket reads packet from io.Reader.
+// DeserializePacket reads packet from io.Reader. Codec is detected by frame header, so packets of any codec are read.
 func DeserializePacket(conn io.Reader) (*Packet, error) {
-
-	lengthBytes := make([]byte, 8)
-	if _, err := io.ReadFull(conn, lengthBytes); err != nil {
+	header := make([]byte, frameHeaderSize)
+	if _, err := io.ReadFull(conn, header); err != nil {
 		return nil, err
 	}
-	lengthReader := bytes.NewBuffer(lengthBytes)
-	length, err := binary.ReadUvarint(lengthReader)
-	if err != nil {
-		return nil, io.ErrUnexpectedEOF
+
+	var codec Codec = gobCodec{}
+	version, length, ok := parseFrameHeader(header)
+	if ok {
+		var err error
+		codec, err = GetCodec(version)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ DeserializePacket ] bad frame header")
+		}
+		if length == 0 {
+			return nil, errors.New("[ DeserializePacket ] unexpected codec hello")
+		}
+	} else {
+		gobLength, err := binary.ReadUvarint(bytes.NewBuffer(header))
+		if err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		length = uint32(gobLength)
 	}
 
-	log.Debugf("[ DeserializePacket ] packet length %d", length)
+	log.Debugf("[ DeserializePacket ] packet length %d, codec %d", length, codec.Version())
 	buf := make([]byte, length)
 	if _, err := io.ReadFull(conn, buf); err != nil {
 		log.Error("[ DeserializePacket ] couldn't read packet: ", err)
@@ -86,10 +83,7 @@ func DeserializePacket(conn io.Reader) (*Packet, error) {
 	}
 	log.Debugf("[ DeserializePacket ] read packet")
 
-	msg := &Packet{}
-	dec := gob.NewDecoder(bytes.NewReader(buf))
-
-	err = dec.Decode(msg)
+	msg, err := codec.Decode(buf)
 	if err != nil {
 		log.Error("[ DeserializePacket ] couldn't decode packet: ", err)
 		return nil, err
@@ -112,28 +112,31 @@ func (cp *connectionPool) getConnection(address net.Addr) (net.Conn, bool) {
 func (cp *connectionPool) getOrCreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
 	logger := inslogger.FromContext(ctx)
 
-	cp.mutex.Lock()
-	defer cp.mutex.Unlock()
-
-	conn, ok := cp.unsafeConnectionsHolder.Get(address)
-	logger.Debugf("[ getOrCreateConnection ] Finding connection to %s in pool: %s", address, ok)
-
-	if ok {
-		return conn, nil
-	}
-
 	logger.Debugf("[ getOrCreateConnection ] Failed to retrieve connection to %s, creating it", address)
 
+	// Connection is created without pool lock, because it includes handshakes and may take a while.
 	ctx, span := instracer.StartSpan(ctx, "connectionPool.getOrCreateConnection")
 	span.AddAttributes(
 		trace.StringAttribute("create connect to", address.String()),
 	)
 	conn, err := cp.connectionFactory.CreateConnection(ctx, address)
-	defer span.End()
+	span.End()
 	if err != nil {
 		return nil, errors.Wrap(err, "[ send ] Failed to create TCP connection")
 	}
 
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	existing, ok := cp.unsafeConnectionsHolder.Get(address)
+	logger.Debugf("[ getOrCreateConnection ] Finding connection to %s in pool: %s", address, ok)
+
+	if ok {
+		// Connection was created concurrently, ours is not needed.
+		utils.CloseVerbose(conn)
+		return existing, nil
+	}
+
 	go func() {
 		b := make([]byte, 1)
 		_, err := conn.Read(b)
@@ -152,6 +155,9 @@ func (cp *connectionPool) getOrCreateConnection(ctx context.Context, address net
 	}
 
 	cp.unsafeConnectionsHolder.Add(address, lc)
+	if keeper, ok := cp.connectionFactory.(connectionKeeper); ok {
+		keeper.KeepConnection(address, conn)
+	}
 	size := cp.unsafeConnectionsHolder.Size()
 	logger.Debugf(
 		"[ getOrCreateConnection ] Added connection to %s. Current pool size: %d",
@@ -32,6 +32,12 @@ type connectionFactory interface {
 	CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error)
 }
 
+// connectionKeeper is implemented by factories that keep state of created connections. KeepConnection is called for
+// connection added to pool, connections closed as concurrently created duplicates are not passed to it.
+type connectionKeeper interface {
+	KeepConnection(address net.Addr, conn net.Conn)
+}
+
 type iterateFunc func(conn net.Conn)
 
 type unsafeConnectionHolder interface {
@@ -28,6 +28,7 @@ import (
 	"net"
 
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network/transport/packet"
 	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/insolar/insolar/network/utils"
 	quic "github.com/lucas-clemente/quic-go"
@@ -63,10 +64,14 @@ func newQuicTransport(conn net.PacketConn, proxy relay.Proxy, publicAddress stri
 	return transport, nil
 }
 
-func (t *quicTransport) send(recvAddress string, data []byte) error {
+func (t *quicTransport) send(recvAddress string, p *packet.Packet) error {
+	data, err := t.serializer.SerializePacket(p)
+	if err != nil {
+		return errors.Wrap(err, "Failed to serialize packet")
+	}
+
 	conn, ok := t.connections[recvAddress]
 	var stream quic.Stream
-	var err error
 	if !ok {
 		var session quic.Session
 		session, stream, err = createConnection(recvAddress)
@@ -18,31 +18,45 @@
 package transport
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
 	"io"
 	"net"
+	"sync"
+	"time"
 
+	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network/transport/packet"
 	"github.com/insolar/insolar/network/transport/pool"
 	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/insolar/insolar/network/utils"
 	"github.com/pkg/errors"
 )
 
+// codecHandshakeTimeout is how long connecting side waits for codec hello answer before falling back to legacy codec.
+const codecHandshakeTimeout = 500 * time.Millisecond
+
 type tcpTransport struct {
 	baseTransport
 
-	pool     pool.ConnectionPool
-	listener net.Listener
-	addr     string
+	pool      pool.ConnectionPool
+	peers     *tcpConnectionFactory
+	listener  net.Listener
+	addr      string
+	tlsConfig *tls.Config
 }
 
-func newTCPTransport(addr string, proxy relay.Proxy, publicAddress string) (*tcpTransport, error) {
+func newTCPTransport(addr string, proxy relay.Proxy, publicAddress string, tlsConfig *tls.Config) (*tcpTransport, error) {
+	factory := &tcpConnectionFactory{tlsConfig: tlsConfig}
 	transport := &tcpTransport{
 		baseTransport: newBaseTransport(proxy, publicAddress),
 		addr:          addr,
-		pool:          pool.NewConnectionPool(&tcpConnectionFactory{}),
+		pool:          pool.NewConnectionPool(factory),
+		peers:         factory,
+		tlsConfig:     tlsConfig,
 	}
 
 	transport.sendFunc = transport.send
@@ -50,7 +64,7 @@ func newTCPTransport(addr string, proxy relay.Proxy, publicAddress string) (*tcp
 	return transport, nil
 }
 
-func (t *tcpTransport) send(address string, data []byte) error {
+func (t *tcpTransport) send(address string, p *packet.Packet) error {
 	ctx := context.Background()
 	logger := inslogger.FromContext(ctx)
 
@@ -64,6 +78,17 @@ func (t *tcpTransport) send(address string, data []byte) error {
 		return errors.Wrap(err, "[ send ] Failed to get connection")
 	}
 
+	err = t.peers.checkReceiver(addr, address, p)
+	if err != nil {
+		return errors.Wrap(err, "[ send ] Wrong peer")
+	}
+
+	// Codec is negotiated when connection is created.
+	data, err := t.peers.codec(addr).Encode(p)
+	if err != nil {
+		return errors.Wrap(err, "[ send ] Failed to serialize packet")
+	}
+
 	logger.Debug("[ send ] len = ", len(data))
 
 	_, err = conn.Write(data)
@@ -79,6 +104,10 @@ func (t *tcpTransport) send(address string, data []byte) error {
 		if err != nil {
 			return errors.Wrap(err, "[ send ] Failed to get connection")
 		}
+		data, err = t.peers.codec(addr).Encode(p)
+		if err != nil {
+			return errors.Wrap(err, "[ send ] Failed to serialize packet")
+		}
 		_, err = conn.Write(data)
 		// 		}
 		// 	}
@@ -98,6 +127,9 @@ func (t *tcpTransport) prepareListen() error {
 	if err != nil {
 		return err
 	}
+	if t.tlsConfig != nil {
+		listener = tls.NewListener(listener, t.tlsConfig)
+	}
 
 	t.listener = listener
 
@@ -144,8 +176,28 @@ func (t *tcpTransport) Stop() {
 func (t *tcpTransport) handleAcceptedConnection(conn net.Conn) {
 	defer utils.CloseVerbose(conn)
 
+	var peer *core.RecordRef
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		var err error
+		peer, err = tlsHandshake(tlsConn)
+		if err != nil {
+			log.Warnf("[ handleAcceptedConnection ] Rejected connection from %s: %s", conn.RemoteAddr(), err.Error())
+			return
+		}
+	}
+
+	reader := bufio.NewReader(conn)
+	hello, err := packet.AcceptHello(reader, conn)
+	if err != nil {
+		log.Warnf("[ handleAcceptedConnection ] Failed to answer codec hello from %s: %s", conn.RemoteAddr(), err.Error())
+		return
+	}
+	if !hello {
+		log.Debugf("[ handleAcceptedConnection ] No codec hello from legacy node %s", conn.RemoteAddr())
+	}
+
 	for {
-		msg, err := t.serializer.DeserializePacket(conn)
+		msg, err := t.serializer.DeserializePacket(reader)
 
 		if err != nil {
 			if err == io.EOF || err == io.ErrUnexpectedEOF {
@@ -154,6 +206,8 @@ func (t *tcpTransport) handleAcceptedConnection(conn net.Conn) {
 			}
 
 			log.Error("[ handleAcceptedConnection ] Failed to deserialize packet: ", err.Error())
+		} else if peer != nil && (msg.Sender == nil || !msg.Sender.NodeID.Equal(*peer)) {
+			log.Warnf("[ handleAcceptedConnection ] Dropped packet of other sender from node %s", peer)
 		} else {
 			ctx, logger := inslogger.WithTraceField(context.Background(), msg.TraceID)
 			logger.Debug("[ handleAcceptedConnection ] Handling packet: ", msg.RequestID)
@@ -163,19 +217,100 @@ func (t *tcpTransport) handleAcceptedConnection(conn net.Conn) {
 	}
 }
 
-type tcpConnectionFactory struct{}
+// tcpPeer is a remote side of connection.
+type tcpPeer struct {
+	codec packet.Codec
+	// ref is a node reference from TLS certificate, nil for plain connections.
+	ref *core.RecordRef
+}
+
+// tcpConnection is a created connection with its peer, the peer is kept when pool keeps the connection.
+type tcpConnection struct {
+	net.Conn
+	peer *tcpPeer
+}
+
+// tcpConnectionFactory creates connections and keeps peers of them.
+type tcpConnectionFactory struct {
+	tlsConfig *tls.Config
+	peers     sync.Map
+}
+
+func (f *tcpConnectionFactory) peer(address net.Addr) *tcpPeer {
+	peer, ok := f.peers.Load(address.String())
+	if !ok {
+		return &tcpPeer{codec: packet.NegotiateCodec(packet.CodecGob)}
+	}
+	return peer.(*tcpPeer)
+}
+
+func (f *tcpConnectionFactory) codec(address net.Addr) packet.Codec {
+	return f.peer(address).codec
+}
+
+// checkReceiver checks that TLS peer is the packet receiver. Packets sent through relay are not checked.
+func (f *tcpConnectionFactory) checkReceiver(address net.Addr, recvAddress string, p *packet.Packet) error {
+	ref := f.peer(address).ref
+	if ref == nil || p.Receiver == nil || p.Receiver.NodeID.IsEmpty() {
+		return nil
+	}
+	if p.Receiver.Address == nil || p.Receiver.Address.String() != recvAddress {
+		return nil
+	}
+	if !p.Receiver.NodeID.Equal(*ref) {
+		return errors.Errorf("connection to %s is established with node %s, not %s", recvAddress, ref, p.Receiver.NodeID)
+	}
+	return nil
+}
 
-func (*tcpConnectionFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
+func (f *tcpConnectionFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
 	logger := inslogger.FromContext(ctx)
 	tcpAddress, ok := address.(*net.TCPAddr)
 	if !ok {
 		return nil, errors.New("[ createConnection ] Failed to get tcp address")
 	}
 
-	conn, err := net.DialTCP("tcp", nil, tcpAddress)
+	conn, peer, err := f.dial(ctx, tcpAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	peer.codec, err = negotiateCodec(ctx, conn)
+	if err != nil {
+		utils.CloseVerbose(conn)
+		// Nodes with TLS support always answer hello, so TLS connection without answer is treated as rejected by peer.
+		if f.tlsConfig != nil {
+			logger.Errorf("[ createConnection ] Failed to negotiate codec with %s: %s", address, err.Error())
+			return nil, errors.Wrap(err, "[ createConnection ] Failed to negotiate codec")
+		}
+
+		// Legacy node has read hello as a beginning of packet, so connection is reopened without hello.
+		logger.Infof("[ createConnection ] No codec hello from %s, using legacy codec: %s", address, err.Error())
+		conn, peer, err = f.dial(ctx, tcpAddress)
+		if err != nil {
+			return nil, err
+		}
+		peer.codec = packet.NegotiateCodec(packet.CodecGob)
+	}
+
+	return &tcpConnection{Conn: conn, peer: peer}, nil
+}
+
+// KeepConnection stores peer of the connection kept in pool. Peers of concurrently created duplicates are dropped
+// with them, so peer of an address always belongs to its open connection.
+func (f *tcpConnectionFactory) KeepConnection(address net.Addr, conn net.Conn) {
+	if c, ok := conn.(*tcpConnection); ok {
+		f.peers.Store(address.String(), c.peer)
+	}
+}
+
+func (f *tcpConnectionFactory) dial(ctx context.Context, address *net.TCPAddr) (net.Conn, *tcpPeer, error) {
+	logger := inslogger.FromContext(ctx)
+
+	conn, err := net.DialTCP("tcp", nil, address)
 	if err != nil {
 		logger.Errorf("[ createConnection ] Failed to open connection to %s: %s", address, err.Error())
-		return nil, errors.Wrap(err, "[ createConnection ] Failed to open connection")
+		return nil, nil, errors.Wrap(err, "[ createConnection ] Failed to open connection")
 	}
 
 	err = conn.SetKeepAlive(true)
@@ -188,5 +323,46 @@ func (*tcpConnectionFactory) CreateConnection(ctx context.Context, address net.A
 		logger.Errorln("[ createConnection ] Failed to set connection no delay: ", err.Error())
 	}
 
-	return conn, nil
+	peer := &tcpPeer{}
+	if f.tlsConfig == nil {
+		return conn, peer, nil
+	}
+
+	tlsConn := tls.Client(conn, f.tlsConfig)
+	peer.ref, err = tlsHandshake(tlsConn)
+	if err != nil {
+		utils.CloseVerbose(conn)
+		logger.Errorf("[ createConnection ] Failed to establish TLS connection to %s: %s", address, err.Error())
+		return nil, nil, errors.Wrap(err, "[ createConnection ] Failed to establish TLS connection")
+	}
+	return tlsConn, peer, nil
+}
+
+// negotiateCodec sends codec hello and reads the answer of the accepting side.
+func negotiateCodec(ctx context.Context, conn net.Conn) (packet.Codec, error) {
+	logger := inslogger.FromContext(ctx)
+
+	err := packet.WriteHello(conn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send codec hello")
+	}
+
+	err = conn.SetReadDeadline(time.Now().Add(codecHandshakeTimeout))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set read deadline")
+	}
+	defer func() {
+		if err := conn.SetReadDeadline(time.Time{}); err != nil {
+			logger.Error("[ negotiateCodec ] Failed to reset read deadline: ", err.Error())
+		}
+	}()
+
+	version, err := packet.ReadHello(conn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read codec hello")
+	}
+
+	codec := packet.NegotiateCodec(version)
+	logger.Debugf("[ negotiateCodec ] Using codec %d for connection to %s", codec.Version(), conn.RemoteAddr())
+	return codec, nil
 }
@@ -0,0 +1,181 @@
+/*
+ * The Clear BSD License
+ *
+ * Copyright (c) 2019 Insolar Technologies
+ *
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without modification, are permitted (subject to the limitations in the disclaimer below) provided that the following conditions are met:
+ *
+ *  Redistributions of source code must retain the above copyright notice, this list of conditions and the following disclaimer.
+ *  Redistributions in binary form must reproduce the above copyright notice, this list of conditions and the following disclaimer in the documentation and/or other materials provided with the distribution.
+ *  Neither the name of Insolar Technologies nor the names of its contributors may be used to endorse or promote products derived from this software without specific prior written permission.
+ *
+ * NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package transport
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
+)
+
+const (
+	tlsHandshakeTimeout     = 10 * time.Second
+	tlsCertificateValidity  = 10 * 365 * 24 * time.Hour
+	tlsCertificateClockSkew = time.Hour
+)
+
+// PeerKeyFunc returns public key of the peer node. Authorization is data embedded into certificate of the peer by
+// NewTLSConfig, it lets nodes that are not known yet (e.g. joining ones) prove their key. Error is returned if the node
+// is unknown and its authorization is not valid.
+type PeerKeyFunc func(ref core.RecordRef, authorization []byte) (crypto.PublicKey, error)
+
+// tlsAuthorizationOID is an identifier of certificate extension carrying node authorization.
+var tlsAuthorizationOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1, 1}
+
+// NewTLSConfig creates mutual TLS 1.3 configuration from node key pair.
+//
+// Node certificate is self-signed by node key, carries node reference as subject common name and authorization as
+// extension. Peer certificate is accepted only if its key matches key returned by peerKey. Transport binds connection
+// to the reference from certificate, so no peer can send packets on behalf of another node.
+func NewTLSConfig(
+	ref core.RecordRef, privateKey crypto.PrivateKey, authorization []byte, peerKey PeerKeyFunc,
+) (*tls.Config, error) {
+	cert, err := newNodeCertificate(ref, privateKey, authorization)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewTLSConfig ] failed to create node certificate")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{*cert},
+		ClientAuth:   tls.RequireAnyClientCert,
+		// Certificates are self-signed, peers are verified by VerifyPeerCertificate.
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: verifyPeerCertificate(peerKey),
+		MinVersion:            tls.VersionTLS13,
+	}, nil
+}
+
+func newNodeCertificate(ref core.RecordRef, privateKey crypto.PrivateKey, authorization []byte) (*tls.Certificate, error) {
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("private key can't sign certificates")
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate serial number")
+	}
+
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: ref.String()},
+		NotBefore:    now.Add(-tlsCertificateClockSkew),
+		NotAfter:     now.Add(tlsCertificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if authorization != nil {
+		template.ExtraExtensions = []pkix.Extension{{Id: tlsAuthorizationOID, Value: authorization}}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, signer.Public(), signer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create certificate")
+	}
+
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: privateKey}, nil
+}
+
+func verifyPeerCertificate(peerKey PeerKeyFunc) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("peer didn't provide certificate")
+		}
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return errors.Wrap(err, "failed to parse peer certificate")
+		}
+		ref, err := certificateRef(cert)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			return errors.Errorf("certificate of node %s is expired or not valid yet", ref)
+		}
+		if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+			return errors.Wrapf(err, "certificate of node %s is not self-signed", ref)
+		}
+
+		expected, err := peerKey(*ref, certificateAuthorization(cert))
+		if err != nil {
+			return errors.Wrapf(err, "node %s is not trusted", ref)
+		}
+		expectedDER, err := x509.MarshalPKIXPublicKey(expected)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal public key of node %s", ref)
+		}
+		actualDER, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal certificate key of node %s", ref)
+		}
+		if !bytes.Equal(expectedDER, actualDER) {
+			return errors.Errorf("certificate key doesn't match public key of node %s", ref)
+		}
+		return nil
+	}
+}
+
+func certificateAuthorization(cert *x509.Certificate) []byte {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(tlsAuthorizationOID) {
+			return ext.Value
+		}
+	}
+	return nil
+}
+
+func certificateRef(cert *x509.Certificate) (*core.RecordRef, error) {
+	ref, err := core.NewRefFromBase58(cert.Subject.CommonName)
+	if err != nil {
+		return nil, errors.Wrap(err, "peer certificate doesn't contain node reference")
+	}
+	return ref, nil
+}
+
+// tlsHandshake performs TLS handshake and returns reference of the peer node.
+func tlsHandshake(conn *tls.Conn) (*core.RecordRef, error) {
+	err := conn.SetDeadline(time.Now().Add(tlsHandshakeTimeout))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set handshake deadline")
+	}
+	err = conn.Handshake()
+	if err != nil {
+		return nil, errors.Wrap(err, "TLS handshake failed")
+	}
+	err = conn.SetDeadline(time.Time{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reset handshake deadline")
+	}
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, errors.New("peer didn't provide certificate")
+	}
+	return certificateRef(certs[0])
+}
@@ -19,6 +19,7 @@ package transport
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 
 	"github.com/insolar/insolar/configuration"
@@ -64,6 +65,22 @@ type Transport interface {
 
 // NewTransport creates new Transport with particular configuration
 func NewTransport(cfg configuration.Transport, proxy relay.Proxy) (Transport, error) {
+	return NewSecureTransport(cfg, proxy, nil)
+}
+
+// NewSecureTransport creates new Transport with particular configuration and TLS config (see NewTLSConfig).
+// TLS config is required if TLS is enabled in configuration.
+func NewSecureTransport(cfg configuration.Transport, proxy relay.Proxy, tlsConfig *tls.Config) (Transport, error) {
+	if cfg.TLS && tlsConfig == nil {
+		return nil, errors.New("[ NewTransport ] TLS is enabled, but node keys are not provided")
+	}
+	if cfg.TLS && cfg.Protocol != "TCP" {
+		return nil, errors.New("[ NewTransport ] TLS is supported by TCP transport only")
+	}
+	if !cfg.TLS {
+		tlsConfig = nil
+	}
+
 	// TODO: let each transport creates connection in their constructor
 	conn, publicAddress, err := NewConnection(cfg)
 	if err != nil {
@@ -75,7 +92,7 @@ func NewTransport(cfg configuration.Transport, proxy relay.Proxy) (Transport, er
 		// TODO: little hack: It's better to change interface for NewConnection
 		utils.CloseVerbose(conn)
 
-		return newTCPTransport(conn.LocalAddr().String(), proxy, publicAddress)
+		return newTCPTransport(conn.LocalAddr().String(), proxy, publicAddress, tlsConfig)
 	case "PURE_UDP":
 		return newUDPTransport(conn, proxy, publicAddress)
 	case "QUIC":
@@ -18,17 +18,29 @@
 package transport
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto"
 	"crypto/rand"
+	"crypto/tls"
 	"encoding/gob"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/insolar/insolar/configuration"
 	consensus "github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/network/transport/host"
 	"github.com/insolar/insolar/network/transport/packet"
 	"github.com/insolar/insolar/network/transport/packet/types"
 	"github.com/insolar/insolar/network/transport/relay"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -36,6 +48,8 @@ type node struct {
 	config    configuration.Transport
 	transport Transport
 	host      *host.Host
+	ref       core.RecordRef
+	key       crypto.Signer
 }
 
 type transportSuite struct {
@@ -64,17 +78,38 @@ func NewConsensusSuite(cfg1 configuration.Transport, cfg2 configuration.Transpor
 
 func setupNode(t *transportSuite, n *node) {
 	var err error
-	n.host, err = host.NewHost(n.config.Address)
+	n.host, err = host.NewHostN(n.config.Address, n.ref)
 	t.Assert().NoError(err)
 
-	n.transport, err = NewTransport(n.config, relay.NewProxy())
+	var tlsConfig *tls.Config
+	if n.config.TLS {
+		tlsConfig, err = NewTLSConfig(n.ref, n.key, nil, t.peerKey)
+		t.Require().NoError(err)
+	}
+
+	n.transport, err = NewSecureTransport(n.config, relay.NewProxy(), tlsConfig)
 	t.Require().NoError(err)
 	t.Require().NotNil(n.transport)
 	t.Require().Implements((*Transport)(nil), n.transport)
 }
 
+func (t *transportSuite) peerKey(ref core.RecordRef, _ []byte) (crypto.PublicKey, error) {
+	for _, n := range []*node{&t.node1, &t.node2} {
+		if n.ref.Equal(ref) {
+			return n.key.Public(), nil
+		}
+	}
+	return nil, errors.New("unknown node")
+}
+
 func (t *transportSuite) SetupTest() {
 	gob.Register(&packet.RequestTest{})
+	for _, n := range []*node{&t.node1, &t.node2} {
+		n.ref = testutils.RandomRef()
+		key, err := platformpolicy.NewKeyProcessor().GeneratePrivateKey()
+		t.Require().NoError(err)
+		n.key = key.(crypto.Signer)
+	}
 	setupNode(t, &t.node1)
 	setupNode(t, &t.node2)
 }
@@ -179,6 +214,108 @@ func TestTCPTransport(t *testing.T) {
 	suite.Run(t, NewSuite(cfg1, cfg2))
 }
 
+func TestTCPTransport_TLS(t *testing.T) {
+	cfg1 := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:17020", BehindNAT: false, TLS: true}
+	cfg2 := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:17021", BehindNAT: false, TLS: true}
+
+	suite.Run(t, NewSuite(cfg1, cfg2))
+}
+
+type tlsTestNode struct {
+	ref  core.RecordRef
+	key  crypto.Signer
+	addr string
+}
+
+func newTLSTestNode(t *testing.T, address string) *tlsTestNode {
+	key, err := platformpolicy.NewKeyProcessor().GeneratePrivateKey()
+	require.NoError(t, err)
+	return &tlsTestNode{ref: testutils.RandomRef(), key: key.(crypto.Signer), addr: address}
+}
+
+func (n *tlsTestNode) transport(t *testing.T, authorization []byte, peerKey PeerKeyFunc) (Transport, *host.Host) {
+	tlsConfig, err := NewTLSConfig(n.ref, n.key, authorization, peerKey)
+	require.NoError(t, err)
+	require.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+	cfg := configuration.Transport{Protocol: "TCP", Address: n.addr, TLS: true}
+	tp, err := NewSecureTransport(cfg, relay.NewProxy(), tlsConfig)
+	require.NoError(t, err)
+	h, err := host.NewHostN(n.addr, n.ref)
+	require.NoError(t, err)
+	return tp, h
+}
+
+func sendTLSPing(t *testing.T, server, client Transport, serverHost, clientHost *host.Host) error {
+	ctx := context.Background()
+	started := make(chan struct{}, 1)
+	go server.Listen(ctx, started)
+	<-started
+	defer func() {
+		go server.Stop()
+		<-server.Stopped()
+		server.Close()
+	}()
+
+	p := packet.NewBuilder(clientHost).Type(types.Ping).Receiver(serverHost).Build()
+	_, err := client.SendRequest(ctx, p)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-server.Packets():
+		return nil
+	case <-time.After(time.Second):
+		return errors.New("packet is not received")
+	}
+}
+
+func TestTCPTransport_TLSRejectsUnknownKey(t *testing.T) {
+	serverNode := newTLSTestNode(t, "127.0.0.1:17022")
+	clientNode := newTLSTestNode(t, "127.0.0.1:17023")
+
+	otherKey, err := platformpolicy.NewKeyProcessor().GeneratePrivateKey()
+	require.NoError(t, err)
+	// Server knows every node with another key, so client certificate is rejected.
+	server, serverHost := serverNode.transport(t, nil, func(core.RecordRef, []byte) (crypto.PublicKey, error) {
+		return otherKey.(crypto.Signer).Public(), nil
+	})
+	client, clientHost := clientNode.transport(t, nil, func(core.RecordRef, []byte) (crypto.PublicKey, error) {
+		return serverNode.key.Public(), nil
+	})
+	require.Error(t, sendTLSPing(t, server, client, serverHost, clientHost))
+
+	_, err = NewTransport(configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:0", TLS: true}, relay.NewProxy())
+	require.Error(t, err)
+}
+
+func TestTCPTransport_TLSUnknownNodeAuthorization(t *testing.T) {
+	serverNode := newTLSTestNode(t, "127.0.0.1:17024")
+	clientNode := newTLSTestNode(t, "127.0.0.1:17025")
+	authorized := []byte("authorized")
+
+	// Server doesn't know client, so it trusts the key only if client authorization is valid.
+	serverPeerKey := func(ref core.RecordRef, authorization []byte) (crypto.PublicKey, error) {
+		if !ref.Equal(clientNode.ref) || !bytes.Equal(authorization, authorized) {
+			return nil, errors.New("unknown node")
+		}
+		return clientNode.key.Public(), nil
+	}
+	clientPeerKey := func(core.RecordRef, []byte) (crypto.PublicKey, error) {
+		return serverNode.key.Public(), nil
+	}
+
+	server, serverHost := serverNode.transport(t, nil, serverPeerKey)
+	client, clientHost := clientNode.transport(t, []byte("forged"), clientPeerKey)
+	require.Error(t, sendTLSPing(t, server, client, serverHost, clientHost))
+	client.Close()
+
+	serverNode.addr, clientNode.addr = "127.0.0.1:17026", "127.0.0.1:17027"
+	server, serverHost = serverNode.transport(t, nil, serverPeerKey)
+	client, clientHost = clientNode.transport(t, authorized, clientPeerKey)
+	require.NoError(t, sendTLSPing(t, server, client, serverHost, clientHost))
+	client.Close()
+}
+
 func TestQuicTransport(t *testing.T) {
 	t.Skip("QUIC internals racing atm. Skip until we want to use it in production")
 
@@ -187,3 +324,43 @@ func TestQuicTransport(t *testing.T) {
 
 	suite.Run(t, NewSuite(cfg1, cfg2))
 }
+
+func TestTCPConnectionFactory_NegotiatesCodec(t *testing.T) {
+	ctx := context.Background()
+
+	newListener := func(hello bool) net.Listener {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		go func() {
+			for {
+				conn, err := l.Accept()
+				if err != nil {
+					return
+				}
+				if hello {
+					_, _ = packet.AcceptHello(bufio.NewReader(conn), conn)
+				}
+			}
+		}()
+		return l
+	}
+
+	upToDate := newListener(true)
+	defer upToDate.Close()
+	legacy := newListener(false)
+	defer legacy.Close()
+
+	factory := &tcpConnectionFactory{}
+	conn, err := factory.CreateConnection(ctx, upToDate.Addr())
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, packet.CodecGob, factory.codec(upToDate.Addr()).Version(), "peer is stored for kept connection only")
+	factory.KeepConnection(upToDate.Addr(), conn)
+	assert.Equal(t, packet.CodecLatest, factory.codec(upToDate.Addr()).Version())
+
+	conn, err = factory.CreateConnection(ctx, legacy.Addr())
+	require.NoError(t, err)
+	defer conn.Close()
+	factory.KeepConnection(legacy.Addr(), conn)
+	assert.Equal(t, packet.CodecGob, factory.codec(legacy.Addr()).Version())
+}
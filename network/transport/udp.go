@@ -86,8 +86,12 @@ func newUDPTransport(conn net.PacketConn, proxy relay.Proxy, publicAddress strin
 	return transport, nil
 }
 
-func (t *udpTransport) send(recvAddress string, data []byte) error {
+func (t *udpTransport) send(recvAddress string, p *packet.Packet) error {
 	log.Debug("Sending PURE_UDP request")
+	data, err := t.serializer.SerializePacket(p)
+	if err != nil {
+		return errors.Wrap(err, "Failed to serialize packet")
+	}
 	if len(data) > udpMaxPacketSize {
 		return errors.New(fmt.Sprintf("udpTransport.send: too big input data. Maximum: %d. Current: %d",
 			udpMaxPacketSize, len(data)))
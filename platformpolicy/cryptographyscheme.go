@@ -23,19 +23,22 @@ import (
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/platformpolicy/internal/hash"
 	"github.com/insolar/insolar/platformpolicy/internal/sign"
+	"github.com/pkg/errors"
 )
 
 type platformCryptographyScheme struct {
 	HashProvider hash.AlgorithmProvider `inject:""`
 	SignProvider sign.AlgorithmProvider `inject:""`
+
+	signProviders map[string]sign.AlgorithmProvider
 }
 
 func (pcs *platformCryptographyScheme) PublicKeySize() int {
-	return sign.TwoBigIntBytesLength
+	return pcs.SignProvider.PublicKeySize()
 }
 
 func (pcs *platformCryptographyScheme) SignatureSIze() int {
-	return sign.TwoBigIntBytesLength
+	return pcs.SignProvider.SignatureSize()
 }
 
 func (pcs *platformCryptographyScheme) ReferenceHasher() core.Hasher {
@@ -46,23 +49,55 @@ func (pcs *platformCryptographyScheme) IntegrityHasher() core.Hasher {
 	return pcs.HashProvider.Hash512bits()
 }
 
+// Signer returns signer of the scheme the private key belongs to.
 func (pcs *platformCryptographyScheme) Signer(privateKey crypto.PrivateKey) core.Signer {
-	return pcs.SignProvider.Sign(privateKey)
+	name, err := SignatureSchemeOfPrivateKey(privateKey)
+	if err != nil {
+		return pcs.SignProvider.Sign(privateKey)
+	}
+	return pcs.signProviders[name].Sign(privateKey)
 }
 
+// Verifier returns verifier of the scheme the public key belongs to.
 func (pcs *platformCryptographyScheme) Verifier(publicKey crypto.PublicKey) core.Verifier {
-	return pcs.SignProvider.Verify(publicKey)
+	name, err := SignatureSchemeOfPublicKey(publicKey)
+	if err != nil {
+		return pcs.SignProvider.Verify(publicKey)
+	}
+	return pcs.signProviders[name].Verify(publicKey)
 }
 
+// NewPlatformCryptographyScheme creates cryptography scheme with DefaultSignatureScheme.
 func NewPlatformCryptographyScheme() core.PlatformCryptographyScheme {
-	platformCryptographyScheme := &platformCryptographyScheme{}
+	pcs, err := NewPlatformCryptographySchemeByName(DefaultSignatureScheme)
+	if err != nil {
+		panic(err)
+	}
+	return pcs
+}
 
-	manager := component.Manager{}
-	manager.Inject(
-		platformCryptographyScheme,
+// NewPlatformCryptographySchemeByName creates cryptography scheme with given signature scheme.
+// Scheme signs and verifies with keys of every registered scheme, the named one defines key and signature sizes.
+func NewPlatformCryptographySchemeByName(name string) (core.PlatformCryptographyScheme, error) {
+	if err := checkSignatureScheme(name); err != nil {
+		return nil, errors.Wrap(err, "[ NewPlatformCryptographySchemeByName ]")
+	}
 
+	platformCryptographyScheme := &platformCryptographyScheme{
+		signProviders: make(map[string]sign.AlgorithmProvider, len(signatureSchemes)),
+	}
+	components := []interface{}{
+		platformCryptographyScheme,
 		hash.NewSHA3Provider(),
-		sign.NewECDSAProvider(),
-	)
-	return platformCryptographyScheme
+	}
+	for schemeName, scheme := range signatureSchemes {
+		provider := scheme.newSignProvider()
+		platformCryptographyScheme.signProviders[schemeName] = provider
+		components = append(components, provider)
+	}
+	platformCryptographyScheme.SignProvider = platformCryptographyScheme.signProviders[name]
+
+	manager := component.Manager{}
+	manager.Inject(components...)
+	return platformCryptographyScheme, nil
 }
@@ -31,3 +31,47 @@ func TestNewPlatformPolicy(t *testing.T) {
 	require.NotNil(t, pcsImpl.HashProvider)
 	require.NotNil(t, pcsImpl.SignProvider)
 }
+
+func TestNewPlatformCryptographySchemeByName(t *testing.T) {
+	pcs, err := NewPlatformCryptographySchemeByName(Ed25519)
+	require.NoError(t, err)
+	require.Equal(t, 32, pcs.PublicKeySize())
+	require.Equal(t, 64, pcs.SignatureSIze())
+
+	_, err = NewPlatformCryptographySchemeByName("unknown")
+	require.Error(t, err)
+}
+
+func TestCrossSchemeVerification(t *testing.T) {
+	data := []byte("data to sign")
+
+	ecdsaPCS := NewPlatformCryptographyScheme()
+	ed25519PCS, err := NewPlatformCryptographySchemeByName(Ed25519)
+	require.NoError(t, err)
+
+	for _, name := range SignatureSchemes() {
+		kp, err := NewKeyProcessorByName(name)
+		require.NoError(t, err)
+		privateKey, err := kp.GeneratePrivateKey()
+		require.NoError(t, err)
+		publicKey := kp.ExtractPublicKey(privateKey)
+
+		signature, err := ecdsaPCS.Signer(privateKey).Sign(data)
+		require.NoError(t, err)
+
+		require.True(t, ecdsaPCS.Verifier(publicKey).Verify(*signature, data), name)
+		require.True(t, ed25519PCS.Verifier(publicKey).Verify(*signature, data), name)
+		require.False(t, ed25519PCS.Verifier(publicKey).Verify(*signature, []byte("other data")), name)
+	}
+
+	ecdsaKey, err := NewKeyProcessor().GeneratePrivateKey()
+	require.NoError(t, err)
+	ed25519KP, err := NewKeyProcessorByName(Ed25519)
+	require.NoError(t, err)
+	ed25519Key, err := ed25519KP.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	ecdsaSignature, err := ecdsaPCS.Signer(ecdsaKey).Sign(data)
+	require.NoError(t, err)
+	require.False(t, ed25519PCS.Verifier(ed25519KP.ExtractPublicKey(ed25519Key)).Verify(*ecdsaSignature, data))
+}
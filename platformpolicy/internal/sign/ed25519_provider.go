@@ -0,0 +1,50 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package sign
+
+import (
+	"crypto"
+
+	"github.com/insolar/insolar/core"
+	"golang.org/x/crypto/ed25519"
+)
+
+type ed25519Provider struct{}
+
+func NewEd25519Provider() AlgorithmProvider {
+	return &ed25519Provider{}
+}
+
+func (p *ed25519Provider) Sign(privateKey crypto.PrivateKey) core.Signer {
+	return &ed25519SignerWrapper{
+		privateKey: MustConvertPrivateKeyToEd25519(privateKey),
+	}
+}
+
+func (p *ed25519Provider) Verify(publicKey crypto.PublicKey) core.Verifier {
+	return &ed25519VerifyWrapper{
+		publicKey: MustConvertPublicKeyToEd25519(publicKey),
+	}
+}
+
+func (p *ed25519Provider) PublicKeySize() int {
+	return ed25519.PublicKeySize
+}
+
+func (p *ed25519Provider) SignatureSize() int {
+	return ed25519.SignatureSize
+}
@@ -0,0 +1,42 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package sign
+
+import (
+	"github.com/insolar/insolar/core"
+	"golang.org/x/crypto/ed25519"
+)
+
+type ed25519SignerWrapper struct {
+	privateKey ed25519.PrivateKey
+}
+
+func (sw *ed25519SignerWrapper) Sign(data []byte) (*core.Signature, error) {
+	signature := core.SignatureFromBytes(ed25519.Sign(sw.privateKey, data))
+	return &signature, nil
+}
+
+type ed25519VerifyWrapper struct {
+	publicKey ed25519.PublicKey
+}
+
+func (sw *ed25519VerifyWrapper) Verify(signature core.Signature, data []byte) bool {
+	if len(signature.Bytes()) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(sw.publicKey, data, signature.Bytes())
+}
@@ -19,6 +19,8 @@ package sign
 import (
 	"crypto"
 	"crypto/ecdsa"
+
+	"golang.org/x/crypto/ed25519"
 )
 
 func MustConvertPublicKeyToEcdsa(publicKey crypto.PublicKey) *ecdsa.PublicKey {
@@ -36,3 +38,19 @@ func MustConvertPrivateKeyToEcdsa(privateKey crypto.PrivateKey) *ecdsa.PrivateKe
 	}
 	return ecdsaPrivateKey
 }
+
+func MustConvertPublicKeyToEd25519(publicKey crypto.PublicKey) ed25519.PublicKey {
+	ed25519PublicKey, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		panic("[ Sign ] Failed to convert public key to ed25519 public key")
+	}
+	return ed25519PublicKey
+}
+
+func MustConvertPrivateKeyToEd25519(privateKey crypto.PrivateKey) ed25519.PrivateKey {
+	ed25519PrivateKey, ok := privateKey.(ed25519.PrivateKey)
+	if !ok {
+		panic("[ Sign ] Failed to convert private key to ed25519 private key")
+	}
+	return ed25519PrivateKey
+}
@@ -25,4 +25,7 @@ import (
 type AlgorithmProvider interface {
 	Sign(crypto.PrivateKey) core.Signer
 	Verify(crypto.PublicKey) core.Verifier
+
+	PublicKeySize() int
+	SignatureSize() int
 }
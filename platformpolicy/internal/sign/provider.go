@@ -44,3 +44,11 @@ func (p *ecdsaProvider) Verify(publicKey crypto.PublicKey) core.Verifier {
 		hasher:    p.HashProvider.Hash512bits(),
 	}
 }
+
+func (p *ecdsaProvider) PublicKeySize() int {
+	return TwoBigIntBytesLength
+}
+
+func (p *ecdsaProvider) SignatureSize() int {
+	return TwoBigIntBytesLength
+}
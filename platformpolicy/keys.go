@@ -28,23 +28,48 @@ import (
 	"github.com/insolar/insolar/core"
 	"github.com/insolar/insolar/platformpolicy/internal/sign"
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
 )
 
+// keyProcessor generates keys of its scheme, but imports and exports keys of any registered scheme.
 type keyProcessor struct {
-	curve elliptic.Curve
+	curve  elliptic.Curve
+	scheme string
 }
 
+// NewKeyProcessor creates key processor with DefaultSignatureScheme.
 func NewKeyProcessor() core.KeyProcessor {
 	return &keyProcessor{
-		curve: elliptic.P256(),
+		curve:  elliptic.P256(),
+		scheme: DefaultSignatureScheme,
 	}
 }
 
+// NewKeyProcessorByName creates key processor generating keys of given signature scheme.
+func NewKeyProcessorByName(name string) (core.KeyProcessor, error) {
+	if err := checkSignatureScheme(name); err != nil {
+		return nil, errors.Wrap(err, "[ NewKeyProcessorByName ]")
+	}
+	return &keyProcessor{
+		curve:  elliptic.P256(),
+		scheme: name,
+	}, nil
+}
+
 func (kp *keyProcessor) GeneratePrivateKey() (crypto.PrivateKey, error) {
-	return ecdsa.GenerateKey(kp.curve, rand.Reader)
+	switch kp.scheme {
+	case Ed25519:
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		return privateKey, err
+	default:
+		return ecdsa.GenerateKey(kp.curve, rand.Reader)
+	}
 }
 
 func (*keyProcessor) ExtractPublicKey(privateKey crypto.PrivateKey) crypto.PublicKey {
+	if ed25519PrivateKey, ok := privateKey.(ed25519.PrivateKey); ok {
+		return ed25519PrivateKey.Public()
+	}
 	ecdsaPrivateKey := sign.MustConvertPrivateKeyToEcdsa(privateKey)
 	publicKey := ecdsaPrivateKey.PublicKey
 	return &publicKey
@@ -56,6 +81,15 @@ func (*keyProcessor) ImportPublicKeyPEM(pemEncoded []byte) (crypto.PublicKey, er
 		return nil, fmt.Errorf("[ ImportPublicKey ] Problems with decoding. Key - %v", pemEncoded)
 	}
 	x509EncodedPub := blockPub.Bytes
+
+	ed25519PublicKey, isEd25519, err := parseEd25519PublicKey(x509EncodedPub)
+	if isEd25519 {
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ ImportPublicKey ] Problems with parsing. Key - %v", pemEncoded)
+		}
+		return ed25519PublicKey, nil
+	}
+
 	publicKey, err := x509.ParsePKIXPublicKey(x509EncodedPub)
 	if err != nil {
 		return nil, fmt.Errorf("[ ImportPublicKey ] Problems with parsing. Key - %v", pemEncoded)
@@ -69,6 +103,15 @@ func (*keyProcessor) ImportPrivateKeyPEM(pemEncoded []byte) (crypto.PrivateKey,
 		return nil, fmt.Errorf("[ ImportPrivateKey ] Problems with decoding. Key - %v", pemEncoded)
 	}
 	x509Encoded := block.Bytes
+
+	ed25519PrivateKey, isEd25519, err := parseEd25519PrivateKey(x509Encoded)
+	if isEd25519 {
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ ImportPrivateKey ] Problems with parsing. Key - %v", pemEncoded)
+		}
+		return ed25519PrivateKey, nil
+	}
+
 	privateKey, err := x509.ParseECPrivateKey(x509Encoded)
 	if err != nil {
 		return nil, fmt.Errorf("[ ImportPrivateKey ] Problems with parsing. Key - %v", pemEncoded)
@@ -77,8 +120,14 @@ func (*keyProcessor) ImportPrivateKeyPEM(pemEncoded []byte) (crypto.PrivateKey,
 }
 
 func (*keyProcessor) ExportPublicKeyPEM(publicKey crypto.PublicKey) ([]byte, error) {
-	ecdsaPublicKey := sign.MustConvertPublicKeyToEcdsa(publicKey)
-	x509EncodedPub, err := x509.MarshalPKIXPublicKey(ecdsaPublicKey)
+	var x509EncodedPub []byte
+	var err error
+	if ed25519PublicKey, ok := publicKey.(ed25519.PublicKey); ok {
+		x509EncodedPub, err = marshalEd25519PublicKey(ed25519PublicKey)
+	} else {
+		ecdsaPublicKey := sign.MustConvertPublicKeyToEcdsa(publicKey)
+		x509EncodedPub, err = x509.MarshalPKIXPublicKey(ecdsaPublicKey)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ExportPublicKey ]")
 	}
@@ -87,8 +136,14 @@ func (*keyProcessor) ExportPublicKeyPEM(publicKey crypto.PublicKey) ([]byte, err
 }
 
 func (*keyProcessor) ExportPrivateKeyPEM(privateKey crypto.PrivateKey) ([]byte, error) {
-	ecdsaPrivateKey := sign.MustConvertPrivateKeyToEcdsa(privateKey)
-	x509Encoded, err := x509.MarshalECPrivateKey(ecdsaPrivateKey)
+	var x509Encoded []byte
+	var err error
+	if ed25519PrivateKey, ok := privateKey.(ed25519.PrivateKey); ok {
+		x509Encoded, err = marshalEd25519PrivateKey(ed25519PrivateKey)
+	} else {
+		ecdsaPrivateKey := sign.MustConvertPrivateKeyToEcdsa(privateKey)
+		x509Encoded, err = x509.MarshalECPrivateKey(ecdsaPrivateKey)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ExportPrivateKey ]")
 	}
@@ -97,11 +152,18 @@ func (*keyProcessor) ExportPrivateKeyPEM(privateKey crypto.PrivateKey) ([]byte,
 }
 
 func (kp *keyProcessor) ExportPublicKeyBinary(publicKey crypto.PublicKey) ([]byte, error) {
+	if ed25519PublicKey, ok := publicKey.(ed25519.PublicKey); ok {
+		return append([]byte(nil), ed25519PublicKey...), nil
+	}
 	ecdsaPublicKey := sign.MustConvertPublicKeyToEcdsa(publicKey)
 	return sign.SerializeTwoBigInt(ecdsaPublicKey.X, ecdsaPublicKey.Y), nil
 }
 
 func (kp *keyProcessor) ImportPublicKeyBinary(data []byte) (crypto.PublicKey, error) {
+	if len(data) == ed25519.PublicKeySize {
+		return ed25519.PublicKey(append([]byte(nil), data...)), nil
+	}
+
 	x, y, err := sign.DeserializeTwoBigInt(data)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ImportPublicKeyBinary ]")
@@ -0,0 +1,94 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package platformpolicy
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+)
+
+// oidEd25519 is Ed25519 algorithm identifier from RFC 8410.
+var oidEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+type pkixPublicKey struct {
+	Algo      pkix.AlgorithmIdentifier
+	BitString asn1.BitString
+}
+
+type pkcs8PrivateKey struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+func marshalEd25519PublicKey(publicKey ed25519.PublicKey) ([]byte, error) {
+	return asn1.Marshal(pkixPublicKey{
+		Algo: pkix.AlgorithmIdentifier{Algorithm: oidEd25519},
+		BitString: asn1.BitString{
+			Bytes:     publicKey,
+			BitLength: len(publicKey) * 8,
+		},
+	})
+}
+
+// parseEd25519PublicKey returns false if der is not an Ed25519 PKIX public key.
+func parseEd25519PublicKey(der []byte) (ed25519.PublicKey, bool, error) {
+	var pki pkixPublicKey
+	if rest, err := asn1.Unmarshal(der, &pki); err != nil || len(rest) != 0 {
+		return nil, false, nil
+	}
+	if !pki.Algo.Algorithm.Equal(oidEd25519) {
+		return nil, false, nil
+	}
+	if len(pki.BitString.Bytes) != ed25519.PublicKeySize {
+		return nil, true, errors.Errorf("wrong ed25519 public key length: %d", len(pki.BitString.Bytes))
+	}
+	return ed25519.PublicKey(pki.BitString.Bytes), true, nil
+}
+
+func marshalEd25519PrivateKey(privateKey ed25519.PrivateKey) ([]byte, error) {
+	seed, err := asn1.Marshal(privateKey.Seed())
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8PrivateKey{
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: oidEd25519},
+		PrivateKey: seed,
+	})
+}
+
+// parseEd25519PrivateKey returns false if der is not an Ed25519 PKCS#8 private key.
+func parseEd25519PrivateKey(der []byte) (ed25519.PrivateKey, bool, error) {
+	var pk pkcs8PrivateKey
+	if rest, err := asn1.Unmarshal(der, &pk); err != nil || len(rest) != 0 {
+		return nil, false, nil
+	}
+	if !pk.Algo.Algorithm.Equal(oidEd25519) {
+		return nil, false, nil
+	}
+	var seed []byte
+	if _, err := asn1.Unmarshal(pk.PrivateKey, &seed); err != nil {
+		return nil, true, errors.Wrap(err, "failed to parse ed25519 seed")
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, true, errors.Errorf("wrong ed25519 seed length: %d", len(seed))
+	}
+	return ed25519.NewKeyFromSeed(seed), true, nil
+}
@@ -71,3 +71,60 @@ func TestExportImportPublicKeyBinary(t *testing.T) {
 
 	assert.Equal(t, encoded, encodedBinPK)
 }
+
+func TestEd25519ExportImportKeys(t *testing.T) {
+	ks, err := NewKeyProcessorByName(Ed25519)
+	require.NoError(t, err)
+
+	privateKey, err := ks.GeneratePrivateKey()
+	require.NoError(t, err)
+	publicKey := ks.ExtractPublicKey(privateKey)
+
+	encodedPrivate, err := ks.ExportPrivateKeyPEM(privateKey)
+	require.NoError(t, err)
+	decodedPrivate, err := ks.ImportPrivateKeyPEM(encodedPrivate)
+	require.NoError(t, err)
+	assert.Equal(t, privateKey, decodedPrivate)
+
+	encodedPublic, err := ks.ExportPublicKeyPEM(publicKey)
+	require.NoError(t, err)
+	decodedPublic, err := ks.ImportPublicKeyPEM(encodedPublic)
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, decodedPublic)
+
+	bin, err := ks.ExportPublicKeyBinary(publicKey)
+	require.NoError(t, err)
+	assert.Len(t, bin, 32)
+	binPK, err := ks.ImportPublicKeyBinary(bin)
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, binPK)
+}
+
+func TestKeyProcessorImportsBothSchemes(t *testing.T) {
+	ecdsaKP := NewKeyProcessor()
+	ed25519KP, err := NewKeyProcessorByName(Ed25519)
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsaKP.GeneratePrivateKey()
+	require.NoError(t, err)
+	ed25519Key, err := ed25519KP.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	for _, key := range []interface{}{ecdsaKey, ed25519Key} {
+		encoded, err := ecdsaKP.ExportPublicKeyPEM(ecdsaKP.ExtractPublicKey(key))
+		require.NoError(t, err)
+		publicKey, err := ed25519KP.ImportPublicKeyPEM(encoded)
+		require.NoError(t, err)
+
+		keyScheme, err := SignatureSchemeOfPrivateKey(key)
+		require.NoError(t, err)
+		publicKeyScheme, err := SignatureSchemeOfPublicKey(publicKey)
+		require.NoError(t, err)
+		assert.Equal(t, keyScheme, publicKeyScheme)
+	}
+}
+
+func TestNewKeyProcessorByName_UnknownScheme(t *testing.T) {
+	_, err := NewKeyProcessorByName("rsa")
+	require.Error(t, err)
+}
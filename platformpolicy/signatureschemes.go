@@ -0,0 +1,106 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package platformpolicy
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"sort"
+
+	"github.com/insolar/insolar/platformpolicy/internal/sign"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+)
+
+// Signature schemes supported by platform.
+const (
+	// ECDSAP256 is ECDSA on P-256 curve over SHA3-512 digest.
+	ECDSAP256 = "ecdsa-p256"
+	// Ed25519 is pure Ed25519 (RFC 8032).
+	Ed25519 = "ed25519"
+
+	// DefaultSignatureScheme is used when configuration does not specify a scheme.
+	DefaultSignatureScheme = ECDSAP256
+)
+
+type signatureScheme struct {
+	newSignProvider func() sign.AlgorithmProvider
+	ownsPublicKey   func(crypto.PublicKey) bool
+	ownsPrivateKey  func(crypto.PrivateKey) bool
+}
+
+var signatureSchemes = map[string]signatureScheme{
+	ECDSAP256: {
+		newSignProvider: sign.NewECDSAProvider,
+		ownsPublicKey: func(key crypto.PublicKey) bool {
+			_, ok := key.(*ecdsa.PublicKey)
+			return ok
+		},
+		ownsPrivateKey: func(key crypto.PrivateKey) bool {
+			_, ok := key.(*ecdsa.PrivateKey)
+			return ok
+		},
+	},
+	Ed25519: {
+		newSignProvider: sign.NewEd25519Provider,
+		ownsPublicKey: func(key crypto.PublicKey) bool {
+			_, ok := key.(ed25519.PublicKey)
+			return ok
+		},
+		ownsPrivateKey: func(key crypto.PrivateKey) bool {
+			_, ok := key.(ed25519.PrivateKey)
+			return ok
+		},
+	},
+}
+
+// SignatureSchemes returns sorted names of all registered signature schemes.
+func SignatureSchemes() []string {
+	names := make([]string, 0, len(signatureSchemes))
+	for name := range signatureSchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SignatureSchemeOfPublicKey returns name of signature scheme the public key belongs to.
+func SignatureSchemeOfPublicKey(publicKey crypto.PublicKey) (string, error) {
+	for name, scheme := range signatureSchemes {
+		if scheme.ownsPublicKey(publicKey) {
+			return name, nil
+		}
+	}
+	return "", errors.Errorf("[ SignatureSchemeOfPublicKey ] unsupported public key type %T", publicKey)
+}
+
+// SignatureSchemeOfPrivateKey returns name of signature scheme the private key belongs to.
+func SignatureSchemeOfPrivateKey(privateKey crypto.PrivateKey) (string, error) {
+	for name, scheme := range signatureSchemes {
+		if scheme.ownsPrivateKey(privateKey) {
+			return name, nil
+		}
+	}
+	return "", errors.Errorf("[ SignatureSchemeOfPrivateKey ] unsupported private key type %T", privateKey)
+}
+
+func checkSignatureScheme(name string) error {
+	if _, ok := signatureSchemes[name]; !ok {
+		return errors.Errorf("unknown signature scheme %q, supported: %v", name, SignatureSchemes())
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pulsar
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	pulsarstorage "github.com/insolar/insolar/pulsar/storage"
+)
+
+const (
+	historyDefaultLimit = 100
+	historyMaxLimit     = 1000
+)
+
+// HistoryPulse is a saved pulse returned by history endpoint.
+type HistoryPulse struct {
+	PulseNumber      core.PulseNumber `json:"pulseNumber"`
+	PrevPulseNumber  core.PulseNumber `json:"prevPulseNumber"`
+	NextPulseNumber  core.PulseNumber `json:"nextPulseNumber"`
+	PulseTimestamp   int64            `json:"pulseTimestamp"`
+	EpochPulseNumber int              `json:"epochPulseNumber"`
+	OriginID         []byte           `json:"originID"`
+	Entropy          []byte           `json:"entropy"`
+	Signs            []HistorySign    `json:"signs"`
+	EntropyProof     []HistoryReveal  `json:"entropyProof,omitempty"`
+}
+
+// HistorySign is a confirmation of a pulse by one of pulsars.
+type HistorySign struct {
+	PublicKey       string           `json:"publicKey"`
+	PulseNumber     core.PulseNumber `json:"pulseNumber"`
+	ChosenPublicKey string           `json:"chosenPublicKey"`
+	Entropy         []byte           `json:"entropy"`
+	Signature       []byte           `json:"signature"`
+}
+
+// HistoryReveal is an entropy of one of pulsars combined into pulse entropy.
+type HistoryReveal struct {
+	PublicKey string `json:"publicKey"`
+	Entropy   []byte `json:"entropy"`
+	Signature []byte `json:"signature"`
+}
+
+// HistoryReply is a reply of history endpoint for range queries.
+type HistoryReply struct {
+	Pulses []HistoryPulse `json:"pulses"`
+}
+
+// NewHistoryPulse converts pulse to HistoryPulse. Signs are sorted by public key.
+func NewHistoryPulse(pulse *core.Pulse) HistoryPulse {
+	result := HistoryPulse{
+		PulseNumber:      pulse.PulseNumber,
+		PrevPulseNumber:  pulse.PrevPulseNumber,
+		NextPulseNumber:  pulse.NextPulseNumber,
+		PulseTimestamp:   pulse.PulseTimestamp,
+		EpochPulseNumber: pulse.EpochPulseNumber,
+		OriginID:         pulse.OriginID[:],
+		Entropy:          pulse.Entropy[:],
+		Signs:            make([]HistorySign, 0, len(pulse.Signs)),
+	}
+	for key, sign := range pulse.Signs {
+		entropy := sign.Entropy
+		result.Signs = append(result.Signs, HistorySign{
+			PublicKey:       key,
+			PulseNumber:     sign.PulseNumber,
+			ChosenPublicKey: sign.ChosenPublicKey,
+			Entropy:         entropy[:],
+			Signature:       sign.Signature,
+		})
+	}
+	sort.Slice(result.Signs, func(i, j int) bool {
+		return result.Signs[i].PublicKey < result.Signs[j].PublicKey
+	})
+	for _, reveal := range pulse.EntropyProof {
+		entropy := reveal.Entropy
+		result.EntropyProof = append(result.EntropyProof, HistoryReveal{
+			PublicKey: reveal.PublicKey,
+			Entropy:   entropy[:],
+			Signature: reveal.Signature,
+		})
+	}
+	return result
+}
+
+// NewHistoryHandler returns HTTP handler serving pulses saved in storage:
+//
+//	GET /pulses/last - the last pulse
+//	GET /pulses/<number> - pulse with number
+//	GET /pulses?from=<number>&to=<number>&limit=<count> - pulses with numbers in [from, to] range
+//	GET /pulses?since=<unix time>&until=<unix time>&limit=<count> - pulses with timestamps in [since, until) range
+//
+// Omitted range bounds are unlimited. Limit is 100 by default and at most 1000.
+func NewHistoryHandler(storage pulsarstorage.PulsarStorage) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pulses", func(w http.ResponseWriter, r *http.Request) {
+		handleHistoryRange(storage, w, r)
+	})
+	mux.HandleFunc("/pulses/", func(w http.ResponseWriter, r *http.Request) {
+		handleHistoryPulse(storage, w, r)
+	})
+	return mux
+}
+
+func handleHistoryPulse(storage pulsarstorage.PulsarStorage, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var pulse *core.Pulse
+	var err error
+	number := strings.TrimPrefix(r.URL.Path, "/pulses/")
+	if number == "last" {
+		pulse, err = storage.GetLastPulse()
+	} else {
+		pn, perr := strconv.ParseUint(number, 10, 32)
+		if perr != nil {
+			http.Error(w, "invalid pulse number", http.StatusBadRequest)
+			return
+		}
+		pulse, err = storage.GetPulse(core.PulseNumber(pn))
+	}
+	if err == pulsarstorage.ErrNotFound {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeHistoryJSON(w, NewHistoryPulse(pulse))
+}
+
+func handleHistoryRange(storage pulsarstorage.PulsarStorage, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	params := map[string]int64{"from": 0, "to": int64(core.PulseNumber(^uint32(0))), "limit": historyDefaultLimit}
+	for name := range params {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			http.Error(w, "invalid "+name+" param", http.StatusBadRequest)
+			return
+		}
+		params[name] = parsed
+	}
+	if params["from"] > math.MaxUint32 || params["to"] > math.MaxUint32 {
+		http.Error(w, "pulse number is out of range", http.StatusBadRequest)
+		return
+	}
+	if params["from"] > params["to"] {
+		http.Error(w, "from param is greater than to param", http.StatusBadRequest)
+		return
+	}
+	limit := int(params["limit"])
+	if limit == 0 || limit > historyMaxLimit {
+		limit = historyMaxLimit
+	}
+
+	var pulses []*core.Pulse
+	var err error
+	if query.Get("since") != "" || query.Get("until") != "" {
+		since, until := time.Unix(0, 0), time.Unix(1<<62, 0)
+		if since, err = parseHistoryTime(query.Get("since"), since); err != nil {
+			http.Error(w, "invalid since param", http.StatusBadRequest)
+			return
+		}
+		if until, err = parseHistoryTime(query.Get("until"), until); err != nil {
+			http.Error(w, "invalid until param", http.StatusBadRequest)
+			return
+		}
+		pulses, err = storage.GetPulsesByTime(since, until, limit)
+	} else {
+		pulses, err = storage.GetPulses(core.PulseNumber(params["from"]), core.PulseNumber(params["to"]), limit)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	reply := HistoryReply{Pulses: make([]HistoryPulse, 0, len(pulses))}
+	for _, pulse := range pulses {
+		reply.Pulses = append(reply.Pulses, NewHistoryPulse(pulse))
+	}
+	writeHistoryJSON(w, reply)
+}
+
+func parseHistoryTime(value string, def time.Time) (time.Time, error) {
+	if value == "" {
+		return def, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return def, err
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+func writeHistoryJSON(w http.ResponseWriter, reply interface{}) {
+	data, err := json.Marshal(reply)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
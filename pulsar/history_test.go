@@ -0,0 +1,106 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pulsar
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/pulsar/pulsartestutils"
+	pulsarstorage "github.com/insolar/insolar/pulsar/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHistoryHandler(t *testing.T) {
+	pulse := &core.Pulse{
+		PulseNumber:    core.FirstPulseNumber + 10,
+		PulseTimestamp: 100,
+		Signs: map[string]core.PulseSenderConfirmation{
+			"b": {Signature: []byte{2}},
+			"a": {Signature: []byte{1}},
+		},
+	}
+	get := func(storage pulsarstorage.PulsarStorage, url string, reply interface{}) int {
+		recorder := httptest.NewRecorder()
+		NewHistoryHandler(storage).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+		if recorder.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), reply))
+		}
+		return recorder.Code
+	}
+
+	t.Run("pulse by number", func(t *testing.T) {
+		storage := pulsartestutils.NewPulsarStorageMock(t)
+		storage.GetPulseMock.Expect(pulse.PulseNumber).Return(pulse, nil)
+
+		reply := HistoryPulse{}
+		require.Equal(t, http.StatusOK, get(storage, "/pulses/65547", &reply))
+		assert.Equal(t, pulse.PulseNumber, reply.PulseNumber)
+		require.Len(t, reply.Signs, 2)
+		assert.Equal(t, "a", reply.Signs[0].PublicKey)
+		assert.Equal(t, []byte{1}, reply.Signs[0].Signature)
+	})
+
+	t.Run("unknown pulse", func(t *testing.T) {
+		storage := pulsartestutils.NewPulsarStorageMock(t)
+		storage.GetPulseMock.Return(nil, pulsarstorage.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, get(storage, "/pulses/1", nil))
+		assert.Equal(t, http.StatusBadRequest, get(storage, "/pulses/abc", nil))
+	})
+
+	t.Run("last pulse", func(t *testing.T) {
+		storage := pulsartestutils.NewPulsarStorageMock(t)
+		storage.GetLastPulseMock.Return(pulse, nil)
+
+		reply := HistoryPulse{}
+		require.Equal(t, http.StatusOK, get(storage, "/pulses/last", &reply))
+		assert.Equal(t, pulse.PulseNumber, reply.PulseNumber)
+	})
+
+	t.Run("range by number", func(t *testing.T) {
+		storage := pulsartestutils.NewPulsarStorageMock(t)
+		storage.GetPulsesMock.Expect(10, 20, 5).Return([]*core.Pulse{pulse}, nil)
+
+		reply := HistoryReply{}
+		require.Equal(t, http.StatusOK, get(storage, "/pulses?from=10&to=20&limit=5", &reply))
+		require.Len(t, reply.Pulses, 1)
+		assert.Equal(t, pulse.PulseNumber, reply.Pulses[0].PulseNumber)
+		assert.Equal(t, http.StatusBadRequest, get(storage, "/pulses?from=-1", nil))
+		assert.Equal(t, http.StatusBadRequest, get(storage, "/pulses?to=4294967306", nil), "to doesn't wrap to 10")
+		assert.Equal(t, http.StatusBadRequest, get(storage, "/pulses?from=4294967296", nil))
+		assert.Equal(t, http.StatusBadRequest, get(storage, "/pulses?from=20&to=10", nil))
+	})
+
+	t.Run("range by time", func(t *testing.T) {
+		storage := pulsartestutils.NewPulsarStorageMock(t)
+		storage.GetPulsesByTimeFunc = func(from, to time.Time, limit int) ([]*core.Pulse, error) {
+			assert.Equal(t, int64(100), from.Unix())
+			assert.Equal(t, int64(200), to.Unix())
+			assert.Equal(t, historyDefaultLimit, limit)
+			return nil, nil
+		}
+
+		reply := HistoryReply{}
+		require.Equal(t, http.StatusOK, get(storage, "/pulses?since=100&until=200", &reply))
+		assert.Empty(t, reply.Pulses)
+	})
+}
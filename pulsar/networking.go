@@ -176,7 +176,7 @@ func (handler *Handler) ReceiveEntropy(request *Payload, response *Payload) erro
 		return errors.Errorf("processing pulse number - %v is bigger than received one - %v", requestBody.PulseNumber, handler.Pulsar.ProcessingPulseNumber)
 	}
 
-	if btfCell, ok := handler.Pulsar.GetItemFromVector(request.PublicKey); ok {
+	if btfCell, ok := handler.Pulsar.GetItemFromVector(request.PublicKey); ok && btfCell != nil {
 
 		publicKey, err := handler.Pulsar.KeyProcessor.ImportPublicKeyPEM([]byte(request.PublicKey))
 		if err != nil {
@@ -184,7 +184,7 @@ func (handler *Handler) ReceiveEntropy(request *Payload, response *Payload) erro
 			return err
 		}
 
-		isVerified := handler.Pulsar.CryptographyService.Verify(publicKey, core.SignatureFromBytes(btfCell.GetSign()), requestBody.Entropy[:])
+		isVerified := handler.Pulsar.CryptographyService.Verify(publicKey, core.SignatureFromBytes(btfCell.GetSign()), core.EntropyCommitment(requestBody.PulseNumber, requestBody.Entropy))
 		if err != nil || !isVerified {
 			handler.Pulsar.AddItemToVector(request.PublicKey, nil)
 			inslog.Errorf("signature and Entropy aren't matched")
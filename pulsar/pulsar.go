@@ -64,8 +64,9 @@ type Pulsar struct {
 
 	GeneratedEntropySign []byte
 
-	currentSlotEntropy     *core.Entropy
-	currentSlotEntropyLock sync.RWMutex
+	currentSlotEntropy      *core.Entropy
+	currentSlotEntropyProof []core.EntropyReveal
+	currentSlotEntropyLock  sync.RWMutex
 
 	CurrentSlotPulseSender string
 
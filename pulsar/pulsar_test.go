@@ -771,7 +771,13 @@ func TestPulsar_verify_Standalone_Success(t *testing.T) {
 	mockSwitcher.SwitchToStateFunc = func(p context.Context, p1 State, p2 interface{}) {
 		require.Equal(t, SendingPulse, p1)
 	}
-	pulsar := &Pulsar{StateSwitcher: mockSwitcher}
+	privateKey, _ := platformpolicy.NewKeyProcessor().GeneratePrivateKey()
+	pulsar := &Pulsar{
+		StateSwitcher:                  mockSwitcher,
+		CryptographyService:            cryptography.NewKeyBoundCryptographyService(privateKey),
+		PlatformCryptographyScheme:     platformpolicy.NewPlatformCryptographyScheme(),
+		CurrentSlotSenderConfirmations: map[string]core.PulseSenderConfirmation{},
+	}
 	pulsar.PublicKeyRaw = "testKey"
 	generatedEntropy := core.Entropy(pulsartestutils.MockEntropy)
 	pulsar.generatedEntropy = &generatedEntropy
@@ -783,6 +789,11 @@ func TestPulsar_verify_Standalone_Success(t *testing.T) {
 	require.Equal(t, uint64(1), mockSwitcher.SwitchToStateCounter)
 	require.Equal(t, "testKey", pulsar.PublicKeyRaw)
 	require.Equal(t, core.Entropy(pulsartestutils.MockEntropy), *pulsar.GetGeneratedEntropy())
+	require.Equal(t, []core.EntropyReveal{{PublicKey: "testKey", Entropy: generatedEntropy}}, pulsar.GetCurrentSlotEntropyProof())
+	confirmation, ok := pulsar.CurrentSlotSenderConfirmations["testKey"]
+	require.True(t, ok)
+	require.Equal(t, generatedEntropy, confirmation.Entropy)
+	require.NotEmpty(t, confirmation.Signature)
 	mockSwitcher.MinimockFinish()
 }
 
@@ -814,9 +825,9 @@ func TestPulsar_verify_NotEnoughForConsensus_Success(t *testing.T) {
 	require.Equal(t, uint64(1), mockSwitcher.SwitchToStateCounter)
 }
 
-func prepareEntropy(t *testing.T, service core.CryptographyService) (entropy core.Entropy, sign []byte) {
+func prepareEntropy(t *testing.T, service core.CryptographyService, pulseNumber core.PulseNumber) (entropy core.Entropy, sign []byte) {
 	entropy = (&entropygenerator.StandardEntropyGenerator{}).GenerateEntropy()
-	fetchedSign, err := service.Sign(entropy[:])
+	fetchedSign, err := service.Sign(core.EntropyCommitment(pulseNumber, entropy))
 	require.NoError(t, err)
 	sign = fetchedSign.Bytes()
 	return
@@ -861,6 +872,7 @@ func TestPulsar_verify_Success(t *testing.T) {
 		CryptographyService:            pulsarCryptoService,
 		PlatformCryptographyScheme:     platformpolicy.NewPlatformCryptographyScheme(),
 		PublicKeyRaw:                   currentPulsarPublicKey,
+		ProcessingPulseNumber:          core.PulseNumber(123),
 		ownedBftRow:                    map[string]*BftCell{},
 		bftGrid:                        map[string]map[string]*BftCell{},
 		CurrentSlotSenderConfirmations: map[string]core.PulseSenderConfirmation{},
@@ -870,9 +882,9 @@ func TestPulsar_verify_Success(t *testing.T) {
 		},
 	}
 
-	firstEntropy, firstSign := prepareEntropy(t, pulsarCryptoService)
-	secondEntropy, secondSign := prepareEntropy(t, secondCryptoService)
-	thirdEntropy, thirdSign := prepareEntropy(t, thirdCryptoService)
+	firstEntropy, firstSign := prepareEntropy(t, pulsarCryptoService, pulsar.ProcessingPulseNumber)
+	secondEntropy, secondSign := prepareEntropy(t, secondCryptoService, pulsar.ProcessingPulseNumber)
+	thirdEntropy, thirdSign := prepareEntropy(t, thirdCryptoService, pulsar.ProcessingPulseNumber)
 
 	pulsar.bftGrid[currentPulsarPublicKey] = map[string]*BftCell{
 		currentPulsarPublicKey: {Entropy: firstEntropy, Sign: firstSign, IsEntropyReceived: true},
@@ -901,5 +913,7 @@ func TestPulsar_verify_Success(t *testing.T) {
 
 	require.NotNil(t, pulsar.CurrentSlotPulseSender)
 	require.Equal(t, expectedEntropy, *pulsar.GetCurrentSlotEntropy())
+	require.Len(t, pulsar.GetCurrentSlotEntropyProof(), 3)
+	require.Equal(t, expectedEntropy, core.CombineEntropy(pulsar.GetCurrentSlotEntropyProof()))
 	require.Equal(t, uint64(1), mockSwitcher.SwitchToStateCounter)
 }
@@ -257,11 +257,20 @@ func (currentPulsar *Pulsar) sendPulseToNodesAndPulsars(ctx context.Context) {
 		return
 	}
 
+	// Only pulsars whose entropy is in the proof confirm the pulse, so the proof can be verified against signs.
+	proof := currentPulsar.GetCurrentSlotEntropyProof()
 	currentPulsar.currentSlotSenderConfirmationsLock.RLock()
+	signs := make(map[string]core.PulseSenderConfirmation, len(proof))
+	for _, reveal := range proof {
+		if sign, ok := currentPulsar.CurrentSlotSenderConfirmations[reveal.PublicKey]; ok {
+			signs[reveal.PublicKey] = sign
+		}
+	}
 	pulseForSending := core.Pulse{
 		PulseNumber:      currentPulsar.ProcessingPulseNumber,
 		Entropy:          *currentPulsar.GetCurrentSlotEntropy(),
-		Signs:            currentPulsar.CurrentSlotSenderConfirmations,
+		EntropyProof:     proof,
+		Signs:            signs,
 		NextPulseNumber:  currentPulsar.ProcessingPulseNumber + core.PulseNumber(currentPulsar.Config.NumberDelta),
 		PrevPulseNumber:  currentPulsar.lastPulse.PulseNumber,
 		EpochPulseNumber: 1,
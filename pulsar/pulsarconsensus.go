@@ -106,8 +106,18 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 
 	}
 	if currentPulsar.isStandalone() {
-		currentPulsar.SetCurrentSlotEntropy(currentPulsar.GetGeneratedEntropy())
+		generatedEntropy := currentPulsar.GetGeneratedEntropy()
+		currentPulsar.SetCurrentSlotEntropy(generatedEntropy)
+		currentPulsar.SetCurrentSlotEntropyProof([]core.EntropyReveal{{
+			PublicKey: currentPulsar.PublicKeyRaw,
+			Entropy:   *generatedEntropy,
+			Signature: currentPulsar.GeneratedEntropySign,
+		}})
 		currentPulsar.CurrentSlotPulseSender = currentPulsar.PublicKeyRaw
+		if err := currentPulsar.confirmOwnPulse(); err != nil {
+			currentPulsar.StateSwitcher.SwitchToState(ctx, Failed, err)
+			return
+		}
 		currentPulsar.StateSwitcher.SwitchToState(ctx, SendingPulse, nil)
 		return
 	}
@@ -117,7 +127,7 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 		PubKey crypto.PublicKey
 	}
 
-	var finalEntropySet []core.Entropy
+	var finalEntropySet []core.EntropyReveal
 
 	keys := []string{currentPulsar.PublicKeyRaw}
 	activePulsars := []*bftMember{{currentPulsar.PublicKeyRaw, currentPulsar.PublicKey}}
@@ -130,6 +140,7 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 	wrongVectors := 0
 	for _, column := range activePulsars {
 		currentColumnStat := map[string]int{}
+		columnSigns := map[string][]byte{}
 		for _, row := range activePulsars {
 			bftCell := currentPulsar.GetBftGridItem(row.PubPem, column.PubPem)
 
@@ -145,21 +156,23 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 			}
 
 			entropy := bftCell.GetEntropy()
-			ok := currentPulsar.CryptographyService.Verify(publicKey, core.SignatureFromBytes(bftCell.GetSign()), entropy[:])
+			ok := currentPulsar.CryptographyService.Verify(publicKey, core.SignatureFromBytes(bftCell.GetSign()), core.EntropyCommitment(currentPulsar.ProcessingPulseNumber, entropy))
 			if !ok {
 				currentColumnStat["nil"]++
 				continue
 			}
 
 			currentColumnStat[string(entropy[:])]++
+			columnSigns[string(entropy[:])] = bftCell.GetSign()
 		}
 
 		maxConfirmationsForEntropy := int(0)
-		var chosenEntropy core.Entropy
+		chosenEntropy := core.EntropyReveal{PublicKey: column.PubPem}
 		for key, value := range currentColumnStat {
 			if value > maxConfirmationsForEntropy && key != "nil" {
 				maxConfirmationsForEntropy = value
-				copy(chosenEntropy[:], []byte(key)[:core.EntropySize])
+				copy(chosenEntropy.Entropy[:], []byte(key)[:core.EntropySize])
+				chosenEntropy.Signature = columnSigns[key]
 			}
 		}
 
@@ -179,14 +192,8 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 		return
 	}
 
-	var finalEntropy core.Entropy
-
-	for _, tempEntropy := range finalEntropySet {
-		for byteIndex := 0; byteIndex < core.EntropySize; byteIndex++ {
-			finalEntropy[byteIndex] ^= tempEntropy[byteIndex]
-		}
-	}
-	currentPulsar.finalizeBft(ctx, finalEntropy, keys)
+	currentPulsar.SetCurrentSlotEntropyProof(finalEntropySet)
+	currentPulsar.finalizeBft(ctx, core.CombineEntropy(finalEntropySet), keys)
 }
 
 func (currentPulsar *Pulsar) finalizeBft(ctx context.Context, finalEntropy core.Entropy, activePulsars []string) {
@@ -201,35 +208,40 @@ func (currentPulsar *Pulsar) finalizeBft(ctx context.Context, finalEntropy core.
 	}
 	currentPulsar.CurrentSlotPulseSender = chosenPulsar[0]
 	if currentPulsar.CurrentSlotPulseSender == currentPulsar.PublicKeyRaw {
-		//here confirmation myself
-		payload := PulseSenderConfirmationPayload{core.PulseSenderConfirmation{
-			ChosenPublicKey: currentPulsar.CurrentSlotPulseSender,
-			Entropy:         *currentPulsar.GetCurrentSlotEntropy(),
-			PulseNumber:     currentPulsar.ProcessingPulseNumber,
-		}}
-		hashProvider := currentPulsar.PlatformCryptographyScheme.IntegrityHasher()
-		hash, err := payload.Hash(hashProvider)
-		if err != nil {
+		if err := currentPulsar.confirmOwnPulse(); err != nil {
 			currentPulsar.StateSwitcher.SwitchToState(ctx, Failed, err)
 			return
 		}
-		signature, err := currentPulsar.CryptographyService.Sign(hash)
-		if err != nil {
-			currentPulsar.StateSwitcher.SwitchToState(ctx, Failed, err)
-			return
-		}
-
-		currentPulsar.currentSlotSenderConfirmationsLock.Lock()
-		currentPulsar.CurrentSlotSenderConfirmations[currentPulsar.PublicKeyRaw] = core.PulseSenderConfirmation{
-			ChosenPublicKey: currentPulsar.CurrentSlotPulseSender,
-			Signature:       signature.Bytes(),
-			Entropy:         *currentPulsar.GetCurrentSlotEntropy(),
-			PulseNumber:     currentPulsar.ProcessingPulseNumber,
-		}
-		currentPulsar.currentSlotSenderConfirmationsLock.Unlock()
-
 		currentPulsar.StateSwitcher.SwitchToState(ctx, WaitingForPulseSigns, nil)
 	} else {
 		currentPulsar.StateSwitcher.SwitchToState(ctx, SendingPulseSign, nil)
 	}
 }
+
+// confirmOwnPulse signs confirmation of current slot entropy by the pulse sender itself.
+func (currentPulsar *Pulsar) confirmOwnPulse() error {
+	payload := PulseSenderConfirmationPayload{core.PulseSenderConfirmation{
+		ChosenPublicKey: currentPulsar.CurrentSlotPulseSender,
+		Entropy:         *currentPulsar.GetCurrentSlotEntropy(),
+		PulseNumber:     currentPulsar.ProcessingPulseNumber,
+	}}
+	hashProvider := currentPulsar.PlatformCryptographyScheme.IntegrityHasher()
+	hash, err := payload.Hash(hashProvider)
+	if err != nil {
+		return err
+	}
+	signature, err := currentPulsar.CryptographyService.Sign(hash)
+	if err != nil {
+		return err
+	}
+
+	currentPulsar.currentSlotSenderConfirmationsLock.Lock()
+	currentPulsar.CurrentSlotSenderConfirmations[currentPulsar.PublicKeyRaw] = core.PulseSenderConfirmation{
+		ChosenPublicKey: currentPulsar.CurrentSlotPulseSender,
+		Signature:       signature.Bytes(),
+		Entropy:         *currentPulsar.GetCurrentSlotEntropy(),
+		PulseNumber:     currentPulsar.ProcessingPulseNumber,
+	}
+	currentPulsar.currentSlotSenderConfirmationsLock.Unlock()
+	return nil
+}
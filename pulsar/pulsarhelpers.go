@@ -68,6 +68,8 @@ func (currentPulsar *Pulsar) clearState() {
 	currentPulsar.GeneratedEntropySign = []byte{}
 	log.Debug("currentPulsar.SetCurrentSlotEntropy(nil)")
 	currentPulsar.SetCurrentSlotEntropy(nil)
+	log.Debug("currentPulsar.SetCurrentSlotEntropyProof(nil)")
+	currentPulsar.SetCurrentSlotEntropyProof(nil)
 	log.Debug("currentPulsar.CurrentSlotPulseSender = ")
 	currentPulsar.CurrentSlotPulseSender = ""
 	log.Debug("currentPulsar.currentSlotSenderConfirmationsLock.Lock()")
@@ -91,7 +93,7 @@ func (currentPulsar *Pulsar) generateNewEntropyAndSign() error {
 	e := currentPulsar.EntropyGenerator.GenerateEntropy()
 	currentPulsar.SetGeneratedEntropy(&e)
 
-	sign, err := currentPulsar.CryptographyService.Sign(currentPulsar.GetGeneratedEntropy()[:])
+	sign, err := currentPulsar.CryptographyService.Sign(core.EntropyCommitment(currentPulsar.ProcessingPulseNumber, e))
 	if err != nil {
 		return err
 	}
@@ -182,6 +184,20 @@ func (currentPulsar *Pulsar) SetCurrentSlotEntropy(currentSlotEntropy *core.Entr
 	currentPulsar.currentSlotEntropy = currentSlotEntropy
 }
 
+// GetCurrentSlotEntropyProof returns currentSlotEntropyProof in the thread-safe mode
+func (currentPulsar *Pulsar) GetCurrentSlotEntropyProof() []core.EntropyReveal {
+	currentPulsar.currentSlotEntropyLock.RLock()
+	defer currentPulsar.currentSlotEntropyLock.RUnlock()
+	return currentPulsar.currentSlotEntropyProof
+}
+
+// SetCurrentSlotEntropyProof sets currentSlotEntropyProof in the thread-safe mode
+func (currentPulsar *Pulsar) SetCurrentSlotEntropyProof(currentSlotEntropyProof []core.EntropyReveal) {
+	currentPulsar.currentSlotEntropyLock.Lock()
+	defer currentPulsar.currentSlotEntropyLock.Unlock()
+	currentPulsar.currentSlotEntropyProof = currentSlotEntropyProof
+}
+
 // GetGeneratedEntropy returns generatedEntropy in the thread-safe mode
 func (currentPulsar *Pulsar) GetGeneratedEntropy() *core.Entropy {
 	currentPulsar.generatedEntropyLock.RLock()
@@ -203,6 +219,9 @@ func (currentPulsar *Pulsar) CreateVectorCopy() map[string]*BftCell {
 	newMap := map[string]*BftCell{}
 
 	for key, value := range currentPulsar.ownedBftRow {
+		if value == nil {
+			continue
+		}
 		newMap[key] = &BftCell{
 			Entropy:           value.GetEntropy(),
 			IsEntropyReceived: value.GetIsEntropyReceived(),
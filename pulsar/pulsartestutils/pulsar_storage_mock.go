@@ -45,6 +45,26 @@ type PulsarStorageMock struct {
 	GetLastPulsePreCounter uint64
 	GetLastPulseMock       mPulsarStorageMockGetLastPulse
 
+	GetPulseFunc       func(p core.PulseNumber) (r *core.Pulse, r1 error)
+	GetPulseCounter    uint64
+	GetPulsePreCounter uint64
+	GetPulseMock       mPulsarStorageMockGetPulse
+
+	GetPulsesFunc       func(p core.PulseNumber, p1 core.PulseNumber, p2 int) (r []*core.Pulse, r1 error)
+	GetPulsesCounter    uint64
+	GetPulsesPreCounter uint64
+	GetPulsesMock       mPulsarStorageMockGetPulses
+
+	GetPulsesByTimeFunc       func(p time.Time, p1 time.Time, p2 int) (r []*core.Pulse, r1 error)
+	GetPulsesByTimeCounter    uint64
+	GetPulsesByTimePreCounter uint64
+	GetPulsesByTimeMock       mPulsarStorageMockGetPulsesByTime
+
+	PruneFunc       func(p time.Time, p1 int) (r int, r1 error)
+	PruneCounter    uint64
+	PrunePreCounter uint64
+	PruneMock       mPulsarStorageMockPrune
+
 	SavePulseFunc       func(p *core.Pulse) (r error)
 	SavePulseCounter    uint64
 	SavePulsePreCounter uint64
@@ -66,6 +86,10 @@ func NewPulsarStorageMock(t minimock.Tester) *PulsarStorageMock {
 
 	m.CloseMock = mPulsarStorageMockClose{mock: m}
 	m.GetLastPulseMock = mPulsarStorageMockGetLastPulse{mock: m}
+	m.GetPulseMock = mPulsarStorageMockGetPulse{mock: m}
+	m.GetPulsesMock = mPulsarStorageMockGetPulses{mock: m}
+	m.GetPulsesByTimeMock = mPulsarStorageMockGetPulsesByTime{mock: m}
+	m.PruneMock = mPulsarStorageMockPrune{mock: m}
 	m.SavePulseMock = mPulsarStorageMockSavePulse{mock: m}
 	m.SetLastPulseMock = mPulsarStorageMockSetLastPulse{mock: m}
 
@@ -156,6 +180,275 @@ func (m *PulsarStorageMock) GetLastPulseMinimockPreCounter() uint64 {
 	return atomic.LoadUint64(&m.GetLastPulsePreCounter)
 }
 
+type mPulsarStorageMockGetPulse struct {
+	mock             *PulsarStorageMock
+	mockExpectations *PulsarStorageMockGetPulseParams
+}
+
+//PulsarStorageMockGetPulseParams represents input parameters of the PulsarStorage.GetPulse
+type PulsarStorageMockGetPulseParams struct {
+	p core.PulseNumber
+}
+
+//Expect sets up expected params for the PulsarStorage.GetPulse
+func (m *mPulsarStorageMockGetPulse) Expect(p core.PulseNumber) *mPulsarStorageMockGetPulse {
+	m.mockExpectations = &PulsarStorageMockGetPulseParams{p}
+	return m
+}
+
+//Return sets up a mock for PulsarStorage.GetPulse to return Return's arguments
+func (m *mPulsarStorageMockGetPulse) Return(r *core.Pulse, r1 error) *PulsarStorageMock {
+	m.mock.GetPulseFunc = func(p core.PulseNumber) (*core.Pulse, error) {
+		return r, r1
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.GetPulse method
+func (m *mPulsarStorageMockGetPulse) Set(f func(p core.PulseNumber) (r *core.Pulse, r1 error)) *PulsarStorageMock {
+	m.mock.GetPulseFunc = f
+	m.mockExpectations = nil
+	return m.mock
+}
+
+//GetPulse implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) GetPulse(p core.PulseNumber) (r *core.Pulse, r1 error) {
+	atomic.AddUint64(&m.GetPulsePreCounter, 1)
+	defer atomic.AddUint64(&m.GetPulseCounter, 1)
+
+	if m.GetPulseMock.mockExpectations != nil {
+		testify_assert.Equal(m.t, *m.GetPulseMock.mockExpectations, PulsarStorageMockGetPulseParams{p},
+			"PulsarStorage.GetPulse got unexpected parameters")
+
+		if m.GetPulseFunc == nil {
+
+			m.t.Fatal("No results are set for the PulsarStorageMock.GetPulse")
+
+			return
+		}
+	}
+
+	if m.GetPulseFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.GetPulse")
+		return
+	}
+
+	return m.GetPulseFunc(p)
+}
+
+//GetPulseMinimockCounter returns a count of PulsarStorageMock.GetPulseFunc invocations
+func (m *PulsarStorageMock) GetPulseMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulseCounter)
+}
+
+//GetPulseMinimockPreCounter returns the value of PulsarStorageMock.GetPulse invocations
+func (m *PulsarStorageMock) GetPulseMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulsePreCounter)
+}
+
+type mPulsarStorageMockGetPulses struct {
+	mock             *PulsarStorageMock
+	mockExpectations *PulsarStorageMockGetPulsesParams
+}
+
+//PulsarStorageMockGetPulsesParams represents input parameters of the PulsarStorage.GetPulses
+type PulsarStorageMockGetPulsesParams struct {
+	p  core.PulseNumber
+	p1 core.PulseNumber
+	p2 int
+}
+
+//Expect sets up expected params for the PulsarStorage.GetPulses
+func (m *mPulsarStorageMockGetPulses) Expect(p core.PulseNumber, p1 core.PulseNumber, p2 int) *mPulsarStorageMockGetPulses {
+	m.mockExpectations = &PulsarStorageMockGetPulsesParams{p, p1, p2}
+	return m
+}
+
+//Return sets up a mock for PulsarStorage.GetPulses to return Return's arguments
+func (m *mPulsarStorageMockGetPulses) Return(r []*core.Pulse, r1 error) *PulsarStorageMock {
+	m.mock.GetPulsesFunc = func(p core.PulseNumber, p1 core.PulseNumber, p2 int) ([]*core.Pulse, error) {
+		return r, r1
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.GetPulses method
+func (m *mPulsarStorageMockGetPulses) Set(f func(p core.PulseNumber, p1 core.PulseNumber, p2 int) (r []*core.Pulse, r1 error)) *PulsarStorageMock {
+	m.mock.GetPulsesFunc = f
+	m.mockExpectations = nil
+	return m.mock
+}
+
+//GetPulses implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) GetPulses(p core.PulseNumber, p1 core.PulseNumber, p2 int) (r []*core.Pulse, r1 error) {
+	atomic.AddUint64(&m.GetPulsesPreCounter, 1)
+	defer atomic.AddUint64(&m.GetPulsesCounter, 1)
+
+	if m.GetPulsesMock.mockExpectations != nil {
+		testify_assert.Equal(m.t, *m.GetPulsesMock.mockExpectations, PulsarStorageMockGetPulsesParams{p, p1, p2},
+			"PulsarStorage.GetPulses got unexpected parameters")
+
+		if m.GetPulsesFunc == nil {
+
+			m.t.Fatal("No results are set for the PulsarStorageMock.GetPulses")
+
+			return
+		}
+	}
+
+	if m.GetPulsesFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.GetPulses")
+		return
+	}
+
+	return m.GetPulsesFunc(p, p1, p2)
+}
+
+//GetPulsesMinimockCounter returns a count of PulsarStorageMock.GetPulsesFunc invocations
+func (m *PulsarStorageMock) GetPulsesMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulsesCounter)
+}
+
+//GetPulsesMinimockPreCounter returns the value of PulsarStorageMock.GetPulses invocations
+func (m *PulsarStorageMock) GetPulsesMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulsesPreCounter)
+}
+
+type mPulsarStorageMockGetPulsesByTime struct {
+	mock             *PulsarStorageMock
+	mockExpectations *PulsarStorageMockGetPulsesByTimeParams
+}
+
+//PulsarStorageMockGetPulsesByTimeParams represents input parameters of the PulsarStorage.GetPulsesByTime
+type PulsarStorageMockGetPulsesByTimeParams struct {
+	p  time.Time
+	p1 time.Time
+	p2 int
+}
+
+//Expect sets up expected params for the PulsarStorage.GetPulsesByTime
+func (m *mPulsarStorageMockGetPulsesByTime) Expect(p time.Time, p1 time.Time, p2 int) *mPulsarStorageMockGetPulsesByTime {
+	m.mockExpectations = &PulsarStorageMockGetPulsesByTimeParams{p, p1, p2}
+	return m
+}
+
+//Return sets up a mock for PulsarStorage.GetPulsesByTime to return Return's arguments
+func (m *mPulsarStorageMockGetPulsesByTime) Return(r []*core.Pulse, r1 error) *PulsarStorageMock {
+	m.mock.GetPulsesByTimeFunc = func(p time.Time, p1 time.Time, p2 int) ([]*core.Pulse, error) {
+		return r, r1
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.GetPulsesByTime method
+func (m *mPulsarStorageMockGetPulsesByTime) Set(f func(p time.Time, p1 time.Time, p2 int) (r []*core.Pulse, r1 error)) *PulsarStorageMock {
+	m.mock.GetPulsesByTimeFunc = f
+	m.mockExpectations = nil
+	return m.mock
+}
+
+//GetPulsesByTime implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) GetPulsesByTime(p time.Time, p1 time.Time, p2 int) (r []*core.Pulse, r1 error) {
+	atomic.AddUint64(&m.GetPulsesByTimePreCounter, 1)
+	defer atomic.AddUint64(&m.GetPulsesByTimeCounter, 1)
+
+	if m.GetPulsesByTimeMock.mockExpectations != nil {
+		testify_assert.Equal(m.t, *m.GetPulsesByTimeMock.mockExpectations, PulsarStorageMockGetPulsesByTimeParams{p, p1, p2},
+			"PulsarStorage.GetPulsesByTime got unexpected parameters")
+
+		if m.GetPulsesByTimeFunc == nil {
+
+			m.t.Fatal("No results are set for the PulsarStorageMock.GetPulsesByTime")
+
+			return
+		}
+	}
+
+	if m.GetPulsesByTimeFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.GetPulsesByTime")
+		return
+	}
+
+	return m.GetPulsesByTimeFunc(p, p1, p2)
+}
+
+//GetPulsesByTimeMinimockCounter returns a count of PulsarStorageMock.GetPulsesByTimeFunc invocations
+func (m *PulsarStorageMock) GetPulsesByTimeMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulsesByTimeCounter)
+}
+
+//GetPulsesByTimeMinimockPreCounter returns the value of PulsarStorageMock.GetPulsesByTime invocations
+func (m *PulsarStorageMock) GetPulsesByTimeMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulsesByTimePreCounter)
+}
+
+type mPulsarStorageMockPrune struct {
+	mock             *PulsarStorageMock
+	mockExpectations *PulsarStorageMockPruneParams
+}
+
+//PulsarStorageMockPruneParams represents input parameters of the PulsarStorage.Prune
+type PulsarStorageMockPruneParams struct {
+	p  time.Time
+	p1 int
+}
+
+//Expect sets up expected params for the PulsarStorage.Prune
+func (m *mPulsarStorageMockPrune) Expect(p time.Time, p1 int) *mPulsarStorageMockPrune {
+	m.mockExpectations = &PulsarStorageMockPruneParams{p, p1}
+	return m
+}
+
+//Return sets up a mock for PulsarStorage.Prune to return Return's arguments
+func (m *mPulsarStorageMockPrune) Return(r int, r1 error) *PulsarStorageMock {
+	m.mock.PruneFunc = func(p time.Time, p1 int) (int, error) {
+		return r, r1
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.Prune method
+func (m *mPulsarStorageMockPrune) Set(f func(p time.Time, p1 int) (r int, r1 error)) *PulsarStorageMock {
+	m.mock.PruneFunc = f
+	m.mockExpectations = nil
+	return m.mock
+}
+
+//Prune implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) Prune(p time.Time, p1 int) (r int, r1 error) {
+	atomic.AddUint64(&m.PrunePreCounter, 1)
+	defer atomic.AddUint64(&m.PruneCounter, 1)
+
+	if m.PruneMock.mockExpectations != nil {
+		testify_assert.Equal(m.t, *m.PruneMock.mockExpectations, PulsarStorageMockPruneParams{p, p1},
+			"PulsarStorage.Prune got unexpected parameters")
+
+		if m.PruneFunc == nil {
+
+			m.t.Fatal("No results are set for the PulsarStorageMock.Prune")
+
+			return
+		}
+	}
+
+	if m.PruneFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.Prune")
+		return
+	}
+
+	return m.PruneFunc(p, p1)
+}
+
+//PruneMinimockCounter returns a count of PulsarStorageMock.PruneFunc invocations
+func (m *PulsarStorageMock) PruneMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.PruneCounter)
+}
+
+//PruneMinimockPreCounter returns the value of PulsarStorageMock.Prune invocations
+func (m *PulsarStorageMock) PruneMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.PrunePreCounter)
+}
+
 type mPulsarStorageMockSavePulse struct {
 	mock             *PulsarStorageMock
 	mockExpectations *PulsarStorageMockSavePulseParams
@@ -300,6 +593,22 @@ func (m *PulsarStorageMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to PulsarStorageMock.GetLastPulse")
 	}
 
+	if m.GetPulseFunc != nil && atomic.LoadUint64(&m.GetPulseCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulse")
+	}
+
+	if m.GetPulsesFunc != nil && atomic.LoadUint64(&m.GetPulsesCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulses")
+	}
+
+	if m.GetPulsesByTimeFunc != nil && atomic.LoadUint64(&m.GetPulsesByTimeCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulsesByTime")
+	}
+
+	if m.PruneFunc != nil && atomic.LoadUint64(&m.PruneCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.Prune")
+	}
+
 	if m.SavePulseFunc != nil && atomic.LoadUint64(&m.SavePulseCounter) == 0 {
 		m.t.Fatal("Expected call to PulsarStorageMock.SavePulse")
 	}
@@ -334,6 +643,22 @@ func (m *PulsarStorageMock) MinimockFinish() {
 		m.t.Fatal("Expected call to PulsarStorageMock.GetLastPulse")
 	}
 
+	if m.GetPulseFunc != nil && atomic.LoadUint64(&m.GetPulseCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulse")
+	}
+
+	if m.GetPulsesFunc != nil && atomic.LoadUint64(&m.GetPulsesCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulses")
+	}
+
+	if m.GetPulsesByTimeFunc != nil && atomic.LoadUint64(&m.GetPulsesByTimeCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulsesByTime")
+	}
+
+	if m.PruneFunc != nil && atomic.LoadUint64(&m.PruneCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.Prune")
+	}
+
 	if m.SavePulseFunc != nil && atomic.LoadUint64(&m.SavePulseCounter) == 0 {
 		m.t.Fatal("Expected call to PulsarStorageMock.SavePulse")
 	}
@@ -358,6 +683,10 @@ func (m *PulsarStorageMock) MinimockWait(timeout time.Duration) {
 		ok := true
 		ok = ok && (m.CloseFunc == nil || atomic.LoadUint64(&m.CloseCounter) > 0)
 		ok = ok && (m.GetLastPulseFunc == nil || atomic.LoadUint64(&m.GetLastPulseCounter) > 0)
+		ok = ok && (m.GetPulseFunc == nil || atomic.LoadUint64(&m.GetPulseCounter) > 0)
+		ok = ok && (m.GetPulsesFunc == nil || atomic.LoadUint64(&m.GetPulsesCounter) > 0)
+		ok = ok && (m.GetPulsesByTimeFunc == nil || atomic.LoadUint64(&m.GetPulsesByTimeCounter) > 0)
+		ok = ok && (m.PruneFunc == nil || atomic.LoadUint64(&m.PruneCounter) > 0)
 		ok = ok && (m.SavePulseFunc == nil || atomic.LoadUint64(&m.SavePulseCounter) > 0)
 		ok = ok && (m.SetLastPulseFunc == nil || atomic.LoadUint64(&m.SetLastPulseCounter) > 0)
 
@@ -376,6 +705,22 @@ func (m *PulsarStorageMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to PulsarStorageMock.GetLastPulse")
 			}
 
+			if m.GetPulseFunc != nil && atomic.LoadUint64(&m.GetPulseCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.GetPulse")
+			}
+
+			if m.GetPulsesFunc != nil && atomic.LoadUint64(&m.GetPulsesCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.GetPulses")
+			}
+
+			if m.GetPulsesByTimeFunc != nil && atomic.LoadUint64(&m.GetPulsesByTimeCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.GetPulsesByTime")
+			}
+
+			if m.PruneFunc != nil && atomic.LoadUint64(&m.PruneCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.Prune")
+			}
+
 			if m.SavePulseFunc != nil && atomic.LoadUint64(&m.SavePulseCounter) == 0 {
 				m.t.Error("Expected call to PulsarStorageMock.SavePulse")
 			}
@@ -404,6 +749,22 @@ func (m *PulsarStorageMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if m.GetPulseFunc != nil && atomic.LoadUint64(&m.GetPulseCounter) == 0 {
+		return false
+	}
+
+	if m.GetPulsesFunc != nil && atomic.LoadUint64(&m.GetPulsesCounter) == 0 {
+		return false
+	}
+
+	if m.GetPulsesByTimeFunc != nil && atomic.LoadUint64(&m.GetPulsesByTimeCounter) == 0 {
+		return false
+	}
+
+	if m.PruneFunc != nil && atomic.LoadUint64(&m.PruneCounter) == 0 {
+		return false
+	}
+
 	if m.SavePulseFunc != nil && atomic.LoadUint64(&m.SavePulseCounter) == 0 {
 		return false
 	}
@@ -0,0 +1,471 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package simulator runs cluster of pulsars in one process over in-memory transport.
+//
+// Simulated pulsars are regular pulsar.Pulsar instances driven by pulsar.StateSwitcherImpl, only rpc-calls between
+// them are replaced. Transport copies every message as net/rpc does, delays it and may lose it. Byzantine pulsars
+// tamper with their own messages. Usage:
+//
+//	sim, err := simulator.New(simulator.Config{Pulsars: 4, Faults: map[int]simulator.Fault{3: simulator.WithholdingEntropy}})
+//	result, err := sim.Round(ctx)
+//	err = result.CheckAgreement()
+//	sim.Stop(ctx)
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net/rpc"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/pulsar"
+	"github.com/insolar/insolar/pulsar/entropygenerator"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultPhaseTimeout = 100
+	numberDelta         = 10
+)
+
+// Fault is a byzantine behavior of simulated pulsar.
+type Fault int
+
+const (
+	// Honest pulsar follows the protocol.
+	Honest Fault = iota
+
+	// EquivocatingEntropy pulsar commits to and reveals different entropies to different neighbours.
+	EquivocatingEntropy
+
+	// WithholdingEntropy pulsar commits to entropy but never reveals it.
+	WithholdingEntropy
+)
+
+// Config describes simulated cluster.
+type Config struct {
+	// Pulsars is a number of pulsars in cluster.
+	Pulsars int
+	// Faults maps indexes of byzantine pulsars to their behavior.
+	Faults map[int]Fault
+
+	// Latency is a delay of every message, random delay up to Jitter is added to it.
+	Latency time.Duration
+	Jitter  time.Duration
+	// Loss is a probability of losing message.
+	Loss float64
+	// Seed selects delays and lost messages.
+	Seed int64
+
+	// PhaseTimeout is a timeout of every consensus phase in milliseconds, 100 by default.
+	PhaseTimeout int32
+}
+
+type node struct {
+	index   int
+	address string
+	fault   Fault
+
+	publicKey   string
+	service     core.CryptographyService
+	pulsar      *pulsar.Pulsar
+	handler     *pulsar.Handler
+	storage     *memoryStorage
+	distributor *distributor
+}
+
+type linkKey struct {
+	from, to int
+	method   string
+}
+
+type equivocationKey struct {
+	from, to    int
+	pulseNumber core.PulseNumber
+}
+
+type equivocation struct {
+	entropy core.Entropy
+	sign    []byte
+}
+
+// Simulator is a cluster of pulsars connected by in-memory transport.
+type Simulator struct {
+	config    Config
+	scheme    core.PlatformCryptographyScheme
+	nodes     []*node
+	byAddress map[string]*node
+
+	pulseNumber core.PulseNumber
+	inFlight    int64
+
+	countersLock sync.Mutex
+	counters     map[linkKey]int
+
+	equivocationsLock sync.Mutex
+	equivocations     map[equivocationKey]equivocation
+}
+
+// RoundResult is an outcome of one consensus round.
+type RoundResult struct {
+	PulseNumber core.PulseNumber
+	// Pulses contains last pulse of every pulsar, nil if pulsar hasn't got pulse of the round.
+	Pulses []*core.Pulse
+	// Distributed contains pulses sent to network by honest pulsars.
+	Distributed []core.Pulse
+
+	honest []bool
+}
+
+// New creates cluster of pulsars and connects them to each other.
+func New(config Config) (*Simulator, error) {
+	if config.Pulsars <= 0 {
+		return nil, errors.New("[ New ] at least one pulsar is required")
+	}
+	if config.Loss < 0 || config.Loss >= 1 {
+		return nil, errors.Errorf("[ New ] loss %v is not in [0, 1) range", config.Loss)
+	}
+	for index := range config.Faults {
+		if index < 0 || index >= config.Pulsars {
+			return nil, errors.Errorf("[ New ] fault for unknown pulsar %v", index)
+		}
+	}
+	if config.PhaseTimeout == 0 {
+		config.PhaseTimeout = defaultPhaseTimeout
+	}
+
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	s := &Simulator{
+		config:        config,
+		scheme:        platformpolicy.NewPlatformCryptographyScheme(),
+		byAddress:     map[string]*node{},
+		pulseNumber:   core.GenesisPulse.PulseNumber,
+		counters:      map[linkKey]int{},
+		equivocations: map[equivocationKey]equivocation{},
+	}
+
+	for i := 0; i < config.Pulsars; i++ {
+		privateKey, err := keyProcessor.GeneratePrivateKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "[ New ] failed to generate key")
+		}
+		publicKey, err := keyProcessor.ExportPublicKeyPEM(keyProcessor.ExtractPublicKey(privateKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "[ New ] failed to export key")
+		}
+
+		n := &node{
+			index:       i,
+			address:     fmt.Sprintf("pulsar-%d", i),
+			fault:       config.Faults[i],
+			publicKey:   string(publicKey),
+			service:     cryptography.NewKeyBoundCryptographyService(privateKey),
+			storage:     newMemoryStorage(),
+			distributor: &distributor{},
+		}
+		s.nodes = append(s.nodes, n)
+		s.byAddress[n.address] = n
+	}
+
+	for _, n := range s.nodes {
+		conf := configuration.Pulsar{
+			ConnectionType:                 configuration.TCP,
+			MainListenerAddress:            n.address,
+			NumberDelta:                    numberDelta,
+			ReceivingSignTimeout:           config.PhaseTimeout,
+			ReceivingNumberTimeout:         config.PhaseTimeout,
+			ReceivingVectorTimeout:         config.PhaseTimeout,
+			ReceivingSignsForChosenTimeout: config.PhaseTimeout,
+		}
+		for _, neighbour := range s.nodes {
+			if neighbour != n {
+				conf.Neighbours = append(conf.Neighbours, configuration.PulsarNodeAddress{
+					ConnectionType: configuration.TCP,
+					Address:        neighbour.address,
+					PublicKey:      neighbour.publicKey,
+				})
+			}
+		}
+
+		switcher := &pulsar.StateSwitcherImpl{}
+		p, err := pulsar.NewPulsar(
+			conf,
+			n.service,
+			s.scheme,
+			keyProcessor,
+			n.distributor,
+			n.storage,
+			&wrapperFactory{simulator: s, from: n},
+			&entropygenerator.StandardEntropyGenerator{},
+			switcher,
+			newListener,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ New ] failed to create pulsar")
+		}
+		switcher.SetPulsar(p)
+		n.pulsar = p
+		n.handler = pulsar.NewHandler(p)
+	}
+
+	return s, nil
+}
+
+// Pulsar returns simulated pulsar by index.
+func (s *Simulator) Pulsar(index int) *pulsar.Pulsar {
+	return s.nodes[index].pulsar
+}
+
+// PublicKeys returns public keys of pulsars in PEM format.
+func (s *Simulator) PublicKeys() []string {
+	keys := make([]string, 0, len(s.nodes))
+	for _, n := range s.nodes {
+		keys = append(keys, n.publicKey)
+	}
+	return keys
+}
+
+// Round starts consensus for the next pulse on every pulsar and waits until pulsars finish it.
+func (s *Simulator) Round(ctx context.Context) (*RoundResult, error) {
+	s.pulseNumber += numberDelta
+	pulseNumber := s.pulseNumber
+
+	for _, n := range s.nodes {
+		n.pulsar.CheckConnectionsToPulsars(ctx)
+	}
+
+	wg := sync.WaitGroup{}
+	for _, n := range s.nodes {
+		wg.Add(1)
+		go func(n *node) {
+			defer wg.Done()
+			err := n.pulsar.StartConsensusProcess(ctx, pulseNumber)
+			if err != nil {
+				inslogger.FromContext(ctx).Warnf("[ Round ] %v failed to start consensus: %v", n.address, err)
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	deadline := time.Now().Add(s.roundTimeout())
+	for !s.isSettled(pulseNumber, true) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Timers of failed pulsars may still be running, wait for them.
+	time.Sleep(time.Duration(s.config.PhaseTimeout) * time.Millisecond)
+	if !s.isSettled(pulseNumber, false) {
+		return nil, errors.Errorf("[ Round ] pulsars haven't finished pulse %v: %v", pulseNumber, s.states())
+	}
+
+	result := &RoundResult{PulseNumber: pulseNumber}
+	for _, n := range s.nodes {
+		honest := n.fault == Honest
+		result.honest = append(result.honest, honest)
+
+		last := n.pulsar.GetLastPulse()
+		if last.PulseNumber != pulseNumber {
+			last = nil
+		}
+		result.Pulses = append(result.Pulses, last)
+
+		if honest {
+			result.Distributed = append(result.Distributed, n.distributor.distributed(pulseNumber)...)
+		}
+	}
+	return result, nil
+}
+
+// Stop stops all pulsars.
+func (s *Simulator) Stop(ctx context.Context) {
+	for _, n := range s.nodes {
+		n.pulsar.StopServer(ctx)
+	}
+}
+
+// CheckAgreement checks that honest pulsars and pulses sent to network have the same entropy
+// and that entropy is combined from published proof.
+func (r *RoundResult) CheckAgreement() error {
+	var agreed *core.Entropy
+	check := func(pulse core.Pulse) error {
+		if pulse.Entropy != core.CombineEntropy(pulse.EntropyProof) {
+			return errors.Errorf("[ CheckAgreement ] entropy of pulse %v doesn't match proof", pulse.PulseNumber)
+		}
+		if agreed == nil {
+			agreed = &pulse.Entropy
+			return nil
+		}
+		if *agreed != pulse.Entropy {
+			return errors.Errorf("[ CheckAgreement ] pulsars disagree on entropy of pulse %v", pulse.PulseNumber)
+		}
+		return nil
+	}
+
+	for i, pulse := range r.Pulses {
+		if pulse == nil || !r.honest[i] {
+			continue
+		}
+		if err := check(*pulse); err != nil {
+			return err
+		}
+	}
+	for _, pulse := range r.Distributed {
+		if err := check(pulse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Simulator) roundTimeout() time.Duration {
+	broadcast := time.Duration(len(s.nodes)) * (s.config.Latency + s.config.Jitter)
+	return 4*time.Duration(s.config.PhaseTimeout)*time.Millisecond + 5*broadcast + time.Second
+}
+
+func (s *Simulator) isSettled(pulseNumber core.PulseNumber, withPulse bool) bool {
+	if atomic.LoadInt64(&s.inFlight) != 0 {
+		return false
+	}
+	for _, n := range s.nodes {
+		if n.pulsar.StateSwitcher.GetState() != pulsar.WaitingForStart {
+			return false
+		}
+		if withPulse && n.pulsar.GetLastPulse().PulseNumber != pulseNumber {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Simulator) states() map[string]string {
+	states := map[string]string{}
+	for _, n := range s.nodes {
+		states[n.address] = n.pulsar.StateSwitcher.GetState().String()
+	}
+	return states
+}
+
+// deliver simulates network and byzantine behavior of sender, then calls handler of receiver.
+func (s *Simulator) deliver(from, to *node, serviceMethod string, request *pulsar.Payload, reply interface{}) error {
+	s.countersLock.Lock()
+	key := linkKey{from: from.index, to: to.index, method: serviceMethod}
+	sequence := s.counters[key]
+	s.counters[key]++
+	s.countersLock.Unlock()
+
+	delay := s.config.Latency + time.Duration(s.random(key, sequence, "latency")*float64(s.config.Jitter))
+	time.Sleep(delay)
+	if s.random(key, sequence, "loss") < s.config.Loss {
+		return errMessageLost
+	}
+
+	request, send, err := s.tamper(from, to, serviceMethod, request)
+	if err != nil {
+		return err
+	}
+	if !send {
+		return nil
+	}
+
+	response := &pulsar.Payload{}
+	err = serve(to.handler, serviceMethod, request, response)
+	if err != nil {
+		return rpc.ServerError(err.Error())
+	}
+	if reply == nil {
+		return nil
+	}
+	copied, err := copyPayload(response)
+	if err != nil {
+		return err
+	}
+	*reply.(*pulsar.Payload) = *copied
+	return nil
+}
+
+// random returns number in [0, 1) range defined by seed and message.
+func (s *Simulator) random(key linkKey, sequence int, purpose string) float64 {
+	hash := fnv.New64a()
+	_, _ = fmt.Fprintf(hash, "%d/%d/%d/%s/%d/%s", s.config.Seed, key.from, key.to, key.method, sequence, purpose)
+	return float64(hash.Sum64()>>11) / float64(1<<53)
+}
+
+func (s *Simulator) tamper(from, to *node, serviceMethod string, request *pulsar.Payload) (*pulsar.Payload, bool, error) {
+	if request == nil {
+		return request, true, nil
+	}
+
+	switch from.fault {
+	case WithholdingEntropy:
+		return request, serviceMethod != pulsar.ReceiveEntropy.String(), nil
+	case EquivocatingEntropy:
+		switch body := request.Body.(type) {
+		case *pulsar.EntropySignaturePayload:
+			e, err := s.equivocate(from, to, body.PulseNumber)
+			if err != nil {
+				return nil, false, err
+			}
+			body.EntropySignature = e.sign
+		case *pulsar.EntropyPayload:
+			e, err := s.equivocate(from, to, body.PulseNumber)
+			if err != nil {
+				return nil, false, err
+			}
+			body.Entropy = e.entropy
+		default:
+			return request, true, nil
+		}
+
+		hash, err := request.Body.Hash(s.scheme.IntegrityHasher())
+		if err != nil {
+			return nil, false, err
+		}
+		sign, err := from.service.Sign(hash)
+		if err != nil {
+			return nil, false, err
+		}
+		request.Signature = sign.Bytes()
+	}
+	return request, true, nil
+}
+
+// equivocate returns entropy that byzantine pulsar uses for the receiver and its commitment.
+func (s *Simulator) equivocate(from, to *node, pulseNumber core.PulseNumber) (equivocation, error) {
+	s.equivocationsLock.Lock()
+	defer s.equivocationsLock.Unlock()
+
+	key := equivocationKey{from: from.index, to: to.index, pulseNumber: pulseNumber}
+	if e, ok := s.equivocations[key]; ok {
+		return e, nil
+	}
+
+	entropy := (&entropygenerator.StandardEntropyGenerator{}).GenerateEntropy()
+	sign, err := from.service.Sign(core.EntropyCommitment(pulseNumber, entropy))
+	if err != nil {
+		return equivocation{}, errors.Wrap(err, "[ equivocate ] failed to sign entropy")
+	}
+	e := equivocation{entropy: entropy, sign: sign.Bytes()}
+	s.equivocations[key] = e
+	return e, nil
+}
@@ -0,0 +1,134 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/stretchr/testify/require"
+)
+
+func runRounds(t *testing.T, config Config, rounds int) []*RoundResult {
+	ctx := inslogger.TestContext(t)
+	sim, err := New(config)
+	require.NoError(t, err)
+	defer sim.Stop(ctx)
+
+	var results []*RoundResult
+	for i := 0; i < rounds; i++ {
+		result, err := sim.Round(ctx)
+		require.NoError(t, err)
+		require.NoError(t, result.CheckAgreement())
+		for _, pulse := range result.Distributed {
+			_, err := foundation.VerifyPulseEntropy(pulse, sim.PublicKeys(), 1)
+			require.NoError(t, err)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func requireAllHonestGotPulse(t *testing.T, result *RoundResult) {
+	for i, pulse := range result.Pulses {
+		if result.honest[i] {
+			require.NotNil(t, pulse, "pulsar %v hasn't got pulse %v", i, result.PulseNumber)
+		}
+	}
+}
+
+func TestSimulator_Standalone(t *testing.T) {
+	results := runRounds(t, Config{Pulsars: 1}, 2)
+
+	for _, result := range results {
+		requireAllHonestGotPulse(t, result)
+		require.Len(t, result.Pulses[0].EntropyProof, 1)
+	}
+	require.Equal(t, results[0].PulseNumber+numberDelta, results[1].PulseNumber)
+}
+
+func TestSimulator_HonestCluster(t *testing.T) {
+	results := runRounds(t, Config{Pulsars: 4, Latency: time.Millisecond, Jitter: 2 * time.Millisecond}, 3)
+
+	for _, result := range results {
+		requireAllHonestGotPulse(t, result)
+		require.Len(t, result.Distributed, 1)
+		require.Len(t, result.Distributed[0].EntropyProof, 4)
+		require.Len(t, result.Distributed[0].Signs, 4)
+	}
+}
+
+func TestSimulator_EquivocatingEntropy(t *testing.T) {
+	results := runRounds(t, Config{Pulsars: 4, Faults: map[int]Fault{3: EquivocatingEntropy}}, 2)
+
+	for _, result := range results {
+		requireAllHonestGotPulse(t, result)
+		require.Len(t, result.Pulses[0].EntropyProof, 3)
+	}
+}
+
+func TestSimulator_WithholdingEntropy(t *testing.T) {
+	results := runRounds(t, Config{Pulsars: 4, Faults: map[int]Fault{0: WithholdingEntropy}}, 2)
+
+	for _, result := range results {
+		requireAllHonestGotPulse(t, result)
+		require.Len(t, result.Pulses[1].EntropyProof, 3)
+	}
+}
+
+func TestSimulator_MessageLoss(t *testing.T) {
+	results := runRounds(t, Config{Pulsars: 4, Latency: time.Millisecond, Loss: 0.02, Seed: 42}, 3)
+
+	produced := 0
+	for _, result := range results {
+		for _, pulse := range result.Pulses {
+			if pulse != nil {
+				produced++
+			}
+		}
+	}
+	require.NotZero(t, produced)
+}
+
+func TestNew_WrongConfig(t *testing.T) {
+	_, err := New(Config{})
+	require.Error(t, err)
+
+	_, err = New(Config{Pulsars: 2, Loss: 1})
+	require.Error(t, err)
+
+	_, err = New(Config{Pulsars: 2, Faults: map[int]Fault{2: WithholdingEntropy}})
+	require.Error(t, err)
+}
+
+func TestRoundResult_CheckAgreement(t *testing.T) {
+	proof := []core.EntropyReveal{{Entropy: core.Entropy{1}}}
+	pulse := &core.Pulse{PulseNumber: 100, Entropy: core.CombineEntropy(proof), EntropyProof: proof}
+	other := &core.Pulse{PulseNumber: 100, Entropy: core.Entropy{2}, EntropyProof: []core.EntropyReveal{{Entropy: core.Entropy{2}}}}
+
+	result := &RoundResult{PulseNumber: 100, Pulses: []*core.Pulse{pulse, nil, other}, honest: []bool{true, true, false}}
+	require.NoError(t, result.CheckAgreement())
+
+	result.honest[2] = true
+	require.Error(t, result.CheckAgreement())
+
+	result.Pulses[2] = &core.Pulse{PulseNumber: 100, Entropy: core.Entropy{1}}
+	require.Error(t, result.CheckAgreement())
+}
@@ -0,0 +1,148 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package simulator
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/core"
+	pulsarstorage "github.com/insolar/insolar/pulsar/storage"
+)
+
+// memoryStorage is an in-memory implementation of pulsarstorage.PulsarStorage.
+type memoryStorage struct {
+	lock   sync.RWMutex
+	last   *core.Pulse
+	pulses map[core.PulseNumber]*core.Pulse
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{
+		last:   core.GenesisPulse,
+		pulses: map[core.PulseNumber]*core.Pulse{core.GenesisPulse.PulseNumber: core.GenesisPulse},
+	}
+}
+
+func (s *memoryStorage) GetLastPulse() (*core.Pulse, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.last, nil
+}
+
+func (s *memoryStorage) SetLastPulse(pulse *core.Pulse) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.last = pulse
+	return nil
+}
+
+func (s *memoryStorage) SavePulse(pulse *core.Pulse) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pulses[pulse.PulseNumber] = pulse
+	return nil
+}
+
+func (s *memoryStorage) GetPulse(pulseNumber core.PulseNumber) (*core.Pulse, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	pulse, ok := s.pulses[pulseNumber]
+	if !ok {
+		return nil, pulsarstorage.ErrNotFound
+	}
+	return pulse, nil
+}
+
+func (s *memoryStorage) GetPulses(from, to core.PulseNumber, limit int) ([]*core.Pulse, error) {
+	return s.filter(func(pulse *core.Pulse) bool {
+		return pulse.PulseNumber >= from && pulse.PulseNumber <= to
+	}, limit), nil
+}
+
+func (s *memoryStorage) GetPulsesByTime(from, to time.Time, limit int) ([]*core.Pulse, error) {
+	return s.filter(func(pulse *core.Pulse) bool {
+		return pulse.PulseTimestamp >= from.Unix() && pulse.PulseTimestamp < to.Unix()
+	}, limit), nil
+}
+
+func (s *memoryStorage) Prune(before time.Time, keep int) (int, error) {
+	all := s.filter(func(*core.Pulse) bool { return true }, 0)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	removed := 0
+	for i, pulse := range all {
+		old := !before.IsZero() && pulse.PulseTimestamp < before.Unix()
+		extra := keep > 0 && i < len(all)-keep
+		if (old || extra) && pulse.PulseNumber != s.last.PulseNumber {
+			delete(s.pulses, pulse.PulseNumber)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (s *memoryStorage) Close() error {
+	return nil
+}
+
+func (s *memoryStorage) filter(match func(*core.Pulse) bool, limit int) []*core.Pulse {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var result []*core.Pulse
+	for _, pulse := range s.pulses {
+		if match(pulse) {
+			result = append(result, pulse)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PulseNumber < result[j].PulseNumber
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// distributor records pulses sent by pulsar to the network.
+type distributor struct {
+	lock   sync.Mutex
+	pulses []core.Pulse
+}
+
+// Distribute records pulse.
+func (d *distributor) Distribute(ctx context.Context, pulse core.Pulse) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.pulses = append(d.pulses, pulse)
+}
+
+func (d *distributor) distributed(pulseNumber core.PulseNumber) []core.Pulse {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	var result []core.Pulse
+	for _, pulse := range d.pulses {
+		if pulse.PulseNumber == pulseNumber {
+			result = append(result, pulse)
+		}
+	}
+	return result
+}
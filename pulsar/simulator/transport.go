@@ -0,0 +1,198 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package simulator
+
+import (
+	"bytes"
+	"encoding/gob"
+	"net"
+	"net/rpc"
+	"sync"
+	"sync/atomic"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/pulsar"
+	"github.com/pkg/errors"
+)
+
+var (
+	errMessageLost    = errors.New("message lost")
+	errNotConnected   = errors.New("connection is not established")
+	errListenerClosed = errors.New("listener is closed")
+)
+
+type wrapperFactory struct {
+	simulator *Simulator
+	from      *node
+}
+
+// CreateWrapper returns client of the in-memory transport.
+func (factory *wrapperFactory) CreateWrapper() pulsar.RPCClientWrapper {
+	return &clientWrapper{simulator: factory.simulator, from: factory.from}
+}
+
+// clientWrapper implements pulsar.RPCClientWrapper by calling handlers of other simulated pulsars directly.
+type clientWrapper struct {
+	sync.Mutex
+	simulator *Simulator
+	from      *node
+
+	targetLock sync.RWMutex
+	target     *node
+}
+
+// IsInitialised checks if connection is established.
+func (client *clientWrapper) IsInitialised() bool {
+	client.targetLock.RLock()
+	defer client.targetLock.RUnlock()
+	return client.target != nil
+}
+
+// CreateConnection connects client to simulated pulsar with provided address.
+func (client *clientWrapper) CreateConnection(connectionType configuration.ConnectionType, connectionAddress string) error {
+	target, ok := client.simulator.byAddress[connectionAddress]
+	if !ok {
+		return errors.Errorf("unknown address %v", connectionAddress)
+	}
+	client.targetLock.Lock()
+	client.target = target
+	client.targetLock.Unlock()
+	return nil
+}
+
+// Close closes connection.
+func (client *clientWrapper) Close() error {
+	client.ResetClient()
+	return nil
+}
+
+// ResetClient clears connection.
+func (client *clientWrapper) ResetClient() {
+	client.targetLock.Lock()
+	client.target = nil
+	client.targetLock.Unlock()
+}
+
+// Go delivers copy of args to the handler of connected pulsar after simulated latency.
+func (client *clientWrapper) Go(serviceMethod string, args interface{}, reply interface{}, done chan *rpc.Call) *rpc.Call {
+	if done == nil {
+		done = make(chan *rpc.Call, 1)
+	}
+	call := &rpc.Call{ServiceMethod: serviceMethod, Args: args, Reply: reply, Done: done}
+
+	client.targetLock.RLock()
+	target := client.target
+	client.targetLock.RUnlock()
+	if target == nil {
+		call.Error = errNotConnected
+		call.Done <- call
+		return call
+	}
+
+	request, err := copyPayload(args)
+	if err != nil {
+		call.Error = err
+		call.Done <- call
+		return call
+	}
+
+	atomic.AddInt64(&client.simulator.inFlight, 1)
+	go func() {
+		defer atomic.AddInt64(&client.simulator.inFlight, -1)
+		call.Error = client.simulator.deliver(client.from, target, serviceMethod, request, reply)
+		call.Done <- call
+	}()
+	return call
+}
+
+func copyPayload(payload interface{}) (*pulsar.Payload, error) {
+	if payload == nil {
+		return nil, nil
+	}
+
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ copyPayload ] failed to encode payload")
+	}
+	result := &pulsar.Payload{}
+	err = gob.NewDecoder(&buffer).Decode(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ copyPayload ] failed to decode payload")
+	}
+	return result, nil
+}
+
+func serve(handler *pulsar.Handler, serviceMethod string, request *pulsar.Payload, response *pulsar.Payload) error {
+	switch pulsar.RequestType(serviceMethod) {
+	case pulsar.HealthCheck:
+		return handler.HealthCheck(request, response)
+	case pulsar.Handshake:
+		return handler.MakeHandshake(request, response)
+	case pulsar.ReceiveSignatureForEntropy:
+		return handler.ReceiveSignatureForEntropy(request, response)
+	case pulsar.ReceiveEntropy:
+		return handler.ReceiveEntropy(request, response)
+	case pulsar.ReceiveVector:
+		return handler.ReceiveVector(request, response)
+	case pulsar.ReceiveChosenSignature:
+		return handler.ReceiveChosenSignature(request, response)
+	case pulsar.ReceivePulse:
+		return handler.ReceivePulse(request, response)
+	}
+	return errors.Errorf("rpc: can't find method %v", serviceMethod)
+}
+
+// listener is a stub of the pulsar's rpc listener, simulated pulsars don't accept connections.
+type listener struct {
+	address   string
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newListener(network, address string) (net.Listener, error) {
+	return &listener{address: address, closed: make(chan struct{})}, nil
+}
+
+// Accept blocks until listener is closed.
+func (l *listener) Accept() (net.Conn, error) {
+	<-l.closed
+	return nil, errListenerClosed
+}
+
+// Close closes listener.
+func (l *listener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
+
+// Addr returns address of simulated pulsar.
+func (l *listener) Addr() net.Addr {
+	return address(l.address)
+}
+
+type address string
+
+func (a address) Network() string {
+	return "simulator"
+}
+
+func (a address) String() string {
+	return string(a)
+}
@@ -17,12 +17,29 @@
 package pulsarstorage
 
 import (
+	"time"
+
 	"github.com/insolar/insolar/core"
+	"github.com/pkg/errors"
 )
 
+// ErrNotFound is returned when requested pulse is not saved.
+var ErrNotFound = errors.New("pulse not found")
+
 type PulsarStorage interface {
 	GetLastPulse() (*core.Pulse, error)
 	SetLastPulse(pulse *core.Pulse) error
 	SavePulse(pulse *core.Pulse) error
+	// GetPulse returns saved pulse with provided number.
+	GetPulse(pulseNumber core.PulseNumber) (*core.Pulse, error)
+	// GetPulses returns at most limit saved pulses with numbers in [from, to] range in ascending order.
+	// Zero limit means no limit.
+	GetPulses(from, to core.PulseNumber, limit int) ([]*core.Pulse, error)
+	// GetPulsesByTime returns at most limit saved pulses with timestamps in [from, to) range in ascending order.
+	// Zero limit means no limit.
+	GetPulsesByTime(from, to time.Time, limit int) ([]*core.Pulse, error)
+	// Prune removes saved pulses older than before or not in keep latest pulses and returns number of removed pulses.
+	// Zero before and keep disable corresponding limits. The last pulse is never removed.
+	Prune(before time.Time, keep int) (int, error)
 	Close() error
 }
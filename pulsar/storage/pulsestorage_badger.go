@@ -19,7 +19,9 @@ package pulsarstorage
 import (
 	"bytes"
 	"encoding/gob"
+	"math"
 	"path/filepath"
+	"time"
 
 	"github.com/dgraph-io/badger"
 	"github.com/insolar/insolar/configuration"
@@ -130,14 +132,188 @@ func (storage *BadgerStorageImpl) SavePulse(pulse *core.Pulse) error {
 	if err != nil {
 		return err
 	}
-	pulseNumber := pulse.PulseNumber.Bytes()
-	key := []byte(PulseRecordID)
-	key = append(key, pulseNumber...)
 
 	return storage.db.Update(func(txn *badger.Txn) error {
-		err := txn.Set(key, buffer.Bytes())
+		err := txn.Set(pulseKey(pulse.PulseNumber), buffer.Bytes())
+		return err
+	})
+}
+
+// pruneBatchSize is a max number of pulses removed in one transaction.
+const pruneBatchSize = 1000
+
+func pulseKey(pulseNumber core.PulseNumber) []byte {
+	key := []byte(PulseRecordID)
+	return append(key, pulseNumber.Bytes()...)
+}
+
+func decodePulse(item *badger.Item) (*core.Pulse, error) {
+	val, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	pulse := &core.Pulse{}
+	err = gob.NewDecoder(bytes.NewReader(val)).Decode(pulse)
+	if err != nil {
+		return nil, err
+	}
+	return pulse, nil
+}
+
+// iteratePulses calls handler for saved pulses in ascending order starting from pulse number from until handler
+// returns false.
+func iteratePulses(txn *badger.Txn, from core.PulseNumber, handler func(*core.Pulse) bool) error {
+	prefix := []byte(PulseRecordID)
+	it := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer it.Close()
+	for it.Seek(pulseKey(from)); it.ValidForPrefix(prefix); it.Next() {
+		pulse, err := decodePulse(it.Item())
+		if err != nil {
+			return err
+		}
+		if !handler(pulse) {
+			break
+		}
+	}
+	return nil
+}
+
+func (storage *BadgerStorageImpl) GetPulse(pulseNumber core.PulseNumber) (*core.Pulse, error) {
+	var pulse *core.Pulse
+	err := storage.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(pulseKey(pulseNumber))
+		if err == badger.ErrKeyNotFound {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		pulse, err = decodePulse(item)
 		return err
 	})
+	return pulse, err
+}
+
+func (storage *BadgerStorageImpl) GetPulses(from, to core.PulseNumber, limit int) ([]*core.Pulse, error) {
+	var pulses []*core.Pulse
+	err := storage.db.View(func(txn *badger.Txn) error {
+		return iteratePulses(txn, from, func(pulse *core.Pulse) bool {
+			if pulse.PulseNumber > to {
+				return false
+			}
+			pulses = append(pulses, pulse)
+			return limit == 0 || len(pulses) < limit
+		})
+	})
+	return pulses, err
+}
+
+// seekPulseByTime returns the smallest pulse number, first saved pulse after which has timestamp not before t. Pulse
+// timestamps grow with pulse numbers, so it is found by binary search. It returns false if there is no such pulse.
+func seekPulseByTime(txn *badger.Txn, t int64) (core.PulseNumber, bool, error) {
+	prefix := []byte(PulseRecordID)
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	lo, hi := uint64(0), uint64(math.MaxUint32)+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		it.Seek(pulseKey(core.PulseNumber(mid)))
+		if !it.ValidForPrefix(prefix) {
+			hi = mid
+			continue
+		}
+		pulse, err := decodePulse(it.Item())
+		if err != nil {
+			return 0, false, err
+		}
+		if pulse.PulseTimestamp >= t {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return core.PulseNumber(lo), lo <= math.MaxUint32, nil
+}
+
+func (storage *BadgerStorageImpl) GetPulsesByTime(from, to time.Time, limit int) ([]*core.Pulse, error) {
+	var pulses []*core.Pulse
+	err := storage.db.View(func(txn *badger.Txn) error {
+		start, ok, err := seekPulseByTime(txn, from.Unix())
+		if err != nil || !ok {
+			return err
+		}
+		// Pulse timestamps grow with pulse numbers, so the scan stops at the first pulse after the range.
+		return iteratePulses(txn, start, func(pulse *core.Pulse) bool {
+			if pulse.PulseTimestamp >= to.Unix() {
+				return false
+			}
+			pulses = append(pulses, pulse)
+			return limit == 0 || len(pulses) < limit
+		})
+	})
+	return pulses, err
+}
+
+func (storage *BadgerStorageImpl) Prune(before time.Time, keep int) (int, error) {
+	if before.IsZero() && keep == 0 {
+		return 0, nil
+	}
+	last, err := storage.GetLastPulse()
+	if err != nil {
+		return 0, errors.Wrap(err, "[ Prune ] failed to get last pulse")
+	}
+
+	var keys [][]byte
+	err = storage.db.View(func(txn *badger.Txn) error {
+		prefix := []byte(PulseRecordID)
+		opts := badger.DefaultIteratorOptions
+		opts.Reverse = true
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		kept := 0
+		for it.Seek(pulseKey(core.PulseNumber(^uint32(0)))); it.ValidForPrefix(prefix); it.Next() {
+			pulse, err := decodePulse(it.Item())
+			if err != nil {
+				return err
+			}
+			expired := !before.IsZero() && pulse.PulseTimestamp < before.Unix()
+			if pulse.PulseNumber == last.PulseNumber || (!expired && (keep == 0 || kept < keep)) {
+				kept++
+				continue
+			}
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "[ Prune ] failed to scan pulses")
+	}
+
+	removed := 0
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > pruneBatchSize {
+			batch = batch[:pruneBatchSize]
+		}
+		err = storage.db.Update(func(txn *badger.Txn) error {
+			for _, key := range batch {
+				if err := txn.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return removed, errors.Wrap(err, "[ Prune ] failed to remove pulses")
+		}
+		removed += len(batch)
+		keys = keys[len(batch):]
+	}
+	return removed, nil
 }
 
 func (storage *BadgerStorageImpl) Close() error {
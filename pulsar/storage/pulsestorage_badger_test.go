@@ -0,0 +1,132 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package pulsarstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStorage(t *testing.T) (PulsarStorage, func()) {
+	dir, err := ioutil.TempDir("", "pulsarstorage")
+	require.NoError(t, err)
+
+	conf := configuration.NewPulsar()
+	conf.Storage.DataDirectory = dir
+	storage, err := NewStorageBadger(conf, nil)
+	require.NoError(t, err)
+
+	return storage, func() {
+		storage.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// savePulses saves count pulses after genesis pulse with timestamps 10 seconds apart.
+func savePulses(t *testing.T, storage PulsarStorage, count int) []*core.Pulse {
+	var pulses []*core.Pulse
+	for i := 1; i <= count; i++ {
+		pulse := &core.Pulse{
+			PulseNumber:    core.FirstPulseNumber + core.PulseNumber(i*10),
+			PulseTimestamp: core.GenesisPulse.PulseTimestamp + int64(i*10),
+			Signs:          map[string]core.PulseSenderConfirmation{"key": {Signature: []byte{byte(i)}}},
+		}
+		require.NoError(t, storage.SavePulse(pulse))
+		require.NoError(t, storage.SetLastPulse(pulse))
+		pulses = append(pulses, pulse)
+	}
+	return pulses
+}
+
+func pulseNumbers(pulses []*core.Pulse) []core.PulseNumber {
+	var numbers []core.PulseNumber
+	for _, pulse := range pulses {
+		numbers = append(numbers, pulse.PulseNumber)
+	}
+	return numbers
+}
+
+func TestBadgerStorageImpl_GetPulses(t *testing.T) {
+	storage, cleanup := newTestStorage(t)
+	defer cleanup()
+	saved := savePulses(t, storage, 5)
+
+	pulse, err := storage.GetPulse(saved[2].PulseNumber)
+	require.NoError(t, err)
+	assert.Equal(t, saved[2], pulse)
+
+	_, err = storage.GetPulse(saved[2].PulseNumber + 1)
+	assert.Equal(t, ErrNotFound, err)
+
+	pulses, err := storage.GetPulses(saved[1].PulseNumber, saved[3].PulseNumber, 0)
+	require.NoError(t, err)
+	assert.Equal(t, pulseNumbers(saved[1:4]), pulseNumbers(pulses))
+
+	pulses, err = storage.GetPulses(0, saved[4].PulseNumber, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []core.PulseNumber{core.GenesisPulse.PulseNumber, saved[0].PulseNumber}, pulseNumbers(pulses))
+
+	pulses, err = storage.GetPulsesByTime(
+		time.Unix(saved[1].PulseTimestamp, 0),
+		time.Unix(saved[3].PulseTimestamp, 0),
+		0,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, pulseNumbers(saved[1:3]), pulseNumbers(pulses))
+
+	pulses, err = storage.GetPulsesByTime(time.Unix(saved[4].PulseTimestamp+1, 0), time.Unix(1<<62, 0), 0)
+	require.NoError(t, err)
+	assert.Empty(t, pulses)
+
+	pulses, err = storage.GetPulsesByTime(time.Unix(0, 0), time.Unix(saved[0].PulseTimestamp, 0), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []core.PulseNumber{core.GenesisPulse.PulseNumber}, pulseNumbers(pulses))
+}
+
+func TestBadgerStorageImpl_Prune(t *testing.T) {
+	storage, cleanup := newTestStorage(t)
+	defer cleanup()
+	saved := savePulses(t, storage, 5)
+
+	removed, err := storage.Prune(time.Time{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed)
+
+	removed, err = storage.Prune(time.Unix(saved[1].PulseTimestamp, 0), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	removed, err = storage.Prune(time.Time{}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	// The last pulse is kept even if it is expired.
+	removed, err = storage.Prune(time.Now(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	pulses, err := storage.GetPulses(0, saved[4].PulseNumber, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []core.PulseNumber{saved[4].PulseNumber}, pulseNumbers(pulses))
+}
@@ -162,6 +162,7 @@ func main() {
 		}
 
 		conf.APIRunner.Address = fmt.Sprintf(defaultHost+":191%02d", nodeIndex)
+		conf.APIRunner.AdminAddress = fmt.Sprintf(defaultHost+":190%02d", nodeIndex)
 		conf.Metrics.ListenAddress = fmt.Sprintf(defaultHost+":80%02d", nodeIndex)
 
 		conf.Tracer.Jaeger.AgentEndpoint = defaultJaegerEndPoint
@@ -196,6 +197,10 @@ func main() {
 	writePulsarConfig(pulsarConfig)
 	writePromConfig(pctx)
 
+	if pulsarConfig.Pulsar.HistoryListenAddress != "" {
+		pwConfig.Pulsars = append(pwConfig.Pulsars, pulsarConfig.Pulsar.HistoryListenAddress)
+	}
+
 	pwConfig.Interval = 100 * time.Millisecond
 	pwConfig.Timeout = 1 * time.Second
 	err = pulsewatcher.WriteConfig(outputDir+"/utils", pulsewatcherFileName, pwConfig)
@@ -70,11 +70,21 @@ type ArtifactManagerMock struct {
 	GetObjectPreCounter uint64
 	GetObjectMock       mArtifactManagerMockGetObject
 
+	GetObjectHistoryFunc       func(p context.Context, p1 core.RecordRef, p2 *core.RecordID, p3 int) (r *core.ObjectHistory, r1 error)
+	GetObjectHistoryCounter    uint64
+	GetObjectHistoryPreCounter uint64
+	GetObjectHistoryMock       mArtifactManagerMockGetObjectHistory
+
 	GetPendingRequestFunc       func(p context.Context, p1 core.RecordID) (r core.Parcel, r1 error)
 	GetPendingRequestCounter    uint64
 	GetPendingRequestPreCounter uint64
 	GetPendingRequestMock       mArtifactManagerMockGetPendingRequest
 
+	GetRequestResultFunc       func(p context.Context, p1 core.RecordRef, p2 core.RecordRef) (r *core.RequestResult, r1 error)
+	GetRequestResultCounter    uint64
+	GetRequestResultPreCounter uint64
+	GetRequestResultMock       mArtifactManagerMockGetRequestResult
+
 	HasPendingRequestsFunc       func(p context.Context, p1 core.RecordRef) (r bool, r1 error)
 	HasPendingRequestsCounter    uint64
 	HasPendingRequestsPreCounter uint64
@@ -129,7 +139,9 @@ func NewArtifactManagerMock(t minimock.Tester) *ArtifactManagerMock {
 	m.GetCodeMock = mArtifactManagerMockGetCode{mock: m}
 	m.GetDelegateMock = mArtifactManagerMockGetDelegate{mock: m}
 	m.GetObjectMock = mArtifactManagerMockGetObject{mock: m}
+	m.GetObjectHistoryMock = mArtifactManagerMockGetObjectHistory{mock: m}
 	m.GetPendingRequestMock = mArtifactManagerMockGetPendingRequest{mock: m}
+	m.GetRequestResultMock = mArtifactManagerMockGetRequestResult{mock: m}
 	m.HasPendingRequestsMock = mArtifactManagerMockHasPendingRequests{mock: m}
 	m.RegisterRequestMock = mArtifactManagerMockRegisterRequest{mock: m}
 	m.RegisterResultMock = mArtifactManagerMockRegisterResult{mock: m}
@@ -1654,6 +1666,159 @@ func (m *ArtifactManagerMock) GetObjectFinished() bool {
 	return true
 }
 
+type mArtifactManagerMockGetObjectHistory struct {
+	mock              *ArtifactManagerMock
+	mainExpectation   *ArtifactManagerMockGetObjectHistoryExpectation
+	expectationSeries []*ArtifactManagerMockGetObjectHistoryExpectation
+}
+
+type ArtifactManagerMockGetObjectHistoryExpectation struct {
+	input  *ArtifactManagerMockGetObjectHistoryInput
+	result *ArtifactManagerMockGetObjectHistoryResult
+}
+
+type ArtifactManagerMockGetObjectHistoryInput struct {
+	p  context.Context
+	p1 core.RecordRef
+	p2 *core.RecordID
+	p3 int
+}
+
+type ArtifactManagerMockGetObjectHistoryResult struct {
+	r  *core.ObjectHistory
+	r1 error
+}
+
+//Expect specifies that invocation of ArtifactManager.GetObjectHistory is expected from 1 to Infinity times
+func (m *mArtifactManagerMockGetObjectHistory) Expect(p context.Context, p1 core.RecordRef, p2 *core.RecordID, p3 int) *mArtifactManagerMockGetObjectHistory {
+	m.mock.GetObjectHistoryFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetObjectHistoryExpectation{}
+	}
+	m.mainExpectation.input = &ArtifactManagerMockGetObjectHistoryInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of ArtifactManager.GetObjectHistory
+func (m *mArtifactManagerMockGetObjectHistory) Return(r *core.ObjectHistory, r1 error) *ArtifactManagerMock {
+	m.mock.GetObjectHistoryFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetObjectHistoryExpectation{}
+	}
+	m.mainExpectation.result = &ArtifactManagerMockGetObjectHistoryResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ArtifactManager.GetObjectHistory is expected once
+func (m *mArtifactManagerMockGetObjectHistory) ExpectOnce(p context.Context, p1 core.RecordRef, p2 *core.RecordID, p3 int) *ArtifactManagerMockGetObjectHistoryExpectation {
+	m.mock.GetObjectHistoryFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ArtifactManagerMockGetObjectHistoryExpectation{}
+	expectation.input = &ArtifactManagerMockGetObjectHistoryInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ArtifactManagerMockGetObjectHistoryExpectation) Return(r *core.ObjectHistory, r1 error) {
+	e.result = &ArtifactManagerMockGetObjectHistoryResult{r, r1}
+}
+
+//Set uses given function f as a mock of ArtifactManager.GetObjectHistory method
+func (m *mArtifactManagerMockGetObjectHistory) Set(f func(p context.Context, p1 core.RecordRef, p2 *core.RecordID, p3 int) (r *core.ObjectHistory, r1 error)) *ArtifactManagerMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetObjectHistoryFunc = f
+	return m.mock
+}
+
+//GetObjectHistory implements github.com/insolar/insolar/core.ArtifactManager interface
+func (m *ArtifactManagerMock) GetObjectHistory(p context.Context, p1 core.RecordRef, p2 *core.RecordID, p3 int) (r *core.ObjectHistory, r1 error) {
+	counter := atomic.AddUint64(&m.GetObjectHistoryPreCounter, 1)
+	defer atomic.AddUint64(&m.GetObjectHistoryCounter, 1)
+
+	if len(m.GetObjectHistoryMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetObjectHistoryMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetObjectHistory. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.GetObjectHistoryMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ArtifactManagerMockGetObjectHistoryInput{p, p1, p2, p3}, "ArtifactManager.GetObjectHistory got unexpected parameters")
+
+		result := m.GetObjectHistoryMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetObjectHistory")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetObjectHistoryMock.mainExpectation != nil {
+
+		input := m.GetObjectHistoryMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ArtifactManagerMockGetObjectHistoryInput{p, p1, p2, p3}, "ArtifactManager.GetObjectHistory got unexpected parameters")
+		}
+
+		result := m.GetObjectHistoryMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetObjectHistory")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetObjectHistoryFunc == nil {
+		m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetObjectHistory. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.GetObjectHistoryFunc(p, p1, p2, p3)
+}
+
+//GetObjectHistoryMinimockCounter returns a count of ArtifactManagerMock.GetObjectHistoryFunc invocations
+func (m *ArtifactManagerMock) GetObjectHistoryMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetObjectHistoryCounter)
+}
+
+//GetObjectHistoryMinimockPreCounter returns the value of ArtifactManagerMock.GetObjectHistory invocations
+func (m *ArtifactManagerMock) GetObjectHistoryMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetObjectHistoryPreCounter)
+}
+
+//GetObjectHistoryFinished returns true if mock invocations count is ok
+func (m *ArtifactManagerMock) GetObjectHistoryFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetObjectHistoryMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetObjectHistoryCounter) == uint64(len(m.GetObjectHistoryMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetObjectHistoryMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetObjectHistoryCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetObjectHistoryFunc != nil {
+		return atomic.LoadUint64(&m.GetObjectHistoryCounter) > 0
+	}
+
+	return true
+}
+
 type mArtifactManagerMockGetPendingRequest struct {
 	mock              *ArtifactManagerMock
 	mainExpectation   *ArtifactManagerMockGetPendingRequestExpectation
@@ -1805,6 +1970,158 @@ func (m *ArtifactManagerMock) GetPendingRequestFinished() bool {
 	return true
 }
 
+type mArtifactManagerMockGetRequestResult struct {
+	mock              *ArtifactManagerMock
+	mainExpectation   *ArtifactManagerMockGetRequestResultExpectation
+	expectationSeries []*ArtifactManagerMockGetRequestResultExpectation
+}
+
+type ArtifactManagerMockGetRequestResultExpectation struct {
+	input  *ArtifactManagerMockGetRequestResultInput
+	result *ArtifactManagerMockGetRequestResultResult
+}
+
+type ArtifactManagerMockGetRequestResultInput struct {
+	p  context.Context
+	p1 core.RecordRef
+	p2 core.RecordRef
+}
+
+type ArtifactManagerMockGetRequestResultResult struct {
+	r  *core.RequestResult
+	r1 error
+}
+
+//Expect specifies that invocation of ArtifactManager.GetRequestResult is expected from 1 to Infinity times
+func (m *mArtifactManagerMockGetRequestResult) Expect(p context.Context, p1 core.RecordRef, p2 core.RecordRef) *mArtifactManagerMockGetRequestResult {
+	m.mock.GetRequestResultFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetRequestResultExpectation{}
+	}
+	m.mainExpectation.input = &ArtifactManagerMockGetRequestResultInput{p, p1, p2}
+	return m
+}
+
+//Return specifies results of invocation of ArtifactManager.GetRequestResult
+func (m *mArtifactManagerMockGetRequestResult) Return(r *core.RequestResult, r1 error) *ArtifactManagerMock {
+	m.mock.GetRequestResultFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ArtifactManagerMockGetRequestResultExpectation{}
+	}
+	m.mainExpectation.result = &ArtifactManagerMockGetRequestResultResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ArtifactManager.GetRequestResult is expected once
+func (m *mArtifactManagerMockGetRequestResult) ExpectOnce(p context.Context, p1 core.RecordRef, p2 core.RecordRef) *ArtifactManagerMockGetRequestResultExpectation {
+	m.mock.GetRequestResultFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ArtifactManagerMockGetRequestResultExpectation{}
+	expectation.input = &ArtifactManagerMockGetRequestResultInput{p, p1, p2}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ArtifactManagerMockGetRequestResultExpectation) Return(r *core.RequestResult, r1 error) {
+	e.result = &ArtifactManagerMockGetRequestResultResult{r, r1}
+}
+
+//Set uses given function f as a mock of ArtifactManager.GetRequestResult method
+func (m *mArtifactManagerMockGetRequestResult) Set(f func(p context.Context, p1 core.RecordRef, p2 core.RecordRef) (r *core.RequestResult, r1 error)) *ArtifactManagerMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetRequestResultFunc = f
+	return m.mock
+}
+
+//GetRequestResult implements github.com/insolar/insolar/core.ArtifactManager.ArtifactManager interface
+func (m *ArtifactManagerMock) GetRequestResult(p context.Context, p1 core.RecordRef, p2 core.RecordRef) (r *core.RequestResult, r1 error) {
+	counter := atomic.AddUint64(&m.GetRequestResultPreCounter, 1)
+	defer atomic.AddUint64(&m.GetRequestResultCounter, 1)
+
+	if len(m.GetRequestResultMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetRequestResultMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetRequestResult. %v %v %v", p, p1, p2)
+			return
+		}
+
+		input := m.GetRequestResultMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ArtifactManagerMockGetRequestResultInput{p, p1, p2}, "ArtifactManager.GetRequestResult got unexpected parameters")
+
+		result := m.GetRequestResultMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetRequestResult")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetRequestResultMock.mainExpectation != nil {
+
+		input := m.GetRequestResultMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ArtifactManagerMockGetRequestResultInput{p, p1, p2}, "ArtifactManager.GetRequestResult got unexpected parameters")
+		}
+
+		result := m.GetRequestResultMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ArtifactManagerMock.GetRequestResult")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetRequestResultFunc == nil {
+		m.t.Fatalf("Unexpected call to ArtifactManagerMock.GetRequestResult. %v %v %v", p, p1, p2)
+		return
+	}
+
+	return m.GetRequestResultFunc(p, p1, p2)
+}
+
+//GetRequestResultMinimockCounter returns a count of ArtifactManagerMock.GetRequestResultFunc invocations
+func (m *ArtifactManagerMock) GetRequestResultMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetRequestResultCounter)
+}
+
+//GetRequestResultMinimockPreCounter returns the value of ArtifactManagerMock.GetRequestResult invocations
+func (m *ArtifactManagerMock) GetRequestResultMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetRequestResultPreCounter)
+}
+
+//GetRequestResultFinished returns true if mock invocations count is ok
+func (m *ArtifactManagerMock) GetRequestResultFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetRequestResultMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetRequestResultCounter) == uint64(len(m.GetRequestResultMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetRequestResultMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetRequestResultCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetRequestResultFunc != nil {
+		return atomic.LoadUint64(&m.GetRequestResultCounter) > 0
+	}
+
+	return true
+}
+
 type mArtifactManagerMockHasPendingRequests struct {
 	mock              *ArtifactManagerMock
 	mainExpectation   *ArtifactManagerMockHasPendingRequestsExpectation
@@ -2902,10 +3219,18 @@ func (m *ArtifactManagerMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetObject")
 	}
 
+	if !m.GetObjectHistoryFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetObjectHistory")
+	}
+
 	if !m.GetPendingRequestFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetPendingRequest")
 	}
 
+	if !m.GetRequestResultFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetRequestResult")
+	}
+
 	if !m.HasPendingRequestsFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.HasPendingRequests")
 	}
@@ -2991,10 +3316,18 @@ func (m *ArtifactManagerMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetObject")
 	}
 
+	if !m.GetObjectHistoryFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetObjectHistory")
+	}
+
 	if !m.GetPendingRequestFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.GetPendingRequest")
 	}
 
+	if !m.GetRequestResultFinished() {
+		m.t.Fatal("Expected call to ArtifactManagerMock.GetRequestResult")
+	}
+
 	if !m.HasPendingRequestsFinished() {
 		m.t.Fatal("Expected call to ArtifactManagerMock.HasPendingRequests")
 	}
@@ -3047,7 +3380,9 @@ func (m *ArtifactManagerMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.GetCodeFinished()
 		ok = ok && m.GetDelegateFinished()
 		ok = ok && m.GetObjectFinished()
+		ok = ok && m.GetObjectHistoryFinished()
 		ok = ok && m.GetPendingRequestFinished()
+		ok = ok && m.GetRequestResultFinished()
 		ok = ok && m.HasPendingRequestsFinished()
 		ok = ok && m.RegisterRequestFinished()
 		ok = ok && m.RegisterResultFinished()
@@ -3103,10 +3438,18 @@ func (m *ArtifactManagerMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ArtifactManagerMock.GetObject")
 			}
 
+			if !m.GetObjectHistoryFinished() {
+				m.t.Error("Expected call to ArtifactManagerMock.GetObjectHistory")
+			}
+
 			if !m.GetPendingRequestFinished() {
 				m.t.Error("Expected call to ArtifactManagerMock.GetPendingRequest")
 			}
 
+			if !m.GetRequestResultFinished() {
+				m.t.Error("Expected call to ArtifactManagerMock.GetRequestResult")
+			}
+
 			if !m.HasPendingRequestsFinished() {
 				m.t.Error("Expected call to ArtifactManagerMock.HasPendingRequests")
 			}
@@ -3187,10 +3530,18 @@ func (m *ArtifactManagerMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetObjectHistoryFinished() {
+		return false
+	}
+
 	if !m.GetPendingRequestFinished() {
 		return false
 	}
 
+	if !m.GetRequestResultFinished() {
+		return false
+	}
+
 	if !m.HasPendingRequestsFinished() {
 		return false
 	}
@@ -20,6 +20,11 @@ import (
 type LogicRunnerMock struct {
 	t minimock.Tester
 
+	DrainFunc       func(p context.Context) (r error)
+	DrainCounter    uint64
+	DrainPreCounter uint64
+	DrainMock       mLogicRunnerMockDrain
+
 	ExecuteFunc       func(p context.Context, p1 core.Parcel) (r core.Reply, r1 error)
 	ExecuteCounter    uint64
 	ExecutePreCounter uint64
@@ -54,6 +59,7 @@ func NewLogicRunnerMock(t minimock.Tester) *LogicRunnerMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.DrainMock = mLogicRunnerMockDrain{mock: m}
 	m.ExecuteMock = mLogicRunnerMockExecute{mock: m}
 	m.HandleExecutorResultsMessageMock = mLogicRunnerMockHandleExecutorResultsMessage{mock: m}
 	m.HandleValidateCaseBindMessageMock = mLogicRunnerMockHandleValidateCaseBindMessage{mock: m}
@@ -63,6 +69,153 @@ func NewLogicRunnerMock(t minimock.Tester) *LogicRunnerMock {
 	return m
 }
 
+type mLogicRunnerMockDrain struct {
+	mock              *LogicRunnerMock
+	mainExpectation   *LogicRunnerMockDrainExpectation
+	expectationSeries []*LogicRunnerMockDrainExpectation
+}
+
+type LogicRunnerMockDrainExpectation struct {
+	input  *LogicRunnerMockDrainInput
+	result *LogicRunnerMockDrainResult
+}
+
+type LogicRunnerMockDrainInput struct {
+	p context.Context
+}
+
+type LogicRunnerMockDrainResult struct {
+	r error
+}
+
+//Expect specifies that invocation of LogicRunner.Drain is expected from 1 to Infinity times
+func (m *mLogicRunnerMockDrain) Expect(p context.Context) *mLogicRunnerMockDrain {
+	m.mock.DrainFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &LogicRunnerMockDrainExpectation{}
+	}
+	m.mainExpectation.input = &LogicRunnerMockDrainInput{p}
+	return m
+}
+
+//Return specifies results of invocation of LogicRunner.Drain
+func (m *mLogicRunnerMockDrain) Return(r error) *LogicRunnerMock {
+	m.mock.DrainFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &LogicRunnerMockDrainExpectation{}
+	}
+	m.mainExpectation.result = &LogicRunnerMockDrainResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of LogicRunner.Drain is expected once
+func (m *mLogicRunnerMockDrain) ExpectOnce(p context.Context) *LogicRunnerMockDrainExpectation {
+	m.mock.DrainFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &LogicRunnerMockDrainExpectation{}
+	expectation.input = &LogicRunnerMockDrainInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *LogicRunnerMockDrainExpectation) Return(r error) {
+	e.result = &LogicRunnerMockDrainResult{r}
+}
+
+//Set uses given function f as a mock of LogicRunner.Drain method
+func (m *mLogicRunnerMockDrain) Set(f func(p context.Context) (r error)) *LogicRunnerMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.DrainFunc = f
+	return m.mock
+}
+
+//Drain implements github.com/insolar/insolar/core.LogicRunner.LogicRunner interface
+func (m *LogicRunnerMock) Drain(p context.Context) (r error) {
+	counter := atomic.AddUint64(&m.DrainPreCounter, 1)
+	defer atomic.AddUint64(&m.DrainCounter, 1)
+
+	if len(m.DrainMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.DrainMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to LogicRunnerMock.Drain. %v", p)
+			return
+		}
+
+		input := m.DrainMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, LogicRunnerMockDrainInput{p}, "LogicRunner.Drain got unexpected parameters")
+
+		result := m.DrainMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the LogicRunnerMock.Drain")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.DrainMock.mainExpectation != nil {
+
+		input := m.DrainMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, LogicRunnerMockDrainInput{p}, "LogicRunner.Drain got unexpected parameters")
+		}
+
+		result := m.DrainMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the LogicRunnerMock.Drain")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.DrainFunc == nil {
+		m.t.Fatalf("Unexpected call to LogicRunnerMock.Drain. %v", p)
+		return
+	}
+
+	return m.DrainFunc(p)
+}
+
+//DrainMinimockCounter returns a count of LogicRunnerMock.DrainFunc invocations
+func (m *LogicRunnerMock) DrainMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.DrainCounter)
+}
+
+//DrainMinimockPreCounter returns the value of LogicRunnerMock.Drain invocations
+func (m *LogicRunnerMock) DrainMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.DrainPreCounter)
+}
+
+//DrainFinished returns true if mock invocations count is ok
+func (m *LogicRunnerMock) DrainFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.DrainMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.DrainCounter) == uint64(len(m.DrainMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.DrainMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.DrainCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.DrainFunc != nil {
+		return atomic.LoadUint64(&m.DrainCounter) > 0
+	}
+
+	return true
+}
+
 type mLogicRunnerMockExecute struct {
 	mock              *LogicRunnerMock
 	mainExpectation   *LogicRunnerMockExecuteExpectation
@@ -819,6 +972,10 @@ func (m *LogicRunnerMock) OnPulseFinished() bool {
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *LogicRunnerMock) ValidateCallCounters() {
 
+	if !m.DrainFinished() {
+		m.t.Fatal("Expected call to LogicRunnerMock.Drain")
+	}
+
 	if !m.ExecuteFinished() {
 		m.t.Fatal("Expected call to LogicRunnerMock.Execute")
 	}
@@ -856,6 +1013,10 @@ func (m *LogicRunnerMock) Finish() {
 //MinimockFinish checks that all mocked methods of the interface have been called at least once
 func (m *LogicRunnerMock) MinimockFinish() {
 
+	if !m.DrainFinished() {
+		m.t.Fatal("Expected call to LogicRunnerMock.Drain")
+	}
+
 	if !m.ExecuteFinished() {
 		m.t.Fatal("Expected call to LogicRunnerMock.Execute")
 	}
@@ -890,6 +1051,7 @@ func (m *LogicRunnerMock) MinimockWait(timeout time.Duration) {
 	timeoutCh := time.After(timeout)
 	for {
 		ok := true
+		ok = ok && m.DrainFinished()
 		ok = ok && m.ExecuteFinished()
 		ok = ok && m.HandleExecutorResultsMessageFinished()
 		ok = ok && m.HandleValidateCaseBindMessageFinished()
@@ -903,6 +1065,10 @@ func (m *LogicRunnerMock) MinimockWait(timeout time.Duration) {
 		select {
 		case <-timeoutCh:
 
+			if !m.DrainFinished() {
+				m.t.Error("Expected call to LogicRunnerMock.Drain")
+			}
+
 			if !m.ExecuteFinished() {
 				m.t.Error("Expected call to LogicRunnerMock.Execute")
 			}
@@ -935,6 +1101,10 @@ func (m *LogicRunnerMock) MinimockWait(timeout time.Duration) {
 //it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
 func (m *LogicRunnerMock) AllMocksCalled() bool {
 
+	if !m.DrainFinished() {
+		return false
+	}
+
 	if !m.ExecuteFinished() {
 		return false
 	}
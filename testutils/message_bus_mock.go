@@ -50,6 +50,16 @@ type MessageBusMock struct {
 	SendCounter    uint64
 	SendPreCounter uint64
 	SendMock       mMessageBusMockSend
+
+	UseDeliverMiddlewareFunc       func(p core.DeliverMiddleware)
+	UseDeliverMiddlewareCounter    uint64
+	UseDeliverMiddlewarePreCounter uint64
+	UseDeliverMiddlewareMock       mMessageBusMockUseDeliverMiddleware
+
+	UseSendMiddlewareFunc       func(p core.SendMiddleware)
+	UseSendMiddlewareCounter    uint64
+	UseSendMiddlewarePreCounter uint64
+	UseSendMiddlewareMock       mMessageBusMockUseSendMiddleware
 }
 
 //NewMessageBusMock returns a mock for github.com/insolar/insolar/core.MessageBus
@@ -66,6 +76,8 @@ func NewMessageBusMock(t minimock.Tester) *MessageBusMock {
 	m.OnPulseMock = mMessageBusMockOnPulse{mock: m}
 	m.RegisterMock = mMessageBusMockRegister{mock: m}
 	m.SendMock = mMessageBusMockSend{mock: m}
+	m.UseDeliverMiddlewareMock = mMessageBusMockUseDeliverMiddleware{mock: m}
+	m.UseSendMiddlewareMock = mMessageBusMockUseSendMiddleware{mock: m}
 
 	return m
 }
@@ -944,6 +956,252 @@ func (m *MessageBusMock) SendFinished() bool {
 	return true
 }
 
+type mMessageBusMockUseDeliverMiddleware struct {
+	mock              *MessageBusMock
+	mainExpectation   *MessageBusMockUseDeliverMiddlewareExpectation
+	expectationSeries []*MessageBusMockUseDeliverMiddlewareExpectation
+}
+
+type MessageBusMockUseDeliverMiddlewareExpectation struct {
+	input *MessageBusMockUseDeliverMiddlewareInput
+}
+
+type MessageBusMockUseDeliverMiddlewareInput struct {
+	p core.DeliverMiddleware
+}
+
+//Expect specifies that invocation of MessageBus.UseDeliverMiddleware is expected from 1 to Infinity times
+func (m *mMessageBusMockUseDeliverMiddleware) Expect(p core.DeliverMiddleware) *mMessageBusMockUseDeliverMiddleware {
+	m.mock.UseDeliverMiddlewareFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &MessageBusMockUseDeliverMiddlewareExpectation{}
+	}
+	m.mainExpectation.input = &MessageBusMockUseDeliverMiddlewareInput{p}
+	return m
+}
+
+//Return specifies results of invocation of MessageBus.UseDeliverMiddleware
+func (m *mMessageBusMockUseDeliverMiddleware) Return() *MessageBusMock {
+	m.mock.UseDeliverMiddlewareFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &MessageBusMockUseDeliverMiddlewareExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of MessageBus.UseDeliverMiddleware is expected once
+func (m *mMessageBusMockUseDeliverMiddleware) ExpectOnce(p core.DeliverMiddleware) *MessageBusMockUseDeliverMiddlewareExpectation {
+	m.mock.UseDeliverMiddlewareFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &MessageBusMockUseDeliverMiddlewareExpectation{}
+	expectation.input = &MessageBusMockUseDeliverMiddlewareInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of MessageBus.UseDeliverMiddleware method
+func (m *mMessageBusMockUseDeliverMiddleware) Set(f func(p core.DeliverMiddleware)) *MessageBusMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.UseDeliverMiddlewareFunc = f
+	return m.mock
+}
+
+//UseDeliverMiddleware implements github.com/insolar/insolar/core.MessageBus interface
+func (m *MessageBusMock) UseDeliverMiddleware(p core.DeliverMiddleware) {
+	counter := atomic.AddUint64(&m.UseDeliverMiddlewarePreCounter, 1)
+	defer atomic.AddUint64(&m.UseDeliverMiddlewareCounter, 1)
+
+	if len(m.UseDeliverMiddlewareMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.UseDeliverMiddlewareMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to MessageBusMock.UseDeliverMiddleware. %v", p)
+			return
+		}
+
+		input := m.UseDeliverMiddlewareMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, MessageBusMockUseDeliverMiddlewareInput{p}, "MessageBus.UseDeliverMiddleware got unexpected parameters")
+
+		return
+	}
+
+	if m.UseDeliverMiddlewareMock.mainExpectation != nil {
+
+		input := m.UseDeliverMiddlewareMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, MessageBusMockUseDeliverMiddlewareInput{p}, "MessageBus.UseDeliverMiddleware got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.UseDeliverMiddlewareFunc == nil {
+		m.t.Fatalf("Unexpected call to MessageBusMock.UseDeliverMiddleware. %v", p)
+		return
+	}
+
+	m.UseDeliverMiddlewareFunc(p)
+}
+
+//UseDeliverMiddlewareMinimockCounter returns a count of MessageBusMock.UseDeliverMiddlewareFunc invocations
+func (m *MessageBusMock) UseDeliverMiddlewareMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.UseDeliverMiddlewareCounter)
+}
+
+//UseDeliverMiddlewareMinimockPreCounter returns the value of MessageBusMock.UseDeliverMiddleware invocations
+func (m *MessageBusMock) UseDeliverMiddlewareMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.UseDeliverMiddlewarePreCounter)
+}
+
+//UseDeliverMiddlewareFinished returns true if mock invocations count is ok
+func (m *MessageBusMock) UseDeliverMiddlewareFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.UseDeliverMiddlewareMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.UseDeliverMiddlewareCounter) == uint64(len(m.UseDeliverMiddlewareMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.UseDeliverMiddlewareMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.UseDeliverMiddlewareCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.UseDeliverMiddlewareFunc != nil {
+		return atomic.LoadUint64(&m.UseDeliverMiddlewareCounter) > 0
+	}
+
+	return true
+}
+
+type mMessageBusMockUseSendMiddleware struct {
+	mock              *MessageBusMock
+	mainExpectation   *MessageBusMockUseSendMiddlewareExpectation
+	expectationSeries []*MessageBusMockUseSendMiddlewareExpectation
+}
+
+type MessageBusMockUseSendMiddlewareExpectation struct {
+	input *MessageBusMockUseSendMiddlewareInput
+}
+
+type MessageBusMockUseSendMiddlewareInput struct {
+	p core.SendMiddleware
+}
+
+//Expect specifies that invocation of MessageBus.UseSendMiddleware is expected from 1 to Infinity times
+func (m *mMessageBusMockUseSendMiddleware) Expect(p core.SendMiddleware) *mMessageBusMockUseSendMiddleware {
+	m.mock.UseSendMiddlewareFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &MessageBusMockUseSendMiddlewareExpectation{}
+	}
+	m.mainExpectation.input = &MessageBusMockUseSendMiddlewareInput{p}
+	return m
+}
+
+//Return specifies results of invocation of MessageBus.UseSendMiddleware
+func (m *mMessageBusMockUseSendMiddleware) Return() *MessageBusMock {
+	m.mock.UseSendMiddlewareFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &MessageBusMockUseSendMiddlewareExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of MessageBus.UseSendMiddleware is expected once
+func (m *mMessageBusMockUseSendMiddleware) ExpectOnce(p core.SendMiddleware) *MessageBusMockUseSendMiddlewareExpectation {
+	m.mock.UseSendMiddlewareFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &MessageBusMockUseSendMiddlewareExpectation{}
+	expectation.input = &MessageBusMockUseSendMiddlewareInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of MessageBus.UseSendMiddleware method
+func (m *mMessageBusMockUseSendMiddleware) Set(f func(p core.SendMiddleware)) *MessageBusMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.UseSendMiddlewareFunc = f
+	return m.mock
+}
+
+//UseSendMiddleware implements github.com/insolar/insolar/core.MessageBus interface
+func (m *MessageBusMock) UseSendMiddleware(p core.SendMiddleware) {
+	counter := atomic.AddUint64(&m.UseSendMiddlewarePreCounter, 1)
+	defer atomic.AddUint64(&m.UseSendMiddlewareCounter, 1)
+
+	if len(m.UseSendMiddlewareMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.UseSendMiddlewareMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to MessageBusMock.UseSendMiddleware. %v", p)
+			return
+		}
+
+		input := m.UseSendMiddlewareMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, MessageBusMockUseSendMiddlewareInput{p}, "MessageBus.UseSendMiddleware got unexpected parameters")
+
+		return
+	}
+
+	if m.UseSendMiddlewareMock.mainExpectation != nil {
+
+		input := m.UseSendMiddlewareMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, MessageBusMockUseSendMiddlewareInput{p}, "MessageBus.UseSendMiddleware got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.UseSendMiddlewareFunc == nil {
+		m.t.Fatalf("Unexpected call to MessageBusMock.UseSendMiddleware. %v", p)
+		return
+	}
+
+	m.UseSendMiddlewareFunc(p)
+}
+
+//UseSendMiddlewareMinimockCounter returns a count of MessageBusMock.UseSendMiddlewareFunc invocations
+func (m *MessageBusMock) UseSendMiddlewareMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.UseSendMiddlewareCounter)
+}
+
+//UseSendMiddlewareMinimockPreCounter returns the value of MessageBusMock.UseSendMiddleware invocations
+func (m *MessageBusMock) UseSendMiddlewareMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.UseSendMiddlewarePreCounter)
+}
+
+//UseSendMiddlewareFinished returns true if mock invocations count is ok
+func (m *MessageBusMock) UseSendMiddlewareFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.UseSendMiddlewareMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.UseSendMiddlewareCounter) == uint64(len(m.UseSendMiddlewareMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.UseSendMiddlewareMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.UseSendMiddlewareCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.UseSendMiddlewareFunc != nil {
+		return atomic.LoadUint64(&m.UseSendMiddlewareCounter) > 0
+	}
+
+	return true
+}
+
 //ValidateCallCounters checks that all mocked methods of the interface have been called at least once
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *MessageBusMock) ValidateCallCounters() {
@@ -972,6 +1230,14 @@ func (m *MessageBusMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to MessageBusMock.Send")
 	}
 
+	if !m.UseDeliverMiddlewareFinished() {
+		m.t.Fatal("Expected call to MessageBusMock.UseDeliverMiddleware")
+	}
+
+	if !m.UseSendMiddlewareFinished() {
+		m.t.Fatal("Expected call to MessageBusMock.UseSendMiddleware")
+	}
+
 }
 
 //CheckMocksCalled checks that all mocked methods of the interface have been called at least once
@@ -1013,6 +1279,14 @@ func (m *MessageBusMock) MinimockFinish() {
 		m.t.Fatal("Expected call to MessageBusMock.Send")
 	}
 
+	if !m.UseDeliverMiddlewareFinished() {
+		m.t.Fatal("Expected call to MessageBusMock.UseDeliverMiddleware")
+	}
+
+	if !m.UseSendMiddlewareFinished() {
+		m.t.Fatal("Expected call to MessageBusMock.UseSendMiddleware")
+	}
+
 }
 
 //Wait waits for all mocked methods to be called at least once
@@ -1033,6 +1307,8 @@ func (m *MessageBusMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.OnPulseFinished()
 		ok = ok && m.RegisterFinished()
 		ok = ok && m.SendFinished()
+		ok = ok && m.UseDeliverMiddlewareFinished()
+		ok = ok && m.UseSendMiddlewareFinished()
 
 		if ok {
 			return
@@ -1065,6 +1341,14 @@ func (m *MessageBusMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to MessageBusMock.Send")
 			}
 
+			if !m.UseDeliverMiddlewareFinished() {
+				m.t.Error("Expected call to MessageBusMock.UseDeliverMiddleware")
+			}
+
+			if !m.UseSendMiddlewareFinished() {
+				m.t.Error("Expected call to MessageBusMock.UseSendMiddleware")
+			}
+
 			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
 			return
 		default:
@@ -1101,5 +1385,13 @@ func (m *MessageBusMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.UseDeliverMiddlewareFinished() {
+		return false
+	}
+
+	if !m.UseSendMiddlewareFinished() {
+		return false
+	}
+
 	return true
 }
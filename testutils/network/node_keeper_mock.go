@@ -91,6 +91,11 @@ type NodeKeeperMock struct {
 	IsBootstrappedPreCounter uint64
 	IsBootstrappedMock       mNodeKeeperMockIsBootstrapped
 
+	LeaveFunc       func() (r <-chan struct{})
+	LeaveCounter    uint64
+	LeavePreCounter uint64
+	LeaveMock       mNodeKeeperMockLeave
+
 	MoveSyncToActiveFunc       func()
 	MoveSyncToActiveCounter    uint64
 	MoveSyncToActivePreCounter uint64
@@ -144,6 +149,7 @@ func NewNodeKeeperMock(t minimock.Tester) *NodeKeeperMock {
 	m.GetStateMock = mNodeKeeperMockGetState{mock: m}
 	m.GetUnsyncListMock = mNodeKeeperMockGetUnsyncList{mock: m}
 	m.IsBootstrappedMock = mNodeKeeperMockIsBootstrapped{mock: m}
+	m.LeaveMock = mNodeKeeperMockLeave{mock: m}
 	m.MoveSyncToActiveMock = mNodeKeeperMockMoveSyncToActive{mock: m}
 	m.NodesJoinedDuringPreviousPulseMock = mNodeKeeperMockNodesJoinedDuringPreviousPulse{mock: m}
 	m.SetCloudHashMock = mNodeKeeperMockSetCloudHash{mock: m}
@@ -2087,6 +2093,140 @@ func (m *NodeKeeperMock) IsBootstrappedFinished() bool {
 	return true
 }
 
+type mNodeKeeperMockLeave struct {
+	mock              *NodeKeeperMock
+	mainExpectation   *NodeKeeperMockLeaveExpectation
+	expectationSeries []*NodeKeeperMockLeaveExpectation
+}
+
+type NodeKeeperMockLeaveExpectation struct {
+	result *NodeKeeperMockLeaveResult
+}
+
+type NodeKeeperMockLeaveResult struct {
+	r <-chan struct{}
+}
+
+//Expect specifies that invocation of NodeKeeper.Leave is expected from 1 to Infinity times
+func (m *mNodeKeeperMockLeave) Expect() *mNodeKeeperMockLeave {
+	m.mock.LeaveFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockLeaveExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of NodeKeeper.Leave
+func (m *mNodeKeeperMockLeave) Return(r <-chan struct{}) *NodeKeeperMock {
+	m.mock.LeaveFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockLeaveExpectation{}
+	}
+	m.mainExpectation.result = &NodeKeeperMockLeaveResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NodeKeeper.Leave is expected once
+func (m *mNodeKeeperMockLeave) ExpectOnce() *NodeKeeperMockLeaveExpectation {
+	m.mock.LeaveFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NodeKeeperMockLeaveExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *NodeKeeperMockLeaveExpectation) Return(r <-chan struct{}) {
+	e.result = &NodeKeeperMockLeaveResult{r}
+}
+
+//Set uses given function f as a mock of NodeKeeper.Leave method
+func (m *mNodeKeeperMockLeave) Set(f func() (r <-chan struct{})) *NodeKeeperMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.LeaveFunc = f
+	return m.mock
+}
+
+//Leave implements github.com/insolar/insolar/network.NodeKeeper.NodeKeeper interface
+func (m *NodeKeeperMock) Leave() (r <-chan struct{}) {
+	counter := atomic.AddUint64(&m.LeavePreCounter, 1)
+	defer atomic.AddUint64(&m.LeaveCounter, 1)
+
+	if len(m.LeaveMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.LeaveMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NodeKeeperMock.Leave.")
+			return
+		}
+
+		result := m.LeaveMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the NodeKeeperMock.Leave")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.LeaveMock.mainExpectation != nil {
+
+		result := m.LeaveMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the NodeKeeperMock.Leave")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.LeaveFunc == nil {
+		m.t.Fatalf("Unexpected call to NodeKeeperMock.Leave.")
+		return
+	}
+
+	return m.LeaveFunc()
+}
+
+//LeaveMinimockCounter returns a count of NodeKeeperMock.LeaveFunc invocations
+func (m *NodeKeeperMock) LeaveMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.LeaveCounter)
+}
+
+//LeaveMinimockPreCounter returns the value of NodeKeeperMock.Leave invocations
+func (m *NodeKeeperMock) LeaveMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.LeavePreCounter)
+}
+
+//LeaveFinished returns true if mock invocations count is ok
+func (m *NodeKeeperMock) LeaveFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.LeaveMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.LeaveCounter) == uint64(len(m.LeaveMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.LeaveMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.LeaveCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.LeaveFunc != nil {
+		return atomic.LoadUint64(&m.LeaveCounter) > 0
+	}
+
+	return true
+}
+
 type mNodeKeeperMockMoveSyncToActive struct {
 	mock              *NodeKeeperMock
 	mainExpectation   *NodeKeeperMockMoveSyncToActiveExpectation
@@ -2883,6 +3023,10 @@ func (m *NodeKeeperMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to NodeKeeperMock.IsBootstrapped")
 	}
 
+	if !m.LeaveFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.Leave")
+	}
+
 	if !m.MoveSyncToActiveFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.MoveSyncToActive")
 	}
@@ -2980,6 +3124,10 @@ func (m *NodeKeeperMock) MinimockFinish() {
 		m.t.Fatal("Expected call to NodeKeeperMock.IsBootstrapped")
 	}
 
+	if !m.LeaveFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.Leave")
+	}
+
 	if !m.MoveSyncToActiveFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.MoveSyncToActive")
 	}
@@ -3032,6 +3180,7 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.GetStateFinished()
 		ok = ok && m.GetUnsyncListFinished()
 		ok = ok && m.IsBootstrappedFinished()
+		ok = ok && m.LeaveFinished()
 		ok = ok && m.MoveSyncToActiveFinished()
 		ok = ok && m.NodesJoinedDuringPreviousPulseFinished()
 		ok = ok && m.SetCloudHashFinished()
@@ -3102,6 +3251,10 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to NodeKeeperMock.IsBootstrapped")
 			}
 
+			if !m.LeaveFinished() {
+				m.t.Error("Expected call to NodeKeeperMock.Leave")
+			}
+
 			if !m.MoveSyncToActiveFinished() {
 				m.t.Error("Expected call to NodeKeeperMock.MoveSyncToActive")
 			}
@@ -3194,6 +3347,10 @@ func (m *NodeKeeperMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.LeaveFinished() {
+		return false
+	}
+
 	if !m.MoveSyncToActiveFinished() {
 		return false
 	}
@@ -20,6 +20,11 @@ import (
 type UnsyncListMock struct {
 	t minimock.Tester
 
+	AddClaimFunc       func(p core.RecordRef, p1 packets.ReferendumClaim)
+	AddClaimCounter    uint64
+	AddClaimPreCounter uint64
+	AddClaimMock       mUnsyncListMockAddClaim
+
 	AddClaimsFunc       func(p map[core.RecordRef][]packets.ReferendumClaim, p1 map[core.RecordRef]string)
 	AddClaimsCounter    uint64
 	AddClaimsPreCounter uint64
@@ -30,6 +35,11 @@ type UnsyncListMock struct {
 	CalculateHashPreCounter uint64
 	CalculateHashMock       mUnsyncListMockCalculateHash
 
+	ExcludeNodesFunc       func(p []core.RecordRef)
+	ExcludeNodesCounter    uint64
+	ExcludeNodesPreCounter uint64
+	ExcludeNodesMock       mUnsyncListMockExcludeNodes
+
 	GetActiveNodeFunc       func(p core.RecordRef) (r core.Node)
 	GetActiveNodeCounter    uint64
 	GetActiveNodePreCounter uint64
@@ -69,8 +79,10 @@ func NewUnsyncListMock(t minimock.Tester) *UnsyncListMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.AddClaimMock = mUnsyncListMockAddClaim{mock: m}
 	m.AddClaimsMock = mUnsyncListMockAddClaims{mock: m}
 	m.CalculateHashMock = mUnsyncListMockCalculateHash{mock: m}
+	m.ExcludeNodesMock = mUnsyncListMockExcludeNodes{mock: m}
 	m.GetActiveNodeMock = mUnsyncListMockGetActiveNode{mock: m}
 	m.GetActiveNodesMock = mUnsyncListMockGetActiveNodes{mock: m}
 	m.IndexToRefMock = mUnsyncListMockIndexToRef{mock: m}
@@ -81,6 +93,130 @@ func NewUnsyncListMock(t minimock.Tester) *UnsyncListMock {
 	return m
 }
 
+type mUnsyncListMockAddClaim struct {
+	mock              *UnsyncListMock
+	mainExpectation   *UnsyncListMockAddClaimExpectation
+	expectationSeries []*UnsyncListMockAddClaimExpectation
+}
+
+type UnsyncListMockAddClaimExpectation struct {
+	input *UnsyncListMockAddClaimInput
+}
+
+type UnsyncListMockAddClaimInput struct {
+	p  core.RecordRef
+	p1 packets.ReferendumClaim
+}
+
+//Expect specifies that invocation of UnsyncList.AddClaim is expected from 1 to Infinity times
+func (m *mUnsyncListMockAddClaim) Expect(p core.RecordRef, p1 packets.ReferendumClaim) *mUnsyncListMockAddClaim {
+	m.mock.AddClaimFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &UnsyncListMockAddClaimExpectation{}
+	}
+	m.mainExpectation.input = &UnsyncListMockAddClaimInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of UnsyncList.AddClaim
+func (m *mUnsyncListMockAddClaim) Return() *UnsyncListMock {
+	m.mock.AddClaimFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &UnsyncListMockAddClaimExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of UnsyncList.AddClaim is expected once
+func (m *mUnsyncListMockAddClaim) ExpectOnce(p core.RecordRef, p1 packets.ReferendumClaim) *UnsyncListMockAddClaimExpectation {
+	m.mock.AddClaimFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &UnsyncListMockAddClaimExpectation{}
+	expectation.input = &UnsyncListMockAddClaimInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of UnsyncList.AddClaim method
+func (m *mUnsyncListMockAddClaim) Set(f func(p core.RecordRef, p1 packets.ReferendumClaim)) *UnsyncListMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.AddClaimFunc = f
+	return m.mock
+}
+
+//AddClaim implements github.com/insolar/insolar/network.UnsyncList.UnsyncList interface
+func (m *UnsyncListMock) AddClaim(p core.RecordRef, p1 packets.ReferendumClaim) {
+	counter := atomic.AddUint64(&m.AddClaimPreCounter, 1)
+	defer atomic.AddUint64(&m.AddClaimCounter, 1)
+
+	if len(m.AddClaimMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.AddClaimMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to UnsyncListMock.AddClaim. %v %v", p, p1)
+			return
+		}
+
+		input := m.AddClaimMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, UnsyncListMockAddClaimInput{p, p1}, "UnsyncList.AddClaim got unexpected parameters")
+
+		return
+	}
+
+	if m.AddClaimMock.mainExpectation != nil {
+
+		input := m.AddClaimMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, UnsyncListMockAddClaimInput{p, p1}, "UnsyncList.AddClaim got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.AddClaimFunc == nil {
+		m.t.Fatalf("Unexpected call to UnsyncListMock.AddClaim. %v %v", p, p1)
+		return
+	}
+
+	m.AddClaimFunc(p, p1)
+}
+
+//AddClaimMinimockCounter returns a count of UnsyncListMock.AddClaimFunc invocations
+func (m *UnsyncListMock) AddClaimMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.AddClaimCounter)
+}
+
+//AddClaimMinimockPreCounter returns the value of UnsyncListMock.AddClaim invocations
+func (m *UnsyncListMock) AddClaimMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.AddClaimPreCounter)
+}
+
+//AddClaimFinished returns true if mock invocations count is ok
+func (m *UnsyncListMock) AddClaimFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.AddClaimMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.AddClaimCounter) == uint64(len(m.AddClaimMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.AddClaimMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.AddClaimCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.AddClaimFunc != nil {
+		return atomic.LoadUint64(&m.AddClaimCounter) > 0
+	}
+
+	return true
+}
+
 type mUnsyncListMockAddClaims struct {
 	mock              *UnsyncListMock
 	mainExpectation   *UnsyncListMockAddClaimsExpectation
@@ -342,6 +478,129 @@ func (m *UnsyncListMock) CalculateHashFinished() bool {
 	return true
 }
 
+type mUnsyncListMockExcludeNodes struct {
+	mock              *UnsyncListMock
+	mainExpectation   *UnsyncListMockExcludeNodesExpectation
+	expectationSeries []*UnsyncListMockExcludeNodesExpectation
+}
+
+type UnsyncListMockExcludeNodesExpectation struct {
+	input *UnsyncListMockExcludeNodesInput
+}
+
+type UnsyncListMockExcludeNodesInput struct {
+	p []core.RecordRef
+}
+
+//Expect specifies that invocation of UnsyncList.ExcludeNodes is expected from 1 to Infinity times
+func (m *mUnsyncListMockExcludeNodes) Expect(p []core.RecordRef) *mUnsyncListMockExcludeNodes {
+	m.mock.ExcludeNodesFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &UnsyncListMockExcludeNodesExpectation{}
+	}
+	m.mainExpectation.input = &UnsyncListMockExcludeNodesInput{p}
+	return m
+}
+
+//Return specifies results of invocation of UnsyncList.ExcludeNodes
+func (m *mUnsyncListMockExcludeNodes) Return() *UnsyncListMock {
+	m.mock.ExcludeNodesFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &UnsyncListMockExcludeNodesExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of UnsyncList.ExcludeNodes is expected once
+func (m *mUnsyncListMockExcludeNodes) ExpectOnce(p []core.RecordRef) *UnsyncListMockExcludeNodesExpectation {
+	m.mock.ExcludeNodesFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &UnsyncListMockExcludeNodesExpectation{}
+	expectation.input = &UnsyncListMockExcludeNodesInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of UnsyncList.ExcludeNodes method
+func (m *mUnsyncListMockExcludeNodes) Set(f func(p []core.RecordRef)) *UnsyncListMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.ExcludeNodesFunc = f
+	return m.mock
+}
+
+//ExcludeNodes implements github.com/insolar/insolar/network.UnsyncList.UnsyncList interface
+func (m *UnsyncListMock) ExcludeNodes(p []core.RecordRef) {
+	counter := atomic.AddUint64(&m.ExcludeNodesPreCounter, 1)
+	defer atomic.AddUint64(&m.ExcludeNodesCounter, 1)
+
+	if len(m.ExcludeNodesMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.ExcludeNodesMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to UnsyncListMock.ExcludeNodes. %v", p)
+			return
+		}
+
+		input := m.ExcludeNodesMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, UnsyncListMockExcludeNodesInput{p}, "UnsyncList.ExcludeNodes got unexpected parameters")
+
+		return
+	}
+
+	if m.ExcludeNodesMock.mainExpectation != nil {
+
+		input := m.ExcludeNodesMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, UnsyncListMockExcludeNodesInput{p}, "UnsyncList.ExcludeNodes got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.ExcludeNodesFunc == nil {
+		m.t.Fatalf("Unexpected call to UnsyncListMock.ExcludeNodes. %v", p)
+		return
+	}
+
+	m.ExcludeNodesFunc(p)
+}
+
+//ExcludeNodesMinimockCounter returns a count of UnsyncListMock.ExcludeNodesFunc invocations
+func (m *UnsyncListMock) ExcludeNodesMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.ExcludeNodesCounter)
+}
+
+//ExcludeNodesMinimockPreCounter returns the value of UnsyncListMock.ExcludeNodes invocations
+func (m *UnsyncListMock) ExcludeNodesMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.ExcludeNodesPreCounter)
+}
+
+//ExcludeNodesFinished returns true if mock invocations count is ok
+func (m *UnsyncListMock) ExcludeNodesFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.ExcludeNodesMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.ExcludeNodesCounter) == uint64(len(m.ExcludeNodesMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.ExcludeNodesMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.ExcludeNodesCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.ExcludeNodesFunc != nil {
+		return atomic.LoadUint64(&m.ExcludeNodesCounter) > 0
+	}
+
+	return true
+}
+
 type mUnsyncListMockGetActiveNode struct {
 	mock              *UnsyncListMock
 	mainExpectation   *UnsyncListMockGetActiveNodeExpectation
@@ -1184,6 +1443,10 @@ func (m *UnsyncListMock) RemoveClaimsFinished() bool {
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *UnsyncListMock) ValidateCallCounters() {
 
+	if !m.AddClaimFinished() {
+		m.t.Fatal("Expected call to UnsyncListMock.AddClaim")
+	}
+
 	if !m.AddClaimsFinished() {
 		m.t.Fatal("Expected call to UnsyncListMock.AddClaims")
 	}
@@ -1192,6 +1455,10 @@ func (m *UnsyncListMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to UnsyncListMock.CalculateHash")
 	}
 
+	if !m.ExcludeNodesFinished() {
+		m.t.Fatal("Expected call to UnsyncListMock.ExcludeNodes")
+	}
+
 	if !m.GetActiveNodeFinished() {
 		m.t.Fatal("Expected call to UnsyncListMock.GetActiveNode")
 	}
@@ -1233,6 +1500,10 @@ func (m *UnsyncListMock) Finish() {
 //MinimockFinish checks that all mocked methods of the interface have been called at least once
 func (m *UnsyncListMock) MinimockFinish() {
 
+	if !m.AddClaimFinished() {
+		m.t.Fatal("Expected call to UnsyncListMock.AddClaim")
+	}
+
 	if !m.AddClaimsFinished() {
 		m.t.Fatal("Expected call to UnsyncListMock.AddClaims")
 	}
@@ -1241,6 +1512,10 @@ func (m *UnsyncListMock) MinimockFinish() {
 		m.t.Fatal("Expected call to UnsyncListMock.CalculateHash")
 	}
 
+	if !m.ExcludeNodesFinished() {
+		m.t.Fatal("Expected call to UnsyncListMock.ExcludeNodes")
+	}
+
 	if !m.GetActiveNodeFinished() {
 		m.t.Fatal("Expected call to UnsyncListMock.GetActiveNode")
 	}
@@ -1279,8 +1554,10 @@ func (m *UnsyncListMock) MinimockWait(timeout time.Duration) {
 	timeoutCh := time.After(timeout)
 	for {
 		ok := true
+		ok = ok && m.AddClaimFinished()
 		ok = ok && m.AddClaimsFinished()
 		ok = ok && m.CalculateHashFinished()
+		ok = ok && m.ExcludeNodesFinished()
 		ok = ok && m.GetActiveNodeFinished()
 		ok = ok && m.GetActiveNodesFinished()
 		ok = ok && m.IndexToRefFinished()
@@ -1295,6 +1572,10 @@ func (m *UnsyncListMock) MinimockWait(timeout time.Duration) {
 		select {
 		case <-timeoutCh:
 
+			if !m.AddClaimFinished() {
+				m.t.Error("Expected call to UnsyncListMock.AddClaim")
+			}
+
 			if !m.AddClaimsFinished() {
 				m.t.Error("Expected call to UnsyncListMock.AddClaims")
 			}
@@ -1303,6 +1584,10 @@ func (m *UnsyncListMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to UnsyncListMock.CalculateHash")
 			}
 
+			if !m.ExcludeNodesFinished() {
+				m.t.Error("Expected call to UnsyncListMock.ExcludeNodes")
+			}
+
 			if !m.GetActiveNodeFinished() {
 				m.t.Error("Expected call to UnsyncListMock.GetActiveNode")
 			}
@@ -1339,6 +1624,10 @@ func (m *UnsyncListMock) MinimockWait(timeout time.Duration) {
 //it can be used with assert/require, i.e. assert.True(mock.AllMocksCalled())
 func (m *UnsyncListMock) AllMocksCalled() bool {
 
+	if !m.AddClaimFinished() {
+		return false
+	}
+
 	if !m.AddClaimsFinished() {
 		return false
 	}
@@ -1347,6 +1636,10 @@ func (m *UnsyncListMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.ExcludeNodesFinished() {
+		return false
+	}
+
 	if !m.GetActiveNodeFinished() {
 		return false
 	}
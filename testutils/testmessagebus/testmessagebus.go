@@ -48,6 +48,9 @@ type TestMessageBus struct {
 	PulseStorage core.PulseStorage
 	ReadingTape  []TapeRecord
 	WritingTape  []TapeRecord
+
+	sendMiddlewares    []core.SendMiddleware
+	deliverMiddlewares []core.DeliverMiddleware
 }
 
 func (mb *TestMessageBus) NewPlayer(ctx context.Context, reader io.Reader) (core.MessageBus, error) {
@@ -121,7 +124,7 @@ func (mb *TestMessageBus) MustRegister(p core.MessageType, handler core.MessageH
 	}
 }
 
-func (mb *TestMessageBus) Send(ctx context.Context, m core.Message, _ *core.MessageSendOptions) (core.Reply, error) {
+func (mb *TestMessageBus) Send(ctx context.Context, m core.Message, ops *core.MessageSendOptions) (core.Reply, error) {
 	if mb.ReadingTape != nil {
 		if len(mb.ReadingTape) == 0 {
 			return nil, errors.Errorf("No expected messages, got %+v", m)
@@ -146,15 +149,12 @@ func (mb *TestMessageBus) Send(ctx context.Context, m core.Message, _ *core.Mess
 	if err != nil {
 		return nil, err
 	}
-	t := parcel.Message().Type()
-	handler, ok := mb.handlers[t]
-	if !ok {
-		return nil, errors.New(fmt.Sprint("no handler for message type:", t.String()))
+	var sender core.ParcelSender = mb.deliver
+	for i := len(mb.sendMiddlewares) - 1; i >= 0; i-- {
+		sender = mb.sendMiddlewares[i](sender)
 	}
 
-	ctx = parcel.Context(context.Background())
-
-	reply, err := handler(ctx, parcel)
+	reply, err := sender(ctx, parcel, *currentPulse, ops)
 	if mb.WritingTape != nil {
 		// WARNING! The following commented line of code is cursed.
 		// It makes some test (e.g. TestNilResults) hang under the debugger, and we have no idea why.
@@ -166,6 +166,29 @@ func (mb *TestMessageBus) Send(ctx context.Context, m core.Message, _ *core.Mess
 	return reply, err
 }
 
+func (mb *TestMessageBus) deliver(
+	ctx context.Context, parcel core.Parcel, currentPulse core.Pulse, options *core.MessageSendOptions,
+) (core.Reply, error) {
+	t := parcel.Message().Type()
+	handler, ok := mb.handlers[t]
+	if !ok {
+		return nil, errors.New(fmt.Sprint("no handler for message type:", t.String()))
+	}
+	for i := len(mb.deliverMiddlewares) - 1; i >= 0; i-- {
+		handler = mb.deliverMiddlewares[i](handler)
+	}
+
+	return handler(parcel.Context(context.Background()), parcel)
+}
+
+func (mb *TestMessageBus) UseSendMiddleware(m core.SendMiddleware) {
+	mb.sendMiddlewares = append(mb.sendMiddlewares, m)
+}
+
+func (mb *TestMessageBus) UseDeliverMiddleware(m core.DeliverMiddleware) {
+	mb.deliverMiddlewares = append(mb.deliverMiddlewares, m)
+}
+
 func (mb *TestMessageBus) OnPulse(context.Context, core.Pulse) error {
 	return nil
 }